@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/turahe/go-restfull/internal/config"
+	"github.com/turahe/go-restfull/internal/database"
+	"github.com/turahe/go-restfull/internal/repository"
+	"github.com/turahe/go-restfull/internal/service"
+	"github.com/turahe/go-restfull/internal/tenant"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+func newExportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export content for other blog engines",
+	}
+	cmd.AddCommand(newExportMarkdownCmd())
+	return cmd
+}
+
+func newExportMarkdownCmd() *cobra.Command {
+	var siteID uint
+	cmd := &cobra.Command{
+		Use:   "markdown <dir>",
+		Short: "Write every post to <dir>/<slug>.md with YAML front matter, SEO fields included",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+			db, err := database.ConnectMySQL(cfg, nil)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = db.SQL.Close() }()
+			if err := database.AutoMigrate(db.Gorm); err != nil {
+				return err
+			}
+
+			log := zap.NewNop()
+			exports := service.NewExportService(repository.NewPostRepository(db.Gorm, log), log)
+			n, err := exports.ExportMarkdown(tenant.WithSiteID(cmd.Context(), siteID), args[0])
+			if err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "exported %d post(s) to %s\n", n, args[0])
+			return nil
+		},
+	}
+	cmd.Flags().UintVar(&siteID, "site", 1, "site to export")
+	return cmd
+}
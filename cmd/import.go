@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/turahe/go-restfull/internal/config"
+	"github.com/turahe/go-restfull/internal/database"
+	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/internal/repository"
+	"github.com/turahe/go-restfull/internal/search"
+	"github.com/turahe/go-restfull/internal/service"
+	"github.com/turahe/go-restfull/internal/tenant"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// importFlags are shared by the import subcommands.
+type importFlags struct {
+	opts   service.ImportOptions
+	siteID uint
+}
+
+func (f *importFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.opts.UserEmail, "user", "", "email of the existing user the import runs as (required)")
+	cmd.Flags().StringVar(&f.opts.Category, "category", "", "category for posts without one (default Uncategorized)")
+	cmd.Flags().BoolVar(&f.opts.DryRun, "dry-run", false, "report what would be imported without changing anything")
+	cmd.Flags().UintVar(&f.siteID, "site", 1, "site to import into")
+	_ = cmd.MarkFlagRequired("user")
+}
+
+func newImportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import content from other blog engines; re-runs skip what was already imported",
+	}
+	cmd.AddCommand(newImportWordPressCmd(), newImportMarkdownCmd())
+	return cmd
+}
+
+func newImportWordPressCmd() *cobra.Command {
+	var f importFlags
+	cmd := &cobra.Command{
+		Use:   "wordpress <file.xml>",
+		Short: "Import a WordPress export (WXR): authors, categories, tags, posts, comments and attachments",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer func() { _ = file.Close() }()
+			return runImport(cmd, f, !f.opts.SkipAttachments, func(svc *service.ImportService) (*service.ImportReport, error) {
+				return svc.ImportWordPress(tenant.WithSiteID(cmd.Context(), f.siteID), file, f.opts)
+			})
+		},
+	}
+	f.register(cmd)
+	cmd.Flags().BoolVar(&f.opts.SkipAttachments, "skip-attachments", false, "do not download attachments into the object store")
+	return cmd
+}
+
+func newImportMarkdownCmd() *cobra.Command {
+	var f importFlags
+	cmd := &cobra.Command{
+		Use:   "markdown <dir>",
+		Short: "Import Markdown files with front matter (Hugo content directory or Jekyll site)",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runImport(cmd, f, false, func(svc *service.ImportService) (*service.ImportReport, error) {
+				return svc.ImportMarkdown(tenant.WithSiteID(cmd.Context(), f.siteID), args[0], f.opts)
+			})
+		},
+	}
+	f.register(cmd)
+	return cmd
+}
+
+func runImport(cmd *cobra.Command, f importFlags, withMedia bool, do func(*service.ImportService) (*service.ImportReport, error)) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	db, err := database.ConnectMySQL(cfg, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = db.SQL.Close() }()
+	if err := database.AutoMigrate(db.Gorm); err != nil {
+		return err
+	}
+
+	log := zap.NewNop()
+	var media *service.MediaService
+	if withMedia {
+		if media, err = service.NewMediaService(repository.NewMediaRepository(db.Gorm, log), cfg, log); err != nil {
+			return err
+		}
+	}
+	report, err := do(service.NewImportService(db.Gorm, media, log))
+	if err != nil {
+		return err
+	}
+	printImportReport(cmd.OutOrStdout(), report)
+	// The memory index lives in the server process (and its snapshot), which this command cannot reach.
+	if cfg.SearchEngine == search.EngineMemory && !report.DryRun && report.Count(model.ImportKindPost).Created > 0 {
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "note: imported posts are not searchable yet; stop the server, run `search reindex` and start it again")
+	}
+	return nil
+}
+
+func printImportReport(out io.Writer, report *service.ImportReport) {
+	if report.DryRun {
+		_, _ = fmt.Fprintln(out, "dry run: nothing was changed")
+	}
+	_, _ = fmt.Fprintf(out, "%-10s %8s %8s %8s\n", "kind", "created", "existing", "skipped")
+	for _, kind := range service.ImportKinds {
+		c := report.Count(kind)
+		_, _ = fmt.Fprintf(out, "%-10s %8d %8d %8d\n", kind, c.Created, c.Existing, c.Skipped)
+	}
+	for _, n := range report.Notes {
+		_, _ = fmt.Fprintf(out, "note: %s\n", n)
+	}
+}
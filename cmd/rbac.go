@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/turahe/go-restfull/internal/config"
+	"github.com/turahe/go-restfull/internal/database"
+	"github.com/turahe/go-restfull/internal/rbac"
+
+	"github.com/spf13/cobra"
+)
+
+func newRBACCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rbac",
+		Short: "RBAC maintenance",
+	}
+	cmd.AddCommand(newRBACCheckCmd())
+	return cmd
+}
+
+func newRBACCheckCmd() *cobra.Command {
+	var repair bool
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Report (and optionally repair) divergence between legacy Casbin rules and the RBAC tables",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+			db, err := database.ConnectMySQL(cfg, nil)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = db.SQL.Close() }()
+
+			if err := database.AutoMigrate(db.Gorm); err != nil {
+				return err
+			}
+
+			check := rbac.CheckConsistency
+			if repair {
+				check = rbac.RepairConsistency
+			}
+			issues, err := check(cmd.Context(), db.Gorm)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			unresolved := 0
+			for _, is := range issues {
+				status := "manual"
+				switch {
+				case is.Repairable && repair:
+					status = "repaired"
+				case is.Repairable:
+					status = "repairable"
+				}
+				if !repair || !is.Repairable {
+					unresolved++
+				}
+				_, _ = fmt.Fprintf(out, "%-26s %-10s %s\n", is.Kind, status, is.Detail)
+			}
+			_, _ = fmt.Fprintf(out, "%d issue(s), %d unresolved\n", len(issues), unresolved)
+			if unresolved > 0 {
+				return fmt.Errorf("rbac: %d unresolved inconsistencies", unresolved)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&repair, "repair", false, "import legacy-only rules and delete orphaned or duplicate rows")
+	return cmd
+}
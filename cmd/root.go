@@ -18,7 +18,7 @@ func newRootCmd() *cobra.Command {
 	seedCmd.AddCommand(newSeedRBACCmd())
 	seedCmd.AddCommand(newSeedSettingsCmd())
 
-	root.AddCommand(serveCmd, seedCmd)
+	root.AddCommand(serveCmd, seedCmd, newRBACCmd(), newSearchCmd(), newImportCmd(), newExportCmd())
 
 	// Backwards compatible: running without args starts server.
 	root.RunE = serveCmd.RunE
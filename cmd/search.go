@@ -0,0 +1,74 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/turahe/go-restfull/internal/config"
+	"github.com/turahe/go-restfull/internal/database"
+	"github.com/turahe/go-restfull/internal/repository"
+	"github.com/turahe/go-restfull/internal/search"
+	"github.com/turahe/go-restfull/internal/service"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+func newSearchCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "search",
+		Short: "Post search index maintenance",
+	}
+	cmd.AddCommand(newSearchReindexCmd())
+	return cmd
+}
+
+func newSearchReindexCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "reindex",
+		Short: "Rebuild the post search index (SEARCH_ENGINE); the memory index is written to SEARCH_INDEX_PATH",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+			idx, err := search.New(cfg.SearchEngine, nil)
+			if err != nil {
+				return err
+			}
+			mem, isMemory := idx.(*search.Memory)
+			if isMemory && cfg.SearchIndexPath == "" {
+				return errors.New("search: SEARCH_INDEX_PATH is required to reindex the memory engine")
+			}
+
+			db, err := database.ConnectMySQL(cfg, nil)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = db.SQL.Close() }()
+			if err := database.AutoMigrate(db.Gorm); err != nil {
+				return err
+			}
+			if !isMemory {
+				idx = search.NewMySQL(db.Gorm)
+			}
+
+			log := zap.NewNop()
+			posts := service.NewPostService(repository.NewPostRepository(db.Gorm, log), repository.NewCategoryRepository(db.Gorm, log), repository.NewTagRepository(db.Gorm, log), nil, nil, log).WithSearch(idx)
+			n, err := posts.Reindex(cmd.Context())
+			if err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+			if isMemory {
+				if err := mem.Save(cfg.SearchIndexPath); err != nil {
+					return err
+				}
+				_, _ = fmt.Fprintf(out, "indexed %d post(s) into %s\n", n, cfg.SearchIndexPath)
+				return nil
+			}
+			_, _ = fmt.Fprintf(out, "FULLTEXT index %s is in place\n", search.FullTextIndexName)
+			return nil
+		},
+	}
+}
@@ -12,7 +12,7 @@ import (
 func newSeedRBACCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "rbac",
-		Short: "Seed default roles, permissions, and Casbin policies",
+		Short: "Seed default roles and permissions (the Casbin enforcer reads them)",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfg, err := config.Load()
 			if err != nil {
@@ -51,6 +51,35 @@ type Config struct {
 	RefreshTokenPepper      string
 
 	CasbinModelPath string
+	// RBACDecisionLogSize > 0 traces denied requests in memory (up to N) and returns X-RBAC-Decision-Id on 403 responses.
+	RBACDecisionLogSize int
+	// RBACGrantSweepMinutes is how often expired time-bound role grants are deleted (0 disables the sweep).
+	RBACGrantSweepMinutes int
+
+	// PostSchedulerSeconds is how often scheduled posts are published and expired ones archived (0 disables).
+	PostSchedulerSeconds int
+
+	// ViewDedupMinutes is how long repeat views of a post by the same visitor count once.
+	ViewDedupMinutes int
+	// ViewFlushSeconds is how often buffered post views are written to post_stats_daily (0 disables tracking).
+	ViewFlushSeconds int
+	// ViewKeyPrefix namespaces the Redis keys of the view buffer.
+	ViewKeyPrefix string
+
+	// TrashRetentionDays is how long deleted posts, categories, comments, tags and media stay in the
+	// trash before they are purged for good (0 keeps them until purged by hand).
+	TrashRetentionDays int
+
+	// PreviewTokenSecret signs draft preview links (falls back to RefreshTokenPepper; empty disables them).
+	PreviewTokenSecret string
+
+	// SearchEngine selects the post search index: "mysql" (FULLTEXT) or "memory" (embedded BM25).
+	SearchEngine string
+	// SearchIndexPath is where the memory index is saved on shutdown and loaded at startup (empty: rebuild each start).
+	SearchIndexPath string
+
+	// SiteStrictHost rejects requests whose Host matches no site instead of serving the default site.
+	SiteStrictHost bool
 
 	TwoFactorEncKey string
 	TwoFactorIssuer string
@@ -105,6 +134,17 @@ func Load() (Config, error) {
 		ImpersonationTTLMinutes: getEnvIntDefault("IMPERSONATION_TTL_MINUTES", 5),
 		RefreshTokenPepper:      os.Getenv("REFRESH_TOKEN_PEPPER"),
 		CasbinModelPath:         strings.TrimSpace(getEnvDefault("CASBIN_MODEL_PATH", "configs/casbin_model.conf")),
+		RBACDecisionLogSize:     getEnvIntDefault("RBAC_DECISION_LOG_SIZE", 0),
+		RBACGrantSweepMinutes:   getEnvIntDefault("RBAC_GRANT_SWEEP_MINUTES", 5),
+		SiteStrictHost:          getEnvBoolDefault("SITE_STRICT_HOST", false),
+		PostSchedulerSeconds:    getEnvIntDefault("POST_SCHEDULER_SECONDS", 60),
+		ViewDedupMinutes:        getEnvIntDefault("VIEW_DEDUP_MINUTES", 30),
+		ViewFlushSeconds:        getEnvIntDefault("VIEW_FLUSH_SECONDS", 60),
+		ViewKeyPrefix:           strings.TrimSpace(getEnvDefault("VIEW_KEY_PREFIX", "views:")),
+		TrashRetentionDays:      getEnvIntDefault("TRASH_RETENTION_DAYS", 30),
+		PreviewTokenSecret:      os.Getenv("PREVIEW_TOKEN_SECRET"),
+		SearchEngine:            strings.ToLower(strings.TrimSpace(getEnvDefault("SEARCH_ENGINE", "mysql"))),
+		SearchIndexPath:         strings.TrimSpace(os.Getenv("SEARCH_INDEX_PATH")),
 		TwoFactorEncKey:         strings.TrimSpace(os.Getenv("TWO_FACTOR_ENC_KEY")),
 		TwoFactorIssuer:         strings.TrimSpace(getEnvDefault("TWO_FACTOR_ISSUER", "")),
 		MediaMaxUploadBytes:     getEnvInt64Default("MEDIA_MAX_UPLOAD_BYTES", 10*1024*1024),
@@ -166,6 +206,27 @@ func Load() (Config, error) {
 	if cfg.CasbinModelPath == "" {
 		return Config{}, errors.New("CASBIN_MODEL_PATH is required")
 	}
+	if cfg.RBACDecisionLogSize < 0 {
+		return Config{}, errors.New("RBAC_DECISION_LOG_SIZE must be >= 0")
+	}
+	if cfg.RBACGrantSweepMinutes < 0 {
+		return Config{}, errors.New("RBAC_GRANT_SWEEP_MINUTES must be >= 0")
+	}
+	if cfg.PostSchedulerSeconds < 0 {
+		return Config{}, errors.New("POST_SCHEDULER_SECONDS must be >= 0")
+	}
+	if cfg.ViewDedupMinutes < 1 || cfg.ViewDedupMinutes > 1440 {
+		return Config{}, errors.New("VIEW_DEDUP_MINUTES must be between 1 and 1440")
+	}
+	if cfg.ViewFlushSeconds < 0 {
+		return Config{}, errors.New("VIEW_FLUSH_SECONDS must be >= 0")
+	}
+	if cfg.TrashRetentionDays < 0 {
+		return Config{}, errors.New("TRASH_RETENTION_DAYS must be >= 0")
+	}
+	if cfg.PreviewTokenSecret == "" {
+		cfg.PreviewTokenSecret = cfg.RefreshTokenPepper
+	}
 	if cfg.RateLimitRPS < 0 {
 		return Config{}, errors.New("RATE_LIMIT_RPS must be >= 0")
 	}
@@ -2,17 +2,20 @@ package database
 
 import (
 	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/internal/tenant"
 
 	"gorm.io/gorm"
 )
 
 func AutoMigrate(db *gorm.DB) error {
-	return db.AutoMigrate(
+	if err := db.AutoMigrate(
+		&model.Site{},
 		&model.User{},
 		&model.Role{},
 		&model.Permission{},
 		&model.UserRole{},
 		&model.RolePermission{},
+		&model.RoleInheritance{},
 		&model.AuthSession{},
 		&model.RefreshToken{},
 		&model.RevokedJTI{},
@@ -20,14 +23,109 @@ func AutoMigrate(db *gorm.DB) error {
 		&model.UserTwoFactor{},
 		&model.TwoFactorChallenge{},
 		&model.CategoryModel{},
+		&model.CategoryTranslation{},
 		&model.Tag{},
+		&model.TagTranslation{},
+		&model.Series{},
 		&model.Post{},
 		&model.PostSEO{},
 		&model.PostMedia{},
 		&model.PostTag{},
+		&model.PostContributor{},
+		&model.PostRevision{},
+		&model.PostPreviewLink{},
+		&model.PostReviewer{},
+		&model.PostReviewComment{},
+		&model.PostTransition{},
 		&model.Comment{},
 		&model.Media{},
 		&model.UserMedia{},
 		&model.Setting{},
-	)
+		&model.SlugHistory{},
+		&model.Redirect{},
+		&model.PostStatDaily{},
+		&model.Reaction{},
+		&model.ReadingList{},
+		&model.ReadingListItem{},
+		&model.ImportRef{},
+	); err != nil {
+		return err
+	}
+	if err := dropLegacyIndexes(db); err != nil {
+		return err
+	}
+	if err := dropLegacyConstraints(db); err != nil {
+		return err
+	}
+	if err := backfillPostContributors(db); err != nil {
+		return err
+	}
+	if err := backfillPostLocales(db); err != nil {
+		return err
+	}
+	return ensureDefaultSite(db)
+}
+
+// ensureDefaultSite creates the site that pre-existing rows (site_id default 1) belong to.
+func ensureDefaultSite(db *gorm.DB) error {
+	s := model.Site{ID: tenant.DefaultSiteID, Key: "default", Name: "Default"}
+	return db.Where("id = ?", tenant.DefaultSiteID).FirstOrCreate(&s).Error
+}
+
+// dropLegacyIndexes removes single-site unique indexes replaced by (site_id, ...) ones;
+// AutoMigrate only adds indexes, so databases created before sites still carry them.
+func dropLegacyIndexes(db *gorm.DB) error {
+	legacy := []struct {
+		model any
+		name  string
+	}{
+		{&model.Post{}, "idx_posts_slug"},
+		{&model.CategoryModel{}, "idx_categories_slug"},
+		{&model.CategoryModel{}, "idx_categories_parent_name"},
+		{&model.Tag{}, "idx_tags_slug"},
+		{&model.Media{}, "idx_media_user_parent_name"},
+		{&model.Setting{}, "idx_settings_setting_key"},
+	}
+	m := db.Migrator()
+	for _, l := range legacy {
+		if !m.HasIndex(l.model, l.name) {
+			continue
+		}
+		if err := m.DropIndex(l.model, l.name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dropLegacyConstraints removes check constraints superseded by renamed ones (AutoMigrate never alters
+// an existing constraint): chk_posts_status predates the scheduled status, chk_posts_status_v2 the
+// review statuses.
+func dropLegacyConstraints(db *gorm.DB) error {
+	m := db.Migrator()
+	for _, name := range []string{"chk_posts_status", "chk_posts_status_v2"} {
+		if !m.HasConstraint(&model.Post{}, name) {
+			continue
+		}
+		if err := m.DropConstraint(&model.Post{}, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// backfillPostContributors credits posts created before post_contributors existed: their user_id
+// becomes their sole author.
+func backfillPostContributors(db *gorm.DB) error {
+	return db.Exec(`INSERT INTO post_contributors (post_id, user_id, role, position)
+		SELECT p.id, p.user_id, ?, 0 FROM posts p
+		WHERE NOT EXISTS (SELECT 1 FROM post_contributors c WHERE c.post_id = p.id)`, model.ContributorAuthor).Error
+}
+
+// backfillPostLocales puts posts created before locales existed in their site's defaultLocale
+// setting ("en" when unset).
+func backfillPostLocales(db *gorm.DB) error {
+	return db.Exec(`UPDATE posts SET locale = COALESCE(
+		(SELECT s.value FROM settings s WHERE s.site_id = posts.site_id AND s.setting_key = ?), ?)
+		WHERE locale = ''`, "defaultLocale", "en").Error
 }
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/turahe/go-restfull/internal/config"
+	"github.com/turahe/go-restfull/internal/tenant"
 
 	"cloud.google.com/go/cloudsqlconn"
 	sqlmysql "github.com/go-sql-driver/mysql"
@@ -78,6 +79,10 @@ func ConnectMySQL(cfg config.Config, log *zap.Logger) (DB, error) {
 	if err != nil {
 		return DB{}, err
 	}
+	// Scope site-owned tables (posts, categories, ...) to the site in each statement's context.
+	if err := gormDB.Use(tenant.Plugin{}); err != nil {
+		return DB{}, err
+	}
 
 	sqlDB, err := gormDB.DB()
 	if err != nil {
@@ -1,7 +1,13 @@
 package handler
 
 import (
+	"errors"
+	"net/http"
+	"net/url"
+
 	"github.com/turahe/go-restfull/internal/middleware"
+	"github.com/turahe/go-restfull/internal/repository"
+	"github.com/turahe/go-restfull/internal/service"
 	"github.com/turahe/go-restfull/pkg/response"
 	"strconv"
 	"strings"
@@ -65,6 +71,48 @@ func (h BaseHandler) internalError(c *gin.Context, serviceCode string, err error
 	)
 }
 
+// listError answers a failed list call: a bad cursor is the client's fault, anything else is internal.
+func (h BaseHandler) listError(c *gin.Context, serviceCode string, err error) {
+	if errors.Is(err, repository.ErrInvalidCursor) {
+		response.BadRequest(c,
+			response.BuildResponseCode(400, serviceCode, response.CaseCodeInvalidValue),
+			"invalid request",
+			err.Error(),
+		)
+		return
+	}
+	h.internalError(c, serviceCode, err, "list failed")
+}
+
+// movedSlug answers a *service.SlugMovedError with a permanent redirect to the current route, the
+// :slug segment swapped for the new slug and the query kept. It reports whether err was one.
+func (h BaseHandler) movedSlug(c *gin.Context, serviceCode string, err error) bool {
+	var moved *service.SlugMovedError
+	if !errors.As(err, &moved) {
+		return false
+	}
+	path := strings.Replace(c.FullPath(), ":slug", url.PathEscape(moved.Slug), 1)
+	if q := c.Request.URL.RawQuery; q != "" {
+		path += "?" + q
+	}
+	c.Header("Location", path)
+	response.JSON(c, http.StatusMovedPermanently,
+		response.BuildResponseCode(http.StatusMovedPermanently, serviceCode, response.CaseCodeMovedPermanently),
+		"moved permanently",
+		gin.H{"slug": moved.Slug, "location": path},
+		nil,
+	)
+	return true
+}
+
+// viewerID is the signed-in caller's user id on optionally authenticated routes, or 0 when anonymous.
+func (h BaseHandler) viewerID(c *gin.Context) uint {
+	if auth, ok := middleware.GetAuth(c); ok {
+		return auth.UserID
+	}
+	return 0
+}
+
 func (h BaseHandler) ParseIntDefault(s string, def int) int {
 	s = strings.TrimSpace(s)
 	if s == "" {
@@ -25,6 +25,9 @@ type categoryService interface {
 	List(ctx context.Context, req request.CategoryListRequest) (repository.CursorPage, error)
 	GetTree(ctx context.Context) ([]service.CategoryTreeNode, error)
 	GetSubtree(ctx context.Context, categoryID uint) ([]service.CategoryTreeNode, error)
+	ListTranslations(ctx context.Context, id uint) ([]model.CategoryTranslation, error)
+	PutTranslation(ctx context.Context, id uint, locale, name string, actorUserID uint) (*model.CategoryTranslation, error)
+	DeleteTranslation(ctx context.Context, id uint, locale string, actorUserID uint) error
 }
 
 type CategoryHandler struct {
@@ -40,8 +43,11 @@ func NewCategoryHandler(svc categoryService, log *zap.Logger) *CategoryHandler {
 // @Summary      List categories (flat, tree order by lft)
 // @Tags         Categories
 // @Produce      json
-// @Param        page    query     int     false  "Page (1-based)"
-// @Param        limit   query     int     false  "Page size (max 200)"
+// @Param        limit      query     int     false  "Page size (max 200)"
+// @Param        sort       query     string  false  "Sort order (first is the default)"  Enums(tree,name)
+// @Param        after      query     string  false  "Page after this cursor (a nextCursor)"
+// @Param        before     query     string  false  "Page before this cursor (a prevCursor)"
+// @Param        withTotal  query     bool    false  "Also return the total count"
 // @Param        name    query     string  false  "Filter by name (contains)"
 // @Param        search  query     string  false  "Search in name (contains)"
 // @Success      200     {object}  response.Envelope
@@ -60,16 +66,17 @@ func (h *CategoryHandler) List(c *gin.Context) {
 
 	page, err := h.svc.List(c.Request.Context(), req)
 	if err != nil {
-		h.internalError(c, response.ServiceCodeCategories, err, "list failed")
+		h.listError(c, response.ServiceCodeCategories, err)
 		return
 	}
-	response.OKPaginated(
+	response.OKCursorPaginated(
 		c,
 		response.BuildResponseCode(http.StatusOK, response.ServiceCodeCategories, response.CaseCodeListRetrieved),
 		"ok",
 		page.Items,
-		page.NextCursor != nil,
-		page.PrevCursor != nil,
+		page.NextCursor,
+		page.PrevCursor,
+		page.Total,
 	)
 }
 
@@ -83,6 +90,7 @@ func (h *CategoryHandler) List(c *gin.Context) {
 // @Success      201   {object}  response.Envelope
 // @Failure      400   {object}  response.Envelope
 // @Failure      401   {object}  response.Envelope
+// @Failure      403   {object}  response.Envelope
 // @Failure      500   {object}  response.Envelope
 // @Router       /api/v1/categories/root [post]
 func (h *CategoryHandler) CreateRoot(c *gin.Context) {
@@ -105,6 +113,8 @@ func (h *CategoryHandler) CreateRoot(c *gin.Context) {
 			response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodeCategories, response.CaseCodeInvalidValue), "invalid name", err.Error())
 		case errors.Is(err, service.ErrCategoryDuplicateName):
 			response.Conflict(c, response.BuildResponseCode(http.StatusConflict, response.ServiceCodeCategories, response.CaseCodeConflict), "duplicate name", err.Error())
+		case errors.Is(err, service.ErrCategoryOutOfScope):
+			response.Forbidden(c, response.BuildResponseCode(http.StatusForbidden, response.ServiceCodeCategories, response.CaseCodePermissionDenied), "forbidden", err.Error())
 		default:
 			h.internalError(c, response.ServiceCodeCategories, err, "create root failed")
 		}
@@ -124,6 +134,7 @@ func (h *CategoryHandler) CreateRoot(c *gin.Context) {
 // @Success      201   {object}  response.Envelope
 // @Failure      400   {object}  response.Envelope
 // @Failure      401   {object}  response.Envelope
+// @Failure      403   {object}  response.Envelope
 // @Failure      404   {object}  response.Envelope
 // @Failure      500   {object}  response.Envelope
 // @Router       /api/v1/categories/{id}/child [post]
@@ -154,6 +165,8 @@ func (h *CategoryHandler) CreateChild(c *gin.Context) {
 			response.NotFound(c, response.BuildResponseCode(http.StatusNotFound, response.ServiceCodeCategories, response.CaseCodeNotFound), "not found", "parent category not found")
 		case errors.Is(err, service.ErrCategoryDuplicateName):
 			response.Conflict(c, response.BuildResponseCode(http.StatusConflict, response.ServiceCodeCategories, response.CaseCodeConflict), "duplicate name", err.Error())
+		case errors.Is(err, service.ErrCategoryOutOfScope):
+			response.Forbidden(c, response.BuildResponseCode(http.StatusForbidden, response.ServiceCodeCategories, response.CaseCodePermissionDenied), "forbidden", err.Error())
 		default:
 			h.internalError(c, response.ServiceCodeCategories, err, "create child failed")
 		}
@@ -218,6 +231,7 @@ func (h *CategoryHandler) GetSubtree(c *gin.Context) {
 // @Success      200   {object}  response.Envelope
 // @Failure      400   {object}  response.Envelope
 // @Failure      401   {object}  response.Envelope
+// @Failure      403   {object}  response.Envelope
 // @Failure      404   {object}  response.Envelope
 // @Failure      500   {object}  response.Envelope
 // @Router       /api/v1/categories/{id} [put]
@@ -248,6 +262,8 @@ func (h *CategoryHandler) Update(c *gin.Context) {
 			response.NotFound(c, response.BuildResponseCode(http.StatusNotFound, response.ServiceCodeCategories, response.CaseCodeNotFound), "not found", "category not found")
 		case errors.Is(err, service.ErrCategoryDuplicateName):
 			response.Conflict(c, response.BuildResponseCode(http.StatusConflict, response.ServiceCodeCategories, response.CaseCodeConflict), "duplicate name", err.Error())
+		case errors.Is(err, service.ErrCategoryOutOfScope):
+			response.Forbidden(c, response.BuildResponseCode(http.StatusForbidden, response.ServiceCodeCategories, response.CaseCodePermissionDenied), "forbidden", err.Error())
 		default:
 			h.internalError(c, response.ServiceCodeCategories, err, "update failed")
 		}
@@ -266,6 +282,7 @@ func (h *CategoryHandler) Update(c *gin.Context) {
 // @Success      200  {object}  response.Envelope
 // @Failure      400  {object}  response.Envelope
 // @Failure      401  {object}  response.Envelope
+// @Failure      403  {object}  response.Envelope
 // @Failure      404  {object}  response.Envelope
 // @Failure      409  {object}  response.Envelope
 // @Failure      500  {object}  response.Envelope
@@ -288,6 +305,8 @@ func (h *CategoryHandler) Delete(c *gin.Context) {
 			response.NotFound(c, response.BuildResponseCode(http.StatusNotFound, response.ServiceCodeCategories, response.CaseCodeNotFound), "not found", "category not found")
 		case errors.Is(err, service.ErrCategoryDeleteHasPosts):
 			response.Conflict(c, response.BuildResponseCode(http.StatusConflict, response.ServiceCodeCategories, response.CaseCodeConflict), "conflict", err.Error())
+		case errors.Is(err, service.ErrCategoryOutOfScope):
+			response.Forbidden(c, response.BuildResponseCode(http.StatusForbidden, response.ServiceCodeCategories, response.CaseCodePermissionDenied), "forbidden", err.Error())
 		default:
 			h.internalError(c, response.ServiceCodeCategories, err, "delete failed")
 		}
@@ -295,3 +314,117 @@ func (h *CategoryHandler) Delete(c *gin.Context) {
 	}
 	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodeCategories, response.CaseCodeDeleted), "deleted", gin.H{"id": uint(id)})
 }
+
+// ListTranslations godoc
+// @Summary      List category translations
+// @Tags         Categories
+// @Produce      json
+// @Param        id  path      int  true  "Category id"
+// @Success      200  {object}  response.Envelope
+// @Failure      400  {object}  response.Envelope
+// @Failure      404  {object}  response.Envelope
+// @Failure      500  {object}  response.Envelope
+// @Router       /api/v1/categories/{id}/translations [get]
+func (h *CategoryHandler) ListTranslations(c *gin.Context) {
+	id, err := h.ParseUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodeCategories, response.CaseCodeInvalidValue), "invalid id", "id must be uint")
+		return
+	}
+	rows, err := h.svc.ListTranslations(c.Request.Context(), id)
+	if err != nil {
+		h.translationError(c, err, "list translations failed")
+		return
+	}
+	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodeCategories, response.CaseCodeListRetrieved), "ok", rows)
+}
+
+// PutTranslation godoc
+// @Summary      Set a category name in a locale
+// @Description  Creates or replaces the translation; its slug is derived from the name and unique per locale.
+// @Tags         Categories
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path      int                         true  "Category id"
+// @Param        locale  path      string                      true  "Locale (BCP 47)"
+// @Param        body    body      request.TranslationRequest  true  "Translated name"
+// @Success      200  {object}  response.Envelope
+// @Failure      400  {object}  response.Envelope
+// @Failure      401  {object}  response.Envelope
+// @Failure      403  {object}  response.Envelope
+// @Failure      404  {object}  response.Envelope
+// @Failure      500  {object}  response.Envelope
+// @Router       /api/v1/categories/{id}/translations/{locale} [put]
+func (h *CategoryHandler) PutTranslation(c *gin.Context) {
+	auth, ok := middleware.GetAuth(c)
+	if !ok {
+		response.Unauthorized(c, response.BuildResponseCode(http.StatusUnauthorized, response.ServiceCodeCategories, response.CaseCodeUnauthorized), "unauthorized", "missing auth")
+		return
+	}
+	id, err := h.ParseUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodeCategories, response.CaseCodeInvalidValue), "invalid id", "id must be uint")
+		return
+	}
+	var req request.TranslationRequest
+	if !h.bindJSON(c, response.ServiceCodeCategories, &req) {
+		return
+	}
+	if !h.validate(c, response.ServiceCodeCategories, req) {
+		return
+	}
+	t, err := h.svc.PutTranslation(c.Request.Context(), id, c.Param("locale"), req.Name, auth.UserID)
+	if err != nil {
+		h.translationError(c, err, "save translation failed")
+		return
+	}
+	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodeCategories, response.CaseCodeUpdated), "updated", t)
+}
+
+// DeleteTranslation godoc
+// @Summary      Delete a category translation
+// @Tags         Categories
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path      int     true  "Category id"
+// @Param        locale  path      string  true  "Locale (BCP 47)"
+// @Success      200  {object}  response.Envelope
+// @Failure      400  {object}  response.Envelope
+// @Failure      401  {object}  response.Envelope
+// @Failure      403  {object}  response.Envelope
+// @Failure      404  {object}  response.Envelope
+// @Failure      500  {object}  response.Envelope
+// @Router       /api/v1/categories/{id}/translations/{locale} [delete]
+func (h *CategoryHandler) DeleteTranslation(c *gin.Context) {
+	auth, ok := middleware.GetAuth(c)
+	if !ok {
+		response.Unauthorized(c, response.BuildResponseCode(http.StatusUnauthorized, response.ServiceCodeCategories, response.CaseCodeUnauthorized), "unauthorized", "missing auth")
+		return
+	}
+	id, err := h.ParseUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodeCategories, response.CaseCodeInvalidValue), "invalid id", "id must be uint")
+		return
+	}
+	if err := h.svc.DeleteTranslation(c.Request.Context(), id, c.Param("locale"), auth.UserID); err != nil {
+		h.translationError(c, err, "delete translation failed")
+		return
+	}
+	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodeCategories, response.CaseCodeDeleted), "deleted", gin.H{"id": id, "locale": c.Param("locale")})
+}
+
+func (h *CategoryHandler) translationError(c *gin.Context, err error, msg string) {
+	switch {
+	case errors.Is(err, service.ErrInvalidLocale), errors.Is(err, service.ErrInvalidName):
+		response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodeCategories, response.CaseCodeInvalidValue), "invalid request", err.Error())
+	case errors.Is(err, service.ErrCategoryNotFound):
+		response.NotFound(c, response.BuildResponseCode(http.StatusNotFound, response.ServiceCodeCategories, response.CaseCodeNotFound), "not found", "category not found")
+	case errors.Is(err, service.ErrTranslationNotFound):
+		response.NotFound(c, response.BuildResponseCode(http.StatusNotFound, response.ServiceCodeCategories, response.CaseCodeNotFound), "not found", err.Error())
+	case errors.Is(err, service.ErrCategoryOutOfScope):
+		response.Forbidden(c, response.BuildResponseCode(http.StatusForbidden, response.ServiceCodeCategories, response.CaseCodePermissionDenied), "forbidden", err.Error())
+	default:
+		h.internalError(c, response.ServiceCodeCategories, err, msg)
+	}
+}
@@ -68,6 +68,23 @@ func (m *mockCategoryService) Delete(ctx context.Context, id uint, actorUserID u
 	return args.Error(0)
 }
 
+func (m *mockCategoryService) ListTranslations(ctx context.Context, id uint) ([]model.CategoryTranslation, error) {
+	args := m.Called(ctx, id)
+	rows, _ := args.Get(0).([]model.CategoryTranslation)
+	return rows, args.Error(1)
+}
+
+func (m *mockCategoryService) PutTranslation(ctx context.Context, id uint, locale, name string, actorUserID uint) (*model.CategoryTranslation, error) {
+	args := m.Called(ctx, id, locale, name, actorUserID)
+	t, _ := args.Get(0).(*model.CategoryTranslation)
+	return t, args.Error(1)
+}
+
+func (m *mockCategoryService) DeleteTranslation(ctx context.Context, id uint, locale string, actorUserID uint) error {
+	args := m.Called(ctx, id, locale, actorUserID)
+	return args.Error(0)
+}
+
 func decodeEnvCat(t *testing.T, rr *httptest.ResponseRecorder) response.Envelope {
 	t.Helper()
 	var env response.Envelope
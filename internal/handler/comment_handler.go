@@ -25,8 +25,8 @@ type CommentHandler struct {
 type commentService interface {
 	CreateRoot(ctx context.Context, postID uint, userID uint, req request.CreateCommentRequest) (*model.Comment, error)
 	CreateChild(ctx context.Context, postID uint, parentID uint, userID uint, req request.CreateCommentRequest) (*model.Comment, error)
-	GetTree(ctx context.Context, postID uint) ([]service.CommentTreeNode, error)
-	GetSubtree(ctx context.Context, postID uint, commentID uint) ([]service.CommentTreeNode, error)
+	GetTree(ctx context.Context, postID uint, viewerID uint) ([]service.CommentTreeNode, error)
+	GetSubtree(ctx context.Context, postID uint, commentID uint, viewerID uint) ([]service.CommentTreeNode, error)
 	Update(ctx context.Context, postID uint, commentID uint, userID uint, req request.UpdateCommentBody) (*model.Comment, error)
 	Delete(ctx context.Context, postID uint, commentID uint, userID uint) error
 	List(ctx context.Context, req request.CommentListRequest) (repository.CursorPage, error)
@@ -144,6 +144,7 @@ func (h *CommentHandler) CreateChild(c *gin.Context) {
 
 // GetTree godoc
 // @Summary      Comment tree for a post
+// @Description  Each node carries its reaction totals; with a bearer token it also lists the caller's own reactions.
 // @Tags         Comments
 // @Produce      json
 // @Param        id  path      int  true  "Post ID"
@@ -158,7 +159,7 @@ func (h *CommentHandler) GetTree(c *gin.Context) {
 		return
 	}
 
-	tree, err := h.comments.GetTree(c.Request.Context(), postID)
+	tree, err := h.comments.GetTree(c.Request.Context(), postID, h.viewerID(c))
 	if err != nil {
 		h.internalError(c, response.ServiceCodeComments, err, "get tree failed")
 		return
@@ -168,6 +169,7 @@ func (h *CommentHandler) GetTree(c *gin.Context) {
 
 // GetSubtree godoc
 // @Summary      Comment subtree from a node
+// @Description  Each node carries its reaction totals; with a bearer token it also lists the caller's own reactions.
 // @Tags         Comments
 // @Produce      json
 // @Param        id   path      int  true  "Post ID"
@@ -189,7 +191,7 @@ func (h *CommentHandler) GetSubtree(c *gin.Context) {
 		return
 	}
 
-	sub, err := h.comments.GetSubtree(c.Request.Context(), postID, uint(cid))
+	sub, err := h.comments.GetSubtree(c.Request.Context(), postID, uint(cid), h.viewerID(c))
 	if err != nil {
 		if errors.Is(err, service.ErrCommentNotFound) {
 			response.NotFound(c, response.BuildResponseCode(http.StatusNotFound, response.ServiceCodeComments, response.CaseCodeNotFound), "not found", "comment not found")
@@ -309,7 +311,11 @@ func (h *CommentHandler) Delete(c *gin.Context) {
 // @Tags         Comments
 // @Produce      json
 // @Param        id     path      int  true   "Post ID"
-// @Param        limit  query     int  false  "Max comments (max 200)"
+// @Param        limit      query     int     false  "Page size (max 200)"
+// @Param        sort       query     string  false  "Sort order (first is the default)"  Enums(tree,newest,oldest)
+// @Param        after      query     string  false  "Page after this cursor (a nextCursor)"
+// @Param        before     query     string  false  "Page before this cursor (a prevCursor)"
+// @Param        withTotal  query     bool    false  "Also return the total count"
 // @Success      200    {object}  response.Envelope
 // @Failure      400    {object}  response.Envelope
 // @Failure      500    {object}  response.Envelope
@@ -334,16 +340,17 @@ func (h *CommentHandler) List(c *gin.Context) {
 
 	page, err := h.comments.List(c.Request.Context(), req)
 	if err != nil {
-		h.internalError(c, response.ServiceCodeComments, err, "list failed")
+		h.listError(c, response.ServiceCodeComments, err)
 		return
 	}
 
-	response.OKPaginated(
+	response.OKCursorPaginated(
 		c,
 		response.BuildResponseCode(200, response.ServiceCodeComments, response.CaseCodeListRetrieved),
 		"ok",
 		page.Items,
-		page.NextCursor != nil,
-		page.PrevCursor != nil,
+		page.NextCursor,
+		page.PrevCursor,
+		page.Total,
 	)
 }
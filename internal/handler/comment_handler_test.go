@@ -32,12 +32,12 @@ func (m *mockCommentService) CreateChild(ctx context.Context, postID uint, paren
 	c, _ := args.Get(0).(*model.Comment)
 	return c, args.Error(1)
 }
-func (m *mockCommentService) GetTree(ctx context.Context, postID uint) ([]service.CommentTreeNode, error) {
-	args := m.Called(ctx, postID)
+func (m *mockCommentService) GetTree(ctx context.Context, postID uint, viewerID uint) ([]service.CommentTreeNode, error) {
+	args := m.Called(ctx, postID, viewerID)
 	return args.Get(0).([]service.CommentTreeNode), args.Error(1)
 }
-func (m *mockCommentService) GetSubtree(ctx context.Context, postID uint, commentID uint) ([]service.CommentTreeNode, error) {
-	args := m.Called(ctx, postID, commentID)
+func (m *mockCommentService) GetSubtree(ctx context.Context, postID uint, commentID uint, viewerID uint) ([]service.CommentTreeNode, error) {
+	args := m.Called(ctx, postID, commentID, viewerID)
 	return args.Get(0).([]service.CommentTreeNode), args.Error(1)
 }
 func (m *mockCommentService) Update(ctx context.Context, postID uint, commentID uint, userID uint, req request.UpdateCommentBody) (*model.Comment, error) {
@@ -0,0 +1,207 @@
+package handler
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/service"
+	"github.com/turahe/go-restfull/pkg/feed"
+	"github.com/turahe/go-restfull/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type feedService interface {
+	Feed(ctx context.Context, q service.FeedQuery) (*feed.Feed, error)
+}
+
+type FeedHandler struct {
+	BaseHandler
+	posts feedService
+}
+
+func NewFeedHandler(posts feedService, log *zap.Logger) *FeedHandler {
+	return &FeedHandler{BaseHandler: BaseHandler{Log: log}, posts: posts}
+}
+
+// Posts godoc
+// @Summary      Feed of published posts
+// @Description  The latest live posts as RSS 2.0 (posts.rss), Atom 1.0 (posts.atom) or JSON Feed 1.1 (posts.json). Channel metadata comes from the siteTitle, siteDescription and siteUrl settings; feedSize sets the item count. Supports If-None-Match and If-Modified-Since.
+// @Tags         Feeds
+// @Produce      xml
+// @Produce      json
+// @Param        file    path      string  true   "Feed file"  Enums(posts.rss,posts.atom,posts.json)
+// @Param        locale  query     string  false  "Content locale (BCP 47); defaults to the site default"
+// @Success      200
+// @Success      304
+// @Failure      400  {object}  response.Envelope
+// @Failure      404  {object}  response.Envelope
+// @Failure      500  {object}  response.Envelope
+// @Router       /feeds/{file} [get]
+func (h *FeedHandler) Posts(c *gin.Context) {
+	h.serve(c, service.FeedQuery{})
+}
+
+// Category godoc
+// @Summary      Feed of a category's published posts
+// @Description  Like /feeds/{file}, limited to posts in the category or its subcategories.
+// @Tags         Feeds
+// @Produce      xml
+// @Produce      json
+// @Param        slug    path      string  true   "Category slug"
+// @Param        file    path      string  true   "Feed file"  Enums(posts.rss,posts.atom,posts.json)
+// @Param        locale  query     string  false  "Content locale (BCP 47); defaults to the site default"
+// @Success      200
+// @Success      304
+// @Failure      301  {object}  response.Envelope
+// @Failure      400  {object}  response.Envelope
+// @Failure      404  {object}  response.Envelope
+// @Failure      500  {object}  response.Envelope
+// @Router       /feeds/categories/{slug}/{file} [get]
+func (h *FeedHandler) Category(c *gin.Context) {
+	h.serve(c, service.FeedQuery{CategorySlug: c.Param("slug")})
+}
+
+// Tag godoc
+// @Summary      Feed of a tag's published posts
+// @Description  Like /feeds/{file}, limited to posts with the tag (by slug or translated slug).
+// @Tags         Feeds
+// @Produce      xml
+// @Produce      json
+// @Param        slug    path      string  true   "Tag slug"
+// @Param        file    path      string  true   "Feed file"  Enums(posts.rss,posts.atom,posts.json)
+// @Param        locale  query     string  false  "Content locale (BCP 47); defaults to the site default"
+// @Success      200
+// @Success      304
+// @Failure      301  {object}  response.Envelope
+// @Failure      400  {object}  response.Envelope
+// @Failure      404  {object}  response.Envelope
+// @Failure      500  {object}  response.Envelope
+// @Router       /feeds/tags/{slug}/{file} [get]
+func (h *FeedHandler) Tag(c *gin.Context) {
+	h.serve(c, service.FeedQuery{TagSlug: c.Param("slug")})
+}
+
+// Author godoc
+// @Summary      Feed of an author's published posts
+// @Description  Like /feeds/{file}, limited to posts listing the user as an author.
+// @Tags         Feeds
+// @Produce      xml
+// @Produce      json
+// @Param        id      path      int     true   "User ID"
+// @Param        file    path      string  true   "Feed file"  Enums(posts.rss,posts.atom,posts.json)
+// @Param        locale  query     string  false  "Content locale (BCP 47); defaults to the site default"
+// @Success      200
+// @Success      304
+// @Failure      400  {object}  response.Envelope
+// @Failure      404  {object}  response.Envelope
+// @Failure      500  {object}  response.Envelope
+// @Router       /feeds/authors/{id}/{file} [get]
+func (h *FeedHandler) Author(c *gin.Context) {
+	id, err := h.ParseUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodePosts, response.CaseCodeInvalidValue), "invalid id", "id must be uint")
+		return
+	}
+	h.serve(c, service.FeedQuery{AuthorID: id})
+}
+
+func (h *FeedHandler) serve(c *gin.Context, q service.FeedQuery) {
+	format, ok := feedFormat(c.Param("file"))
+	if !ok {
+		response.NotFound(c, response.BuildResponseCode(http.StatusNotFound, response.ServiceCodePosts, response.CaseCodeNotFound), "not found", "feed not found")
+		return
+	}
+	q.Locale = c.Query("locale")
+	q.Origin = requestOrigin(c)
+	q.Path = c.Request.URL.RequestURI()
+
+	f, err := h.posts.Feed(c.Request.Context(), q)
+	if h.movedSlug(c, response.ServiceCodePosts, err) {
+		return
+	}
+	switch err {
+	case nil:
+	case service.ErrInvalidLocale:
+		response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodePosts, response.CaseCodeInvalidValue), "invalid request", err.Error())
+		return
+	case service.ErrCategoryNotFound, service.ErrTagNotFound, service.ErrUserNotFound:
+		response.NotFound(c, response.BuildResponseCode(http.StatusNotFound, response.ServiceCodePosts, response.CaseCodeNotFound), "not found", err.Error())
+		return
+	default:
+		h.internalError(c, response.ServiceCodePosts, err, "feed failed")
+		return
+	}
+	body, err := feed.Render(f, format)
+	if err != nil {
+		h.internalError(c, response.ServiceCodePosts, err, "feed render failed")
+		return
+	}
+	serveCacheable(c, format.ContentType(), body, f.Updated)
+}
+
+// feedFormat maps a feed file name (posts.rss, posts.atom, posts.json) to its format.
+func feedFormat(file string) (feed.Format, bool) {
+	name, ext, ok := strings.Cut(file, ".")
+	if !ok || name != "posts" {
+		return "", false
+	}
+	switch f := feed.Format(ext); f {
+	case feed.RSS, feed.Atom, feed.JSON:
+		return f, true
+	}
+	return "", false
+}
+
+// requestOrigin is the scheme://host the request was made to, honoring X-Forwarded-Proto from proxies.
+func requestOrigin(c *gin.Context) string {
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+	if p := c.GetHeader("X-Forwarded-Proto"); p == "http" || p == "https" {
+		scheme = p
+	}
+	return scheme + "://" + c.Request.Host
+}
+
+// serveCacheable writes body with an ETag (its content hash) and, when lastModified is set, a
+// Last-Modified header, answering 304 when the request's If-None-Match or If-Modified-Since matches.
+// If-None-Match takes precedence, as in RFC 9110.
+func serveCacheable(c *gin.Context, contentType string, body []byte, lastModified time.Time) {
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", "public, max-age=300")
+	if !lastModified.IsZero() {
+		c.Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+	if inm := c.GetHeader("If-None-Match"); inm != "" {
+		if etagMatches(inm, etag) {
+			c.Status(http.StatusNotModified)
+			return
+		}
+	} else if ims := c.GetHeader("If-Modified-Since"); ims != "" && !lastModified.IsZero() {
+		if t, err := http.ParseTime(ims); err == nil && !lastModified.Truncate(time.Second).After(t) {
+			c.Status(http.StatusNotModified)
+			return
+		}
+	}
+	c.Data(http.StatusOK, contentType, body)
+}
+
+// etagMatches reports whether an If-None-Match header lists etag (weak comparison) or is "*".
+func etagMatches(header, etag string) bool {
+	for _, t := range strings.Split(header, ",") {
+		t = strings.TrimSpace(t)
+		if t == "*" || strings.TrimPrefix(t, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
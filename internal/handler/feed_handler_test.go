@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/service"
+	"github.com/turahe/go-restfull/pkg/feed"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type mockFeedService struct{ mock.Mock }
+
+func (m *mockFeedService) Feed(ctx context.Context, q service.FeedQuery) (*feed.Feed, error) {
+	args := m.Called(ctx, q)
+	f, _ := args.Get(0).(*feed.Feed)
+	return f, args.Error(1)
+}
+
+func TestFeedHandler(t *testing.T) {
+	t.Parallel()
+	updated := time.Date(2026, 5, 4, 12, 0, 0, 0, time.UTC)
+	svc := new(mockFeedService)
+	svc.On("Feed", mock.Anything, mock.MatchedBy(func(q service.FeedQuery) bool { return q.TagSlug == "" && q.AuthorID == 0 })).
+		Return(&feed.Feed{Title: "Blog", Link: "https://blog.example", Updated: updated}, nil)
+	svc.On("Feed", mock.Anything, mock.MatchedBy(func(q service.FeedQuery) bool { return q.TagSlug == "nope" })).
+		Return(nil, service.ErrTagNotFound)
+	svc.On("Feed", mock.Anything, mock.MatchedBy(func(q service.FeedQuery) bool { return q.AuthorID == 7 })).
+		Return(&feed.Feed{Title: "Blog – Ada"}, nil)
+
+	h := NewFeedHandler(svc, zap.NewNop())
+	r := gin.New()
+	r.GET("/feeds/:file", h.Posts)
+	r.GET("/feeds/tags/:slug/:file", h.Tag)
+	r.GET("/feeds/authors/:id/:file", h.Author)
+
+	get := func(path string, header map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		for k, v := range header {
+			req.Header.Set(k, v)
+		}
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := get("/feeds/posts.atom", map[string]string{"X-Forwarded-Proto": "https"})
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/atom+xml; charset=utf-8", rr.Header().Get("Content-Type"))
+	assert.Equal(t, "Mon, 04 May 2026 12:00:00 GMT", rr.Header().Get("Last-Modified"))
+	etag := rr.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+	svc.AssertCalled(t, "Feed", mock.Anything, service.FeedQuery{Origin: "https://example.com", Path: "/feeds/posts.atom"})
+
+	assert.Equal(t, http.StatusNotModified, get("/feeds/posts.atom", map[string]string{"If-None-Match": `"other", ` + etag}).Code)
+	assert.Equal(t, http.StatusOK, get("/feeds/posts.atom", map[string]string{"If-None-Match": `"other"`}).Code)
+	assert.Equal(t, http.StatusNotModified, get("/feeds/posts.atom", map[string]string{"If-Modified-Since": "Mon, 04 May 2026 12:00:00 GMT"}).Code)
+	assert.Equal(t, http.StatusOK, get("/feeds/posts.atom", map[string]string{"If-Modified-Since": "Mon, 04 May 2026 11:59:59 GMT"}).Code)
+	assert.NotEqual(t, etag, get("/feeds/posts.rss", nil).Header().Get("ETag"), "each format has its own ETag")
+
+	assert.Equal(t, http.StatusNotFound, get("/feeds/posts.xml", nil).Code)
+	assert.Equal(t, http.StatusNotFound, get("/feeds/tags/nope/posts.json", nil).Code)
+	assert.Equal(t, http.StatusBadRequest, get("/feeds/authors/x/posts.json", nil).Code)
+
+	rr = get("/feeds/authors/7/posts.json", nil)
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Empty(t, rr.Header().Get("Last-Modified"), "an empty feed has no Last-Modified")
+	assert.Contains(t, rr.Body.String(), `"title": "Blog – Ada"`)
+}
@@ -24,22 +24,38 @@ type Deps struct {
 	JWT      *service.JWTService
 	RBAC     *service.RBACService
 	AuthRepo *repository.AuthRepository
+	Sites    middleware.SiteResolver
 
 	Handlers Handlers
 }
 
 type Handlers struct {
-	Health   *handler.HealthHandler
-	Auth     *handler.AuthHandler
-	User     *handler.UserHandler
-	Role     *handler.RoleHandler
-	Category *handler.CategoryHandler
-	Tag      *handler.TagHandler
-	Post     *handler.PostHandler
-	Comment  *handler.CommentHandler
-	Media    *handler.MediaHandler
-	RBAC     *handler.RBACHandler
-	Settings *handler.SettingsHandler
+	Health       *handler.HealthHandler
+	Auth         *handler.AuthHandler
+	User         *handler.UserHandler
+	Role         *handler.RoleHandler
+	Category     *handler.CategoryHandler
+	Tag          *handler.TagHandler
+	Post         *handler.PostHandler
+	PostRevision *handler.PostRevisionHandler
+	PostWorkflow *handler.PostWorkflowHandler
+	PostPreview  *handler.PostPreviewHandler
+	PostSearch   *handler.PostSearchHandler
+	PostRelated  *handler.PostRelatedHandler
+	PostMeta     *handler.PostMetaHandler
+	PostStats    *handler.PostStatsHandler
+	Reaction     *handler.ReactionHandler
+	ReadingList  *handler.ReadingListHandler
+	Trash        *handler.TrashHandler
+	Feed         *handler.FeedHandler
+	Sitemap      *handler.SitemapHandler
+	Series       *handler.SeriesHandler
+	Redirect     *handler.RedirectHandler
+	Comment      *handler.CommentHandler
+	Media        *handler.MediaHandler
+	RBAC         *handler.RBACHandler
+	Settings     *handler.SettingsHandler
+	Site         *handler.SiteHandler
 }
 
 func NewRouter(d Deps) *gin.Engine {
@@ -69,22 +85,49 @@ func NewRouter(d Deps) *gin.Engine {
 		r.GET("/swagger/*any", ginswagger.WrapHandler(swaggerfiles.Handler))
 	}
 
+	feeds := r.Group("/feeds")
+	feeds.Use(middleware.Site(d.Sites, d.Log))
+	{
+		feeds.GET("/:file", d.Handlers.Feed.Posts)
+		feeds.GET("/categories/:slug/:file", d.Handlers.Feed.Category)
+		feeds.GET("/tags/:slug/:file", d.Handlers.Feed.Tag)
+		feeds.GET("/authors/:id/:file", d.Handlers.Feed.Author)
+	}
+	r.GET("/sitemap.xml", middleware.Site(d.Sites, d.Log), d.Handlers.Sitemap.Index)
+	r.GET("/sitemaps/:file", middleware.Site(d.Sites, d.Log), d.Handlers.Sitemap.Page)
+
 	api := r.Group("/api/v1")
+	api.Use(middleware.Site(d.Sites, d.Log))
 	{
 		api.POST("auth/register", d.Handlers.Auth.Register)
 		api.POST("auth/login", d.Handlers.Auth.Login)
 		api.POST("auth/refresh", d.Handlers.Auth.Refresh)
 
-		api.GET("/posts", d.Handlers.Post.List)
-		api.GET("/posts/slug/:slug", d.Handlers.Post.GetBySlug)
-		api.GET("/posts/:id/comments/tree", d.Handlers.Comment.GetTree)
-		api.GET("/posts/:id/comments/:cid/subtree", d.Handlers.Comment.GetSubtree)
+		// Signed-in readers see their own reactions in lists and comment trees.
+		api.GET("/posts", middleware.OptionalJWTAuth(d.JWT, d.AuthRepo, d.Log), d.Handlers.Post.List)
+		api.GET("/posts/search", d.Handlers.PostSearch.Search)
+		api.GET("/posts/popular", d.Handlers.PostStats.Popular)
+		// Signed-in editors may read their unpublished posts by slug, so identify them when a token is sent.
+		api.GET("/posts/slug/:slug", middleware.OptionalJWTAuth(d.JWT, d.AuthRepo, d.Log), d.Handlers.Post.GetBySlug)
+		api.GET("/posts/slug/:slug/meta", middleware.OptionalJWTAuth(d.JWT, d.AuthRepo, d.Log), d.Handlers.PostMeta.Meta)
+		api.GET("/preview/:token", d.Handlers.PostPreview.Get)
+		api.GET("/posts/:id/related", d.Handlers.PostRelated.Related)
+		api.GET("/posts/:id/comments/tree", middleware.OptionalJWTAuth(d.JWT, d.AuthRepo, d.Log), d.Handlers.Comment.GetTree)
+		api.GET("/posts/:id/comments/:cid/subtree", middleware.OptionalJWTAuth(d.JWT, d.AuthRepo, d.Log), d.Handlers.Comment.GetSubtree)
+		api.GET("/reactions/types", d.Handlers.Reaction.Types)
 		api.GET("/categories", d.Handlers.Category.List)
 		api.GET("/categories/tree", d.Handlers.Category.GetTree)
 		api.GET("/categories/:id/subtree", d.Handlers.Category.GetSubtree)
+		api.GET("/categories/:id/translations", d.Handlers.Category.ListTranslations)
 		api.GET("/tags", d.Handlers.Tag.List)
 		api.GET("/tags/:slug", d.Handlers.Tag.GetBySlug)
+		// Shares the :slug wildcard of the route above; writes address tags by id.
+		api.GET("/tags/:slug/translations", d.Handlers.Tag.ListTranslations)
+		api.GET("/series", d.Handlers.Series.List)
+		api.GET("/series/slug/:slug", d.Handlers.Series.GetBySlug)
 		api.GET("/settings", d.Handlers.Settings.Get)
+		api.GET("/redirects/resolve", d.Handlers.Redirect.Resolve)
+		api.GET("/reading-lists/shared/:slug", d.Handlers.ReadingList.Shared)
 
 		auth := api.Group("")
 		auth.Use(middleware.JWTAuth(d.JWT, d.AuthRepo, d.Log))
@@ -100,19 +143,69 @@ func NewRouter(d Deps) *gin.Engine {
 			auth.POST("/posts", d.Handlers.Post.Create)
 			auth.PUT("/posts/:id", d.Handlers.Post.Update)
 			auth.DELETE("/posts/:id", d.Handlers.Post.Delete)
+			auth.GET("/posts/:id/revisions", d.Handlers.PostRevision.List)
+			auth.GET("/posts/:id/revisions/:rid/diff", d.Handlers.PostRevision.Diff)
+			auth.POST("/posts/:id/revisions/:rid/restore", d.Handlers.PostRevision.Restore)
+			auth.GET("/posts/workflow", d.Handlers.PostWorkflow.Workflow)
+			auth.GET("/posts/:id/transitions", d.Handlers.PostWorkflow.Transitions)
+			auth.POST("/posts/:id/transitions", d.Handlers.PostWorkflow.Transition)
+			auth.GET("/posts/:id/reviewers", d.Handlers.PostWorkflow.Reviewers)
+			auth.POST("/posts/:id/reviewers", d.Handlers.PostWorkflow.AssignReviewer)
+			auth.DELETE("/posts/:id/reviewers/:userId", d.Handlers.PostWorkflow.UnassignReviewer)
+			auth.GET("/posts/:id/review-comments", d.Handlers.PostWorkflow.ReviewComments)
+			auth.POST("/posts/:id/review-comments", d.Handlers.PostWorkflow.AddReviewComment)
+			auth.POST("/posts/:id/preview-links", d.Handlers.PostPreview.Create)
+			auth.GET("/posts/:id/preview-links", d.Handlers.PostPreview.List)
+			auth.DELETE("/posts/:id/preview-links/:lid", d.Handlers.PostPreview.Revoke)
+			auth.GET("/posts/:id/stats", d.Handlers.PostStats.Stats)
 			auth.POST("/posts/:id/comments/root", d.Handlers.Comment.CreateRoot)
 			auth.POST("/posts/:id/comments/:cid/child", d.Handlers.Comment.CreateChild)
 			auth.PUT("/posts/:id/comments/:cid", d.Handlers.Comment.Update)
 			auth.DELETE("/posts/:id/comments/:cid", d.Handlers.Comment.Delete)
+			auth.PUT("/posts/:id/reactions/:type", d.Handlers.Reaction.PutPost)
+			auth.DELETE("/posts/:id/reactions/:type", d.Handlers.Reaction.DeletePost)
+			auth.PUT("/posts/:id/comments/:cid/reactions/:type", d.Handlers.Reaction.PutComment)
+			auth.DELETE("/posts/:id/comments/:cid/reactions/:type", d.Handlers.Reaction.DeleteComment)
 
 			auth.POST("/categories/root", d.Handlers.Category.CreateRoot)
 			auth.POST("/categories/:id/child", d.Handlers.Category.CreateChild)
 			auth.PUT("/categories/:id", d.Handlers.Category.Update)
 			auth.DELETE("/categories/:id", d.Handlers.Category.Delete)
+			auth.PUT("/categories/:id/translations/:locale", d.Handlers.Category.PutTranslation)
+			auth.DELETE("/categories/:id/translations/:locale", d.Handlers.Category.DeleteTranslation)
 
 			auth.POST("/tags", d.Handlers.Tag.Create)
 			auth.PUT("/tags/:id", d.Handlers.Tag.Update)
 			auth.DELETE("/tags/:id", d.Handlers.Tag.Delete)
+			auth.PUT("/tags/:id/translations/:locale", d.Handlers.Tag.PutTranslation)
+			auth.DELETE("/tags/:id/translations/:locale", d.Handlers.Tag.DeleteTranslation)
+
+			auth.POST("/series", d.Handlers.Series.Create)
+			auth.PUT("/series/:id", d.Handlers.Series.Update)
+			auth.DELETE("/series/:id", d.Handlers.Series.Delete)
+			auth.GET("/series/:id/posts", d.Handlers.Series.ListPosts)
+			auth.POST("/series/:id/posts", d.Handlers.Series.AddPost)
+			auth.PUT("/series/:id/posts", d.Handlers.Series.Reorder)
+			auth.DELETE("/series/:id/posts/:postId", d.Handlers.Series.RemovePost)
+
+			auth.GET("/redirects", d.Handlers.Redirect.List)
+			auth.POST("/redirects", d.Handlers.Redirect.Create)
+			auth.PUT("/redirects/:id", d.Handlers.Redirect.Update)
+			auth.DELETE("/redirects/:id", d.Handlers.Redirect.Delete)
+
+			auth.GET("/trash", d.Handlers.Trash.List)
+			auth.POST("/trash/:type/:id/restore", d.Handlers.Trash.Restore)
+			auth.DELETE("/trash/:type/:id", d.Handlers.Trash.Purge)
+
+			auth.GET("/reading-lists", d.Handlers.ReadingList.List)
+			auth.POST("/reading-lists", d.Handlers.ReadingList.Create)
+			auth.GET("/reading-lists/:id", d.Handlers.ReadingList.Get)
+			auth.PUT("/reading-lists/:id", d.Handlers.ReadingList.Update)
+			auth.DELETE("/reading-lists/:id", d.Handlers.ReadingList.Delete)
+			auth.POST("/reading-lists/:id/items", d.Handlers.ReadingList.AddItem)
+			auth.PUT("/reading-lists/:id/items", d.Handlers.ReadingList.Reorder)
+			auth.PUT("/reading-lists/:id/items/:postId", d.Handlers.ReadingList.MarkRead)
+			auth.DELETE("/reading-lists/:id/items/:postId", d.Handlers.ReadingList.RemoveItem)
 
 			auth.GET("/media/tree", d.Handlers.Media.GetTree)
 			auth.GET("/media/:id/subtree", d.Handlers.Media.GetSubtree)
@@ -134,6 +227,13 @@ func NewRouter(d Deps) *gin.Engine {
 
 			auth.POST("/rbac/assign-role", d.Handlers.RBAC.AssignRole)
 			auth.POST("/rbac/add-permission", d.Handlers.RBAC.AddPermission)
+			auth.POST("/rbac/add-role-inheritance", d.Handlers.RBAC.AddRoleInheritance)
+			auth.POST("/rbac/remove-role-inheritance", d.Handlers.RBAC.RemoveRoleInheritance)
+			auth.GET("/rbac/explain", d.Handlers.RBAC.Explain)
+			auth.GET("/rbac/decisions/:id", d.Handlers.RBAC.GetDecision)
+
+			auth.GET("/sites", d.Handlers.Site.List)
+			auth.POST("/sites", d.Handlers.Site.Create)
 		}
 
 		api.GET("/posts/:id/comments", d.Handlers.Comment.List)
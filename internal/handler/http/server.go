@@ -14,6 +14,7 @@ import (
 	"github.com/turahe/go-restfull/internal/handler"
 	"github.com/turahe/go-restfull/internal/rbac"
 	"github.com/turahe/go-restfull/internal/repository"
+	"github.com/turahe/go-restfull/internal/search"
 	"github.com/turahe/go-restfull/internal/service"
 	"github.com/turahe/go-restfull/pkg/logger"
 
@@ -67,6 +68,14 @@ func Serve(ctx context.Context) error {
 		log.Fatal("casbin init failed", zap.Error(err))
 	}
 	rbacSvc := service.NewRBACService(enf, db.Gorm, log)
+	if cfg.RBACDecisionLogSize > 0 {
+		rbacSvc.EnableDecisionLog(cfg.RBACDecisionLogSize)
+	}
+
+	// Background jobs stop when Serve returns.
+	bgCtx, stopBackground := context.WithCancel(ctx)
+	defer stopBackground()
+	go rbacSvc.RunGrantSweeper(bgCtx, time.Duration(cfg.RBACGrantSweepMinutes)*time.Minute)
 
 	// Repositories
 	userRepo := repository.NewUserRepository(db.Gorm, log)
@@ -76,10 +85,18 @@ func Serve(ctx context.Context) error {
 	tagRepo := repository.NewTagRepository(db.Gorm, log)
 	roleRepo := repository.NewRoleRepository(db.Gorm, log)
 	postRepo := repository.NewPostRepository(db.Gorm, log)
+	postRevisionRepo := repository.NewPostRevisionRepository(db.Gorm, log)
+	postPreviewRepo := repository.NewPostPreviewLinkRepository(db.Gorm, log)
+	postWorkflowRepo := repository.NewPostWorkflowRepository(db.Gorm, log)
+	seriesRepo := repository.NewSeriesRepository(db.Gorm, log)
+	redirectRepo := repository.NewRedirectRepository(db.Gorm, log)
+	readingListRepo := repository.NewReadingListRepository(db.Gorm, log)
+	trashRepo := repository.NewTrashRepository(db.Gorm, log)
 	commentRepo := repository.NewCommentRepository(db.Gorm, log)
 	twoFARepo := repository.NewTwoFactorRepository(db.Gorm, log)
 	mediaRepo := repository.NewMediaRepository(db.Gorm, log)
 	settingRepo := repository.NewSettingRepository(db.Gorm, log)
+	siteRepo := repository.NewSiteRepository(db.Gorm, log)
 
 	// Services
 	twoFASvc := service.NewTwoFactorService(twoFARepo, []byte(cfg.TwoFactorEncKey), cfg.TwoFactorIssuer, log)
@@ -104,9 +121,45 @@ func Serve(ctx context.Context) error {
 	roleSvc := service.NewRoleService(roleRepo, log)
 	categorySvc := service.NewCategoryService(categoryRepo, log)
 	tagSvc := service.NewTagService(tagRepo, log)
-	postSvc := service.NewPostService(postRepo, categoryRepo, tagRepo, log)
+	searchIndex, err := search.New(cfg.SearchEngine, db.Gorm)
+	if err != nil {
+		return err
+	}
+	postSvc := service.NewPostService(postRepo, categoryRepo, tagRepo, postRevisionRepo, settingRepo, log).
+		WithPreviews(postPreviewRepo, []byte(cfg.PreviewTokenSecret)).
+		WithSearch(searchIndex).
+		WithWorkflow(postWorkflowRepo, rbacSvc)
+	postRevisionSvc := service.NewPostRevisionService(postSvc, postRevisionRepo, log)
+	seriesSvc := service.NewSeriesService(postSvc, seriesRepo, log)
+	redirectSvc := service.NewRedirectService(redirectRepo, log)
+	readingListSvc := service.NewReadingListService(postSvc, readingListRepo, log)
+	trashSvc := service.NewTrashService(trashRepo, postSvc, mediaSvc, cfg.TrashRetentionDays, log)
+	go trashSvc.RunPurger(bgCtx, time.Hour)
+	// Views are buffered in Redis when configured, else in memory, like the rate limiter.
+	var viewBuf service.ViewBuffer
+	switch {
+	case cfg.ViewFlushSeconds <= 0:
+	case rdb != nil:
+		viewBuf = service.NewRedisViewBuffer(rdb, cfg.ViewKeyPrefix)
+	default:
+		viewBuf = service.NewMemoryViewBuffer()
+	}
+	postStatsSvc := service.NewPostStatsService(postSvc, viewBuf, time.Duration(cfg.ViewDedupMinutes)*time.Minute, log)
+	go postStatsSvc.RunFlusher(bgCtx, time.Duration(cfg.ViewFlushSeconds)*time.Second)
+	go postSvc.RunScheduler(bgCtx, time.Duration(cfg.PostSchedulerSeconds)*time.Second)
+	prepareSearchIndex(ctx, postSvc, searchIndex, cfg.SearchIndexPath, log)
+	if mem, ok := searchIndex.(*search.Memory); ok && cfg.SearchIndexPath != "" {
+		defer func() {
+			if err := mem.Save(cfg.SearchIndexPath); err != nil {
+				log.Warn("search index save failed", zap.Error(err))
+			}
+		}()
+	}
 	commentSvc := service.NewCommentService(commentRepo, tagRepo, log)
+	reactionSvc := service.NewReactionService(postRepo, commentRepo, settingRepo, log)
 	settingsSvc := service.NewSettingsService(settingRepo)
+	sitemapSvc := service.NewSitemapService(postRepo, categoryRepo, tagRepo, settingRepo, log)
+	siteSvc := service.NewSiteService(siteRepo, cfg.SiteStrictHost, log)
 
 	// Handlers
 	healthH := handler.NewHealthHandler(db.SQL, rdb, cfg)
@@ -115,11 +168,26 @@ func Serve(ctx context.Context) error {
 	roleH := handler.NewRoleHandler(roleSvc, log)
 	categoryH := handler.NewCategoryHandler(categorySvc, log)
 	tagH := handler.NewTagHandler(tagSvc, log)
-	postH := handler.NewPostHandler(postSvc, log)
+	postH := handler.NewPostHandler(postSvc, postStatsSvc, log)
+	postRevisionH := handler.NewPostRevisionHandler(postRevisionSvc, log)
+	postWorkflowH := handler.NewPostWorkflowHandler(postSvc, log)
+	postPreviewH := handler.NewPostPreviewHandler(postSvc, log)
+	postSearchH := handler.NewPostSearchHandler(postSvc, log)
+	postRelatedH := handler.NewPostRelatedHandler(postSvc, log)
+	postMetaH := handler.NewPostMetaHandler(postSvc, log)
+	postStatsH := handler.NewPostStatsHandler(postStatsSvc, log)
+	feedH := handler.NewFeedHandler(postSvc, log)
+	sitemapH := handler.NewSitemapHandler(sitemapSvc, log)
+	seriesH := handler.NewSeriesHandler(seriesSvc, log)
+	redirectH := handler.NewRedirectHandler(redirectSvc, log)
+	readingListH := handler.NewReadingListHandler(readingListSvc, log)
+	trashH := handler.NewTrashHandler(trashSvc, log)
 	commentH := handler.NewCommentHandler(commentSvc, log)
+	reactionH := handler.NewReactionHandler(reactionSvc, log)
 	mediaH := handler.NewMediaHandler(mediaSvc, log)
 	rbacH := handler.NewRBACHandler(rbacSvc, log)
 	settingsH := handler.NewSettingsHandler(settingsSvc, log)
+	siteH := handler.NewSiteHandler(siteSvc, log)
 
 	r := NewRouter(Deps{
 		Cfg:      cfg,
@@ -128,18 +196,34 @@ func Serve(ctx context.Context) error {
 		JWT:      jwtm,
 		RBAC:     rbacSvc,
 		AuthRepo: authRepo,
+		Sites:    siteSvc,
 		Handlers: Handlers{
-			Health:   healthH,
-			Auth:     authH,
-			User:     userH,
-			Role:     roleH,
-			Category: categoryH,
-			Tag:      tagH,
-			Post:     postH,
-			Comment:  commentH,
-			Media:    mediaH,
-			RBAC:     rbacH,
-			Settings: settingsH,
+			Health:       healthH,
+			Auth:         authH,
+			User:         userH,
+			Role:         roleH,
+			Category:     categoryH,
+			Tag:          tagH,
+			Post:         postH,
+			PostRevision: postRevisionH,
+			PostWorkflow: postWorkflowH,
+			PostPreview:  postPreviewH,
+			PostSearch:   postSearchH,
+			PostRelated:  postRelatedH,
+			PostMeta:     postMetaH,
+			PostStats:    postStatsH,
+			Reaction:     reactionH,
+			Feed:         feedH,
+			Sitemap:      sitemapH,
+			Series:       seriesH,
+			Redirect:     redirectH,
+			ReadingList:  readingListH,
+			Trash:        trashH,
+			Comment:      commentH,
+			Media:        mediaH,
+			RBAC:         rbacH,
+			Settings:     settingsH,
+			Site:         siteH,
 		},
 	})
 
@@ -181,3 +265,24 @@ func Serve(ctx context.Context) error {
 	log.Info("server stopped")
 	return nil
 }
+
+// prepareSearchIndex makes sure the index can answer queries: MySQL gets its FULLTEXT index, and the
+// memory index is loaded from its snapshot or rebuilt from the database. Failures only degrade search.
+func prepareSearchIndex(ctx context.Context, posts *service.PostService, idx search.Index, path string, log *zap.Logger) {
+	if mem, ok := idx.(*search.Memory); ok && path != "" {
+		loaded, err := mem.Load(ctx, path)
+		if err != nil {
+			log.Warn("search index load failed; rebuilding", zap.Error(err))
+		}
+		if loaded {
+			log.Info("search index loaded", zap.String("path", path))
+			return
+		}
+	}
+	n, err := posts.Reindex(ctx)
+	if err != nil {
+		log.Warn("search index preparation failed", zap.String("engine", idx.Engine()), zap.Error(err))
+		return
+	}
+	log.Info("search index ready", zap.String("engine", idx.Engine()), zap.Int("posts", n))
+}
@@ -293,7 +293,11 @@ func (h *MediaHandler) UpdateMedia(c *gin.Context) {
 // @Tags         Media
 // @Produce      json
 // @Security     BearerAuth
-// @Param        limit  query     int  false  "Max items (max 500)"
+// @Param        limit      query     int     false  "Page size (max 200)"
+// @Param        sort       query     string  false  "Sort order (first is the default)"  Enums(tree,newest,name)
+// @Param        after      query     string  false  "Page after this cursor (a nextCursor)"
+// @Param        before     query     string  false  "Page before this cursor (a prevCursor)"
+// @Param        withTotal  query     bool    false  "Also return the total count"
 // @Success      200    {object}  response.Envelope
 // @Failure      401    {object}  response.Envelope
 // @Failure      500    {object}  response.Envelope
@@ -312,13 +316,11 @@ func (h *MediaHandler) ListMedia(c *gin.Context) {
 
 	page, err := h.mediaSvc.List(c.Request.Context(), auth.UserID, req)
 	if err != nil {
-		h.internalError(c, response.ServiceCodeMedia, err, "list failed")
+		h.listError(c, response.ServiceCodeMedia, err)
 		return
 	}
 
-	next := page.NextCursor != nil
-	prev := page.PrevCursor != nil
-	response.OKPaginated(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodeMedia, response.CaseCodeListRetrieved), "Successfully retrieved media list", page.Items, next, prev)
+	response.OKCursorPaginated(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodeMedia, response.CaseCodeListRetrieved), "Successfully retrieved media list", page.Items, page.NextCursor, page.PrevCursor, page.Total)
 }
 
 // GetMediaByID godoc
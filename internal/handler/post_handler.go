@@ -18,27 +18,38 @@ import (
 type PostHandler struct {
 	BaseHandler
 	posts PostService
+	// views counts reads of posts fetched by slug; nil disables view tracking.
+	views postViewRecorder
 }
 
 type PostService interface {
 	List(ctx context.Context, req request.PostListRequest) (repository.CursorPage, error)
-	GetBySlug(ctx context.Context, slug string) (*model.Post, error)
+	GetBySlug(ctx context.Context, slug string, access service.PostAccess, pref service.LocalePreference) (*model.Post, error)
 	Create(ctx context.Context, userID uint, req request.CreatePostRequest) (*model.Post, error)
 	Update(ctx context.Context, id uint, actorUserID uint, req request.UpdatePostRequest) (*model.Post, error)
 	Delete(ctx context.Context, id uint, actorUserID uint) error
 }
 
-func NewPostHandler(posts PostService, log *zap.Logger) *PostHandler {
-	return &PostHandler{BaseHandler: BaseHandler{Log: log}, posts: posts}
+type postViewRecorder interface {
+	RecordView(ctx context.Context, p *model.Post, v service.Visitor)
+}
+
+func NewPostHandler(posts PostService, views postViewRecorder, log *zap.Logger) *PostHandler {
+	return &PostHandler{BaseHandler: BaseHandler{Log: log}, posts: posts, views: views}
 }
 
 // ListPosts godoc
 // @Summary      List posts (cursor pagination)
+// @Description  Each post carries its reaction totals; with a bearer token it also lists the caller's own reactions.
 // @Tags         Posts
 // @Produce      json
-// @Param        limit   query     int     false  "Page size (max 50)"  minimum(1)  maximum(50)
-// @Param        cursor  query     string  false  "Cursor (post id)"
-// @Param        dir     query     string  false  "Direction: next|prev"  Enums(next,prev)
+// @Param        limit      query     int     false  "Page size (max 50)"
+// @Param        sort       query     string  false  "Sort order (first is the default)"  Enums(oldest,newest,title)
+// @Param        after      query     string  false  "Page after this cursor (a nextCursor)"
+// @Param        before     query     string  false  "Page before this cursor (a prevCursor)"
+// @Param        withTotal  query     bool    false  "Also return the total count"
+// @Param        locale     query     string  false  "Content locale (BCP 47); defaults to Accept-Language, then the site default"
+// @Param        readingListId  query     int     false  "Only posts in this reading list (the caller's own, or a shared one)"
 // @Success      200     {object}  response.Envelope
 // @Failure      400     {object}  response.Envelope
 // @Failure      500     {object}  response.Envelope
@@ -52,38 +63,54 @@ func (h *PostHandler) List(c *gin.Context) {
 	if !h.validate(c, response.ServiceCodePosts, req) {
 		return
 	}
+	req.AcceptLanguage = c.GetHeader("Accept-Language")
+	req.ViewerID = h.viewerID(c)
+	c.Header("Vary", "Accept-Language")
 
 	page, err := h.posts.List(c.Request.Context(), req)
 	if err != nil {
-		h.internalError(c, response.ServiceCodePosts, err, "list failed")
+		h.listError(c, response.ServiceCodePosts, err)
 		return
 	}
 
-	next := page.NextCursor != nil
-	prev := page.PrevCursor != nil
-
-	response.OKPaginated(c,
+	response.OKCursorPaginated(c,
 		response.BuildResponseCode(http.StatusOK, response.ServiceCodePosts, response.CaseCodeListRetrieved),
 		"Successfully retrieved posts",
 		page.Items,
-		next,
-		prev,
+		page.NextCursor,
+		page.PrevCursor,
+		page.Total,
 	)
 }
 
 // GetPostBySlug godoc
 // @Summary      Get post by slug
+// @Description  Unpublished posts are returned only with a valid preview token or to a signed-in user who may edit them.
+// @Description  A translated post is served in the requested locale when a live variant exists, else in the site default locale.
+// @Description  Reads of live posts count as views, once per visitor within the dedup window.
 // @Tags         Posts
 // @Produce      json
-// @Param        slug  path      string  true  "Post slug"
+// @Param        slug     path      string  true   "Post slug"
+// @Param        preview  query     string  false  "Preview token"
+// @Param        locale   query     string  false  "Content locale (BCP 47); defaults to Accept-Language, then the site default"
 // @Success      200   {object}  response.Envelope
+// @Failure      301   {object}  response.Envelope
 // @Failure      400   {object}  response.Envelope
 // @Failure      404   {object}  response.Envelope
 // @Router       /api/v1/posts/slug/{slug} [get]
 func (h *PostHandler) GetBySlug(c *gin.Context) {
 	slug := c.Param("slug")
-	p, err := h.posts.GetBySlug(c.Request.Context(), slug)
+	access := service.PostAccess{PreviewToken: c.Query("preview")}
+	if auth, ok := middleware.GetAuth(c); ok {
+		access.ViewerID = auth.UserID
+	}
+	pref := service.LocalePreference{Requested: c.Query("locale"), AcceptLanguage: c.GetHeader("Accept-Language")}
+	c.Header("Vary", "Accept-Language")
+	p, err := h.posts.GetBySlug(c.Request.Context(), slug, access, pref)
 	if err != nil {
+		if h.movedSlug(c, response.ServiceCodePosts, err) {
+			return
+		}
 		if err == service.ErrPostNotFound {
 			response.NotFound(c, response.BuildResponseCode(http.StatusNotFound, response.ServiceCodePosts, response.CaseCodeNotFound), "not found", "post not found")
 			return
@@ -91,6 +118,10 @@ func (h *PostHandler) GetBySlug(c *gin.Context) {
 		response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodePosts, response.CaseCodeInvalidFormat), "invalid request", err.Error())
 		return
 	}
+	if h.views != nil {
+		h.views.RecordView(c.Request.Context(), p, service.Visitor{UserID: access.ViewerID, IP: c.ClientIP(), UserAgent: c.Request.UserAgent()})
+	}
+	c.Header("Content-Language", p.Locale)
 	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodePosts, response.CaseCodeRetrieved), "Successfully retrieved post by slug", p)
 }
 
@@ -104,6 +135,8 @@ func (h *PostHandler) GetBySlug(c *gin.Context) {
 // @Success      201   {object}  response.Envelope
 // @Failure      400   {object}  response.Envelope
 // @Failure      401   {object}  response.Envelope
+// @Failure      403   {object}  response.Envelope
+// @Failure      409   {object}  response.Envelope
 // @Router       /api/v1/posts [post]
 func (h *PostHandler) Create(c *gin.Context) {
 	auth, ok := middleware.GetAuth(c)
@@ -122,6 +155,17 @@ func (h *PostHandler) Create(c *gin.Context) {
 
 	p, err := h.posts.Create(c.Request.Context(), auth.UserID, req)
 	if err != nil {
+		switch err {
+		case service.ErrCategoryOutOfScope, service.ErrNotPostOwner, service.ErrTransitionForbidden:
+			response.Forbidden(c, response.BuildResponseCode(http.StatusForbidden, response.ServiceCodePosts, response.CaseCodePermissionDenied), "forbidden", err.Error())
+			return
+		case service.ErrTranslationExists:
+			response.Conflict(c, response.BuildResponseCode(http.StatusConflict, response.ServiceCodePosts, response.CaseCodeDuplicateEntry), "conflict", err.Error())
+			return
+		case service.ErrTransitionNotAllowed:
+			response.Conflict(c, response.BuildResponseCode(http.StatusConflict, response.ServiceCodePosts, response.CaseCodeConflict), "conflict", err.Error())
+			return
+		}
 		response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodePosts, response.CaseCodeInvalidValue), "invalid request", err.Error())
 		return
 	}
@@ -129,7 +173,8 @@ func (h *PostHandler) Create(c *gin.Context) {
 }
 
 // UpdatePost godoc
-// @Summary      Update a post (owner only)
+// @Summary      Update a post (owner or category-scoped editor)
+// @Description  With the editorial workflow enabled, a status change must match a transition the caller may run (409 when none exists, 403 when not permitted).
 // @Tags         Posts
 // @Accept       json
 // @Produce      json
@@ -141,6 +186,7 @@ func (h *PostHandler) Create(c *gin.Context) {
 // @Failure      401   {object}  response.Envelope
 // @Failure      403   {object}  response.Envelope
 // @Failure      404   {object}  response.Envelope
+// @Failure      409   {object}  response.Envelope
 // @Failure      500   {object}  response.Envelope
 // @Router       /api/v1/posts/{id} [put]
 func (h *PostHandler) Update(c *gin.Context) {
@@ -171,6 +217,12 @@ func (h *PostHandler) Update(c *gin.Context) {
 			response.NotFound(c, response.BuildResponseCode(http.StatusNotFound, response.ServiceCodePosts, response.CaseCodeNotFound), "not found", "post not found")
 		case service.ErrNotPostOwner:
 			response.Forbidden(c, response.BuildResponseCode(http.StatusForbidden, response.ServiceCodePosts, response.CaseCodePermissionDenied), "forbidden", "owner only")
+		case service.ErrCategoryOutOfScope, service.ErrTransitionForbidden:
+			response.Forbidden(c, response.BuildResponseCode(http.StatusForbidden, response.ServiceCodePosts, response.CaseCodePermissionDenied), "forbidden", err.Error())
+		case service.ErrTransitionNotAllowed:
+			response.Conflict(c, response.BuildResponseCode(http.StatusConflict, response.ServiceCodePosts, response.CaseCodeConflict), "conflict", err.Error())
+		case service.ErrInvalidSchedule, service.ErrPostNeedsAuthor, service.ErrDuplicateContributor, service.ErrContributorNotFound, service.ErrInvalidSlug:
+			response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodePosts, response.CaseCodeInvalidValue), "invalid request", err.Error())
 		default:
 			h.internalError(c, response.ServiceCodePosts, err, "update failed")
 		}
@@ -181,7 +233,7 @@ func (h *PostHandler) Update(c *gin.Context) {
 }
 
 // DeletePost godoc
-// @Summary      Delete a post (owner only)
+// @Summary      Delete a post (owner or category-scoped editor)
 // @Tags         Posts
 // @Produce      json
 // @Security     BearerAuth
@@ -213,6 +265,8 @@ func (h *PostHandler) Delete(c *gin.Context) {
 			response.NotFound(c, response.BuildResponseCode(http.StatusNotFound, response.ServiceCodePosts, response.CaseCodeNotFound), "not found", "post not found")
 		case service.ErrNotPostOwner:
 			response.Forbidden(c, response.BuildResponseCode(http.StatusForbidden, response.ServiceCodePosts, response.CaseCodePermissionDenied), "forbidden", "owner only")
+		case service.ErrCategoryOutOfScope:
+			response.Forbidden(c, response.BuildResponseCode(http.StatusForbidden, response.ServiceCodePosts, response.CaseCodePermissionDenied), "forbidden", err.Error())
 		default:
 			h.internalError(c, response.ServiceCodePosts, err, "delete failed")
 		}
@@ -26,8 +26,8 @@ func (m *mockPostService) List(ctx context.Context, req request.PostListRequest)
 	args := m.Called(ctx, req)
 	return args.Get(0).(repository.CursorPage), args.Error(1)
 }
-func (m *mockPostService) GetBySlug(ctx context.Context, slug string) (*model.Post, error) {
-	args := m.Called(ctx, slug)
+func (m *mockPostService) GetBySlug(ctx context.Context, slug string, access service.PostAccess, pref service.LocalePreference) (*model.Post, error) {
+	args := m.Called(ctx, slug, access, pref)
 	p, _ := args.Get(0).(*model.Post)
 	return p, args.Error(1)
 }
@@ -60,7 +60,7 @@ func TestPostHandler_List_InvalidCursor(t *testing.T) {
 	t.Parallel()
 
 	svc := &mockPostService{}
-	h := NewPostHandler(svc, nil)
+	h := NewPostHandler(svc, nil, nil)
 	r := gin.New()
 	r.GET("/api/v1/posts", h.List)
 
@@ -78,7 +78,7 @@ func TestPostHandler_List_Success_Defaults(t *testing.T) {
 	t.Parallel()
 
 	svc := &mockPostService{}
-	h := NewPostHandler(svc, nil)
+	h := NewPostHandler(svc, nil, nil)
 	r := gin.New()
 	r.GET("/api/v1/posts", h.List)
 
@@ -103,17 +103,18 @@ func TestPostHandler_GetBySlug(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
-		name       string
-		slug       string
-		setupMock  func(s *mockPostService)
-		wantStatus int
-		wantMsg    string
+		name         string
+		slug         string
+		setupMock    func(s *mockPostService)
+		wantStatus   int
+		wantMsg      string
+		wantLocation string
 	}{
 		{
 			name: "not found",
 			slug: "x",
 			setupMock: func(s *mockPostService) {
-				s.On("GetBySlug", mock.Anything, "x").Return((*model.Post)(nil), service.ErrPostNotFound).Once()
+				s.On("GetBySlug", mock.Anything, "x", service.PostAccess{}, service.LocalePreference{}).Return((*model.Post)(nil), service.ErrPostNotFound).Once()
 			},
 			wantStatus: http.StatusNotFound,
 			wantMsg:    "not found",
@@ -122,7 +123,7 @@ func TestPostHandler_GetBySlug(t *testing.T) {
 			name: "invalid slug maps to bad request",
 			slug: "%20",
 			setupMock: func(s *mockPostService) {
-				s.On("GetBySlug", mock.Anything, " ").Return((*model.Post)(nil), service.ErrInvalidSlug).Once()
+				s.On("GetBySlug", mock.Anything, " ", service.PostAccess{}, service.LocalePreference{}).Return((*model.Post)(nil), service.ErrInvalidSlug).Once()
 			},
 			wantStatus: http.StatusBadRequest,
 			wantMsg:    "invalid request",
@@ -131,7 +132,26 @@ func TestPostHandler_GetBySlug(t *testing.T) {
 			name: "success",
 			slug: "hello",
 			setupMock: func(s *mockPostService) {
-				s.On("GetBySlug", mock.Anything, "hello").Return(&model.Post{ID: 1, Slug: "hello"}, nil).Once()
+				s.On("GetBySlug", mock.Anything, "hello", service.PostAccess{}, service.LocalePreference{}).Return(&model.Post{ID: 1, Slug: "hello"}, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+			wantMsg:    "Successfully retrieved post by slug",
+		},
+		{
+			name: "old slug redirects to the current one",
+			slug: "old?locale=id",
+			setupMock: func(s *mockPostService) {
+				s.On("GetBySlug", mock.Anything, "old", service.PostAccess{}, service.LocalePreference{Requested: "id"}).Return((*model.Post)(nil), &service.SlugMovedError{Slug: "new slug"}).Once()
+			},
+			wantStatus:   http.StatusMovedPermanently,
+			wantMsg:      "moved permanently",
+			wantLocation: "/api/v1/posts/slug/new%20slug?locale=id",
+		},
+		{
+			name: "preview token is passed on",
+			slug: "draft?preview=tok",
+			setupMock: func(s *mockPostService) {
+				s.On("GetBySlug", mock.Anything, "draft", service.PostAccess{PreviewToken: "tok"}, service.LocalePreference{}).Return(&model.Post{ID: 2, Slug: "draft"}, nil).Once()
 			},
 			wantStatus: http.StatusOK,
 			wantMsg:    "Successfully retrieved post by slug",
@@ -144,7 +164,7 @@ func TestPostHandler_GetBySlug(t *testing.T) {
 			t.Parallel()
 			svc := &mockPostService{}
 			tc.setupMock(svc)
-			h := NewPostHandler(svc, nil)
+			h := NewPostHandler(svc, nil, nil)
 			r := gin.New()
 			r.GET("/api/v1/posts/slug/:slug", h.GetBySlug)
 
@@ -156,7 +176,35 @@ func TestPostHandler_GetBySlug(t *testing.T) {
 			assert.Equal(t, tc.wantStatus, rr.Code)
 			env := decodeEnvelopePost(t, rr)
 			assert.Equal(t, tc.wantMsg, env.Message)
+			assert.Equal(t, tc.wantLocation, rr.Header().Get("Location"))
 			svc.AssertExpectations(t)
 		})
 	}
 }
+
+type mockPostViews struct{ mock.Mock }
+
+func (m *mockPostViews) RecordView(ctx context.Context, p *model.Post, v service.Visitor) {
+	m.Called(ctx, p, v)
+}
+
+func TestPostHandler_GetBySlug_RecordsView(t *testing.T) {
+	t.Parallel()
+	svc := &mockPostService{}
+	views := &mockPostViews{}
+	post := &model.Post{ID: 1, Slug: "hello"}
+	svc.On("GetBySlug", mock.Anything, "hello", service.PostAccess{}, service.LocalePreference{}).Return(post, nil).Once()
+	views.On("RecordView", mock.Anything, post, service.Visitor{IP: "192.0.2.1", UserAgent: "Mozilla/5.0"}).Once()
+	h := NewPostHandler(svc, views, nil)
+	r := gin.New()
+	r.GET("/api/v1/posts/slug/:slug", h.GetBySlug)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/posts/slug/hello", nil)
+	req.RemoteAddr = "192.0.2.1:4000"
+	req.Header.Set("User-Agent", "Mozilla/5.0")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	views.AssertExpectations(t)
+}
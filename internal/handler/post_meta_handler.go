@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/turahe/go-restfull/internal/middleware"
+	"github.com/turahe/go-restfull/internal/service"
+	"github.com/turahe/go-restfull/internal/service/dto"
+	"github.com/turahe/go-restfull/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type postMetaService interface {
+	Meta(ctx context.Context, slug string, access service.PostAccess, pref service.LocalePreference, origin string) (*dto.PostMeta, error)
+}
+
+type PostMetaHandler struct {
+	BaseHandler
+	posts postMetaService
+}
+
+func NewPostMetaHandler(posts postMetaService, log *zap.Logger) *PostMetaHandler {
+	return &PostMetaHandler{BaseHandler: BaseHandler{Log: log}, posts: posts}
+}
+
+// Meta godoc
+// @Summary      Head metadata of a post by slug
+// @Description  Title, description, canonical and hreflang links, Open Graph and Twitter card fields, and schema.org BlogPosting and BreadcrumbList JSON-LD (home, category ancestors, post). Empty SEO fields fall back to the post's title, excerpt, content and first image, then to the siteTitle, siteUrl, defaultShareImage and twitterSite settings. The post is resolved like GET /api/v1/posts/slug/{slug}, including ?preview= and the locale choice.
+// @Tags         Posts
+// @Produce      json
+// @Param        slug     path      string  true   "Post slug"
+// @Param        locale   query     string  false  "Content locale (BCP 47); defaults to Accept-Language, then the site default"
+// @Param        preview  query     string  false  "Preview token for an unpublished post"
+// @Success      200      {object}  response.Envelope{data=dto.PostMeta}
+// @Failure      301      {object}  response.Envelope
+// @Failure      400      {object}  response.Envelope
+// @Failure      404      {object}  response.Envelope
+// @Failure      500      {object}  response.Envelope
+// @Router       /api/v1/posts/slug/{slug}/meta [get]
+func (h *PostMetaHandler) Meta(c *gin.Context) {
+	access := service.PostAccess{PreviewToken: c.Query("preview")}
+	if auth, ok := middleware.GetAuth(c); ok {
+		access.ViewerID = auth.UserID
+	}
+	pref := service.LocalePreference{Requested: c.Query("locale"), AcceptLanguage: c.GetHeader("Accept-Language")}
+	c.Header("Vary", "Accept-Language")
+
+	meta, err := h.posts.Meta(c.Request.Context(), c.Param("slug"), access, pref, requestOrigin(c))
+	if h.movedSlug(c, response.ServiceCodePosts, err) {
+		return
+	}
+	switch err {
+	case nil:
+		c.Header("Content-Language", meta.Locale)
+		response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodePosts, response.CaseCodeRetrieved), "Successfully retrieved post metadata", meta)
+	case service.ErrPostNotFound:
+		response.NotFound(c, response.BuildResponseCode(http.StatusNotFound, response.ServiceCodePosts, response.CaseCodeNotFound), "not found", "post not found")
+	case service.ErrInvalidSlug, service.ErrInvalidLocale:
+		response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodePosts, response.CaseCodeInvalidValue), "invalid request", err.Error())
+	default:
+		h.internalError(c, response.ServiceCodePosts, err, "post metadata failed")
+	}
+}
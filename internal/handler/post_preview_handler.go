@@ -0,0 +1,184 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/handler/request"
+	"github.com/turahe/go-restfull/internal/middleware"
+	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/internal/service"
+	"github.com/turahe/go-restfull/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type postPreviewService interface {
+	CreatePreviewLink(ctx context.Context, postID, actorUserID uint, ttl time.Duration) (*service.CreatedPreviewLink, error)
+	ListPreviewLinks(ctx context.Context, postID, actorUserID uint) ([]model.PostPreviewLink, error)
+	RevokePreviewLink(ctx context.Context, postID, linkID, actorUserID uint) error
+	GetByPreviewToken(ctx context.Context, token string) (*model.Post, error)
+}
+
+type PostPreviewHandler struct {
+	BaseHandler
+	previews postPreviewService
+}
+
+func NewPostPreviewHandler(previews postPreviewService, log *zap.Logger) *PostPreviewHandler {
+	return &PostPreviewHandler{BaseHandler: BaseHandler{Log: log}, previews: previews}
+}
+
+func (h *PostPreviewHandler) writeError(c *gin.Context, err error, message string) {
+	switch err {
+	case service.ErrPostNotFound:
+		response.NotFound(c, response.BuildResponseCode(http.StatusNotFound, response.ServiceCodePosts, response.CaseCodeNotFound), "not found", "post not found")
+	case service.ErrPreviewLinkNotFound:
+		response.NotFound(c, response.BuildResponseCode(http.StatusNotFound, response.ServiceCodePosts, response.CaseCodeNotFound), "not found", "preview link not found")
+	case service.ErrInvalidPreviewToken:
+		response.NotFound(c, response.BuildResponseCode(http.StatusNotFound, response.ServiceCodePosts, response.CaseCodeNotFound), "not found", err.Error())
+	case service.ErrNotPostOwner:
+		response.Forbidden(c, response.BuildResponseCode(http.StatusForbidden, response.ServiceCodePosts, response.CaseCodePermissionDenied), "forbidden", "owner only")
+	case service.ErrCategoryOutOfScope:
+		response.Forbidden(c, response.BuildResponseCode(http.StatusForbidden, response.ServiceCodePosts, response.CaseCodePermissionDenied), "forbidden", err.Error())
+	case service.ErrInvalidPreviewLinkTTL, service.ErrPreviewLinksDisabled:
+		response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodePosts, response.CaseCodeInvalidValue), "invalid request", err.Error())
+	default:
+		h.internalError(c, response.ServiceCodePosts, err, message)
+	}
+}
+
+// CreatePreviewLink godoc
+// @Summary      Create a preview link for a post (owner or category-scoped editor)
+// @Description  Returns a signed token, shown only once, that lets anyone read the post before it is published via GET /api/v1/preview/{token} or ?preview= on the slug route.
+// @Tags         Posts
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      int                               true   "Post ID"
+// @Param        body  body      request.CreatePreviewLinkRequest  false  "Link lifetime"
+// @Success      201   {object}  response.Envelope
+// @Failure      400   {object}  response.Envelope
+// @Failure      401   {object}  response.Envelope
+// @Failure      403   {object}  response.Envelope
+// @Failure      404   {object}  response.Envelope
+// @Failure      500   {object}  response.Envelope
+// @Router       /api/v1/posts/{id}/preview-links [post]
+func (h *PostPreviewHandler) Create(c *gin.Context) {
+	auth, ok := middleware.GetAuth(c)
+	if !ok {
+		response.Unauthorized(c, response.BuildResponseCode(http.StatusUnauthorized, response.ServiceCodePosts, response.CaseCodeUnauthorized), "unauthorized", "missing auth")
+		return
+	}
+	id, err := h.ParseUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodePosts, response.CaseCodeInvalidValue), "invalid id", "id must be uint")
+		return
+	}
+	var req request.CreatePreviewLinkRequest
+	if c.Request.ContentLength != 0 && !h.bindJSON(c, response.ServiceCodePosts, &req) {
+		return
+	}
+	if !h.validate(c, response.ServiceCodePosts, req) {
+		return
+	}
+
+	link, err := h.previews.CreatePreviewLink(c.Request.Context(), id, auth.UserID, time.Duration(req.ExpiresInHours)*time.Hour)
+	if err != nil {
+		h.writeError(c, err, "create preview link failed")
+		return
+	}
+	response.Created(c, response.BuildResponseCode(http.StatusCreated, response.ServiceCodePosts, response.CaseCodeCreated), "Successfully created preview link", link)
+}
+
+// ListPreviewLinks godoc
+// @Summary      List a post's preview links, including expired and revoked ones
+// @Tags         Posts
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Post ID"
+// @Success      200  {object}  response.Envelope
+// @Failure      400  {object}  response.Envelope
+// @Failure      401  {object}  response.Envelope
+// @Failure      403  {object}  response.Envelope
+// @Failure      404  {object}  response.Envelope
+// @Failure      500  {object}  response.Envelope
+// @Router       /api/v1/posts/{id}/preview-links [get]
+func (h *PostPreviewHandler) List(c *gin.Context) {
+	auth, ok := middleware.GetAuth(c)
+	if !ok {
+		response.Unauthorized(c, response.BuildResponseCode(http.StatusUnauthorized, response.ServiceCodePosts, response.CaseCodeUnauthorized), "unauthorized", "missing auth")
+		return
+	}
+	id, err := h.ParseUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodePosts, response.CaseCodeInvalidValue), "invalid id", "id must be uint")
+		return
+	}
+	links, err := h.previews.ListPreviewLinks(c.Request.Context(), id, auth.UserID)
+	if err != nil {
+		h.writeError(c, err, "list preview links failed")
+		return
+	}
+	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodePosts, response.CaseCodeListRetrieved), "Successfully retrieved preview links", links)
+}
+
+// RevokePreviewLink godoc
+// @Summary      Revoke a preview link
+// @Tags         Posts
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Post ID"
+// @Param        lid  path      int  true  "Preview link ID"
+// @Success      200  {object}  response.Envelope
+// @Failure      400  {object}  response.Envelope
+// @Failure      401  {object}  response.Envelope
+// @Failure      403  {object}  response.Envelope
+// @Failure      404  {object}  response.Envelope
+// @Failure      500  {object}  response.Envelope
+// @Router       /api/v1/posts/{id}/preview-links/{lid} [delete]
+func (h *PostPreviewHandler) Revoke(c *gin.Context) {
+	auth, ok := middleware.GetAuth(c)
+	if !ok {
+		response.Unauthorized(c, response.BuildResponseCode(http.StatusUnauthorized, response.ServiceCodePosts, response.CaseCodeUnauthorized), "unauthorized", "missing auth")
+		return
+	}
+	id, err := h.ParseUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodePosts, response.CaseCodeInvalidValue), "invalid id", "id must be uint")
+		return
+	}
+	lid, err := h.ParseUintParam(c, "lid")
+	if err != nil {
+		response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodePosts, response.CaseCodeInvalidValue), "invalid link id", "lid must be uint")
+		return
+	}
+	if err := h.previews.RevokePreviewLink(c.Request.Context(), id, lid, auth.UserID); err != nil {
+		h.writeError(c, err, "revoke preview link failed")
+		return
+	}
+	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodePosts, response.CaseCodeDeleted), "Successfully revoked preview link", nil)
+}
+
+// GetPostPreview godoc
+// @Summary      Read a post through a preview link
+// @Description  Works for drafts, scheduled and archived posts. Responses are not cacheable and ask crawlers not to index them.
+// @Tags         Posts
+// @Produce      json
+// @Param        token  path      string  true  "Preview token"
+// @Success      200    {object}  response.Envelope
+// @Failure      404    {object}  response.Envelope
+// @Failure      500    {object}  response.Envelope
+// @Router       /api/v1/preview/{token} [get]
+func (h *PostPreviewHandler) Get(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	c.Header("X-Robots-Tag", "noindex, nofollow")
+	p, err := h.previews.GetByPreviewToken(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		h.writeError(c, err, "preview failed")
+		return
+	}
+	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodePosts, response.CaseCodeRetrieved), "Successfully retrieved post preview", p)
+}
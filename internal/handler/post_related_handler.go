@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/turahe/go-restfull/internal/handler/request"
+	"github.com/turahe/go-restfull/internal/service"
+	"github.com/turahe/go-restfull/internal/service/dto"
+	"github.com/turahe/go-restfull/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type postRelatedService interface {
+	Related(ctx context.Context, id uint, limit int) ([]dto.RelatedPost, error)
+}
+
+type PostRelatedHandler struct {
+	BaseHandler
+	posts postRelatedService
+}
+
+func NewPostRelatedHandler(posts postRelatedService, log *zap.Logger) *PostRelatedHandler {
+	return &PostRelatedHandler{BaseHandler: BaseHandler{Log: log}, posts: posts}
+}
+
+// RelatedPosts godoc
+// @Summary      Posts related to a published post
+// @Description  Other live posts ranked by shared tags, same or ancestor category, and recency; the per-site relatedPostWeights setting tunes the weights. Rankings are cached for a few minutes.
+// @Tags         Posts
+// @Produce      json
+// @Param        id     path      int  true   "Post ID"
+// @Param        limit  query     int  false  "How many (default 5, max 20)"
+// @Success      200    {object}  response.Envelope
+// @Failure      400    {object}  response.Envelope
+// @Failure      404    {object}  response.Envelope
+// @Failure      500    {object}  response.Envelope
+// @Router       /api/v1/posts/{id}/related [get]
+func (h *PostRelatedHandler) Related(c *gin.Context) {
+	id, err := h.ParseUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodePosts, response.CaseCodeInvalidValue), "invalid id", "id must be uint")
+		return
+	}
+	var req request.RelatedPostsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodePosts, response.CaseCodeInvalidFormat), "invalid request", err.Error())
+		return
+	}
+	if !h.validate(c, response.ServiceCodePosts, req) {
+		return
+	}
+
+	rows, err := h.posts.Related(c.Request.Context(), id, req.Limit)
+	switch err {
+	case nil:
+		response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodePosts, response.CaseCodeListRetrieved), "Successfully retrieved related posts", rows)
+	case service.ErrPostNotFound:
+		response.NotFound(c, response.BuildResponseCode(http.StatusNotFound, response.ServiceCodePosts, response.CaseCodeNotFound), "not found", "post not found")
+	default:
+		h.internalError(c, response.ServiceCodePosts, err, "related posts failed")
+	}
+}
@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/turahe/go-restfull/internal/middleware"
+	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/internal/service"
+	"github.com/turahe/go-restfull/internal/service/dto"
+	"github.com/turahe/go-restfull/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type postRevisionService interface {
+	List(ctx context.Context, postID, actorUserID uint) ([]model.PostRevision, error)
+	Diff(ctx context.Context, postID, id, against, actorUserID uint) (*dto.PostRevisionDiff, error)
+	Restore(ctx context.Context, postID, id, actorUserID uint) (*model.Post, error)
+}
+
+type PostRevisionHandler struct {
+	BaseHandler
+	revisions postRevisionService
+}
+
+func NewPostRevisionHandler(revisions postRevisionService, log *zap.Logger) *PostRevisionHandler {
+	return &PostRevisionHandler{BaseHandler: BaseHandler{Log: log}, revisions: revisions}
+}
+
+// params reads the caller and the post id (and the revision id when withRevision is set), writing the
+// error response itself when one is missing or malformed.
+func (h *PostRevisionHandler) params(c *gin.Context, withRevision bool) (actorUserID, postID, revisionID uint, ok bool) {
+	auth, ok := middleware.GetAuth(c)
+	if !ok {
+		response.Unauthorized(c, response.BuildResponseCode(http.StatusUnauthorized, response.ServiceCodePosts, response.CaseCodeUnauthorized), "unauthorized", "missing auth")
+		return 0, 0, 0, false
+	}
+	postID, err := h.ParseUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodePosts, response.CaseCodeInvalidValue), "invalid id", "id must be uint")
+		return 0, 0, 0, false
+	}
+	if withRevision {
+		revisionID, err = h.ParseUintParam(c, "rid")
+		if err != nil {
+			response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodePosts, response.CaseCodeInvalidValue), "invalid revision id", "rid must be uint")
+			return 0, 0, 0, false
+		}
+	}
+	return auth.UserID, postID, revisionID, true
+}
+
+func (h *PostRevisionHandler) writeError(c *gin.Context, err error, message string) {
+	switch err {
+	case service.ErrPostNotFound:
+		response.NotFound(c, response.BuildResponseCode(http.StatusNotFound, response.ServiceCodePosts, response.CaseCodeNotFound), "not found", "post not found")
+	case service.ErrPostRevisionNotFound:
+		response.NotFound(c, response.BuildResponseCode(http.StatusNotFound, response.ServiceCodePosts, response.CaseCodeNotFound), "not found", "revision not found")
+	case service.ErrNotPostOwner:
+		response.Forbidden(c, response.BuildResponseCode(http.StatusForbidden, response.ServiceCodePosts, response.CaseCodePermissionDenied), "forbidden", "owner only")
+	case service.ErrCategoryOutOfScope:
+		response.Forbidden(c, response.BuildResponseCode(http.StatusForbidden, response.ServiceCodePosts, response.CaseCodePermissionDenied), "forbidden", err.Error())
+	default:
+		h.internalError(c, response.ServiceCodePosts, err, message)
+	}
+}
+
+// ListPostRevisions godoc
+// @Summary      List a post's revisions, newest first (owner or category-scoped editor)
+// @Description  Content is omitted; use the diff endpoint to compare revisions.
+// @Tags         Posts
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Post ID"
+// @Success      200  {object}  response.Envelope
+// @Failure      400  {object}  response.Envelope
+// @Failure      401  {object}  response.Envelope
+// @Failure      403  {object}  response.Envelope
+// @Failure      404  {object}  response.Envelope
+// @Failure      500  {object}  response.Envelope
+// @Router       /api/v1/posts/{id}/revisions [get]
+func (h *PostRevisionHandler) List(c *gin.Context) {
+	actor, postID, _, ok := h.params(c, false)
+	if !ok {
+		return
+	}
+	revs, err := h.revisions.List(c.Request.Context(), postID, actor)
+	if err != nil {
+		h.writeError(c, err, "list revisions failed")
+		return
+	}
+	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodePosts, response.CaseCodeListRetrieved), "Successfully retrieved post revisions", revs)
+}
+
+// DiffPostRevision godoc
+// @Summary      Diff a post revision against another (default: the one before it)
+// @Description  Returns changed scalar fields and a line diff of the content.
+// @Tags         Posts
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      int  true   "Post ID"
+// @Param        rid      path      int  true   "Revision ID"
+// @Param        against  query     int  false  "Revision ID to compare with"
+// @Success      200      {object}  response.Envelope
+// @Failure      400      {object}  response.Envelope
+// @Failure      401      {object}  response.Envelope
+// @Failure      403      {object}  response.Envelope
+// @Failure      404      {object}  response.Envelope
+// @Failure      500      {object}  response.Envelope
+// @Router       /api/v1/posts/{id}/revisions/{rid}/diff [get]
+func (h *PostRevisionHandler) Diff(c *gin.Context) {
+	actor, postID, revID, ok := h.params(c, true)
+	if !ok {
+		return
+	}
+	var against uint64
+	if s := strings.TrimSpace(c.Query("against")); s != "" {
+		var err error
+		if against, err = strconv.ParseUint(s, 10, 64); err != nil {
+			response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodePosts, response.CaseCodeInvalidValue), "invalid against", "against must be uint")
+			return
+		}
+	}
+	d, err := h.revisions.Diff(c.Request.Context(), postID, revID, uint(against), actor)
+	if err != nil {
+		h.writeError(c, err, "diff revisions failed")
+		return
+	}
+	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodePosts, response.CaseCodeRetrieved), "Successfully diffed post revisions", d)
+}
+
+// RestorePostRevision godoc
+// @Summary      Restore a post revision (owner or category-scoped editor)
+// @Description  Writes the revision's title, content, category, tags, layout and SEO back to the post and records a new revision. Status and schedule are unchanged.
+// @Tags         Posts
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Post ID"
+// @Param        rid  path      int  true  "Revision ID"
+// @Success      200  {object}  response.Envelope
+// @Failure      400  {object}  response.Envelope
+// @Failure      401  {object}  response.Envelope
+// @Failure      403  {object}  response.Envelope
+// @Failure      404  {object}  response.Envelope
+// @Failure      500  {object}  response.Envelope
+// @Router       /api/v1/posts/{id}/revisions/{rid}/restore [post]
+func (h *PostRevisionHandler) Restore(c *gin.Context) {
+	actor, postID, revID, ok := h.params(c, true)
+	if !ok {
+		return
+	}
+	p, err := h.revisions.Restore(c.Request.Context(), postID, revID, actor)
+	if err != nil {
+		h.writeError(c, err, "restore revision failed")
+		return
+	}
+	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodePosts, response.CaseCodeUpdated), "Successfully restored post revision", p)
+}
@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/turahe/go-restfull/internal/handler/request"
+	"github.com/turahe/go-restfull/internal/service"
+	"github.com/turahe/go-restfull/internal/service/dto"
+	"github.com/turahe/go-restfull/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type postSearchService interface {
+	Search(ctx context.Context, req request.PostSearchRequest) (*dto.PostSearchResult, error)
+}
+
+type PostSearchHandler struct {
+	BaseHandler
+	posts postSearchService
+}
+
+func NewPostSearchHandler(posts postSearchService, log *zap.Logger) *PostSearchHandler {
+	return &PostSearchHandler{BaseHandler: BaseHandler{Log: log}, posts: posts}
+}
+
+// SearchPosts godoc
+// @Summary      Full-text search over published posts
+// @Description  Results are ranked by relevance and carry highlighted title and content fragments (matches wrapped in <mark>). All given tags must be present.
+// @Tags         Posts
+// @Produce      json
+// @Param        q           query     string  true   "Search text"
+// @Param        tagId       query     []int   false  "Tag filter (repeatable)"  collectionFormat(multi)
+// @Param        categoryId  query     int     false  "Category filter"
+// @Param        authorId    query     int     false  "Author filter"
+// @Param        from        query     string  false  "Published on or after (YYYY-MM-DD)"
+// @Param        to          query     string  false  "Published on or before (YYYY-MM-DD)"
+// @Param        page        query     int     false  "Page (default 1)"
+// @Param        limit       query     int     false  "Page size (default 10, max 50)"
+// @Success      200         {object}  response.Envelope
+// @Failure      400         {object}  response.Envelope
+// @Failure      500         {object}  response.Envelope
+// @Failure      503         {object}  response.Envelope
+// @Router       /api/v1/posts/search [get]
+func (h *PostSearchHandler) Search(c *gin.Context) {
+	var req request.PostSearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodePosts, response.CaseCodeInvalidFormat), "invalid request", err.Error())
+		return
+	}
+	if !h.validate(c, response.ServiceCodePosts, req) {
+		return
+	}
+	if req.From != nil && req.To != nil && req.To.Before(*req.From) {
+		response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodePosts, response.CaseCodeInvalidValue), "invalid request", "to must not be before from")
+		return
+	}
+
+	res, err := h.posts.Search(c.Request.Context(), req)
+	switch {
+	case err == nil:
+		response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodePosts, response.CaseCodeListRetrieved), "Successfully searched posts", res)
+	case errors.Is(err, service.ErrInvalidSearch):
+		response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodePosts, response.CaseCodeInvalidValue), "invalid request", err.Error())
+	case errors.Is(err, service.ErrSearchUnavailable):
+		response.JSON(c, http.StatusServiceUnavailable, response.BuildResponseCode(http.StatusServiceUnavailable, response.ServiceCodePosts, response.CaseCodeInternalError), "search unavailable", nil, err.Error())
+	default:
+		h.internalError(c, response.ServiceCodePosts, err, "search failed")
+	}
+}
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/turahe/go-restfull/internal/handler/request"
+	"github.com/turahe/go-restfull/internal/middleware"
+	"github.com/turahe/go-restfull/internal/service"
+	"github.com/turahe/go-restfull/internal/service/dto"
+	"github.com/turahe/go-restfull/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type postStatsService interface {
+	Popular(ctx context.Context, window string, limit int) ([]dto.PopularPost, error)
+	Stats(ctx context.Context, postID, actorUserID uint, days int) (*dto.PostStats, error)
+}
+
+type PostStatsHandler struct {
+	BaseHandler
+	stats postStatsService
+}
+
+func NewPostStatsHandler(stats postStatsService, log *zap.Logger) *PostStatsHandler {
+	return &PostStatsHandler{BaseHandler: BaseHandler{Log: log}, stats: stats}
+}
+
+// PopularPosts godoc
+// @Summary      Most viewed live posts
+// @Description  Ranks live posts by views over the window, in whole UTC days ending today. Counts are flushed periodically, so the latest views may be missing.
+// @Tags         Posts
+// @Produce      json
+// @Param        window  query     string  false  "Days to count, e.g. 7d (default) or 30d; at most 365d"
+// @Param        limit   query     int     false  "How many (default 10, max 50)"
+// @Success      200     {object}  response.Envelope
+// @Failure      400     {object}  response.Envelope
+// @Failure      500     {object}  response.Envelope
+// @Router       /api/v1/posts/popular [get]
+func (h *PostStatsHandler) Popular(c *gin.Context) {
+	var req request.PopularPostsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodePosts, response.CaseCodeInvalidFormat), "invalid request", err.Error())
+		return
+	}
+	if !h.validate(c, response.ServiceCodePosts, req) {
+		return
+	}
+
+	rows, err := h.stats.Popular(c.Request.Context(), req.Window, req.Limit)
+	switch err {
+	case nil:
+		response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodePosts, response.CaseCodeListRetrieved), "Successfully retrieved popular posts", rows)
+	case service.ErrInvalidWindow:
+		response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodePosts, response.CaseCodeInvalidValue), "invalid request", err.Error())
+	default:
+		h.internalError(c, response.ServiceCodePosts, err, "popular posts failed")
+	}
+}
+
+// PostStats godoc
+// @Summary      A post's views per day (owner or category-scoped editor)
+// @Description  Every day of the span is listed, zero when the post had no views; total covers all time. Views since the last flush are not counted yet.
+// @Tags         Posts
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      int  true   "Post ID"
+// @Param        days  query     int  false  "Days of history ending today (default 30, max 365)"
+// @Success      200   {object}  response.Envelope{data=dto.PostStats}
+// @Failure      400   {object}  response.Envelope
+// @Failure      401   {object}  response.Envelope
+// @Failure      403   {object}  response.Envelope
+// @Failure      404   {object}  response.Envelope
+// @Failure      500   {object}  response.Envelope
+// @Router       /api/v1/posts/{id}/stats [get]
+func (h *PostStatsHandler) Stats(c *gin.Context) {
+	auth, ok := middleware.GetAuth(c)
+	if !ok {
+		response.Unauthorized(c, response.BuildResponseCode(http.StatusUnauthorized, response.ServiceCodePosts, response.CaseCodeUnauthorized), "unauthorized", "missing auth")
+		return
+	}
+	id, err := h.ParseUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodePosts, response.CaseCodeInvalidValue), "invalid id", "id must be uint")
+		return
+	}
+	var req request.PostStatsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodePosts, response.CaseCodeInvalidFormat), "invalid request", err.Error())
+		return
+	}
+	if !h.validate(c, response.ServiceCodePosts, req) {
+		return
+	}
+
+	st, err := h.stats.Stats(c.Request.Context(), id, auth.UserID, req.Days)
+	switch err {
+	case nil:
+		response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodePosts, response.CaseCodeRetrieved), "Successfully retrieved post stats", st)
+	case service.ErrPostNotFound:
+		response.NotFound(c, response.BuildResponseCode(http.StatusNotFound, response.ServiceCodePosts, response.CaseCodeNotFound), "not found", "post not found")
+	case service.ErrNotPostOwner, service.ErrCategoryOutOfScope:
+		response.Forbidden(c, response.BuildResponseCode(http.StatusForbidden, response.ServiceCodePosts, response.CaseCodePermissionDenied), "forbidden", err.Error())
+	default:
+		h.internalError(c, response.ServiceCodePosts, err, "post stats failed")
+	}
+}
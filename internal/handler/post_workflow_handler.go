@@ -0,0 +1,312 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/turahe/go-restfull/internal/handler/request"
+	"github.com/turahe/go-restfull/internal/middleware"
+	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/internal/service"
+	"github.com/turahe/go-restfull/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type postWorkflowService interface {
+	Workflow(ctx context.Context) (*service.Workflow, error)
+	Transition(ctx context.Context, postID, actorUserID uint, req request.PostTransitionRequest) (*model.Post, error)
+	Transitions(ctx context.Context, postID, actorUserID uint) ([]model.PostTransition, error)
+	Reviewers(ctx context.Context, postID, actorUserID uint) ([]model.PostReviewer, error)
+	AssignReviewer(ctx context.Context, postID, actorUserID uint, req request.AssignPostReviewerRequest) ([]model.PostReviewer, error)
+	UnassignReviewer(ctx context.Context, postID, reviewerUserID, actorUserID uint) error
+	ReviewComments(ctx context.Context, postID, actorUserID uint) ([]model.PostReviewComment, error)
+	AddReviewComment(ctx context.Context, postID, actorUserID uint, req request.CreatePostReviewCommentRequest) (*model.PostReviewComment, error)
+}
+
+type PostWorkflowHandler struct {
+	BaseHandler
+	posts postWorkflowService
+}
+
+func NewPostWorkflowHandler(posts postWorkflowService, log *zap.Logger) *PostWorkflowHandler {
+	return &PostWorkflowHandler{BaseHandler: BaseHandler{Log: log}, posts: posts}
+}
+
+// params reads the caller and the post id, writing the error response itself when one is missing or malformed.
+func (h *PostWorkflowHandler) params(c *gin.Context) (actorUserID, postID uint, ok bool) {
+	auth, ok := middleware.GetAuth(c)
+	if !ok {
+		response.Unauthorized(c, response.BuildResponseCode(http.StatusUnauthorized, response.ServiceCodePosts, response.CaseCodeUnauthorized), "unauthorized", "missing auth")
+		return 0, 0, false
+	}
+	postID, err := h.ParseUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodePosts, response.CaseCodeInvalidValue), "invalid id", "id must be uint")
+		return 0, 0, false
+	}
+	return auth.UserID, postID, true
+}
+
+func (h *PostWorkflowHandler) writeError(c *gin.Context, err error, message string) {
+	switch err {
+	case service.ErrPostNotFound:
+		response.NotFound(c, response.BuildResponseCode(http.StatusNotFound, response.ServiceCodePosts, response.CaseCodeNotFound), "not found", "post not found")
+	case service.ErrPostRevisionNotFound:
+		response.NotFound(c, response.BuildResponseCode(http.StatusNotFound, response.ServiceCodePosts, response.CaseCodeNotFound), "not found", "revision not found")
+	case service.ErrReviewerNotFound:
+		response.NotFound(c, response.BuildResponseCode(http.StatusNotFound, response.ServiceCodePosts, response.CaseCodeNotFound), "not found", err.Error())
+	case service.ErrWorkflowDisabled:
+		response.NotFound(c, response.BuildResponseCode(http.StatusNotFound, response.ServiceCodePosts, response.CaseCodeNotFound), "not found", err.Error())
+	case service.ErrUnknownTransition, service.ErrReviewerIsAuthor, service.ErrInvalidSchedule:
+		response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodePosts, response.CaseCodeInvalidValue), "invalid request", err.Error())
+	case service.ErrTransitionNotAllowed:
+		response.Conflict(c, response.BuildResponseCode(http.StatusConflict, response.ServiceCodePosts, response.CaseCodeConflict), "conflict", err.Error())
+	case service.ErrNotPostOwner:
+		response.Forbidden(c, response.BuildResponseCode(http.StatusForbidden, response.ServiceCodePosts, response.CaseCodePermissionDenied), "forbidden", "owner or reviewer only")
+	case service.ErrCategoryOutOfScope, service.ErrTransitionForbidden:
+		response.Forbidden(c, response.BuildResponseCode(http.StatusForbidden, response.ServiceCodePosts, response.CaseCodePermissionDenied), "forbidden", err.Error())
+	default:
+		h.internalError(c, response.ServiceCodePosts, err, message)
+	}
+}
+
+// GetPostWorkflow godoc
+// @Summary      Get the site's editorial workflow
+// @Description  Lists the workflow's initial status, its states and its named transitions (from, to, and who may run them: author, reviewer or anyone holding the transition's permission).
+// @Tags         Posts
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Envelope
+// @Failure      401  {object}  response.Envelope
+// @Failure      404  {object}  response.Envelope
+// @Failure      500  {object}  response.Envelope
+// @Router       /api/v1/posts/workflow [get]
+func (h *PostWorkflowHandler) Workflow(c *gin.Context) {
+	w, err := h.posts.Workflow(c.Request.Context())
+	if err != nil {
+		h.writeError(c, err, "get workflow failed")
+		return
+	}
+	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodePosts, response.CaseCodeRetrieved), "Successfully retrieved post workflow", w)
+}
+
+// TransitionPost godoc
+// @Summary      Run a workflow transition on a post
+// @Description  Requires permission POST on /api/v1/posts/:id/transitions/{transition}; author transitions are limited to the post's owners (or category-scoped editors) and reviewer transitions to its assigned reviewers. The change is recorded in the post's history.
+// @Tags         Posts
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      int                            true  "Post ID"
+// @Param        body  body      request.PostTransitionRequest  true  "Transition payload"
+// @Success      200   {object}  response.Envelope
+// @Failure      400   {object}  response.Envelope
+// @Failure      401   {object}  response.Envelope
+// @Failure      403   {object}  response.Envelope
+// @Failure      404   {object}  response.Envelope
+// @Failure      409   {object}  response.Envelope
+// @Failure      500   {object}  response.Envelope
+// @Router       /api/v1/posts/{id}/transitions [post]
+func (h *PostWorkflowHandler) Transition(c *gin.Context) {
+	actor, postID, ok := h.params(c)
+	if !ok {
+		return
+	}
+	var req request.PostTransitionRequest
+	if !h.bindJSON(c, response.ServiceCodePosts, &req) {
+		return
+	}
+	if !h.validate(c, response.ServiceCodePosts, req) {
+		return
+	}
+	p, err := h.posts.Transition(c.Request.Context(), postID, actor, req)
+	if err != nil {
+		h.writeError(c, err, "transition failed")
+		return
+	}
+	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodePosts, response.CaseCodeUpdated), "Successfully transitioned post", p)
+}
+
+// ListPostTransitions godoc
+// @Summary      List a post's workflow history, newest first (owner, reviewer or reviewer manager)
+// @Tags         Posts
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Post ID"
+// @Success      200  {object}  response.Envelope
+// @Failure      400  {object}  response.Envelope
+// @Failure      401  {object}  response.Envelope
+// @Failure      403  {object}  response.Envelope
+// @Failure      404  {object}  response.Envelope
+// @Failure      500  {object}  response.Envelope
+// @Router       /api/v1/posts/{id}/transitions [get]
+func (h *PostWorkflowHandler) Transitions(c *gin.Context) {
+	actor, postID, ok := h.params(c)
+	if !ok {
+		return
+	}
+	rows, err := h.posts.Transitions(c.Request.Context(), postID, actor)
+	if err != nil {
+		h.writeError(c, err, "list transitions failed")
+		return
+	}
+	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodePosts, response.CaseCodeListRetrieved), "Successfully retrieved post transitions", rows)
+}
+
+// ListPostReviewers godoc
+// @Summary      List a post's assigned reviewers (owner, reviewer or reviewer manager)
+// @Tags         Posts
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Post ID"
+// @Success      200  {object}  response.Envelope
+// @Failure      400  {object}  response.Envelope
+// @Failure      401  {object}  response.Envelope
+// @Failure      403  {object}  response.Envelope
+// @Failure      404  {object}  response.Envelope
+// @Failure      500  {object}  response.Envelope
+// @Router       /api/v1/posts/{id}/reviewers [get]
+func (h *PostWorkflowHandler) Reviewers(c *gin.Context) {
+	actor, postID, ok := h.params(c)
+	if !ok {
+		return
+	}
+	rows, err := h.posts.Reviewers(c.Request.Context(), postID, actor)
+	if err != nil {
+		h.writeError(c, err, "list reviewers failed")
+		return
+	}
+	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodePosts, response.CaseCodeListRetrieved), "Successfully retrieved post reviewers", rows)
+}
+
+// AssignPostReviewer godoc
+// @Summary      Assign a reviewer to a post
+// @Description  Authors cannot review their own post. Category-scoped editors may only assign reviewers inside their subtrees. Returns the post's reviewers.
+// @Tags         Posts
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      int                                true  "Post ID"
+// @Param        body  body      request.AssignPostReviewerRequest  true  "Reviewer payload"
+// @Success      200   {object}  response.Envelope
+// @Failure      400   {object}  response.Envelope
+// @Failure      401   {object}  response.Envelope
+// @Failure      403   {object}  response.Envelope
+// @Failure      404   {object}  response.Envelope
+// @Failure      500   {object}  response.Envelope
+// @Router       /api/v1/posts/{id}/reviewers [post]
+func (h *PostWorkflowHandler) AssignReviewer(c *gin.Context) {
+	actor, postID, ok := h.params(c)
+	if !ok {
+		return
+	}
+	var req request.AssignPostReviewerRequest
+	if !h.bindJSON(c, response.ServiceCodePosts, &req) {
+		return
+	}
+	if !h.validate(c, response.ServiceCodePosts, req) {
+		return
+	}
+	rows, err := h.posts.AssignReviewer(c.Request.Context(), postID, actor, req)
+	if err != nil {
+		h.writeError(c, err, "assign reviewer failed")
+		return
+	}
+	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodePosts, response.CaseCodeUpdated), "Successfully assigned post reviewer", rows)
+}
+
+// UnassignPostReviewer godoc
+// @Summary      Remove a reviewer from a post
+// @Description  The reviewer's comments are kept.
+// @Tags         Posts
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path      int  true  "Post ID"
+// @Param        userId  path      int  true  "Reviewer user ID"
+// @Success      200     {object}  response.Envelope
+// @Failure      400     {object}  response.Envelope
+// @Failure      401     {object}  response.Envelope
+// @Failure      403     {object}  response.Envelope
+// @Failure      404     {object}  response.Envelope
+// @Failure      500     {object}  response.Envelope
+// @Router       /api/v1/posts/{id}/reviewers/{userId} [delete]
+func (h *PostWorkflowHandler) UnassignReviewer(c *gin.Context) {
+	actor, postID, ok := h.params(c)
+	if !ok {
+		return
+	}
+	reviewerID, err := h.ParseUintParam(c, "userId")
+	if err != nil {
+		response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodePosts, response.CaseCodeInvalidValue), "invalid user id", "userId must be uint")
+		return
+	}
+	if err := h.posts.UnassignReviewer(c.Request.Context(), postID, reviewerID, actor); err != nil {
+		h.writeError(c, err, "remove reviewer failed")
+		return
+	}
+	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodePosts, response.CaseCodeDeleted), "Successfully removed post reviewer", nil)
+}
+
+// ListPostReviewComments godoc
+// @Summary      List a post's review comments, oldest first (owner, reviewer or reviewer manager)
+// @Tags         Posts
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Post ID"
+// @Success      200  {object}  response.Envelope
+// @Failure      400  {object}  response.Envelope
+// @Failure      401  {object}  response.Envelope
+// @Failure      403  {object}  response.Envelope
+// @Failure      404  {object}  response.Envelope
+// @Failure      500  {object}  response.Envelope
+// @Router       /api/v1/posts/{id}/review-comments [get]
+func (h *PostWorkflowHandler) ReviewComments(c *gin.Context) {
+	actor, postID, ok := h.params(c)
+	if !ok {
+		return
+	}
+	rows, err := h.posts.ReviewComments(c.Request.Context(), postID, actor)
+	if err != nil {
+		h.writeError(c, err, "list review comments failed")
+		return
+	}
+	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodePosts, response.CaseCodeListRetrieved), "Successfully retrieved post review comments", rows)
+}
+
+// CreatePostReviewComment godoc
+// @Summary      Comment on a post revision (owner, reviewer or reviewer manager)
+// @Description  The comment is attached to revisionId, or to the post's latest revision when omitted.
+// @Tags         Posts
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      int                                     true  "Post ID"
+// @Param        body  body      request.CreatePostReviewCommentRequest  true  "Review comment payload"
+// @Success      201   {object}  response.Envelope
+// @Failure      400   {object}  response.Envelope
+// @Failure      401   {object}  response.Envelope
+// @Failure      403   {object}  response.Envelope
+// @Failure      404   {object}  response.Envelope
+// @Failure      500   {object}  response.Envelope
+// @Router       /api/v1/posts/{id}/review-comments [post]
+func (h *PostWorkflowHandler) AddReviewComment(c *gin.Context) {
+	actor, postID, ok := h.params(c)
+	if !ok {
+		return
+	}
+	var req request.CreatePostReviewCommentRequest
+	if !h.bindJSON(c, response.ServiceCodePosts, &req) {
+		return
+	}
+	if !h.validate(c, response.ServiceCodePosts, req) {
+		return
+	}
+	rc, err := h.posts.AddReviewComment(c.Request.Context(), postID, actor, req)
+	if err != nil {
+		h.writeError(c, err, "add review comment failed")
+		return
+	}
+	response.Created(c, response.BuildResponseCode(http.StatusCreated, response.ServiceCodePosts, response.CaseCodeCreated), "Successfully added review comment", rc)
+}
@@ -1,11 +1,14 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
+	"strings"
 
 	"github.com/turahe/go-restfull/internal/handler/request"
 	"github.com/turahe/go-restfull/internal/middleware"
 	"github.com/turahe/go-restfull/internal/service"
+	"github.com/turahe/go-restfull/internal/service/dto"
 	"github.com/turahe/go-restfull/pkg/response"
 
 	"github.com/gin-gonic/gin"
@@ -23,6 +26,7 @@ func NewRBACHandler(rbacSvc *service.RBACService, log *zap.Logger) *RBACHandler
 
 // AssignRole godoc
 // @Summary      Assign role to user
+// @Description  Optional categoryId scopes the grant to that category subtree; validFrom/validUntil bound it in time.
 // @Tags         RBAC
 // @Accept       json
 // @Produce      json
@@ -32,6 +36,7 @@ func NewRBACHandler(rbacSvc *service.RBACService, log *zap.Logger) *RBACHandler
 // @Failure      400   {object}  response.Envelope
 // @Failure      401   {object}  response.Envelope
 // @Failure      403   {object}  response.Envelope
+// @Failure      404   {object}  response.Envelope
 // @Failure      500   {object}  response.Envelope
 // @Router       /api/v1/rbac/assign-role [post]
 func (h *RBACHandler) AssignRole(c *gin.Context) {
@@ -53,9 +58,20 @@ func (h *RBACHandler) AssignRole(c *gin.Context) {
 		return
 	}
 
-	ok2, err := h.rbac.AssignRole(c.Request.Context(), req.UserID, req.Role)
+	ok2, err := h.rbac.GrantRole(c.Request.Context(), req.UserID, req.Role, dto.RoleGrantOptions{
+		CategoryID: req.CategoryID,
+		ValidFrom:  req.ValidFrom,
+		ValidUntil: req.ValidUntil,
+	})
 	if err != nil {
-		h.internalError(c, response.ServiceCodeCommon, err, "assign role failed")
+		switch {
+		case errors.Is(err, service.ErrInvalidRoleGrantWindow):
+			response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodeCommon, response.CaseCodeInvalidValue), "invalid request", "validUntil must be in the future and after validFrom")
+		case errors.Is(err, service.ErrCategoryNotFound):
+			response.NotFound(c, response.BuildResponseCode(http.StatusNotFound, response.ServiceCodeCategories, response.CaseCodeNotFound), "not found", "category not found")
+		default:
+			h.internalError(c, response.ServiceCodeCommon, err, "assign role failed")
+		}
 		return
 	}
 	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodeCommon, response.CaseCodeSuccess), "ok", gin.H{"assigned": ok2})
@@ -100,3 +116,167 @@ func (h *RBACHandler) AddPermission(c *gin.Context) {
 	}
 	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodeCommon, response.CaseCodeSuccess), "ok", gin.H{"added": ok2})
 }
+
+// AddRoleInheritance godoc
+// @Summary      Make a role inherit another role's permissions
+// @Description  Adds a role -> parentRole edge; permissions resolve transitively. Edges that would create a cycle are rejected.
+// @Tags         RBAC
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        body  body      request.RoleInheritanceRequest  true  "Role inheritance"
+// @Success      200   {object}  response.Envelope
+// @Failure      400   {object}  response.Envelope
+// @Failure      401   {object}  response.Envelope
+// @Failure      403   {object}  response.Envelope
+// @Failure      404   {object}  response.Envelope
+// @Failure      409   {object}  response.Envelope
+// @Failure      500   {object}  response.Envelope
+// @Router       /api/v1/rbac/add-role-inheritance [post]
+func (h *RBACHandler) AddRoleInheritance(c *gin.Context) {
+	auth, ok := middleware.GetAuth(c)
+	if !ok {
+		response.Unauthorized(c, response.BuildResponseCode(http.StatusUnauthorized, response.ServiceCodeAuth, response.CaseCodeUnauthorized), "unauthorized", "missing auth")
+		return
+	}
+	if auth.Role != "admin" {
+		response.Forbidden(c, response.BuildResponseCode(http.StatusForbidden, response.ServiceCodeAuth, response.CaseCodePermissionDenied), "forbidden", "admin only")
+		return
+	}
+
+	var req request.RoleInheritanceRequest
+	if !h.bindJSON(c, response.ServiceCodeCommon, &req) {
+		return
+	}
+	if !h.validate(c, response.ServiceCodeCommon, req) {
+		return
+	}
+
+	ok2, err := h.rbac.AddRoleInheritance(c.Request.Context(), req.Role, req.ParentRole)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrRoleNotFound):
+			response.NotFound(c, response.BuildResponseCode(http.StatusNotFound, response.ServiceCodeRoles, response.CaseCodeNotFound), "not found", "role not found")
+		case errors.Is(err, service.ErrRoleInheritanceCycle):
+			response.Conflict(c, response.BuildResponseCode(http.StatusConflict, response.ServiceCodeRoles, response.CaseCodeConflict), "conflict", "role inheritance would create a cycle")
+		default:
+			h.internalError(c, response.ServiceCodeCommon, err, "add role inheritance failed")
+		}
+		return
+	}
+	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodeCommon, response.CaseCodeSuccess), "ok", gin.H{"added": ok2})
+}
+
+// RemoveRoleInheritance godoc
+// @Summary      Remove a role inheritance edge
+// @Tags         RBAC
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        body  body      request.RoleInheritanceRequest  true  "Role inheritance"
+// @Success      200   {object}  response.Envelope
+// @Failure      400   {object}  response.Envelope
+// @Failure      401   {object}  response.Envelope
+// @Failure      403   {object}  response.Envelope
+// @Failure      500   {object}  response.Envelope
+// @Router       /api/v1/rbac/remove-role-inheritance [post]
+func (h *RBACHandler) RemoveRoleInheritance(c *gin.Context) {
+	auth, ok := middleware.GetAuth(c)
+	if !ok {
+		response.Unauthorized(c, response.BuildResponseCode(http.StatusUnauthorized, response.ServiceCodeAuth, response.CaseCodeUnauthorized), "unauthorized", "missing auth")
+		return
+	}
+	if auth.Role != "admin" {
+		response.Forbidden(c, response.BuildResponseCode(http.StatusForbidden, response.ServiceCodeAuth, response.CaseCodePermissionDenied), "forbidden", "admin only")
+		return
+	}
+
+	var req request.RoleInheritanceRequest
+	if !h.bindJSON(c, response.ServiceCodeCommon, &req) {
+		return
+	}
+	if !h.validate(c, response.ServiceCodeCommon, req) {
+		return
+	}
+
+	ok2, err := h.rbac.RemoveRoleInheritance(c.Request.Context(), req.Role, req.ParentRole)
+	if err != nil {
+		h.internalError(c, response.ServiceCodeCommon, err, "remove role inheritance failed")
+		return
+	}
+	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodeCommon, response.CaseCodeSuccess), "ok", gin.H{"removed": ok2})
+}
+
+// Explain godoc
+// @Summary      Explain an authorization decision
+// @Description  Evaluates (path, method) for a user and returns the roles, every permission key checked, which keyMatch2/regexMatch pair matched (or why none did), and whether the DB or Casbin path was used.
+// @Tags         RBAC
+// @Produce      json
+// @Security     BearerAuth
+// @Param        userId  query     int     true  "User ID"
+// @Param        path    query     string  true  "Route template, e.g. /api/v1/posts/:id"
+// @Param        method  query     string  true  "HTTP method"
+// @Success      200     {object}  response.Envelope{data=dto.RBACDecision}
+// @Failure      400     {object}  response.Envelope
+// @Failure      401     {object}  response.Envelope
+// @Failure      403     {object}  response.Envelope
+// @Failure      500     {object}  response.Envelope
+// @Router       /api/v1/rbac/explain [get]
+func (h *RBACHandler) Explain(c *gin.Context) {
+	auth, ok := middleware.GetAuth(c)
+	if !ok {
+		response.Unauthorized(c, response.BuildResponseCode(http.StatusUnauthorized, response.ServiceCodeAuth, response.CaseCodeUnauthorized), "unauthorized", "missing auth")
+		return
+	}
+	if auth.Role != "admin" {
+		response.Forbidden(c, response.BuildResponseCode(http.StatusForbidden, response.ServiceCodeAuth, response.CaseCodePermissionDenied), "forbidden", "admin only")
+		return
+	}
+
+	var req request.ExplainRBACRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodeCommon, response.CaseCodeInvalidFormat), "invalid request", err.Error())
+		return
+	}
+	if !h.validate(c, response.ServiceCodeCommon, req) {
+		return
+	}
+
+	d, err := h.rbac.Explain(c.Request.Context(), req.UserID, strings.TrimSpace(req.Path), strings.ToUpper(strings.TrimSpace(req.Method)))
+	if err != nil {
+		h.internalError(c, response.ServiceCodeCommon, err, "explain failed")
+		return
+	}
+	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodeCommon, response.CaseCodeRetrieved), "ok", d)
+}
+
+// GetDecision godoc
+// @Summary      Get a traced authorization decision
+// @Description  Looks up the trace behind the X-RBAC-Decision-Id header of a 403 response (in-memory, per replica).
+// @Tags         RBAC
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Decision ID"
+// @Success      200  {object}  response.Envelope{data=dto.RBACDecision}
+// @Failure      401  {object}  response.Envelope
+// @Failure      403  {object}  response.Envelope
+// @Failure      404  {object}  response.Envelope
+// @Router       /api/v1/rbac/decisions/{id} [get]
+func (h *RBACHandler) GetDecision(c *gin.Context) {
+	auth, ok := middleware.GetAuth(c)
+	if !ok {
+		response.Unauthorized(c, response.BuildResponseCode(http.StatusUnauthorized, response.ServiceCodeAuth, response.CaseCodeUnauthorized), "unauthorized", "missing auth")
+		return
+	}
+	if auth.Role != "admin" {
+		response.Forbidden(c, response.BuildResponseCode(http.StatusForbidden, response.ServiceCodeAuth, response.CaseCodePermissionDenied), "forbidden", "admin only")
+		return
+	}
+
+	d, ok := h.rbac.Decision(c.Param("id"))
+	if !ok {
+		response.NotFound(c, response.BuildResponseCode(http.StatusNotFound, response.ServiceCodeCommon, response.CaseCodeNotFound), "not found", "decision not found")
+		return
+	}
+	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodeCommon, response.CaseCodeRetrieved), "ok", d)
+}
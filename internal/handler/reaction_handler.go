@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/turahe/go-restfull/internal/middleware"
+	"github.com/turahe/go-restfull/internal/service"
+	"github.com/turahe/go-restfull/internal/service/dto"
+	"github.com/turahe/go-restfull/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type reactionService interface {
+	Types(ctx context.Context) []string
+	SetPostReaction(ctx context.Context, postID, userID uint, typ string, on bool) (*dto.Reactions, error)
+	SetCommentReaction(ctx context.Context, postID, commentID, userID uint, typ string, on bool) (*dto.Reactions, error)
+}
+
+type ReactionHandler struct {
+	BaseHandler
+	reactions reactionService
+}
+
+func NewReactionHandler(reactions reactionService, log *zap.Logger) *ReactionHandler {
+	return &ReactionHandler{BaseHandler: BaseHandler{Log: log}, reactions: reactions}
+}
+
+// ReactionTypes godoc
+// @Summary      Reaction types readers may use
+// @Description  Configured per site by the reactionTypes setting (comma separated); defaults to like, love, laugh, insightful and sad.
+// @Tags         Reactions
+// @Produce      json
+// @Success      200  {object}  response.Envelope
+// @Router       /api/v1/reactions/types [get]
+func (h *ReactionHandler) Types(c *gin.Context) {
+	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodePosts, response.CaseCodeListRetrieved), "Successfully retrieved reaction types", h.reactions.Types(c.Request.Context()))
+}
+
+// PutPostReaction godoc
+// @Summary      React to a post
+// @Description  Adds the caller's reaction of this type; repeating it changes nothing. Only live posts take reactions.
+// @Tags         Reactions
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      int     true  "Post ID"
+// @Param        type  path      string  true  "Reaction type, e.g. like"
+// @Success      200   {object}  response.Envelope{data=dto.Reactions}
+// @Failure      400   {object}  response.Envelope
+// @Failure      401   {object}  response.Envelope
+// @Failure      404   {object}  response.Envelope
+// @Failure      500   {object}  response.Envelope
+// @Router       /api/v1/posts/{id}/reactions/{type} [put]
+func (h *ReactionHandler) PutPost(c *gin.Context) {
+	h.setPost(c, true)
+}
+
+// DeletePostReaction godoc
+// @Summary      Take back a reaction to a post
+// @Description  Removes the caller's reaction of this type, if any.
+// @Tags         Reactions
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      int     true  "Post ID"
+// @Param        type  path      string  true  "Reaction type, e.g. like"
+// @Success      200   {object}  response.Envelope{data=dto.Reactions}
+// @Failure      400   {object}  response.Envelope
+// @Failure      401   {object}  response.Envelope
+// @Failure      404   {object}  response.Envelope
+// @Failure      500   {object}  response.Envelope
+// @Router       /api/v1/posts/{id}/reactions/{type} [delete]
+func (h *ReactionHandler) DeletePost(c *gin.Context) {
+	h.setPost(c, false)
+}
+
+// PutCommentReaction godoc
+// @Summary      React to a comment
+// @Description  Adds the caller's reaction of this type; repeating it changes nothing. The comment's post must be live.
+// @Tags         Reactions
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      int     true  "Post ID"
+// @Param        cid   path      int     true  "Comment ID"
+// @Param        type  path      string  true  "Reaction type, e.g. like"
+// @Success      200   {object}  response.Envelope{data=dto.Reactions}
+// @Failure      400   {object}  response.Envelope
+// @Failure      401   {object}  response.Envelope
+// @Failure      404   {object}  response.Envelope
+// @Failure      500   {object}  response.Envelope
+// @Router       /api/v1/posts/{id}/comments/{cid}/reactions/{type} [put]
+func (h *ReactionHandler) PutComment(c *gin.Context) {
+	h.setComment(c, true)
+}
+
+// DeleteCommentReaction godoc
+// @Summary      Take back a reaction to a comment
+// @Description  Removes the caller's reaction of this type, if any.
+// @Tags         Reactions
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      int     true  "Post ID"
+// @Param        cid   path      int     true  "Comment ID"
+// @Param        type  path      string  true  "Reaction type, e.g. like"
+// @Success      200   {object}  response.Envelope{data=dto.Reactions}
+// @Failure      400   {object}  response.Envelope
+// @Failure      401   {object}  response.Envelope
+// @Failure      404   {object}  response.Envelope
+// @Failure      500   {object}  response.Envelope
+// @Router       /api/v1/posts/{id}/comments/{cid}/reactions/{type} [delete]
+func (h *ReactionHandler) DeleteComment(c *gin.Context) {
+	h.setComment(c, false)
+}
+
+func (h *ReactionHandler) setPost(c *gin.Context, on bool) {
+	auth, ok := middleware.GetAuth(c)
+	if !ok {
+		response.Unauthorized(c, response.BuildResponseCode(http.StatusUnauthorized, response.ServiceCodePosts, response.CaseCodeUnauthorized), "unauthorized", "missing auth")
+		return
+	}
+	id, err := h.ParseUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodePosts, response.CaseCodeInvalidValue), "invalid id", "id must be uint")
+		return
+	}
+	out, err := h.reactions.SetPostReaction(c.Request.Context(), id, auth.UserID, c.Param("type"), on)
+	h.writeResult(c, response.ServiceCodePosts, out, err)
+}
+
+func (h *ReactionHandler) setComment(c *gin.Context, on bool) {
+	auth, ok := middleware.GetAuth(c)
+	if !ok {
+		response.Unauthorized(c, response.BuildResponseCode(http.StatusUnauthorized, response.ServiceCodeComments, response.CaseCodeUnauthorized), "unauthorized", "missing auth")
+		return
+	}
+	postID, err := h.ParseUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodeComments, response.CaseCodeInvalidValue), "invalid post id", "id must be uint")
+		return
+	}
+	cid, err := h.ParseUintParam(c, "cid")
+	if err != nil {
+		response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodeComments, response.CaseCodeInvalidValue), "invalid comment id", "cid must be uint")
+		return
+	}
+	out, err := h.reactions.SetCommentReaction(c.Request.Context(), postID, cid, auth.UserID, c.Param("type"), on)
+	h.writeResult(c, response.ServiceCodeComments, out, err)
+}
+
+func (h *ReactionHandler) writeResult(c *gin.Context, serviceCode string, out *dto.Reactions, err error) {
+	switch err {
+	case nil:
+		response.OK(c, response.BuildResponseCode(http.StatusOK, serviceCode, response.CaseCodeUpdated), "Successfully updated reactions", out)
+	case service.ErrInvalidReactionType:
+		response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, serviceCode, response.CaseCodeInvalidValue), "invalid request", err.Error())
+	case service.ErrPostNotFound:
+		response.NotFound(c, response.BuildResponseCode(http.StatusNotFound, serviceCode, response.CaseCodeNotFound), "not found", "post not found")
+	case service.ErrCommentNotFound:
+		response.NotFound(c, response.BuildResponseCode(http.StatusNotFound, serviceCode, response.CaseCodeNotFound), "not found", "comment not found")
+	default:
+		h.internalError(c, serviceCode, err, "set reaction failed")
+	}
+}
@@ -0,0 +1,384 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/turahe/go-restfull/internal/handler/request"
+	"github.com/turahe/go-restfull/internal/middleware"
+	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/internal/service"
+	"github.com/turahe/go-restfull/internal/service/dto"
+	"github.com/turahe/go-restfull/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type readingListService interface {
+	List(ctx context.Context, userID uint) ([]model.ReadingList, error)
+	Create(ctx context.Context, userID uint, req request.CreateReadingListRequest) (*model.ReadingList, error)
+	Update(ctx context.Context, id, userID uint, req request.UpdateReadingListRequest) (*model.ReadingList, error)
+	Delete(ctx context.Context, id, userID uint) error
+	Get(ctx context.Context, id, userID uint) (*dto.ReadingListView, error)
+	Shared(ctx context.Context, slug string) (*dto.ReadingListView, error)
+	AddItem(ctx context.Context, id, userID uint, req request.AddReadingListItemRequest) (*dto.ReadingListView, error)
+	RemoveItem(ctx context.Context, id, postID, userID uint) error
+	Reorder(ctx context.Context, id, userID uint, req request.ReorderReadingListRequest) (*dto.ReadingListView, error)
+	MarkRead(ctx context.Context, id, postID, userID uint, read bool) error
+}
+
+type ReadingListHandler struct {
+	BaseHandler
+	lists readingListService
+}
+
+func NewReadingListHandler(lists readingListService, log *zap.Logger) *ReadingListHandler {
+	return &ReadingListHandler{BaseHandler: BaseHandler{Log: log}, lists: lists}
+}
+
+// params reads the caller and the list id, writing the error response itself when one is missing or
+// malformed.
+func (h *ReadingListHandler) params(c *gin.Context) (userID, listID uint, ok bool) {
+	auth, ok := middleware.GetAuth(c)
+	if !ok {
+		response.Unauthorized(c, response.BuildResponseCode(http.StatusUnauthorized, response.ServiceCodeReadingLists, response.CaseCodeUnauthorized), "unauthorized", "missing auth")
+		return 0, 0, false
+	}
+	listID, err := h.ParseUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodeReadingLists, response.CaseCodeInvalidValue), "invalid id", "id must be uint")
+		return 0, 0, false
+	}
+	return auth.UserID, listID, true
+}
+
+// itemParams is params plus the post id of an entry.
+func (h *ReadingListHandler) itemParams(c *gin.Context) (userID, listID, postID uint, ok bool) {
+	userID, listID, ok = h.params(c)
+	if !ok {
+		return 0, 0, 0, false
+	}
+	postID, err := h.ParseUintParam(c, "postId")
+	if err != nil {
+		response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodeReadingLists, response.CaseCodeInvalidValue), "invalid post id", "postId must be uint")
+		return 0, 0, 0, false
+	}
+	return userID, listID, postID, true
+}
+
+func (h *ReadingListHandler) writeError(c *gin.Context, err error, message string) {
+	switch err {
+	case service.ErrReadingListNotFound, service.ErrPostNotFound, service.ErrPostNotInReadingList:
+		response.NotFound(c, response.BuildResponseCode(http.StatusNotFound, response.ServiceCodeReadingLists, response.CaseCodeNotFound), "not found", err.Error())
+	case service.ErrReadingListExists:
+		response.Conflict(c, response.BuildResponseCode(http.StatusConflict, response.ServiceCodeReadingLists, response.CaseCodeDuplicateEntry), "conflict", err.Error())
+	case service.ErrReadingListFull:
+		response.Conflict(c, response.BuildResponseCode(http.StatusConflict, response.ServiceCodeReadingLists, response.CaseCodeConflict), "conflict", err.Error())
+	case service.ErrReadingListSetMismatch, service.ErrInvalidPayload:
+		response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodeReadingLists, response.CaseCodeInvalidValue), "invalid request", err.Error())
+	default:
+		h.internalError(c, response.ServiceCodeReadingLists, err, message)
+	}
+}
+
+// ListReadingLists godoc
+// @Summary      The caller's reading lists
+// @Description  By name, with how many posts each holds.
+// @Tags         Reading lists
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Envelope
+// @Failure      401  {object}  response.Envelope
+// @Failure      500  {object}  response.Envelope
+// @Router       /api/v1/reading-lists [get]
+func (h *ReadingListHandler) List(c *gin.Context) {
+	auth, ok := middleware.GetAuth(c)
+	if !ok {
+		response.Unauthorized(c, response.BuildResponseCode(http.StatusUnauthorized, response.ServiceCodeReadingLists, response.CaseCodeUnauthorized), "unauthorized", "missing auth")
+		return
+	}
+	rows, err := h.lists.List(c.Request.Context(), auth.UserID)
+	if err != nil {
+		h.writeError(c, err, "list reading lists failed")
+		return
+	}
+	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodeReadingLists, response.CaseCodeListRetrieved), "ok", rows)
+}
+
+// CreateReadingList godoc
+// @Summary      Create a reading list
+// @Description  Lists are private unless shared is true, which issues a share slug.
+// @Tags         Reading lists
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        body  body      request.CreateReadingListRequest  true  "Create reading list payload"
+// @Success      201   {object}  response.Envelope
+// @Failure      400   {object}  response.Envelope
+// @Failure      401   {object}  response.Envelope
+// @Failure      409   {object}  response.Envelope
+// @Failure      500   {object}  response.Envelope
+// @Router       /api/v1/reading-lists [post]
+func (h *ReadingListHandler) Create(c *gin.Context) {
+	auth, ok := middleware.GetAuth(c)
+	if !ok {
+		response.Unauthorized(c, response.BuildResponseCode(http.StatusUnauthorized, response.ServiceCodeReadingLists, response.CaseCodeUnauthorized), "unauthorized", "missing auth")
+		return
+	}
+	var req request.CreateReadingListRequest
+	if !h.bindJSON(c, response.ServiceCodeReadingLists, &req) {
+		return
+	}
+	if !h.validate(c, response.ServiceCodeReadingLists, req) {
+		return
+	}
+
+	l, err := h.lists.Create(c.Request.Context(), auth.UserID, req)
+	if err != nil {
+		h.writeError(c, err, "create failed")
+		return
+	}
+	response.Created(c, response.BuildResponseCode(http.StatusCreated, response.ServiceCodeReadingLists, response.CaseCodeCreated), "created", l)
+}
+
+// GetReadingList godoc
+// @Summary      A reading list with its posts (owner only)
+// @Description  Every entry in order, with its read marker. Entries whose post is no longer live keep their place but carry no post.
+// @Tags         Reading lists
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id  path      int  true  "Reading list ID"
+// @Success      200 {object}  response.Envelope
+// @Failure      400 {object}  response.Envelope
+// @Failure      401 {object}  response.Envelope
+// @Failure      404 {object}  response.Envelope
+// @Failure      500 {object}  response.Envelope
+// @Router       /api/v1/reading-lists/{id} [get]
+func (h *ReadingListHandler) Get(c *gin.Context) {
+	user, id, ok := h.params(c)
+	if !ok {
+		return
+	}
+	v, err := h.lists.Get(c.Request.Context(), id, user)
+	if err != nil {
+		h.writeError(c, err, "get reading list failed")
+		return
+	}
+	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodeReadingLists, response.CaseCodeRetrieved), "ok", v)
+}
+
+// GetSharedReadingList godoc
+// @Summary      A shared reading list
+// @Description  Read-only: the live posts in order, without read markers.
+// @Tags         Reading lists
+// @Produce      json
+// @Param        slug  path      string  true  "Share slug"
+// @Success      200   {object}  response.Envelope
+// @Failure      404   {object}  response.Envelope
+// @Failure      500   {object}  response.Envelope
+// @Router       /api/v1/reading-lists/shared/{slug} [get]
+func (h *ReadingListHandler) Shared(c *gin.Context) {
+	v, err := h.lists.Shared(c.Request.Context(), c.Param("slug"))
+	if err != nil {
+		h.writeError(c, err, "get shared reading list failed")
+		return
+	}
+	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodeReadingLists, response.CaseCodeRetrieved), "ok", v)
+}
+
+// UpdateReadingList godoc
+// @Summary      Rename, share or unshare a reading list (owner only)
+// @Description  shared=false makes the list private; sharing it again issues a new slug.
+// @Tags         Reading lists
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      int                               true  "Reading list ID"
+// @Param        body  body      request.UpdateReadingListRequest  true  "Update reading list payload"
+// @Success      200   {object}  response.Envelope
+// @Failure      400   {object}  response.Envelope
+// @Failure      401   {object}  response.Envelope
+// @Failure      404   {object}  response.Envelope
+// @Failure      409   {object}  response.Envelope
+// @Failure      500   {object}  response.Envelope
+// @Router       /api/v1/reading-lists/{id} [put]
+func (h *ReadingListHandler) Update(c *gin.Context) {
+	user, id, ok := h.params(c)
+	if !ok {
+		return
+	}
+	var req request.UpdateReadingListRequest
+	if !h.bindJSON(c, response.ServiceCodeReadingLists, &req) {
+		return
+	}
+	if !h.validate(c, response.ServiceCodeReadingLists, req) {
+		return
+	}
+
+	l, err := h.lists.Update(c.Request.Context(), id, user, req)
+	if err != nil {
+		h.writeError(c, err, "update failed")
+		return
+	}
+	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodeReadingLists, response.CaseCodeUpdated), "updated", l)
+}
+
+// DeleteReadingList godoc
+// @Summary      Delete a reading list (owner only)
+// @Description  Its posts are not affected.
+// @Tags         Reading lists
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id  path      int  true  "Reading list ID"
+// @Success      200 {object}  response.Envelope
+// @Failure      400 {object}  response.Envelope
+// @Failure      401 {object}  response.Envelope
+// @Failure      404 {object}  response.Envelope
+// @Failure      500 {object}  response.Envelope
+// @Router       /api/v1/reading-lists/{id} [delete]
+func (h *ReadingListHandler) Delete(c *gin.Context) {
+	user, id, ok := h.params(c)
+	if !ok {
+		return
+	}
+	if err := h.lists.Delete(c.Request.Context(), id, user); err != nil {
+		h.writeError(c, err, "delete failed")
+		return
+	}
+	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodeReadingLists, response.CaseCodeDeleted), "deleted", nil)
+}
+
+// AddReadingListItem godoc
+// @Summary      Save a post to a reading list (owner only)
+// @Description  Inserts at position (appending by default); a post already in the list moves there and keeps its read marker. Only live posts can be added, up to 1000 per list.
+// @Tags         Reading lists
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      int                                true  "Reading list ID"
+// @Param        body  body      request.AddReadingListItemRequest  true  "Post and position"
+// @Success      200   {object}  response.Envelope
+// @Failure      400   {object}  response.Envelope
+// @Failure      401   {object}  response.Envelope
+// @Failure      404   {object}  response.Envelope
+// @Failure      409   {object}  response.Envelope
+// @Failure      500   {object}  response.Envelope
+// @Router       /api/v1/reading-lists/{id}/items [post]
+func (h *ReadingListHandler) AddItem(c *gin.Context) {
+	user, id, ok := h.params(c)
+	if !ok {
+		return
+	}
+	var req request.AddReadingListItemRequest
+	if !h.bindJSON(c, response.ServiceCodeReadingLists, &req) {
+		return
+	}
+	if !h.validate(c, response.ServiceCodeReadingLists, req) {
+		return
+	}
+
+	v, err := h.lists.AddItem(c.Request.Context(), id, user, req)
+	if err != nil {
+		h.writeError(c, err, "add reading list item failed")
+		return
+	}
+	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodeReadingLists, response.CaseCodeUpdated), "updated", v)
+}
+
+// ReorderReadingList godoc
+// @Summary      Reorder a reading list (owner only)
+// @Description  postIds must list every post in the list exactly once; the first comes first.
+// @Tags         Reading lists
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      int                                true  "Reading list ID"
+// @Param        body  body      request.ReorderReadingListRequest  true  "New order"
+// @Success      200   {object}  response.Envelope
+// @Failure      400   {object}  response.Envelope
+// @Failure      401   {object}  response.Envelope
+// @Failure      404   {object}  response.Envelope
+// @Failure      500   {object}  response.Envelope
+// @Router       /api/v1/reading-lists/{id}/items [put]
+func (h *ReadingListHandler) Reorder(c *gin.Context) {
+	user, id, ok := h.params(c)
+	if !ok {
+		return
+	}
+	var req request.ReorderReadingListRequest
+	if !h.bindJSON(c, response.ServiceCodeReadingLists, &req) {
+		return
+	}
+	if !h.validate(c, response.ServiceCodeReadingLists, req) {
+		return
+	}
+
+	v, err := h.lists.Reorder(c.Request.Context(), id, user, req)
+	if err != nil {
+		h.writeError(c, err, "reorder reading list failed")
+		return
+	}
+	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodeReadingLists, response.CaseCodeUpdated), "updated", v)
+}
+
+// MarkReadingListItem godoc
+// @Summary      Mark a saved post read or unread (owner only)
+// @Tags         Reading lists
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path      int                                 true  "Reading list ID"
+// @Param        postId  path      int                                 true  "Post ID"
+// @Param        body    body      request.MarkReadingListItemRequest  true  "Read marker"
+// @Success      200     {object}  response.Envelope
+// @Failure      400     {object}  response.Envelope
+// @Failure      401     {object}  response.Envelope
+// @Failure      404     {object}  response.Envelope
+// @Failure      500     {object}  response.Envelope
+// @Router       /api/v1/reading-lists/{id}/items/{postId} [put]
+func (h *ReadingListHandler) MarkRead(c *gin.Context) {
+	user, id, postID, ok := h.itemParams(c)
+	if !ok {
+		return
+	}
+	var req request.MarkReadingListItemRequest
+	if !h.bindJSON(c, response.ServiceCodeReadingLists, &req) {
+		return
+	}
+	if !h.validate(c, response.ServiceCodeReadingLists, req) {
+		return
+	}
+
+	if err := h.lists.MarkRead(c.Request.Context(), id, postID, user, *req.Read); err != nil {
+		h.writeError(c, err, "mark reading list item failed")
+		return
+	}
+	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodeReadingLists, response.CaseCodeUpdated), "updated", nil)
+}
+
+// RemoveReadingListItem godoc
+// @Summary      Remove a post from a reading list (owner only)
+// @Description  Later entries move up by one.
+// @Tags         Reading lists
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path      int  true  "Reading list ID"
+// @Param        postId  path      int  true  "Post ID"
+// @Success      200     {object}  response.Envelope
+// @Failure      400     {object}  response.Envelope
+// @Failure      401     {object}  response.Envelope
+// @Failure      404     {object}  response.Envelope
+// @Failure      500     {object}  response.Envelope
+// @Router       /api/v1/reading-lists/{id}/items/{postId} [delete]
+func (h *ReadingListHandler) RemoveItem(c *gin.Context) {
+	user, id, postID, ok := h.itemParams(c)
+	if !ok {
+		return
+	}
+	if err := h.lists.RemoveItem(c.Request.Context(), id, postID, user); err != nil {
+		h.writeError(c, err, "remove reading list item failed")
+		return
+	}
+	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodeReadingLists, response.CaseCodeDeleted), "deleted", nil)
+}
@@ -0,0 +1,219 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/turahe/go-restfull/internal/handler/request"
+	"github.com/turahe/go-restfull/internal/middleware"
+	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/internal/repository"
+	"github.com/turahe/go-restfull/internal/service"
+	"github.com/turahe/go-restfull/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type redirectService interface {
+	List(ctx context.Context, req request.RedirectListRequest) (repository.CursorPage, error)
+	Create(ctx context.Context, actorUserID uint, req request.CreateRedirectRequest) (*model.Redirect, error)
+	Update(ctx context.Context, id, actorUserID uint, req request.UpdateRedirectRequest) (*model.Redirect, error)
+	Delete(ctx context.Context, id uint) error
+	Resolve(ctx context.Context, path string) (*model.Redirect, error)
+}
+
+type RedirectHandler struct {
+	BaseHandler
+	redirects redirectService
+}
+
+func NewRedirectHandler(redirects redirectService, log *zap.Logger) *RedirectHandler {
+	return &RedirectHandler{BaseHandler: BaseHandler{Log: log}, redirects: redirects}
+}
+
+func (h *RedirectHandler) writeError(c *gin.Context, err error, message string) {
+	switch err {
+	case service.ErrRedirectNotFound:
+		response.NotFound(c, response.BuildResponseCode(http.StatusNotFound, response.ServiceCodeRedirects, response.CaseCodeNotFound), "not found", "redirect not found")
+	case service.ErrRedirectExists:
+		response.Conflict(c, response.BuildResponseCode(http.StatusConflict, response.ServiceCodeRedirects, response.CaseCodeDuplicateEntry), "conflict", err.Error())
+	case service.ErrInvalidRedirect, service.ErrRedirectLoop:
+		response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodeRedirects, response.CaseCodeInvalidValue), "invalid request", err.Error())
+	default:
+		h.internalError(c, response.ServiceCodeRedirects, err, message)
+	}
+}
+
+// ResolveRedirect godoc
+// @Summary      Look up the redirect for a site path
+// @Description  For the public site's router: returns where path redirects to and with which status, and counts the hit. The query string and a trailing slash of path are ignored.
+// @Tags         Redirects
+// @Produce      json
+// @Param        path  query     string  true  "Site path, e.g. /old/about"
+// @Success      200   {object}  response.Envelope
+// @Failure      400   {object}  response.Envelope
+// @Failure      404   {object}  response.Envelope
+// @Failure      500   {object}  response.Envelope
+// @Router       /api/v1/redirects/resolve [get]
+func (h *RedirectHandler) Resolve(c *gin.Context) {
+	rd, err := h.redirects.Resolve(c.Request.Context(), c.Query("path"))
+	if err != nil {
+		h.writeError(c, err, "resolve redirect failed")
+		return
+	}
+	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodeRedirects, response.CaseCodeRetrieved), "ok", rd)
+}
+
+// ListRedirects godoc
+// @Summary      List redirects
+// @Tags         Redirects
+// @Produce      json
+// @Security     BearerAuth
+// @Param        search     query     string  false  "From or to path contains"
+// @Param        limit      query     int     false  "Page size (max 100)"
+// @Param        sort       query     string  false  "Sort order (first is the default)"  Enums(newest,oldest,hits)
+// @Param        after      query     string  false  "Page after this cursor (a nextCursor)"
+// @Param        before     query     string  false  "Page before this cursor (a prevCursor)"
+// @Param        withTotal  query     bool    false  "Also return the total count"
+// @Success      200        {object}  response.Envelope
+// @Failure      400        {object}  response.Envelope
+// @Failure      401        {object}  response.Envelope
+// @Failure      403        {object}  response.Envelope
+// @Failure      500        {object}  response.Envelope
+// @Router       /api/v1/redirects [get]
+func (h *RedirectHandler) List(c *gin.Context) {
+	var req request.RedirectListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequest(c,
+			response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodeRedirects, response.CaseCodeInvalidFormat),
+			"invalid request",
+			err.Error(),
+		)
+		return
+	}
+	if !h.validate(c, response.ServiceCodeRedirects, req) {
+		return
+	}
+
+	page, err := h.redirects.List(c.Request.Context(), req)
+	if err != nil {
+		h.listError(c, response.ServiceCodeRedirects, err)
+		return
+	}
+	response.OKCursorPaginated(
+		c,
+		response.BuildResponseCode(http.StatusOK, response.ServiceCodeRedirects, response.CaseCodeListRetrieved),
+		"ok",
+		page.Items,
+		page.NextCursor,
+		page.PrevCursor,
+		page.Total,
+	)
+}
+
+// CreateRedirect godoc
+// @Summary      Create a redirect
+// @Tags         Redirects
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        body  body      request.CreateRedirectRequest  true  "Create redirect payload"
+// @Success      201   {object}  response.Envelope
+// @Failure      400   {object}  response.Envelope
+// @Failure      401   {object}  response.Envelope
+// @Failure      403   {object}  response.Envelope
+// @Failure      409   {object}  response.Envelope
+// @Failure      500   {object}  response.Envelope
+// @Router       /api/v1/redirects [post]
+func (h *RedirectHandler) Create(c *gin.Context) {
+	auth, ok := middleware.GetAuth(c)
+	if !ok {
+		response.Unauthorized(c, response.BuildResponseCode(http.StatusUnauthorized, response.ServiceCodeRedirects, response.CaseCodeUnauthorized), "unauthorized", "missing auth")
+		return
+	}
+	var req request.CreateRedirectRequest
+	if !h.bindJSON(c, response.ServiceCodeRedirects, &req) {
+		return
+	}
+	if !h.validate(c, response.ServiceCodeRedirects, req) {
+		return
+	}
+
+	rd, err := h.redirects.Create(c.Request.Context(), auth.UserID, req)
+	if err != nil {
+		h.writeError(c, err, "create failed")
+		return
+	}
+	response.Created(c, response.BuildResponseCode(http.StatusCreated, response.ServiceCodeRedirects, response.CaseCodeCreated), "created", rd)
+}
+
+// UpdateRedirect godoc
+// @Summary      Update a redirect
+// @Description  Changes the fields given; the hit count is kept.
+// @Tags         Redirects
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      int                            true  "Redirect ID"
+// @Param        body  body      request.UpdateRedirectRequest  true  "Update redirect payload"
+// @Success      200   {object}  response.Envelope
+// @Failure      400   {object}  response.Envelope
+// @Failure      401   {object}  response.Envelope
+// @Failure      403   {object}  response.Envelope
+// @Failure      404   {object}  response.Envelope
+// @Failure      409   {object}  response.Envelope
+// @Failure      500   {object}  response.Envelope
+// @Router       /api/v1/redirects/{id} [put]
+func (h *RedirectHandler) Update(c *gin.Context) {
+	auth, ok := middleware.GetAuth(c)
+	if !ok {
+		response.Unauthorized(c, response.BuildResponseCode(http.StatusUnauthorized, response.ServiceCodeRedirects, response.CaseCodeUnauthorized), "unauthorized", "missing auth")
+		return
+	}
+	id, err := h.ParseUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodeRedirects, response.CaseCodeInvalidValue), "invalid id", "id must be uint")
+		return
+	}
+	var req request.UpdateRedirectRequest
+	if !h.bindJSON(c, response.ServiceCodeRedirects, &req) {
+		return
+	}
+	if !h.validate(c, response.ServiceCodeRedirects, req) {
+		return
+	}
+
+	rd, err := h.redirects.Update(c.Request.Context(), id, auth.UserID, req)
+	if err != nil {
+		h.writeError(c, err, "update failed")
+		return
+	}
+	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodeRedirects, response.CaseCodeUpdated), "updated", rd)
+}
+
+// DeleteRedirect godoc
+// @Summary      Delete a redirect
+// @Tags         Redirects
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id  path      int  true  "Redirect ID"
+// @Success      200 {object}  response.Envelope
+// @Failure      400 {object}  response.Envelope
+// @Failure      401 {object}  response.Envelope
+// @Failure      403 {object}  response.Envelope
+// @Failure      404 {object}  response.Envelope
+// @Failure      500 {object}  response.Envelope
+// @Router       /api/v1/redirects/{id} [delete]
+func (h *RedirectHandler) Delete(c *gin.Context) {
+	id, err := h.ParseUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodeRedirects, response.CaseCodeInvalidValue), "invalid id", "id must be uint")
+		return
+	}
+	if err := h.redirects.Delete(c.Request.Context(), id); err != nil {
+		h.writeError(c, err, "delete failed")
+		return
+	}
+	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodeRedirects, response.CaseCodeDeleted), "deleted", nil)
+}
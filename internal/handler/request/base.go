@@ -5,6 +5,16 @@ type PageRequest struct {
 	Limit int `form:"limit" json:"limit" binding:"omitempty,min=1,max=200"`
 }
 
+// CursorRequest pages a list by keyset: pass a page's nextCursor as after, or its prevCursor as
+// before. Cursors are opaque and only valid with the sort order that produced them.
+type CursorRequest struct {
+	Limit  int    `form:"limit" json:"limit" binding:"omitempty,min=1,max=200"`
+	After  string `form:"after" json:"after" binding:"omitempty,max=512,excluded_with=Before"`
+	Before string `form:"before" json:"before" binding:"omitempty,max=512"`
+	// WithTotal also counts every matching row, which costs a full scan of the filtered set.
+	WithTotal bool `form:"withTotal" json:"withTotal"`
+}
+
 type SortRequest struct {
 	Sort   string `form:"sort" json:"sort" binding:"omitempty,oneof=asc desc"`
 	SortBy string `form:"sortBy" json:"sortBy" binding:"omitempty,min=1,max=255"`
@@ -13,7 +13,9 @@ type UpdateCategoryBody struct {
 }
 
 type CategoryListRequest struct {
-	PageRequest
+	CursorRequest
 	SearchRequest
+	// Sort: tree (default, nested-set order) or name.
+	Sort string `form:"sort" json:"sort" binding:"omitempty,oneof=tree name"`
 	Name string `form:"name" json:"name" binding:"omitempty,min=1,max=255"`
 }
@@ -10,7 +10,9 @@ type UpdateCommentBody struct {
 }
 
 type CommentListRequest struct {
-	PageRequest
+	CursorRequest
 	SearchRequest
-	PostID uint `form:"postId" json:"postId" binding:"required,gt=0"`
+	// Sort: tree (default, thread order), newest or oldest.
+	Sort   string `form:"sort" json:"sort" binding:"omitempty,oneof=tree newest oldest"`
+	PostID uint   `form:"postId" json:"postId" binding:"required,gt=0"`
 }
@@ -1,8 +1,10 @@
 package request
 
 type MediaListRequest struct {
-	PageRequest
+	CursorRequest
 	SearchRequest
+	// Sort: tree (default, folder order), newest or name.
+	Sort string `form:"sort" json:"sort" binding:"omitempty,oneof=tree newest name"`
 	Name string `form:"name" json:"name" binding:"omitempty,min=1,max=255"`
 }
 
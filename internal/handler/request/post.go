@@ -1,11 +1,19 @@
 package request
 
+import "time"
+
 type CreatePostRequest struct {
-	Title      string `json:"title" binding:"required,min=3,max=200"`
-	Content    string `json:"content" binding:"required,min=1"`
-	CategoryID uint   `json:"categoryId" binding:"required,gt=0"`
-	Layout     string `json:"layout" binding:"omitempty,oneof=simple author book list"`
-	Status     string `json:"status" binding:"omitempty,oneof=draft published archived"`
+	Title string `json:"title" binding:"required,min=3,max=200"`
+	// Slug is normalized and made unique; absent derives it from Title.
+	Slug          string `json:"slug" binding:"omitempty,max=200"`
+	Content       string `json:"content" binding:"required,min=1"`
+	ContentFormat string `json:"contentFormat" binding:"omitempty,oneof=markdown html plain"`
+	CategoryID    uint   `json:"categoryId" binding:"required,gt=0"`
+	Layout        string `json:"layout" binding:"omitempty,oneof=simple author book list"`
+	Status        string `json:"status" binding:"omitempty,oneof=draft published archived scheduled in_review changes_requested approved"`
+	// PublishAt in the future schedules the post (status becomes scheduled); UnpublishAt archives it later.
+	PublishAt   *time.Time `json:"publishAt"`
+	UnpublishAt *time.Time `json:"unpublishAt"`
 	// SEO (optional)
 	Excerpt         string `json:"excerpt" binding:"omitempty,max=2000"`
 	MetaTitle       string `json:"metaTitle" binding:"omitempty,max=200"`
@@ -14,14 +22,33 @@ type CreatePostRequest struct {
 	OgImageURL      string `json:"ogImageUrl" binding:"omitempty,max=512"`
 	RobotsMeta      string `json:"robotsMeta" binding:"omitempty,max=100"`
 	TagIDs          []uint `json:"tagIds" binding:"omitempty,dive,gt=0"`
+	// Locale (BCP 47) defaults to the site's defaultLocale. TranslationOf links the post to the
+	// translation group of another post, which must not have a variant in Locale yet.
+	Locale        string `json:"locale" binding:"omitempty,max=35"`
+	TranslationOf *uint  `json:"translationOf" binding:"omitempty,gt=0"`
+	// Contributors in display order; absent credits the creator as sole author. At least one must be an author.
+	Contributors []PostContributorRequest `json:"contributors" binding:"omitempty,max=20,dive"`
+}
+
+// PostContributorRequest credits a user on a post; Role is author, editor or illustrator.
+type PostContributorRequest struct {
+	UserID uint   `json:"userId" binding:"required,gt=0"`
+	Role   string `json:"role" binding:"required,oneof=author editor illustrator"`
 }
 
 type UpdatePostRequest struct {
-	Title      string `json:"title" binding:"omitempty,min=3,max=200"`
-	Content    string `json:"content" binding:"omitempty,min=1"`
-	CategoryID *uint  `json:"categoryId" binding:"omitempty,gt=0"`
-	Layout     string `json:"layout" binding:"omitempty,oneof=simple author book list"`
-	Status     string `json:"status" binding:"omitempty,oneof=draft published archived"`
+	Title string `json:"title" binding:"omitempty,min=3,max=200"`
+	// Slug renames the post's URL (it is normalized and made unique); the old slug keeps redirecting.
+	Slug          string `json:"slug" binding:"omitempty,max=200"`
+	Content       string `json:"content" binding:"omitempty,min=1"`
+	ContentFormat string `json:"contentFormat" binding:"omitempty,oneof=markdown html plain"`
+	CategoryID    *uint  `json:"categoryId" binding:"omitempty,gt=0"`
+	Layout        string `json:"layout" binding:"omitempty,oneof=simple author book list"`
+	Status        string `json:"status" binding:"omitempty,oneof=draft published archived scheduled in_review changes_requested approved"`
+	// PublishAt/UnpublishAt: absent means no change; ClearSchedule removes both before applying them.
+	PublishAt     *time.Time `json:"publishAt"`
+	UnpublishAt   *time.Time `json:"unpublishAt"`
+	ClearSchedule bool       `json:"clearSchedule"`
 	// SEO: use pointers so JSON null/absence can mean "no change"; present string (including "") updates/clears.
 	Excerpt         *string `json:"excerpt" binding:"omitempty,max=2000"`
 	MetaTitle       *string `json:"metaTitle" binding:"omitempty,max=200"`
@@ -30,13 +57,63 @@ type UpdatePostRequest struct {
 	OgImageURL      *string `json:"ogImageUrl" binding:"omitempty,max=512"`
 	RobotsMeta      *string `json:"robotsMeta" binding:"omitempty,max=100"`
 	TagIDs          []uint  `json:"tagIds" binding:"omitempty,dive,gt=0"`
+	// Contributors, when present, replaces the whole list (same rules as on create).
+	Contributors []PostContributorRequest `json:"contributors" binding:"omitempty,max=20,dive"`
 }
 
 type PostListRequest struct {
-	PageRequest
+	CursorRequest
 	SearchRequest
+	// Sort: oldest (default), newest or title.
+	Sort       string `form:"sort" json:"sort" binding:"omitempty,oneof=oldest newest title"`
 	Title      string `form:"title" json:"title" binding:"omitempty,min=3,max=200"`
 	CategoryID *uint  `form:"categoryId" json:"categoryId" binding:"omitempty,gt=0"`
 	Layout     string `form:"layout" json:"layout" binding:"omitempty,oneof=simple author book list"`
-	Status     string `form:"status" json:"status" binding:"omitempty,oneof=draft published archived"`
+	Status     string `form:"status" json:"status" binding:"omitempty,oneof=draft published archived scheduled in_review changes_requested approved"`
+	// ReadingListID keeps posts in that reading list: one of the caller's, or a shared one.
+	ReadingListID *uint `form:"readingListId" json:"readingListId" binding:"omitempty,gt=0"`
+	// Locale (BCP 47) selects the language; without it Accept-Language is negotiated, then the site default.
+	Locale string `form:"locale" json:"locale" binding:"omitempty,max=35"`
+	// AcceptLanguage is copied from the request header by the handler.
+	AcceptLanguage string `form:"-" json:"-"`
+	// DefaultLocale, set by the service, lists default-locale posts lacking a Locale variant in its place.
+	DefaultLocale string `form:"-" json:"-"`
+	// LiveAt, set by the service (never bound), restricts results to posts publicly visible at that time.
+	LiveAt *time.Time `form:"-" json:"-"`
+	// MatchIDs, set by the service when a search index answers Search, replaces the LIKE filter.
+	MatchIDs []uint `form:"-" json:"-"`
+	// ViewerID, set by the handler for signed-in callers, fills each post's myReactions and grants
+	// access to their private reading lists.
+	ViewerID uint `form:"-" json:"-"`
+}
+
+type PostSearchRequest struct {
+	PageRequest
+	Q          string `form:"q" json:"q" binding:"required,min=1,max=255"`
+	TagIDs     []uint `form:"tagId" json:"tagIds" binding:"omitempty,max=10,dive,gt=0"`
+	CategoryID *uint  `form:"categoryId" json:"categoryId" binding:"omitempty,gt=0"`
+	AuthorID   *uint  `form:"authorId" json:"authorId" binding:"omitempty,gt=0"`
+	// From/To bound the publish date (inclusive, whole days).
+	From *time.Time `form:"from" json:"from" time_format:"2006-01-02"`
+	To   *time.Time `form:"to" json:"to" time_format:"2006-01-02"`
+}
+
+type RelatedPostsRequest struct {
+	Limit int `form:"limit" json:"limit" binding:"omitempty,min=1,max=20"`
+}
+
+type PopularPostsRequest struct {
+	// Window is how many days back to count, e.g. 7d (default) or 30d; at most 365d.
+	Window string `form:"window" json:"window" binding:"omitempty,max=4"`
+	Limit  int    `form:"limit" json:"limit" binding:"omitempty,min=1,max=50"`
+}
+
+type PostStatsRequest struct {
+	// Days is how many days of history to return, ending today (default 30).
+	Days int `form:"days" json:"days" binding:"omitempty,min=1,max=365"`
+}
+
+type CreatePreviewLinkRequest struct {
+	// ExpiresInHours is the link lifetime; 0 or absent uses the default (72).
+	ExpiresInHours int `json:"expiresInHours" binding:"omitempty,min=1,max=720"`
 }
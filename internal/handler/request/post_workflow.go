@@ -0,0 +1,17 @@
+package request
+
+type PostTransitionRequest struct {
+	// Transition names a transition of the site's workflow, e.g. submit or approve.
+	Transition string `json:"transition" binding:"required,max=50"`
+	Note       string `json:"note" binding:"omitempty,max=2000"`
+}
+
+type AssignPostReviewerRequest struct {
+	UserID uint `json:"userId" binding:"required,gt=0"`
+}
+
+type CreatePostReviewCommentRequest struct {
+	Body string `json:"body" binding:"required,min=1,max=5000"`
+	// RevisionID is the revision commented on; absent means the post's latest revision.
+	RevisionID uint `json:"revisionId" binding:"omitempty,gt=0"`
+}
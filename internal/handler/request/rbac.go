@@ -1,8 +1,21 @@
 package request
 
+import "time"
+
 type AssignRoleRequest struct {
 	UserID uint   `json:"userId" binding:"required,gt=0"`
 	Role   string `json:"role" binding:"required,min=2,max=50"`
+	// Optional category scope: the grant only applies to posts and categories in this category's subtree.
+	CategoryID *uint `json:"categoryId" binding:"omitempty,gt=0"`
+	// Optional grant window (RFC 3339). Omit both for a permanent assignment.
+	ValidFrom  *time.Time `json:"validFrom" binding:"omitempty"`
+	ValidUntil *time.Time `json:"validUntil" binding:"omitempty"`
+}
+
+// RoleInheritanceRequest makes Role inherit every permission of ParentRole (or removes that edge).
+type RoleInheritanceRequest struct {
+	Role       string `json:"role" binding:"required,min=2,max=50"`
+	ParentRole string `json:"parentRole" binding:"required,min=2,max=50"`
 }
 
 type AddPermissionRequest struct {
@@ -10,3 +23,11 @@ type AddPermissionRequest struct {
 	Obj  string `json:"obj" binding:"required,min=1,max=200"`
 	Act  string `json:"act" binding:"required,min=1,max=50"`
 }
+
+// ExplainRBACRequest asks why userId is (not) allowed to call method on path.
+// Path should be the route template as matched by Gin (e.g. /api/v1/posts/:id), which is what the RBAC middleware enforces.
+type ExplainRBACRequest struct {
+	UserID uint   `form:"userId" json:"userId" binding:"required,gt=0"`
+	Path   string `form:"path" json:"path" binding:"required,min=1,max=200"`
+	Method string `form:"method" json:"method" binding:"required,min=3,max=10"`
+}
@@ -0,0 +1,28 @@
+package request
+
+type CreateReadingListRequest struct {
+	Name string `json:"name" binding:"required,min=1,max=100"`
+	// Shared issues a share slug right away; lists are private by default.
+	Shared bool `json:"shared"`
+}
+
+type UpdateReadingListRequest struct {
+	Name string `json:"name" binding:"omitempty,min=1,max=100"`
+	// Shared true issues a share slug if the list has none; false makes the list private again.
+	Shared *bool `json:"shared"`
+}
+
+type AddReadingListItemRequest struct {
+	PostID uint `json:"postId" binding:"required,gt=0"`
+	// Position is the 1-based place to insert at; absent or past the end appends.
+	Position int `json:"position" binding:"omitempty,min=1"`
+}
+
+type ReorderReadingListRequest struct {
+	// PostIDs lists every post in the reading list in its new order.
+	PostIDs []uint `json:"postIds" binding:"required,min=1,max=1000,dive,gt=0"`
+}
+
+type MarkReadingListItemRequest struct {
+	Read *bool `json:"read" binding:"required"`
+}
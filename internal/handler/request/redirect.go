@@ -0,0 +1,23 @@
+package request
+
+type CreateRedirectRequest struct {
+	// FromPath is the site path to redirect, e.g. /old/about; a trailing slash and any query are ignored.
+	FromPath string `json:"fromPath" binding:"required,max=512"`
+	// ToPath is a site path or an absolute http(s) URL.
+	ToPath string `json:"toPath" binding:"required,max=1024"`
+	// StatusCode is 301 (default), 302, 307 or 308.
+	StatusCode int `json:"statusCode" binding:"omitempty,oneof=301 302 307 308"`
+}
+
+type UpdateRedirectRequest struct {
+	FromPath   string `json:"fromPath" binding:"omitempty,max=512"`
+	ToPath     string `json:"toPath" binding:"omitempty,max=1024"`
+	StatusCode int    `json:"statusCode" binding:"omitempty,oneof=301 302 307 308"`
+}
+
+type RedirectListRequest struct {
+	CursorRequest
+	SearchRequest
+	// Sort: newest (default), oldest or hits (most hit first).
+	Sort string `form:"sort" json:"sort" binding:"omitempty,oneof=newest oldest hits"`
+}
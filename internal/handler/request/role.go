@@ -5,7 +5,9 @@ type CreateRoleRequest struct {
 }
 
 type RoleListRequest struct {
-	PageRequest
+	CursorRequest
 	SearchRequest
+	// Sort: oldest (default) or name.
+	Sort string `form:"sort" json:"sort" binding:"omitempty,oneof=oldest name"`
 	Name string `form:"name" json:"name" binding:"omitempty,min=2,max=50"`
 }
@@ -0,0 +1,30 @@
+package request
+
+type CreateSeriesRequest struct {
+	Title       string `json:"title" binding:"required,min=3,max=200"`
+	Description string `json:"description" binding:"omitempty,max=5000"`
+}
+
+type UpdateSeriesRequest struct {
+	Title       string  `json:"title" binding:"omitempty,min=3,max=200"`
+	Description *string `json:"description" binding:"omitempty,max=5000"`
+}
+
+type SeriesListRequest struct {
+	CursorRequest
+	SearchRequest
+	// Sort: oldest (default), newest or title.
+	Sort   string `form:"sort" json:"sort" binding:"omitempty,oneof=oldest newest title"`
+	UserID *uint  `form:"userId" json:"userId" binding:"omitempty,gt=0"`
+}
+
+type AddSeriesPostRequest struct {
+	PostID uint `json:"postId" binding:"required,gt=0"`
+	// Chapter is the 1-based position to insert at; absent or past the end appends.
+	Chapter int `json:"chapter" binding:"omitempty,min=1"`
+}
+
+type ReorderSeriesRequest struct {
+	// PostIDs lists every post in the series in its new chapter order.
+	PostIDs []uint `json:"postIds" binding:"required,min=1,max=1000,dive,gt=0"`
+}
@@ -0,0 +1,8 @@
+package request
+
+type CreateSiteRequest struct {
+	Key  string `json:"key" binding:"required,min=2,max=64"`
+	Name string `json:"name" binding:"required,min=1,max=255"`
+	// Host is the bare hostname requests for this site arrive on (no scheme or port). Optional.
+	Host string `json:"host" binding:"omitempty,max=255"`
+}
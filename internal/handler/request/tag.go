@@ -6,10 +6,14 @@ type CreateTagRequest struct {
 
 type UpdateTagRequest struct {
 	Name string `json:"name" binding:"omitempty,min=2,max=100"`
+	// Slug renames the tag's URL (it is normalized and made unique); the old slug keeps redirecting.
+	Slug string `json:"slug" binding:"omitempty,max=100"`
 }
 
 type TagListRequest struct {
-	PageRequest
+	CursorRequest
 	SearchRequest
+	// Sort: oldest (default), newest or name.
+	Sort string `form:"sort" json:"sort" binding:"omitempty,oneof=oldest newest name"`
 	Name string `form:"name" json:"name" binding:"omitempty,min=2,max=100"`
 }
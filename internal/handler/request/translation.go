@@ -0,0 +1,6 @@
+package request
+
+// TranslationRequest names a category or tag in one locale (taken from the path); the slug is derived from the name.
+type TranslationRequest struct {
+	Name string `json:"name" binding:"required,min=1,max=100"`
+}
@@ -0,0 +1,8 @@
+package request
+
+type TrashListRequest struct {
+	CursorRequest
+	Type string `form:"type" json:"type" binding:"required,oneof=posts categories comments tags media"`
+	// Sort: recent (default, last deleted first) or oldest (next to be purged first).
+	Sort string `form:"sort" json:"sort" binding:"omitempty,oneof=recent oldest"`
+}
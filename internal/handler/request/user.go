@@ -11,8 +11,10 @@ type CreateUserRequest struct {
 }
 
 type UserListRequest struct {
-	PageRequest
+	CursorRequest
 	SearchRequest
+	// Sort: oldest (default), newest or name.
+	Sort  string `form:"sort" json:"sort" binding:"omitempty,oneof=oldest newest name"`
 	Name  string `form:"name" json:"name" binding:"omitempty,min=2,max=100"`
 	Email string `form:"email" json:"email" binding:"omitempty,email,max=190"`
 }
@@ -26,7 +26,11 @@ func NewRoleHandler(roles *service.RoleService, log *zap.Logger) *RoleHandler {
 // @Tags         Roles
 // @Produce      json
 // @Security     BearerAuth
-// @Param        limit  query     int  false  "Max items (max 500)"
+// @Param        limit      query     int     false  "Page size (max 200)"
+// @Param        sort       query     string  false  "Sort order (first is the default)"  Enums(oldest,name)
+// @Param        after      query     string  false  "Page after this cursor (a nextCursor)"
+// @Param        before     query     string  false  "Page before this cursor (a prevCursor)"
+// @Param        withTotal  query     bool    false  "Also return the total count"
 // @Success      200    {object}  response.Envelope
 // @Failure      401    {object}  response.Envelope
 // @Failure      403    {object}  response.Envelope
@@ -54,19 +58,18 @@ func (h *RoleHandler) List(c *gin.Context) {
 
 	page, err := h.roles.List(c.Request.Context(), req)
 	if err != nil {
-		h.internalError(c, response.ServiceCodeRoles, err, "list failed")
+		h.listError(c, response.ServiceCodeRoles, err)
 		return
 	}
 
-	next := page.NextCursor != nil
-	prev := page.PrevCursor != nil
-	response.OKPaginated(
+	response.OKCursorPaginated(
 		c,
 		response.BuildResponseCode(http.StatusOK, response.ServiceCodeRoles, response.CaseCodeListRetrieved),
 		"ok",
 		page.Items,
-		next,
-		prev,
+		page.NextCursor,
+		page.PrevCursor,
+		page.Total,
 	)
 }
 
@@ -0,0 +1,365 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/turahe/go-restfull/internal/handler/request"
+	"github.com/turahe/go-restfull/internal/middleware"
+	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/internal/repository"
+	"github.com/turahe/go-restfull/internal/service"
+	"github.com/turahe/go-restfull/internal/service/dto"
+	"github.com/turahe/go-restfull/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type seriesService interface {
+	List(ctx context.Context, req request.SeriesListRequest) (repository.CursorPage, error)
+	Landing(ctx context.Context, slug string) (*dto.SeriesLanding, error)
+	Chapters(ctx context.Context, id, actorUserID uint) ([]model.Post, error)
+	Create(ctx context.Context, actorUserID uint, req request.CreateSeriesRequest) (*model.Series, error)
+	Update(ctx context.Context, id, actorUserID uint, req request.UpdateSeriesRequest) (*model.Series, error)
+	Delete(ctx context.Context, id, actorUserID uint) error
+	AddPost(ctx context.Context, id, actorUserID uint, req request.AddSeriesPostRequest) ([]model.Post, error)
+	RemovePost(ctx context.Context, id, postID, actorUserID uint) error
+	Reorder(ctx context.Context, id, actorUserID uint, req request.ReorderSeriesRequest) ([]model.Post, error)
+}
+
+type SeriesHandler struct {
+	BaseHandler
+	series seriesService
+}
+
+func NewSeriesHandler(series seriesService, log *zap.Logger) *SeriesHandler {
+	return &SeriesHandler{BaseHandler: BaseHandler{Log: log}, series: series}
+}
+
+// params reads the caller and the series id, writing the error response itself when one is missing
+// or malformed.
+func (h *SeriesHandler) params(c *gin.Context) (actorUserID, seriesID uint, ok bool) {
+	auth, ok := middleware.GetAuth(c)
+	if !ok {
+		response.Unauthorized(c, response.BuildResponseCode(http.StatusUnauthorized, response.ServiceCodeSeries, response.CaseCodeUnauthorized), "unauthorized", "missing auth")
+		return 0, 0, false
+	}
+	seriesID, err := h.ParseUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodeSeries, response.CaseCodeInvalidValue), "invalid id", "id must be uint")
+		return 0, 0, false
+	}
+	return auth.UserID, seriesID, true
+}
+
+func (h *SeriesHandler) writeError(c *gin.Context, err error, message string) {
+	switch err {
+	case service.ErrSeriesNotFound:
+		response.NotFound(c, response.BuildResponseCode(http.StatusNotFound, response.ServiceCodeSeries, response.CaseCodeNotFound), "not found", "series not found")
+	case service.ErrPostNotFound, service.ErrPostNotInSeries:
+		response.NotFound(c, response.BuildResponseCode(http.StatusNotFound, response.ServiceCodeSeries, response.CaseCodeNotFound), "not found", err.Error())
+	case service.ErrNotSeriesOwner, service.ErrNotPostOwner, service.ErrCategoryOutOfScope:
+		response.Forbidden(c, response.BuildResponseCode(http.StatusForbidden, response.ServiceCodeSeries, response.CaseCodePermissionDenied), "forbidden", err.Error())
+	case service.ErrChapterSetMismatch:
+		response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodeSeries, response.CaseCodeInvalidValue), "invalid request", err.Error())
+	case service.ErrInvalidSlug:
+		response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodeSeries, response.CaseCodeInvalidFormat), "invalid request", err.Error())
+	default:
+		h.internalError(c, response.ServiceCodeSeries, err, message)
+	}
+}
+
+// ListSeries godoc
+// @Summary      List series
+// @Tags         Series
+// @Produce      json
+// @Param        search     query     string  false  "Title contains"
+// @Param        userId     query     int     false  "Owner user ID"
+// @Param        limit      query     int     false  "Page size (max 100)"
+// @Param        sort       query     string  false  "Sort order (first is the default)"  Enums(oldest,newest,title)
+// @Param        after      query     string  false  "Page after this cursor (a nextCursor)"
+// @Param        before     query     string  false  "Page before this cursor (a prevCursor)"
+// @Param        withTotal  query     bool    false  "Also return the total count"
+// @Success      200        {object}  response.Envelope
+// @Failure      400        {object}  response.Envelope
+// @Failure      500        {object}  response.Envelope
+// @Router       /api/v1/series [get]
+func (h *SeriesHandler) List(c *gin.Context) {
+	var req request.SeriesListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequest(c,
+			response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodeSeries, response.CaseCodeInvalidFormat),
+			"invalid request",
+			err.Error(),
+		)
+		return
+	}
+	if !h.validate(c, response.ServiceCodeSeries, req) {
+		return
+	}
+
+	page, err := h.series.List(c.Request.Context(), req)
+	if err != nil {
+		h.listError(c, response.ServiceCodeSeries, err)
+		return
+	}
+	response.OKCursorPaginated(
+		c,
+		response.BuildResponseCode(http.StatusOK, response.ServiceCodeSeries, response.CaseCodeListRetrieved),
+		"ok",
+		page.Items,
+		page.NextCursor,
+		page.PrevCursor,
+		page.Total,
+	)
+}
+
+// GetSeriesBySlug godoc
+// @Summary      Series landing page
+// @Description  The series with its live chapters in order, and publication progress (live vs. all chapters, next scheduled chapter).
+// @Tags         Series
+// @Produce      json
+// @Param        slug  path      string  true  "Series slug"
+// @Success      200   {object}  response.Envelope
+// @Failure      400   {object}  response.Envelope
+// @Failure      404   {object}  response.Envelope
+// @Failure      500   {object}  response.Envelope
+// @Router       /api/v1/series/slug/{slug} [get]
+func (h *SeriesHandler) GetBySlug(c *gin.Context) {
+	l, err := h.series.Landing(c.Request.Context(), c.Param("slug"))
+	if err != nil {
+		h.writeError(c, err, "get series failed")
+		return
+	}
+	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodeSeries, response.CaseCodeRetrieved), "ok", l)
+}
+
+// CreateSeries godoc
+// @Summary      Create a series
+// @Tags         Series
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        body  body      request.CreateSeriesRequest  true  "Create series payload"
+// @Success      201   {object}  response.Envelope
+// @Failure      400   {object}  response.Envelope
+// @Failure      401   {object}  response.Envelope
+// @Failure      500   {object}  response.Envelope
+// @Router       /api/v1/series [post]
+func (h *SeriesHandler) Create(c *gin.Context) {
+	auth, ok := middleware.GetAuth(c)
+	if !ok {
+		response.Unauthorized(c, response.BuildResponseCode(http.StatusUnauthorized, response.ServiceCodeSeries, response.CaseCodeUnauthorized), "unauthorized", "missing auth")
+		return
+	}
+	var req request.CreateSeriesRequest
+	if !h.bindJSON(c, response.ServiceCodeSeries, &req) {
+		return
+	}
+	if !h.validate(c, response.ServiceCodeSeries, req) {
+		return
+	}
+
+	s, err := h.series.Create(c.Request.Context(), auth.UserID, req)
+	if err != nil {
+		h.writeError(c, err, "create failed")
+		return
+	}
+	response.Created(c, response.BuildResponseCode(http.StatusCreated, response.ServiceCodeSeries, response.CaseCodeCreated), "created", s)
+}
+
+// UpdateSeries godoc
+// @Summary      Update a series (owner only)
+// @Tags         Series
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      int                          true  "Series ID"
+// @Param        body  body      request.UpdateSeriesRequest  true  "Update series payload"
+// @Success      200   {object}  response.Envelope
+// @Failure      400   {object}  response.Envelope
+// @Failure      401   {object}  response.Envelope
+// @Failure      403   {object}  response.Envelope
+// @Failure      404   {object}  response.Envelope
+// @Failure      500   {object}  response.Envelope
+// @Router       /api/v1/series/{id} [put]
+func (h *SeriesHandler) Update(c *gin.Context) {
+	actor, id, ok := h.params(c)
+	if !ok {
+		return
+	}
+	var req request.UpdateSeriesRequest
+	if !h.bindJSON(c, response.ServiceCodeSeries, &req) {
+		return
+	}
+	if !h.validate(c, response.ServiceCodeSeries, req) {
+		return
+	}
+
+	s, err := h.series.Update(c.Request.Context(), id, actor, req)
+	if err != nil {
+		h.writeError(c, err, "update failed")
+		return
+	}
+	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodeSeries, response.CaseCodeUpdated), "updated", s)
+}
+
+// DeleteSeries godoc
+// @Summary      Delete a series (owner only)
+// @Description  Its posts are kept and leave the series.
+// @Tags         Series
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id  path      int  true  "Series ID"
+// @Success      200 {object}  response.Envelope
+// @Failure      400 {object}  response.Envelope
+// @Failure      401 {object}  response.Envelope
+// @Failure      403 {object}  response.Envelope
+// @Failure      404 {object}  response.Envelope
+// @Failure      500 {object}  response.Envelope
+// @Router       /api/v1/series/{id} [delete]
+func (h *SeriesHandler) Delete(c *gin.Context) {
+	actor, id, ok := h.params(c)
+	if !ok {
+		return
+	}
+	if err := h.series.Delete(c.Request.Context(), id, actor); err != nil {
+		h.writeError(c, err, "delete failed")
+		return
+	}
+	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodeSeries, response.CaseCodeDeleted), "deleted", nil)
+}
+
+// ListSeriesPosts godoc
+// @Summary      List every post in a series in chapter order (owner only)
+// @Description  Includes drafts and scheduled posts.
+// @Tags         Series
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id  path      int  true  "Series ID"
+// @Success      200 {object}  response.Envelope
+// @Failure      400 {object}  response.Envelope
+// @Failure      401 {object}  response.Envelope
+// @Failure      403 {object}  response.Envelope
+// @Failure      404 {object}  response.Envelope
+// @Failure      500 {object}  response.Envelope
+// @Router       /api/v1/series/{id}/posts [get]
+func (h *SeriesHandler) ListPosts(c *gin.Context) {
+	actor, id, ok := h.params(c)
+	if !ok {
+		return
+	}
+	posts, err := h.series.Chapters(c.Request.Context(), id, actor)
+	if err != nil {
+		h.writeError(c, err, "list series posts failed")
+		return
+	}
+	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodeSeries, response.CaseCodeListRetrieved), "ok", posts)
+}
+
+// AddSeriesPost godoc
+// @Summary      Add a post to a series, or move it within it
+// @Description  Inserts at chapter (appending when absent) and shifts later chapters. Takes the series owner, who must also be allowed to edit the post; a post in another series leaves it.
+// @Tags         Series
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      int                           true  "Series ID"
+// @Param        body  body      request.AddSeriesPostRequest  true  "Post and chapter"
+// @Success      200   {object}  response.Envelope
+// @Failure      400   {object}  response.Envelope
+// @Failure      401   {object}  response.Envelope
+// @Failure      403   {object}  response.Envelope
+// @Failure      404   {object}  response.Envelope
+// @Failure      500   {object}  response.Envelope
+// @Router       /api/v1/series/{id}/posts [post]
+func (h *SeriesHandler) AddPost(c *gin.Context) {
+	actor, id, ok := h.params(c)
+	if !ok {
+		return
+	}
+	var req request.AddSeriesPostRequest
+	if !h.bindJSON(c, response.ServiceCodeSeries, &req) {
+		return
+	}
+	if !h.validate(c, response.ServiceCodeSeries, req) {
+		return
+	}
+
+	posts, err := h.series.AddPost(c.Request.Context(), id, actor, req)
+	if err != nil {
+		h.writeError(c, err, "add series post failed")
+		return
+	}
+	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodeSeries, response.CaseCodeUpdated), "updated", posts)
+}
+
+// ReorderSeriesPosts godoc
+// @Summary      Reorder a series' chapters (owner only)
+// @Description  postIds must list every post in the series exactly once; the first becomes chapter 1.
+// @Tags         Series
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      int                           true  "Series ID"
+// @Param        body  body      request.ReorderSeriesRequest  true  "New chapter order"
+// @Success      200   {object}  response.Envelope
+// @Failure      400   {object}  response.Envelope
+// @Failure      401   {object}  response.Envelope
+// @Failure      403   {object}  response.Envelope
+// @Failure      404   {object}  response.Envelope
+// @Failure      500   {object}  response.Envelope
+// @Router       /api/v1/series/{id}/posts [put]
+func (h *SeriesHandler) Reorder(c *gin.Context) {
+	actor, id, ok := h.params(c)
+	if !ok {
+		return
+	}
+	var req request.ReorderSeriesRequest
+	if !h.bindJSON(c, response.ServiceCodeSeries, &req) {
+		return
+	}
+	if !h.validate(c, response.ServiceCodeSeries, req) {
+		return
+	}
+
+	posts, err := h.series.Reorder(c.Request.Context(), id, actor, req)
+	if err != nil {
+		h.writeError(c, err, "reorder series failed")
+		return
+	}
+	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodeSeries, response.CaseCodeUpdated), "updated", posts)
+}
+
+// RemoveSeriesPost godoc
+// @Summary      Remove a post from a series (owner only)
+// @Description  The post is kept; later chapters move up by one.
+// @Tags         Series
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path      int  true  "Series ID"
+// @Param        postId  path      int  true  "Post ID"
+// @Success      200     {object}  response.Envelope
+// @Failure      400     {object}  response.Envelope
+// @Failure      401     {object}  response.Envelope
+// @Failure      403     {object}  response.Envelope
+// @Failure      404     {object}  response.Envelope
+// @Failure      500     {object}  response.Envelope
+// @Router       /api/v1/series/{id}/posts/{postId} [delete]
+func (h *SeriesHandler) RemovePost(c *gin.Context) {
+	actor, id, ok := h.params(c)
+	if !ok {
+		return
+	}
+	postID, err := h.ParseUintParam(c, "postId")
+	if err != nil {
+		response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodeSeries, response.CaseCodeInvalidValue), "invalid post id", "postId must be uint")
+		return
+	}
+	if err := h.series.RemovePost(c.Request.Context(), id, postID, actor); err != nil {
+		h.writeError(c, err, "remove series post failed")
+		return
+	}
+	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodeSeries, response.CaseCodeDeleted), "deleted", nil)
+}
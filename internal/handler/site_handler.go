@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/turahe/go-restfull/internal/handler/request"
+	"github.com/turahe/go-restfull/internal/middleware"
+	"github.com/turahe/go-restfull/internal/service"
+	"github.com/turahe/go-restfull/internal/tenant"
+	"github.com/turahe/go-restfull/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type SiteHandler struct {
+	BaseHandler
+	sites *service.SiteService
+}
+
+func NewSiteHandler(sites *service.SiteService, log *zap.Logger) *SiteHandler {
+	return &SiteHandler{BaseHandler: BaseHandler{Log: log}, sites: sites}
+}
+
+// requireSiteAdmin allows admins of the default site only; site admins cannot manage other sites.
+func (h *SiteHandler) requireSiteAdmin(c *gin.Context) bool {
+	auth, ok := middleware.GetAuth(c)
+	if !ok {
+		response.Unauthorized(c, response.BuildResponseCode(http.StatusUnauthorized, response.ServiceCodeAuth, response.CaseCodeUnauthorized), "unauthorized", "missing auth")
+		return false
+	}
+	if auth.Role != "admin" || tenant.SiteID(c.Request.Context()) != tenant.DefaultSiteID {
+		response.Forbidden(c, response.BuildResponseCode(http.StatusForbidden, response.ServiceCodeAuth, response.CaseCodePermissionDenied), "forbidden", "default site admin only")
+		return false
+	}
+	return true
+}
+
+// List godoc
+// @Summary      List sites
+// @Description  Admins of the default site only.
+// @Tags         Sites
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Envelope
+// @Failure      401  {object}  response.Envelope
+// @Failure      403  {object}  response.Envelope
+// @Failure      500  {object}  response.Envelope
+// @Router       /api/v1/sites [get]
+func (h *SiteHandler) List(c *gin.Context) {
+	if !h.requireSiteAdmin(c) {
+		return
+	}
+	sites, err := h.sites.List(c.Request.Context())
+	if err != nil {
+		h.internalError(c, response.ServiceCodeSites, err, "list sites failed")
+		return
+	}
+	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodeSites, response.CaseCodeListRetrieved), "ok", sites)
+}
+
+// Create godoc
+// @Summary      Create site
+// @Description  Admins of the default site only. Requests select the site with the X-Site header (key) or its host.
+// @Tags         Sites
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        body  body      request.CreateSiteRequest  true  "Site"
+// @Success      201   {object}  response.Envelope
+// @Failure      400   {object}  response.Envelope
+// @Failure      401   {object}  response.Envelope
+// @Failure      403   {object}  response.Envelope
+// @Failure      409   {object}  response.Envelope
+// @Failure      500   {object}  response.Envelope
+// @Router       /api/v1/sites [post]
+func (h *SiteHandler) Create(c *gin.Context) {
+	if !h.requireSiteAdmin(c) {
+		return
+	}
+	var req request.CreateSiteRequest
+	if !h.bindJSON(c, response.ServiceCodeSites, &req) {
+		return
+	}
+	if !h.validate(c, response.ServiceCodeSites, req) {
+		return
+	}
+	site, err := h.sites.Create(c.Request.Context(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidSiteKey):
+			response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodeSites, response.CaseCodeInvalidValue), "invalid request", err.Error())
+		case errors.Is(err, service.ErrSiteExists):
+			response.Conflict(c, response.BuildResponseCode(http.StatusConflict, response.ServiceCodeSites, response.CaseCodeDuplicateEntry), "duplicate site", err.Error())
+		default:
+			h.internalError(c, response.ServiceCodeSites, err, "create site failed")
+		}
+		return
+	}
+	response.Created(c, response.BuildResponseCode(http.StatusCreated, response.ServiceCodeSites, response.CaseCodeCreated), "created", site)
+}
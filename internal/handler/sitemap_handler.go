@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/turahe/go-restfull/internal/service"
+	"github.com/turahe/go-restfull/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const sitemapContentType = "application/xml; charset=utf-8"
+
+type sitemapService interface {
+	Index(ctx context.Context, origin string) (*service.SitemapDoc, error)
+	Page(ctx context.Context, kind service.SitemapKind, page int, origin string) (*service.SitemapDoc, error)
+}
+
+type SitemapHandler struct {
+	BaseHandler
+	sitemaps sitemapService
+}
+
+func NewSitemapHandler(sitemaps sitemapService, log *zap.Logger) *SitemapHandler {
+	return &SitemapHandler{BaseHandler: BaseHandler{Log: log}, sitemaps: sitemaps}
+}
+
+// Index godoc
+// @Summary      Sitemap index
+// @Description  Sitemap index (sitemaps.org 0.9) pointing at the paginated post, category and tag sitemaps under /sitemaps. Supports If-None-Match and If-Modified-Since.
+// @Tags         Sitemaps
+// @Produce      xml
+// @Success      200
+// @Success      304
+// @Failure      500  {object}  response.Envelope
+// @Router       /sitemap.xml [get]
+func (h *SitemapHandler) Index(c *gin.Context) {
+	doc, err := h.sitemaps.Index(c.Request.Context(), requestOrigin(c))
+	if err != nil {
+		h.internalError(c, response.ServiceCodePosts, err, "sitemap failed")
+		return
+	}
+	serveCacheable(c, sitemapContentType, doc.Body, doc.LastMod)
+}
+
+// Page godoc
+// @Summary      Child sitemap
+// @Description  One page of post, category or tag URLs, e.g. posts-1.xml. Posts link to their canonical URL when set, else to {siteUrl}/posts/{slug}; unpublished posts and posts whose robots meta says noindex are left out. lastmod is the row's last update.
+// @Tags         Sitemaps
+// @Produce      xml
+// @Param        file  path      string  true  "Sitemap file: {posts|categories|tags}-{page}.xml"
+// @Success      200
+// @Success      304
+// @Failure      404  {object}  response.Envelope
+// @Failure      500  {object}  response.Envelope
+// @Router       /sitemaps/{file} [get]
+func (h *SitemapHandler) Page(c *gin.Context) {
+	kind, page, ok := sitemapFile(c.Param("file"))
+	if !ok {
+		response.NotFound(c, response.BuildResponseCode(http.StatusNotFound, response.ServiceCodePosts, response.CaseCodeNotFound), "not found", service.ErrSitemapNotFound.Error())
+		return
+	}
+	doc, err := h.sitemaps.Page(c.Request.Context(), kind, page, requestOrigin(c))
+	switch err {
+	case nil:
+	case service.ErrSitemapNotFound:
+		response.NotFound(c, response.BuildResponseCode(http.StatusNotFound, response.ServiceCodePosts, response.CaseCodeNotFound), "not found", err.Error())
+		return
+	default:
+		h.internalError(c, response.ServiceCodePosts, err, "sitemap failed")
+		return
+	}
+	serveCacheable(c, sitemapContentType, doc.Body, doc.LastMod)
+}
+
+// sitemapFile parses a child sitemap file name such as posts-3.xml.
+func sitemapFile(file string) (service.SitemapKind, int, bool) {
+	name, ok := strings.CutSuffix(file, ".xml")
+	if !ok {
+		return "", 0, false
+	}
+	kind, num, ok := strings.Cut(name, "-")
+	if !ok {
+		return "", 0, false
+	}
+	page, err := strconv.Atoi(num)
+	if err != nil || page < 1 {
+		return "", 0, false
+	}
+	return service.SitemapKind(kind), page, true
+}
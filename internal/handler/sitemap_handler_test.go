@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type mockSitemapService struct{ mock.Mock }
+
+func (m *mockSitemapService) Index(ctx context.Context, origin string) (*service.SitemapDoc, error) {
+	args := m.Called(ctx, origin)
+	d, _ := args.Get(0).(*service.SitemapDoc)
+	return d, args.Error(1)
+}
+
+func (m *mockSitemapService) Page(ctx context.Context, kind service.SitemapKind, page int, origin string) (*service.SitemapDoc, error) {
+	args := m.Called(ctx, kind, page, origin)
+	d, _ := args.Get(0).(*service.SitemapDoc)
+	return d, args.Error(1)
+}
+
+func TestSitemapHandler(t *testing.T) {
+	t.Parallel()
+	lastMod := time.Date(2026, 5, 4, 12, 0, 0, 0, time.UTC)
+	svc := new(mockSitemapService)
+	svc.On("Index", mock.Anything, "https://example.com").Return(&service.SitemapDoc{Body: []byte("<sitemapindex/>"), LastMod: lastMod}, nil)
+	svc.On("Page", mock.Anything, service.SitemapPosts, 3, "http://example.com").Return(&service.SitemapDoc{Body: []byte("<urlset/>"), LastMod: lastMod}, nil)
+	svc.On("Page", mock.Anything, service.SitemapTags, 9, "http://example.com").Return(nil, service.ErrSitemapNotFound)
+
+	h := NewSitemapHandler(svc, zap.NewNop())
+	r := gin.New()
+	r.GET("/sitemap.xml", h.Index)
+	r.GET("/sitemaps/:file", h.Page)
+
+	get := func(path string, header map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		for k, v := range header {
+			req.Header.Set(k, v)
+		}
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := get("/sitemap.xml", map[string]string{"X-Forwarded-Proto": "https"})
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/xml; charset=utf-8", rr.Header().Get("Content-Type"))
+	assert.Equal(t, "<sitemapindex/>", rr.Body.String())
+	assert.Equal(t, http.StatusNotModified, get("/sitemap.xml", map[string]string{"X-Forwarded-Proto": "https", "If-None-Match": rr.Header().Get("ETag")}).Code)
+
+	rr = get("/sitemaps/posts-3.xml", nil)
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "Mon, 04 May 2026 12:00:00 GMT", rr.Header().Get("Last-Modified"))
+
+	assert.Equal(t, http.StatusNotFound, get("/sitemaps/tags-9.xml", nil).Code)
+	for _, file := range []string{"posts.xml", "posts-0.xml", "posts-x.xml", "posts-1.txt"} {
+		assert.Equal(t, http.StatusNotFound, get("/sitemaps/"+file, nil).Code, file)
+	}
+	svc.AssertNumberOfCalls(t, "Page", 2)
+}
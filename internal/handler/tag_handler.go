@@ -25,7 +25,11 @@ func NewTagHandler(tags *service.TagService, log *zap.Logger) *TagHandler {
 // @Summary      List tags
 // @Tags         Tags
 // @Produce      json
-// @Param        limit  query     int  false  "Max items (max 500)"
+// @Param        limit      query     int     false  "Page size (max 200)"
+// @Param        sort       query     string  false  "Sort order (first is the default)"  Enums(oldest,newest,name)
+// @Param        after      query     string  false  "Page after this cursor (a nextCursor)"
+// @Param        before     query     string  false  "Page before this cursor (a prevCursor)"
+// @Param        withTotal  query     bool    false  "Also return the total count"
 // @Success      200    {object}  response.Envelope
 // @Failure      500    {object}  response.Envelope
 // @Router       /api/v1/tags [get]
@@ -45,18 +49,17 @@ func (h *TagHandler) List(c *gin.Context) {
 
 	page, err := h.tags.List(c.Request.Context(), req)
 	if err != nil {
-		h.internalError(c, response.ServiceCodeTags, err, "list failed")
+		h.listError(c, response.ServiceCodeTags, err)
 		return
 	}
-	next := page.NextCursor != nil
-	prev := page.PrevCursor != nil
-	response.OKPaginated(
+	response.OKCursorPaginated(
 		c,
 		response.BuildResponseCode(http.StatusOK, response.ServiceCodeTags, response.CaseCodeListRetrieved),
 		"ok",
 		page.Items,
-		next,
-		prev,
+		page.NextCursor,
+		page.PrevCursor,
+		page.Total,
 	)
 }
 
@@ -66,6 +69,7 @@ func (h *TagHandler) List(c *gin.Context) {
 // @Produce      json
 // @Param        slug  path      string  true  "Tag slug"
 // @Success      200   {object}  response.Envelope
+// @Failure      301   {object}  response.Envelope
 // @Failure      404   {object}  response.Envelope
 // @Failure      400   {object}  response.Envelope
 // @Router       /api/v1/tags/{slug} [get]
@@ -73,6 +77,9 @@ func (h *TagHandler) GetBySlug(c *gin.Context) {
 	slug := c.Param("slug")
 	t, err := h.tags.GetBySlug(c.Request.Context(), slug)
 	if err != nil {
+		if h.movedSlug(c, response.ServiceCodeTags, err) {
+			return
+		}
 		switch err {
 		case service.ErrTagNotFound:
 			response.NotFound(c, response.BuildResponseCode(http.StatusNotFound, response.ServiceCodeTags, response.CaseCodeNotFound), "not found", "tag not found")
@@ -156,6 +163,8 @@ func (h *TagHandler) Update(c *gin.Context) {
 		switch err {
 		case service.ErrTagNotFound:
 			response.NotFound(c, response.BuildResponseCode(http.StatusNotFound, response.ServiceCodeTags, response.CaseCodeNotFound), "not found", "tag not found")
+		case service.ErrInvalidSlug:
+			response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodeTags, response.CaseCodeInvalidValue), "invalid request", err.Error())
 		default:
 			h.internalError(c, response.ServiceCodeTags, err, "update failed")
 		}
@@ -200,3 +209,111 @@ func (h *TagHandler) Delete(c *gin.Context) {
 	}
 	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodeTags, response.CaseCodeDeleted), "deleted", gin.H{"id": uint(id)})
 }
+
+// ListTagTranslations godoc
+// @Summary      List tag translations
+// @Tags         Tags
+// @Produce      json
+// @Param        slug  path      string  true  "Tag slug (or a translated slug)"
+// @Success      200 {object}  response.Envelope
+// @Failure      301 {object}  response.Envelope
+// @Failure      400 {object}  response.Envelope
+// @Failure      404 {object}  response.Envelope
+// @Failure      500 {object}  response.Envelope
+// @Router       /api/v1/tags/{slug}/translations [get]
+func (h *TagHandler) ListTranslations(c *gin.Context) {
+	t, err := h.tags.GetBySlug(c.Request.Context(), c.Param("slug"))
+	if err != nil {
+		if h.movedSlug(c, response.ServiceCodeTags, err) {
+			return
+		}
+		if err == service.ErrInvalidSlug {
+			response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodeTags, response.CaseCodeInvalidValue), "invalid request", err.Error())
+			return
+		}
+		h.translationError(c, err, "list translations failed")
+		return
+	}
+	rows, err := h.tags.ListTranslations(c.Request.Context(), t.ID)
+	if err != nil {
+		h.translationError(c, err, "list translations failed")
+		return
+	}
+	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodeTags, response.CaseCodeListRetrieved), "ok", rows)
+}
+
+// PutTagTranslation godoc
+// @Summary      Set a tag name in a locale
+// @Description  Creates or replaces the translation; its slug is derived from the name and unique per locale.
+// @Tags         Tags
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path      int                         true  "Tag ID"
+// @Param        locale  path      string                      true  "Locale (BCP 47)"
+// @Param        body    body      request.TranslationRequest  true  "Translated name"
+// @Success      200 {object}  response.Envelope
+// @Failure      400 {object}  response.Envelope
+// @Failure      401 {object}  response.Envelope
+// @Failure      404 {object}  response.Envelope
+// @Failure      500 {object}  response.Envelope
+// @Router       /api/v1/tags/{id}/translations/{locale} [put]
+func (h *TagHandler) PutTranslation(c *gin.Context) {
+	id, err := h.ParseUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodeTags, response.CaseCodeInvalidValue), "invalid id", "id must be uint")
+		return
+	}
+	var req request.TranslationRequest
+	if !h.bindJSON(c, response.ServiceCodeTags, &req) {
+		return
+	}
+	if !h.validate(c, response.ServiceCodeTags, req) {
+		return
+	}
+	t, err := h.tags.PutTranslation(c.Request.Context(), id, c.Param("locale"), req.Name)
+	if err != nil {
+		h.translationError(c, err, "save translation failed")
+		return
+	}
+	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodeTags, response.CaseCodeUpdated), "updated", t)
+}
+
+// DeleteTagTranslation godoc
+// @Summary      Delete a tag translation
+// @Tags         Tags
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path      int     true  "Tag ID"
+// @Param        locale  path      string  true  "Locale (BCP 47)"
+// @Success      200 {object}  response.Envelope
+// @Failure      400 {object}  response.Envelope
+// @Failure      401 {object}  response.Envelope
+// @Failure      404 {object}  response.Envelope
+// @Failure      500 {object}  response.Envelope
+// @Router       /api/v1/tags/{id}/translations/{locale} [delete]
+func (h *TagHandler) DeleteTranslation(c *gin.Context) {
+	id, err := h.ParseUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodeTags, response.CaseCodeInvalidValue), "invalid id", "id must be uint")
+		return
+	}
+	if err := h.tags.DeleteTranslation(c.Request.Context(), id, c.Param("locale")); err != nil {
+		h.translationError(c, err, "delete translation failed")
+		return
+	}
+	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodeTags, response.CaseCodeDeleted), "deleted", gin.H{"id": id, "locale": c.Param("locale")})
+}
+
+func (h *TagHandler) translationError(c *gin.Context, err error, msg string) {
+	switch err {
+	case service.ErrInvalidLocale, service.ErrInvalidTagReq, service.ErrInvalidTagID:
+		response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodeTags, response.CaseCodeInvalidValue), "invalid request", err.Error())
+	case service.ErrTagNotFound:
+		response.NotFound(c, response.BuildResponseCode(http.StatusNotFound, response.ServiceCodeTags, response.CaseCodeNotFound), "not found", "tag not found")
+	case service.ErrTranslationNotFound:
+		response.NotFound(c, response.BuildResponseCode(http.StatusNotFound, response.ServiceCodeTags, response.CaseCodeNotFound), "not found", err.Error())
+	default:
+		h.internalError(c, response.ServiceCodeTags, err, msg)
+	}
+}
@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/turahe/go-restfull/internal/handler/request"
+	"github.com/turahe/go-restfull/internal/middleware"
+	"github.com/turahe/go-restfull/internal/repository"
+	"github.com/turahe/go-restfull/internal/service"
+	"github.com/turahe/go-restfull/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type trashService interface {
+	List(ctx context.Context, req request.TrashListRequest) (repository.CursorPage, error)
+	Restore(ctx context.Context, typ string, id uint, actorUserID uint) error
+	Purge(ctx context.Context, typ string, id uint) error
+}
+
+type TrashHandler struct {
+	BaseHandler
+	trash trashService
+}
+
+func NewTrashHandler(trash trashService, log *zap.Logger) *TrashHandler {
+	return &TrashHandler{BaseHandler: BaseHandler{Log: log}, trash: trash}
+}
+
+func (h *TrashHandler) writeError(c *gin.Context, err error, message string) {
+	switch err {
+	case service.ErrTrashItemNotFound:
+		response.NotFound(c, response.BuildResponseCode(http.StatusNotFound, response.ServiceCodeTrash, response.CaseCodeNotFound), "not found", err.Error())
+	case service.ErrInvalidTrashType:
+		response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodeTrash, response.CaseCodeInvalidValue), "invalid request", err.Error())
+	case service.ErrTrashParentDeleted, service.ErrTrashInUse:
+		response.Conflict(c, response.BuildResponseCode(http.StatusConflict, response.ServiceCodeTrash, response.CaseCodeConflict), "conflict", err.Error())
+	case service.ErrTrashConflict:
+		response.Conflict(c, response.BuildResponseCode(http.StatusConflict, response.ServiceCodeTrash, response.CaseCodeDuplicateEntry), "conflict", err.Error())
+	default:
+		h.internalError(c, response.ServiceCodeTrash, err, message)
+	}
+}
+
+// params reads the trash type and item id from the path.
+func (h *TrashHandler) params(c *gin.Context) (typ string, id uint, ok bool) {
+	id, err := h.ParseUintParam(c, "id")
+	if err != nil {
+		response.BadRequest(c, response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodeTrash, response.CaseCodeInvalidValue), "invalid id", "id must be uint")
+		return "", 0, false
+	}
+	return c.Param("type"), id, true
+}
+
+// ListTrash godoc
+// @Summary      List deleted items
+// @Description  One entry per delete: a category, comment or media subtree deleted together is one entry, with items counting its rows. purgeAt is when the auto-purge removes the entry.
+// @Tags         Trash
+// @Produce      json
+// @Security     BearerAuth
+// @Param        type       query     string  true   "Item type"  Enums(posts,categories,comments,tags,media)
+// @Param        limit      query     int     false  "Page size (max 100)"
+// @Param        sort       query     string  false  "Sort order (first is the default)"  Enums(recent,oldest)
+// @Param        after      query     string  false  "Page after this cursor (a nextCursor)"
+// @Param        before     query     string  false  "Page before this cursor (a prevCursor)"
+// @Param        withTotal  query     bool    false  "Also return the total count"
+// @Success      200        {object}  response.Envelope
+// @Failure      400        {object}  response.Envelope
+// @Failure      401        {object}  response.Envelope
+// @Failure      403        {object}  response.Envelope
+// @Failure      500        {object}  response.Envelope
+// @Router       /api/v1/trash [get]
+func (h *TrashHandler) List(c *gin.Context) {
+	var req request.TrashListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.BadRequest(c,
+			response.BuildResponseCode(http.StatusBadRequest, response.ServiceCodeTrash, response.CaseCodeInvalidFormat),
+			"invalid request",
+			err.Error(),
+		)
+		return
+	}
+	if !h.validate(c, response.ServiceCodeTrash, req) {
+		return
+	}
+
+	page, err := h.trash.List(c.Request.Context(), req)
+	if err != nil {
+		h.listError(c, response.ServiceCodeTrash, err)
+		return
+	}
+	response.OKCursorPaginated(
+		c,
+		response.BuildResponseCode(http.StatusOK, response.ServiceCodeTrash, response.CaseCodeListRetrieved),
+		"ok",
+		page.Items,
+		page.NextCursor,
+		page.PrevCursor,
+		page.Total,
+	)
+}
+
+// RestoreTrash godoc
+// @Summary      Restore a deleted item
+// @Description  Categories, comments and media come back with the whole subtree deleted with them, as the last child of their parent. 409 when the parent (or a post's category, or a comment's post) is itself deleted.
+// @Tags         Trash
+// @Produce      json
+// @Security     BearerAuth
+// @Param        type  path      string  true  "Item type"  Enums(posts,categories,comments,tags,media)
+// @Param        id    path      int     true  "Item ID"
+// @Success      200   {object}  response.Envelope
+// @Failure      400   {object}  response.Envelope
+// @Failure      401   {object}  response.Envelope
+// @Failure      403   {object}  response.Envelope
+// @Failure      404   {object}  response.Envelope
+// @Failure      409   {object}  response.Envelope
+// @Failure      500   {object}  response.Envelope
+// @Router       /api/v1/trash/{type}/{id}/restore [post]
+func (h *TrashHandler) Restore(c *gin.Context) {
+	auth, ok := middleware.GetAuth(c)
+	if !ok {
+		response.Unauthorized(c, response.BuildResponseCode(http.StatusUnauthorized, response.ServiceCodeTrash, response.CaseCodeUnauthorized), "unauthorized", "missing auth")
+		return
+	}
+	typ, id, ok := h.params(c)
+	if !ok {
+		return
+	}
+	if err := h.trash.Restore(c.Request.Context(), typ, id, auth.UserID); err != nil {
+		h.writeError(c, err, "restore failed")
+		return
+	}
+	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodeTrash, response.CaseCodeUpdated), "restored", nil)
+}
+
+// PurgeTrash godoc
+// @Summary      Permanently delete a deleted item
+// @Description  Removes the entry with everything hanging off it (a post's comments, SEO, stats and history; a subtree's descendants) and the stored files of media. 409 for categories still used by posts, deleted ones included.
+// @Tags         Trash
+// @Produce      json
+// @Security     BearerAuth
+// @Param        type  path      string  true  "Item type"  Enums(posts,categories,comments,tags,media)
+// @Param        id    path      int     true  "Item ID"
+// @Success      200   {object}  response.Envelope
+// @Failure      400   {object}  response.Envelope
+// @Failure      401   {object}  response.Envelope
+// @Failure      403   {object}  response.Envelope
+// @Failure      404   {object}  response.Envelope
+// @Failure      409   {object}  response.Envelope
+// @Failure      500   {object}  response.Envelope
+// @Router       /api/v1/trash/{type}/{id} [delete]
+func (h *TrashHandler) Purge(c *gin.Context) {
+	typ, id, ok := h.params(c)
+	if !ok {
+		return
+	}
+	if err := h.trash.Purge(c.Request.Context(), typ, id); err != nil {
+		h.writeError(c, err, "purge failed")
+		return
+	}
+	response.OK(c, response.BuildResponseCode(http.StatusOK, response.ServiceCodeTrash, response.CaseCodeDeleted), "deleted", nil)
+}
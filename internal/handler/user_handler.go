@@ -36,7 +36,11 @@ func NewUserHandler(users UserService, log *zap.Logger) *UserHandler {
 // @Tags         Users
 // @Produce      json
 // @Security     BearerAuth
-// @Param        limit  query     int  false  "Max items (max 500)"
+// @Param        limit      query     int     false  "Page size (max 200)"
+// @Param        sort       query     string  false  "Sort order (first is the default)"  Enums(oldest,newest,name)
+// @Param        after      query     string  false  "Page after this cursor (a nextCursor)"
+// @Param        before     query     string  false  "Page before this cursor (a prevCursor)"
+// @Param        withTotal  query     bool    false  "Also return the total count"
 // @Success      200    {object}  response.Envelope
 // @Failure      401    {object}  response.Envelope
 // @Failure      403    {object}  response.Envelope
@@ -64,17 +68,16 @@ func (h *UserHandler) List(c *gin.Context) {
 
 	page, err := h.users.List(c.Request.Context(), req)
 	if err != nil {
-		h.internalError(c, response.ServiceCodeUsers, err, "list failed")
+		h.listError(c, response.ServiceCodeUsers, err)
 		return
 	}
-	next := page.NextCursor != nil
-	prev := page.PrevCursor != nil
-	response.OKPaginated(c,
+	response.OKCursorPaginated(c,
 		response.BuildResponseCode(http.StatusOK, response.ServiceCodeUsers, response.CaseCodeListRetrieved),
 		"Successfully retrieved users",
 		page.Items,
-		next,
-		prev,
+		page.NextCursor,
+		page.PrevCursor,
+		page.Total,
 	)
 }
 
@@ -5,6 +5,7 @@ import (
 
 	"github.com/turahe/go-restfull/internal/repository"
 	"github.com/turahe/go-restfull/internal/service"
+	"github.com/turahe/go-restfull/internal/tenant"
 	"github.com/turahe/go-restfull/pkg/response"
 
 	"github.com/gin-gonic/gin"
@@ -18,6 +19,7 @@ type AuthClaims struct {
 	SessionID   string
 	DeviceID    string
 	JTI         string
+	SiteID      uint
 
 	Impersonation       bool
 	ImpersonatorID      *uint
@@ -27,6 +29,11 @@ type AuthClaims struct {
 
 const ctxAuthKey = "auth_claims"
 
+// authFailure is why a bearer token was rejected: the response code's case and the message/detail pair.
+type authFailure struct {
+	caseCode, message, detail string
+}
+
 func JWTAuth(jwtSvc *service.JWTService, authRepo *repository.AuthRepository, log *zap.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		tokenStr, ok := bearerToken(c.GetHeader("Authorization"))
@@ -36,56 +43,78 @@ func JWTAuth(jwtSvc *service.JWTService, authRepo *repository.AuthRepository, lo
 			return
 		}
 
-		claims, err := jwtSvc.ParseAndValidateAccess(tokenStr)
-		if err != nil {
-			log.Warn("jwt invalid", zap.Error(err))
-			response.Unauthorized(c, response.BuildResponseCode(401, response.ServiceCodeAuth, response.CaseCodeInvalidToken), "invalid token", "invalid token")
+		ac, fail := authenticate(c, tokenStr, jwtSvc, authRepo, log)
+		if fail != nil {
+			response.Unauthorized(c, response.BuildResponseCode(401, response.ServiceCodeAuth, fail.caseCode), fail.message, fail.detail)
 			c.Abort()
 			return
 		}
 
-		revoked, err := authRepo.IsJTIRevoked(c.Request.Context(), claims.ID)
-		if err != nil {
-			log.Warn("jti check failed", zap.Error(err))
-			response.Unauthorized(c, response.BuildResponseCode(401, response.ServiceCodeAuth, response.CaseCodeInvalidToken), "invalid token", "invalid token")
-			c.Abort()
-			return
-		}
-		if revoked {
-			response.Unauthorized(c, response.BuildResponseCode(401, response.ServiceCodeAuth, response.CaseCodeInvalidToken), "invalid token", "revoked token")
-			c.Abort()
-			return
-		}
+		c.Set(ctxAuthKey, ac)
+		c.Next()
+	}
+}
 
-		active, err := authRepo.SessionActive(c.Request.Context(), claims.SessionID)
-		if err != nil {
-			log.Warn("session check failed", zap.Error(err))
-			response.Unauthorized(c, response.BuildResponseCode(401, response.ServiceCodeAuth, response.CaseCodeInvalidToken), "invalid token", "invalid token")
-			c.Abort()
-			return
-		}
-		if !active {
-			response.Unauthorized(c, response.BuildResponseCode(401, response.ServiceCodeAuth, response.CaseCodeInvalidToken), "invalid token", "session revoked")
-			c.Abort()
-			return
+// OptionalJWTAuth identifies the caller on public routes: a valid bearer token sets the auth claims
+// like JWTAuth, while a missing or invalid one leaves the request anonymous instead of failing it.
+func OptionalJWTAuth(jwtSvc *service.JWTService, authRepo *repository.AuthRepository, log *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if tokenStr, ok := bearerToken(c.GetHeader("Authorization")); ok {
+			if ac, fail := authenticate(c, tokenStr, jwtSvc, authRepo, log); fail == nil {
+				c.Set(ctxAuthKey, ac)
+			}
 		}
+		c.Next()
+	}
+}
 
-		ac := AuthClaims{
-			UserID:      claims.UserID,
-			Role:        claims.Role,
-			Permissions: claims.Permissions,
-			SessionID:   claims.SessionID,
-			DeviceID:    claims.DeviceID,
-			JTI:         claims.ID,
-			Impersonation: claims.Impersonation,
-			ImpersonatorID: claims.ImpersonatorID,
-			ImpersonatedUserID: claims.ImpersonatedUserID,
-			ImpersonationReason: claims.ImpersonationReason,
-		}
+func authenticate(c *gin.Context, tokenStr string, jwtSvc *service.JWTService, authRepo *repository.AuthRepository, log *zap.Logger) (AuthClaims, *authFailure) {
+	claims, err := jwtSvc.ParseAndValidateAccess(tokenStr)
+	if err != nil {
+		log.Warn("jwt invalid", zap.Error(err))
+		return AuthClaims{}, &authFailure{response.CaseCodeInvalidToken, "invalid token", "invalid token"}
+	}
 
-		c.Set(ctxAuthKey, ac)
-		c.Next()
+	revoked, err := authRepo.IsJTIRevoked(c.Request.Context(), claims.ID)
+	if err != nil {
+		log.Warn("jti check failed", zap.Error(err))
+		return AuthClaims{}, &authFailure{response.CaseCodeInvalidToken, "invalid token", "invalid token"}
 	}
+	if revoked {
+		return AuthClaims{}, &authFailure{response.CaseCodeInvalidToken, "invalid token", "revoked token"}
+	}
+
+	// Roles in the token belong to the site it was issued on; tokens minted before sites belong to the default site.
+	siteID := claims.SiteID
+	if siteID == 0 {
+		siteID = tenant.DefaultSiteID
+	}
+	if siteID != tenant.SiteID(c.Request.Context()) {
+		return AuthClaims{}, &authFailure{response.CaseCodeInvalidToken, "invalid token", "token issued for another site"}
+	}
+
+	active, err := authRepo.SessionActive(c.Request.Context(), claims.SessionID)
+	if err != nil {
+		log.Warn("session check failed", zap.Error(err))
+		return AuthClaims{}, &authFailure{response.CaseCodeInvalidToken, "invalid token", "invalid token"}
+	}
+	if !active {
+		return AuthClaims{}, &authFailure{response.CaseCodeInvalidToken, "invalid token", "session revoked"}
+	}
+
+	return AuthClaims{
+		UserID:              claims.UserID,
+		Role:                claims.Role,
+		Permissions:         claims.Permissions,
+		SessionID:           claims.SessionID,
+		DeviceID:            claims.DeviceID,
+		JTI:                 claims.ID,
+		SiteID:              siteID,
+		Impersonation:       claims.Impersonation,
+		ImpersonatorID:      claims.ImpersonatorID,
+		ImpersonatedUserID:  claims.ImpersonatedUserID,
+		ImpersonationReason: claims.ImpersonationReason,
+	}, nil
 }
 
 func GetAuth(c *gin.Context) (AuthClaims, bool) {
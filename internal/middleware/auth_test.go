@@ -142,6 +142,28 @@ func TestJWTAuth(t *testing.T) {
 	})
 }
 
+func TestOptionalJWTAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	jwtSvc, authRepo := stubAuthDeps(t)
+	r := gin.New()
+	r.Use(OptionalJWTAuth(jwtSvc, authRepo, zap.NewNop()))
+	r.GET("/", func(c *gin.Context) {
+		_, ok := GetAuth(c)
+		assert.False(t, ok)
+		c.String(200, "ok")
+	})
+
+	for _, header := range []string{"", "Bearer invalid.jwt.token"} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code, "anonymous with header %q", header)
+	}
+}
+
 func stubAuthDeps(t *testing.T) (*service.JWTService, *repository.AuthRepository) {
 	t.Helper()
 	priv, err := rsa.GenerateKey(rand.Reader, 2048)
@@ -10,6 +10,10 @@ import (
 	"go.uber.org/zap"
 )
 
+// RBACDecisionHeader carries the decision id of a traced 403 (only set when the RBAC decision log is enabled).
+// Admins can look the trace up via GET /api/v1/rbac/decisions/:id.
+const RBACDecisionHeader = "X-RBAC-Decision-Id"
+
 // RBAC enforces Casbin permissions based on (sub=userID, obj=route template, act=http method).
 func RBAC(rbacSvc *service.RBACService, log *zap.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -36,6 +40,13 @@ func RBAC(rbacSvc *service.RBACService, log *zap.Logger) gin.HandlerFunc {
 			return
 		}
 		if !allowed {
+			if id, err := rbacSvc.TraceDenied(c.Request.Context(), auth.UserID, obj, act); err != nil {
+				if log != nil {
+					log.Warn("rbac decision trace failed", zap.Error(err))
+				}
+			} else if id != "" {
+				c.Header(RBACDecisionHeader, id)
+			}
 			response.Forbidden(c, response.BuildResponseCode(http.StatusForbidden, response.ServiceCodeAuth, response.CaseCodePermissionDenied), "forbidden", "insufficient permissions")
 			c.Abort()
 			return
@@ -1,10 +1,12 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/turahe/go-restfull/internal/model"
 	"github.com/turahe/go-restfull/internal/rbac"
 	"github.com/turahe/go-restfull/internal/service"
 	"github.com/turahe/go-restfull/internal/testutil"
@@ -25,6 +27,8 @@ func TestRBAC(t *testing.T) {
 		Logger: logger.Default.LogMode(testutil.GormLogLevelFromEnv()),
 	})
 	require.NoError(t, err)
+	// The enforcer loads its policy from the RBAC tables.
+	require.NoError(t, db.AutoMigrate(&model.Role{}, &model.Permission{}, &model.UserRole{}, &model.RolePermission{}, &model.RoleInheritance{}))
 	e, err := rbac.NewEnforcer(db, "../../configs/casbin_model.conf")
 	require.NoError(t, err)
 	rbacSvc := service.NewRBACService(e, db, log)
@@ -52,7 +56,72 @@ func TestRBAC(t *testing.T) {
 		req := httptest.NewRequest(http.MethodGet, "/api/posts", nil)
 		w := httptest.NewRecorder()
 		r.ServeHTTP(w, req)
-		// With auth set, we get past 401. Enforce may return 500 (no roles table) or 403.
+		// With auth set, we get past 401; the user holds no roles, so 403.
 		require.NotEqual(t, http.StatusUnauthorized, w.Code)
 	})
 }
+
+func TestRBAC_DecisionTrace(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	log := zap.NewNop()
+
+	db, err := gorm.Open(sqlite.Open("file:rbac_mw_trace_test?mode=memory&cache=private"), &gorm.Config{
+		Logger: logger.Default.LogMode(testutil.GormLogLevelFromEnv()),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&model.Role{}, &model.Permission{}, &model.UserRole{}, &model.RolePermission{}, &model.RoleInheritance{}))
+	e, err := rbac.NewEnforcer(db, "../../configs/casbin_model.conf")
+	require.NoError(t, err)
+	rbacSvc := service.NewRBACService(e, db, log)
+	ctx := context.Background()
+	_, err = rbacSvc.AddPermissionToRole(ctx, "user", "/api/posts", "GET")
+	require.NoError(t, err)
+	_, err = rbacSvc.AssignRole(ctx, 1, "user")
+	require.NoError(t, err)
+
+	newRouter := func() *gin.Engine {
+		r := gin.New()
+		r.Use(func(c *gin.Context) {
+			c.Set(ctxAuthKey, AuthClaims{UserID: 1, Role: "user"})
+			c.Next()
+		})
+		r.Use(RBAC(rbacSvc, log))
+		r.GET("/api/posts", func(c *gin.Context) { c.String(200, "ok") })
+		r.POST("/api/posts", func(c *gin.Context) { c.String(200, "ok") })
+		return r
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		newRouter().ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/posts", nil))
+		require.Equal(t, http.StatusForbidden, w.Code)
+		require.Empty(t, w.Header().Get(RBACDecisionHeader))
+	})
+
+	rbacSvc.EnableDecisionLog(10)
+
+	t.Run("allowed request has no decision header", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		newRouter().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/posts", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Empty(t, w.Header().Get(RBACDecisionHeader))
+	})
+
+	t.Run("denied request carries a resolvable decision id", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		newRouter().ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/posts", nil))
+		require.Equal(t, http.StatusForbidden, w.Code)
+		id := w.Header().Get(RBACDecisionHeader)
+		require.NotEmpty(t, id)
+
+		d, ok := rbacSvc.Decision(id)
+		require.True(t, ok)
+		require.False(t, d.Allowed)
+		require.Equal(t, "db", d.Source)
+		require.Equal(t, []string{"user"}, d.Roles)
+		require.Len(t, d.Checks, 1)
+		require.True(t, d.Checks[0].ObjMatched)
+		require.False(t, d.Checks[0].ActMatched)
+		require.Equal(t, "method does not match", d.Checks[0].Reason)
+	})
+}
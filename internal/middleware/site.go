@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/internal/service"
+	"github.com/turahe/go-restfull/internal/tenant"
+	"github.com/turahe/go-restfull/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	SiteHeader = "X-Site"
+	ctxSiteKey = "site"
+)
+
+// SiteResolver maps a request's X-Site key or Host to a site.
+type SiteResolver interface {
+	Resolve(ctx context.Context, key, host string) (*model.Site, error)
+}
+
+// Site resolves the tenant for the request and scopes the request context to it, so every
+// repository call made with c.Request.Context() only sees that site's rows.
+func Site(sites SiteResolver, log *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		site, err := sites.Resolve(c.Request.Context(), c.GetHeader(SiteHeader), c.Request.Host)
+		if err != nil {
+			if errors.Is(err, service.ErrSiteNotFound) {
+				response.NotFound(c, response.BuildResponseCode(http.StatusNotFound, response.ServiceCodeSites, response.CaseCodeNotFound), "not found", "unknown site")
+			} else {
+				log.Error("site resolve failed", zap.Error(err))
+				response.InternalServerError(c, response.BuildResponseCode(http.StatusInternalServerError, response.ServiceCodeSites, response.CaseCodeInternalError), "internal error", "site resolve failed")
+			}
+			c.Abort()
+			return
+		}
+		c.Set(ctxSiteKey, site)
+		c.Request = c.Request.WithContext(tenant.WithSiteID(c.Request.Context(), site.ID))
+		c.Next()
+	}
+}
+
+// GetSite returns the site resolved by the Site middleware.
+func GetSite(c *gin.Context) (*model.Site, bool) {
+	v, ok := c.Get(ctxSiteKey)
+	if !ok {
+		return nil, false
+	}
+	s, ok := v.(*model.Site)
+	return s, ok
+}
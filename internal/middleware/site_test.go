@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/internal/service"
+	"github.com/turahe/go-restfull/internal/tenant"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type fakeSiteResolver map[string]*model.Site
+
+func (f fakeSiteResolver) Resolve(_ context.Context, key, host string) (*model.Site, error) {
+	if key != "" {
+		if s, ok := f[key]; ok {
+			return s, nil
+		}
+		return nil, service.ErrSiteNotFound
+	}
+	if s, ok := f[host]; ok {
+		return s, nil
+	}
+	return f["default"], nil
+}
+
+func TestSite(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	sites := fakeSiteResolver{
+		"default":          {ID: 1, Key: "default"},
+		"docs":             {ID: 2, Key: "docs"},
+		"blog.example.com": {ID: 3, Key: "blog"},
+	}
+	r := gin.New()
+	r.Use(Site(sites, zap.NewNop()))
+	r.GET("/", func(c *gin.Context) {
+		s, ok := GetSite(c)
+		assert.True(t, ok)
+		assert.Equal(t, s.ID, tenant.SiteID(c.Request.Context()))
+		c.String(http.StatusOK, strconv.FormatUint(uint64(s.ID), 10))
+	})
+
+	cases := []struct {
+		name, header, host string
+		code               int
+		body               string
+	}{
+		{"falls back to default site", "", "example.com", http.StatusOK, "1"},
+		{"X-Site header", "docs", "blog.example.com", http.StatusOK, "2"},
+		{"host", "", "blog.example.com", http.StatusOK, "3"},
+		{"unknown key", "nope", "", http.StatusNotFound, ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Host = tc.host
+			if tc.header != "" {
+				req.Header.Set(SiteHeader, tc.header)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			assert.Equal(t, tc.code, w.Code)
+			if tc.body != "" {
+				assert.Equal(t, tc.body, w.Body.String())
+			}
+		})
+	}
+}
@@ -6,13 +6,14 @@ import (
 	"gorm.io/gorm"
 )
 
-// CategoryModel maps to table categories (nested set: lft, rgt, depth).
-// Unique (parent_id, name) prevents duplicate sibling names; roots (parent_id NULL) also checked in application code where MySQL allows duplicate (NULL, name).
+// CategoryModel maps to table categories (nested set: lft, rgt, depth; one forest per site).
+// Unique (site_id, parent_id, name) prevents duplicate sibling names; roots (parent_id NULL) also checked in application code where MySQL allows duplicate (NULL, name).
 type CategoryModel struct {
 	ID       uint   `json:"id" gorm:"primaryKey;autoIncrement"`
-	Name     string `json:"name" gorm:"type:varchar(255);not null;uniqueIndex:idx_categories_parent_name"`
-	Slug     string `json:"slug" gorm:"type:varchar(255);not null;uniqueIndex"`
-	ParentID *uint  `json:"parentId,omitempty" gorm:"column:parent_id;index;uniqueIndex:idx_categories_parent_name"`
+	SiteID   uint   `json:"siteId" gorm:"not null;default:1;uniqueIndex:idx_categories_site_slug,priority:1;uniqueIndex:idx_categories_site_parent_name,priority:1"`
+	Name     string `json:"name" gorm:"type:varchar(255);not null;uniqueIndex:idx_categories_site_parent_name,priority:3"`
+	Slug     string `json:"slug" gorm:"type:varchar(255);not null;uniqueIndex:idx_categories_site_slug,priority:2"`
+	ParentID *uint  `json:"parentId,omitempty" gorm:"column:parent_id;index;uniqueIndex:idx_categories_site_parent_name,priority:2"`
 
 	Lft   int `json:"lft" gorm:"column:lft;index;not null"`
 	Rgt   int `json:"rgt" gorm:"column:rgt;index;not null"`
@@ -9,6 +9,7 @@ import (
 // Comment is a threaded comment on a post (nested set scoped by post_id).
 type Comment struct {
 	ID     uint `json:"id" gorm:"primaryKey;autoIncrement"`
+	SiteID uint `json:"siteId" gorm:"not null;default:1;index"`
 	PostID uint `json:"postId" gorm:"not null;index"`
 	// ParentID nil for root comments in this post's tree.
 	ParentID *uint `json:"parentId,omitempty" gorm:"column:parent_id;index"`
@@ -25,6 +26,9 @@ type Comment struct {
 
 	Media []Media `json:"media,omitempty" gorm:"many2many:comment_media;"`
 
+	// Reactions totals the readers' reactions by type.
+	Reactions ReactionCounts `json:"reactions" gorm:"column:reaction_counts;type:varchar(512);not null;default:'{}'"`
+
 	CreatedBy uint  `json:"createdBy" gorm:"not null;index"`
 	UpdatedBy uint  `json:"updatedBy" gorm:"not null;index"`
 	DeletedBy *uint `json:"deletedBy,omitempty" gorm:"index"`
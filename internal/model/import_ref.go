@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+// Kinds of imported items recorded in ImportRef.
+const (
+	ImportKindUser     = "user"
+	ImportKindCategory = "category"
+	ImportKindTag      = "tag"
+	ImportKindPost     = "post"
+	ImportKindComment  = "comment"
+	ImportKindMedia    = "media"
+)
+
+// ImportRef maps an item of an import source (a WordPress export, a Markdown tree) to the row it
+// became, so re-running an import skips what is already there.
+type ImportRef struct {
+	ID         uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	SiteID     uint      `json:"siteId" gorm:"not null;default:1;uniqueIndex:idx_import_refs_item,priority:1"`
+	Source     string    `json:"source" gorm:"type:varchar(32);not null;uniqueIndex:idx_import_refs_item,priority:2"`
+	Kind       string    `json:"kind" gorm:"type:varchar(20);not null;uniqueIndex:idx_import_refs_item,priority:3"`
+	ExternalID string    `json:"externalId" gorm:"type:varchar(255);not null;uniqueIndex:idx_import_refs_item,priority:4"`
+	LocalID    uint      `json:"localId" gorm:"not null"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+func (ImportRef) TableName() string {
+	return "import_refs"
+}
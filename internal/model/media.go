@@ -7,13 +7,14 @@ import (
 )
 
 // Media is user-scoped storage; folders use media_type "folder", files use image/file.
-// Nested set is scoped by user_id (same pattern as categories, per-user forest).
+// Nested set is scoped by (site_id, user_id) (same pattern as categories, per-user forest on each site).
 type Media struct {
 	ID     uint   `json:"id" gorm:"primaryKey;autoIncrement"`
-	UserID uint   `json:"userId" gorm:"not null;uniqueIndex:idx_media_user_parent_name"`
-	Name   string `json:"name" gorm:"type:varchar(255);not null;uniqueIndex:idx_media_user_parent_name"`
+	SiteID uint   `json:"siteId" gorm:"not null;default:1;uniqueIndex:idx_media_site_user_parent_name,priority:1"`
+	UserID uint   `json:"userId" gorm:"not null;uniqueIndex:idx_media_site_user_parent_name,priority:2"`
+	Name   string `json:"name" gorm:"type:varchar(255);not null;uniqueIndex:idx_media_site_user_parent_name,priority:4"`
 	// ParentID nil for roots in this user's tree.
-	ParentID *uint `json:"parentId,omitempty" gorm:"column:parent_id;uniqueIndex:idx_media_user_parent_name"`
+	ParentID *uint `json:"parentId,omitempty" gorm:"column:parent_id;uniqueIndex:idx_media_site_user_parent_name,priority:3"`
 
 	Lft   int `json:"lft" gorm:"column:lft;index;not null"`
 	Rgt   int `json:"rgt" gorm:"column:rgt;index;not null"`
@@ -31,39 +31,115 @@ const (
 	PostStatusDraft     PostStatus = "draft"
 	PostStatusPublished PostStatus = "published"
 	PostStatusArchived  PostStatus = "archived"
+	// PostStatusScheduled posts become published when PublishAt passes (see PostService.RunScheduler).
+	PostStatusScheduled PostStatus = "scheduled"
+	// Review states of the editorial workflow (see PostService.Transition); posts in them are not live.
+	PostStatusInReview         PostStatus = "in_review"
+	PostStatusChangesRequested PostStatus = "changes_requested"
+	PostStatusApproved         PostStatus = "approved"
 )
 
 func (s PostStatus) IsValid() bool {
 	switch s {
-	case PostStatusDraft, PostStatusPublished, PostStatusArchived:
+	case PostStatusDraft, PostStatusPublished, PostStatusArchived, PostStatusScheduled,
+		PostStatusInReview, PostStatusChangesRequested, PostStatusApproved:
 		return true
 	default:
 		return false
 	}
 }
 
+// ContentFormat is how Post.Content is written; it is rendered to sanitized HTML on save.
+type ContentFormat string
+
+const (
+	ContentFormatMarkdown ContentFormat = "markdown"
+	ContentFormatHTML     ContentFormat = "html"
+	ContentFormatPlain    ContentFormat = "plain"
+)
+
+func (f ContentFormat) IsValid() bool {
+	switch f {
+	case ContentFormatMarkdown, ContentFormatHTML, ContentFormatPlain:
+		return true
+	default:
+		return false
+	}
+}
+
+// PostHeading is a table-of-contents entry; ID is the anchor on the rendered heading.
+type PostHeading struct {
+	Level int    `json:"level"`
+	ID    string `json:"id"`
+	Text  string `json:"text"`
+}
+
+// PostAlternate is one locale variant of a post. Default marks the site's default locale (x-default).
+type PostAlternate struct {
+	Locale  string `json:"locale"`
+	Slug    string `json:"slug"`
+	Default bool   `json:"default,omitempty"`
+}
+
 type Post struct {
-	ID    uint   `json:"id" gorm:"primaryKey;autoIncrement"`
-	Title string `json:"title" gorm:"type:varchar(200);not null"`
-	// Unique index on slug also serves as the required index for lookups.
-	Slug    string `json:"slug" gorm:"type:varchar(220);not null;uniqueIndex"`
+	ID     uint   `json:"id" gorm:"primaryKey;autoIncrement"`
+	SiteID uint   `json:"siteId" gorm:"not null;default:1;uniqueIndex:idx_posts_site_slug,priority:1"`
+	Title  string `json:"title" gorm:"type:varchar(200);not null"`
+	// Unique (site_id, slug) also serves as the required index for lookups.
+	Slug    string `json:"slug" gorm:"type:varchar(220);not null;uniqueIndex:idx_posts_site_slug,priority:2"`
 	Content string `json:"content" gorm:"type:longtext;not null"`
 
+	// ContentHTML through ReadingTimeMinutes are rendered from Content (in ContentFormat) on save;
+	// RenderVersion records the rendering rules used, so older renders are refreshed when read.
+	ContentFormat      ContentFormat `json:"contentFormat" gorm:"type:varchar(16);not null;default:markdown"`
+	ContentHTML        string        `json:"contentHtml" gorm:"type:longtext"`
+	TOC                []PostHeading `json:"toc" gorm:"serializer:json;type:text"`
+	WordCount          int           `json:"wordCount" gorm:"not null;default:0"`
+	ReadingTimeMinutes int           `json:"readingTimeMinutes" gorm:"not null;default:0"`
+	RenderVersion      int           `json:"-" gorm:"not null;default:0"`
+
+	// Locale is the post's language (BCP 47). Variants of one piece in other locales share a
+	// TranslationGroupID (the id of the first post in the group); a group has one post per locale.
+	// Alternates is filled on reads and lists the live variants, for hreflang links.
+	Locale             string          `json:"locale" gorm:"type:varchar(35);not null;default:'';index;uniqueIndex:idx_posts_translation_locale,priority:2"`
+	TranslationGroupID *uint           `json:"translationGroupId,omitempty" gorm:"uniqueIndex:idx_posts_translation_locale,priority:1"`
+	Alternates         []PostAlternate `json:"alternates,omitempty" gorm:"-"`
+
 	// SEO / sharing lives in post_seo (optional; see PostSEO).
 	PostSEO *PostSEO `json:"seo,omitempty" gorm:"foreignKey:PostID;constraint:OnDelete:CASCADE"`
 
-	UserID uint  `json:"userId" gorm:"not null;index"`
-	User   *User `json:"author,omitempty" gorm:"constraint:OnDelete:CASCADE"`
+	// UserID is the primary author: the first listed author in Contributors. Payloads credit people
+	// through Contributors.
+	UserID       uint              `json:"userId" gorm:"not null;index"`
+	User         *User             `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	Contributors []PostContributor `json:"contributors,omitempty" gorm:"foreignKey:PostID;constraint:OnDelete:CASCADE"`
 
 	CategoryID uint       `json:"categoryId" gorm:"not null;index"`
 	Layout     PostLayout `json:"layout" gorm:"type:varchar(50);not null;check:layout IN ('simple','author','book','list')"`
-	Status     PostStatus `json:"status" gorm:"type:varchar(20);not null;default:published;index;check:status IN ('draft','published','archived')"`
+	Status     PostStatus `json:"status" gorm:"type:varchar(20);not null;default:published;index;check:chk_posts_status_v3,status IN ('draft','published','archived','scheduled','in_review','changes_requested','approved')"`
 	Category   *CategoryModel `json:"category,omitempty" gorm:"constraint:OnDelete:RESTRICT"`
 
+	// PublishAt is when a scheduled post goes live; UnpublishAt is when a published post is archived.
+	// Both are optional; a post is publicly visible only while published and inside [PublishAt, UnpublishAt).
+	PublishAt   *time.Time `json:"publishAt,omitempty" gorm:"index"`
+	UnpublishAt *time.Time `json:"unpublishAt,omitempty" gorm:"index"`
+
+	// SeriesID and Chapter place the post in a series (see Series); both are managed by the series
+	// endpoints. SeriesNav is filled on single-post reads only.
+	SeriesID  *uint             `json:"seriesId,omitempty" gorm:"index:idx_posts_series_chapter,priority:1"`
+	Chapter   int               `json:"chapter,omitempty" gorm:"not null;default:0;index:idx_posts_series_chapter,priority:2"`
+	Series    *Series           `json:"series,omitempty" gorm:"constraint:OnDelete:SET NULL"`
+	SeriesNav *SeriesNavigation `json:"seriesNav,omitempty" gorm:"-"`
+
 	Media []Media `json:"media,omitempty" gorm:"many2many:post_media;"`
 
 	Tags []Tag `json:"tags,omitempty" gorm:"many2many:post_tags"`
 
+	// Reactions totals the readers' reactions by type; MyReactions lists the viewer's own and is
+	// filled on lists for signed-in readers.
+	Reactions   ReactionCounts `json:"reactions" gorm:"column:reaction_counts;type:varchar(512);not null;default:'{}'"`
+	MyReactions []string       `json:"myReactions,omitempty" gorm:"-"`
+
 	CreatedBy uint  `json:"createdBy" gorm:"not null;index"`
 	UpdatedBy uint  `json:"updatedBy" gorm:"not null;index"`
 	DeletedBy *uint `json:"deletedBy,omitempty" gorm:"index"`
@@ -86,6 +162,12 @@ func (p *Post) BeforeCreate(tx *gorm.DB) error {
 	if !p.Status.IsValid() {
 		return fmt.Errorf("invalid post status: %q", p.Status)
 	}
+	if p.ContentFormat == "" {
+		p.ContentFormat = ContentFormatMarkdown
+	}
+	if !p.ContentFormat.IsValid() {
+		return fmt.Errorf("invalid post content format: %q", p.ContentFormat)
+	}
 	p.CreatedAt = time.Now()
 	p.UpdatedAt = time.Now()
 	return nil
@@ -98,6 +180,9 @@ func (p *Post) BeforeUpdate(tx *gorm.DB) error {
 	if p.Status != "" && !p.Status.IsValid() {
 		return fmt.Errorf("invalid post status: %q", p.Status)
 	}
+	if p.ContentFormat != "" && !p.ContentFormat.IsValid() {
+		return fmt.Errorf("invalid post content format: %q", p.ContentFormat)
+	}
 	p.UpdatedAt = time.Now()
 	return nil
 }
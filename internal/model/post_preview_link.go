@@ -0,0 +1,29 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PostPreviewLink is a shareable, expiring link to an unpublished post. Only the token's hash is
+// stored; the token itself is shown once, when the link is created.
+type PostPreviewLink struct {
+	ID        uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	SiteID    uint       `json:"siteId" gorm:"not null;default:1;index"`
+	PostID    uint       `json:"postId" gorm:"not null;index"`
+	TokenHash string     `json:"-" gorm:"type:char(64);not null;uniqueIndex"`
+	ExpiresAt time.Time  `json:"expiresAt" gorm:"index"`
+	RevokedAt *time.Time `json:"revokedAt,omitempty"`
+	CreatedBy uint       `json:"createdBy" gorm:"not null"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+func (PostPreviewLink) TableName() string {
+	return TablePostPreviewLinks
+}
+
+func (l *PostPreviewLink) BeforeCreate(tx *gorm.DB) error {
+	l.CreatedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,38 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PostRevision is a snapshot of a post's editable state, written after each create, update and
+// restore. Number counts revisions per post from 1; older rows are pruned past the retention setting.
+type PostRevision struct {
+	ID     uint `json:"id" gorm:"primaryKey;autoIncrement"`
+	SiteID uint `json:"siteId" gorm:"not null;default:1;index"`
+	PostID uint `json:"postId" gorm:"not null;uniqueIndex:idx_post_revisions_post_number,priority:1"`
+	Number uint `json:"number" gorm:"not null;uniqueIndex:idx_post_revisions_post_number,priority:2"`
+
+	Title         string        `json:"title" gorm:"type:varchar(200);not null"`
+	Content       string        `json:"content,omitempty" gorm:"type:longtext;not null"`
+	ContentFormat ContentFormat `json:"contentFormat" gorm:"type:varchar(16);not null;default:markdown"`
+	SEO           *PostSEO      `json:"seo,omitempty" gorm:"serializer:json;type:text"`
+	CategoryID    uint          `json:"categoryId" gorm:"not null"`
+	TagIDs        []uint        `json:"tagIds" gorm:"serializer:json;type:text"`
+	Layout        PostLayout    `json:"layout" gorm:"type:varchar(50);not null"`
+	Status        PostStatus    `json:"status" gorm:"type:varchar(20);not null"`
+
+	// ActorID is the user whose save produced this state.
+	ActorID   uint      `json:"actorId" gorm:"not null;index"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func (PostRevision) TableName() string {
+	return TablePostRevisions
+}
+
+func (r *PostRevision) BeforeCreate(tx *gorm.DB) error {
+	r.CreatedAt = time.Now()
+	return nil
+}
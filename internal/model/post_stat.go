@@ -0,0 +1,14 @@
+package model
+
+// PostStatDaily is how often a post was viewed on one UTC day (YYYY-MM-DD). Rows are written by the
+// view flusher, which adds the buffered counts to them.
+type PostStatDaily struct {
+	PostID uint   `json:"postId" gorm:"primaryKey;autoIncrement:false"`
+	Day    string `json:"day" gorm:"primaryKey;type:char(10);index:idx_post_stats_daily_site_day,priority:2"`
+	SiteID uint   `json:"siteId" gorm:"not null;default:1;index:idx_post_stats_daily_site_day,priority:1"`
+	Views  int64  `json:"views" gorm:"not null;default:0"`
+}
+
+func (PostStatDaily) TableName() string {
+	return "post_stats_daily"
+}
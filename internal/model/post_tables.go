@@ -6,6 +6,16 @@ const (
 	TablePostSEO   = "post_seo"
 	TablePostMedia = "post_media"
 	TablePostTags  = "post_tags"
+
+	TablePostContributors = "post_contributors"
+
+	TablePostRevisions    = "post_revisions"
+	TablePostPreviewLinks = "post_preview_links"
+	TableSeries           = "series"
+
+	TablePostReviewers      = "post_reviewers"
+	TablePostReviewComments = "post_review_comments"
+	TablePostTransitions    = "post_transitions"
 )
 
 func (Post) TableName() string {
@@ -49,3 +59,36 @@ type PostTag struct {
 func (PostTag) TableName() string {
 	return TablePostTags
 }
+
+// ContributorRole is what a contributor did on a post. Authors own the post; editors and illustrators
+// are credited only.
+type ContributorRole string
+
+const (
+	ContributorAuthor      ContributorRole = "author"
+	ContributorEditor      ContributorRole = "editor"
+	ContributorIllustrator ContributorRole = "illustrator"
+)
+
+func (r ContributorRole) IsValid() bool {
+	switch r {
+	case ContributorAuthor, ContributorEditor, ContributorIllustrator:
+		return true
+	default:
+		return false
+	}
+}
+
+// PostContributor credits a user on a post, in display order (Position, ascending). A user appears
+// at most once per post.
+type PostContributor struct {
+	PostID   uint            `json:"-" gorm:"primaryKey"`
+	UserID   uint            `json:"userId" gorm:"primaryKey;index"`
+	Role     ContributorRole `json:"role" gorm:"type:varchar(20);not null;check:chk_post_contributors_role,role IN ('author','editor','illustrator')"`
+	Position int             `json:"position" gorm:"not null;default:0"`
+	User     *User           `json:"user,omitempty" gorm:"constraint:OnDelete:CASCADE"`
+}
+
+func (PostContributor) TableName() string {
+	return TablePostContributors
+}
@@ -0,0 +1,71 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PostReviewer assigns a user to review a post. Assigned reviewers run the workflow's reviewer
+// transitions (approve, request changes) and may comment on the post's revisions.
+type PostReviewer struct {
+	PostID     uint      `json:"-" gorm:"primaryKey"`
+	UserID     uint      `json:"userId" gorm:"primaryKey;index"`
+	AssignedBy uint      `json:"assignedBy" gorm:"not null"`
+	CreatedAt  time.Time `json:"assignedAt"`
+	User       *User     `json:"user,omitempty" gorm:"constraint:OnDelete:CASCADE"`
+}
+
+func (PostReviewer) TableName() string {
+	return TablePostReviewers
+}
+
+func (r *PostReviewer) BeforeCreate(tx *gorm.DB) error {
+	r.CreatedAt = time.Now()
+	return nil
+}
+
+// PostReviewComment is a review note on one revision of a post, so feedback stays tied to the text it
+// was written against.
+type PostReviewComment struct {
+	ID         uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	SiteID     uint      `json:"siteId" gorm:"not null;default:1;index"`
+	PostID     uint      `json:"postId" gorm:"not null;index"`
+	RevisionID uint      `json:"revisionId" gorm:"not null;index"`
+	UserID     uint      `json:"userId" gorm:"not null;index"`
+	Body       string    `json:"body" gorm:"type:text;not null"`
+	CreatedAt  time.Time `json:"createdAt"`
+	User       *User     `json:"user,omitempty" gorm:"constraint:OnDelete:CASCADE"`
+}
+
+func (PostReviewComment) TableName() string {
+	return TablePostReviewComments
+}
+
+func (c *PostReviewComment) BeforeCreate(tx *gorm.DB) error {
+	c.CreatedAt = time.Now()
+	return nil
+}
+
+// PostTransition records one status change made through the editorial workflow: the transition
+// that ran (Name), the statuses it moved between, who ran it and their note.
+type PostTransition struct {
+	ID         uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	SiteID     uint       `json:"siteId" gorm:"not null;default:1;index"`
+	PostID     uint       `json:"postId" gorm:"not null;index"`
+	Name       string     `json:"name" gorm:"type:varchar(50);not null"`
+	FromStatus PostStatus `json:"fromStatus" gorm:"type:varchar(20);not null"`
+	ToStatus   PostStatus `json:"toStatus" gorm:"type:varchar(20);not null"`
+	ActorID    uint       `json:"actorId" gorm:"not null;index"`
+	Note       string     `json:"note,omitempty" gorm:"type:text"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+func (PostTransition) TableName() string {
+	return TablePostTransitions
+}
+
+func (t *PostTransition) BeforeCreate(tx *gorm.DB) error {
+	t.CreatedAt = time.Now()
+	return nil
+}
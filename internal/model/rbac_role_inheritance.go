@@ -0,0 +1,26 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// RoleInheritance makes RoleID inherit every permission of ParentRoleID (role -> role edge, transitive).
+// Mirrors Casbin grouping policies `g, role, parentRole`.
+type RoleInheritance struct {
+	ID           uint `json:"id" gorm:"primaryKey;autoIncrement"`
+	RoleID       uint `json:"roleId" gorm:"not null;uniqueIndex:idx_role_inheritances_pair"`
+	ParentRoleID uint `json:"parentRoleId" gorm:"not null;index;uniqueIndex:idx_role_inheritances_pair"`
+
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func (RoleInheritance) TableName() string {
+	return "role_inheritances"
+}
+
+func (ri *RoleInheritance) BeforeCreate(tx *gorm.DB) error {
+	ri.CreatedAt = time.Now()
+	return nil
+}
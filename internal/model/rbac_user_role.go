@@ -6,10 +6,19 @@ import (
 	"gorm.io/gorm"
 )
 
+// UserRole assigns a role to a user. ValidFrom/ValidUntil bound the grant in time
+// (nil = unbounded); expired rows are ignored at evaluation time and removed by the RBAC grant sweeper.
+// CategoryID scopes the grant to that category's subtree (nil = site-wide); a user holding any scoped
+// grant may only mutate posts and categories inside the granted subtrees. Assignments belong to one site.
 type UserRole struct {
-	ID     uint `json:"id" gorm:"primaryKey;autoIncrement"`
-	UserID uint `json:"userId" gorm:"not null;index"`
-	RoleID uint `json:"roleId" gorm:"not null;index"`
+	ID         uint  `json:"id" gorm:"primaryKey;autoIncrement"`
+	SiteID     uint  `json:"siteId" gorm:"not null;default:1;index"`
+	UserID     uint  `json:"userId" gorm:"not null;index"`
+	RoleID     uint  `json:"roleId" gorm:"not null;index"`
+	CategoryID *uint `json:"categoryId,omitempty" gorm:"index"`
+
+	ValidFrom  *time.Time `json:"validFrom,omitempty"`
+	ValidUntil *time.Time `json:"validUntil,omitempty" gorm:"index"`
 
 	CreatedAt time.Time `json:"createdAt"`
 }
@@ -0,0 +1,68 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Target types a Reaction can point at.
+const (
+	ReactionTargetPost    = "post"
+	ReactionTargetComment = "comment"
+)
+
+// Reaction is one user's reaction of one type (e.g. "like") to a post or comment. A user reacts at
+// most once per type and target; the totals are kept on the target's ReactionCounts.
+type Reaction struct {
+	ID         uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	SiteID     uint      `json:"siteId" gorm:"not null;default:1;index"`
+	TargetType string    `json:"targetType" gorm:"type:varchar(16);not null;uniqueIndex:idx_reactions_target_user_type,priority:1"`
+	TargetID   uint      `json:"targetId" gorm:"not null;uniqueIndex:idx_reactions_target_user_type,priority:2"`
+	UserID     uint      `json:"userId" gorm:"not null;index;uniqueIndex:idx_reactions_target_user_type,priority:3"`
+	Type       string    `json:"type" gorm:"type:varchar(32);not null;uniqueIndex:idx_reactions_target_user_type,priority:4"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+func (Reaction) TableName() string {
+	return "reactions"
+}
+
+// ReactionCounts maps a reaction type to how many users reacted with it. It is stored as a JSON
+// object and denormalized onto posts and comments so lists need no aggregation.
+type ReactionCounts map[string]int64
+
+func (c ReactionCounts) Value() (driver.Value, error) {
+	if len(c) == 0 {
+		return "{}", nil
+	}
+	b, err := json.Marshal(map[string]int64(c))
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (c *ReactionCounts) Scan(src any) error {
+	var b []byte
+	switch v := src.(type) {
+	case nil:
+		*c = ReactionCounts{}
+		return nil
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return fmt.Errorf("unsupported reaction counts type %T", src)
+	}
+	m := ReactionCounts{}
+	if len(b) > 0 {
+		if err := json.Unmarshal(b, &m); err != nil {
+			return err
+		}
+	}
+	*c = m
+	return nil
+}
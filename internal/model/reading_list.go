@@ -0,0 +1,58 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	TableReadingLists     = "reading_lists"
+	TableReadingListItems = "reading_list_items"
+)
+
+// ReadingList is a user's named list of posts saved for later. Lists are private to their owner;
+// setting ShareSlug shares the list read-only at /reading-lists/shared/{ShareSlug}.
+type ReadingList struct {
+	ID     uint   `json:"id" gorm:"primaryKey;autoIncrement"`
+	SiteID uint   `json:"siteId" gorm:"not null;default:1;uniqueIndex:idx_reading_lists_site_user_name,priority:1;uniqueIndex:idx_reading_lists_site_share,priority:1"`
+	UserID uint   `json:"userId" gorm:"not null;uniqueIndex:idx_reading_lists_site_user_name,priority:2"`
+	Name   string `json:"name" gorm:"type:varchar(100);not null;uniqueIndex:idx_reading_lists_site_user_name,priority:3"`
+	// ShareSlug is nil while the list is private.
+	ShareSlug *string `json:"shareSlug,omitempty" gorm:"type:varchar(32);uniqueIndex:idx_reading_lists_site_share,priority:2"`
+	// ItemCount is filled when listing a user's lists.
+	ItemCount int64 `json:"itemCount" gorm:"-"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+func (ReadingList) TableName() string {
+	return TableReadingLists
+}
+
+func (l *ReadingList) BeforeCreate(tx *gorm.DB) error {
+	l.CreatedAt = time.Now()
+	l.UpdatedAt = time.Now()
+	return nil
+}
+
+func (l *ReadingList) BeforeUpdate(tx *gorm.DB) error {
+	l.UpdatedAt = time.Now()
+	return nil
+}
+
+// ReadingListItem places a post in a reading list at Position (1..n without gaps). ReadAt is set once
+// the owner marks the post read.
+type ReadingListItem struct {
+	ListID    uint       `json:"-" gorm:"primaryKey"`
+	PostID    uint       `json:"postId" gorm:"primaryKey;index"`
+	Position  int        `json:"position" gorm:"not null"`
+	ReadAt    *time.Time `json:"readAt,omitempty"`
+	CreatedAt time.Time  `json:"addedAt"`
+	Post      *Post      `json:"post,omitempty" gorm:"constraint:OnDelete:CASCADE"`
+}
+
+func (ReadingListItem) TableName() string {
+	return TableReadingListItems
+}
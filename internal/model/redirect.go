@@ -0,0 +1,48 @@
+package model
+
+import "time"
+
+// Entity types recorded in SlugHistory.
+const (
+	SlugEntityPost     = "post"
+	SlugEntityCategory = "category"
+	SlugEntityTag      = "tag"
+)
+
+// SlugHistory is a slug an entity used before; slug lookups that miss consult it to redirect old
+// links to the entity's current slug. A slug maps to one entity per type and site: the latest one to
+// give it up.
+type SlugHistory struct {
+	ID         uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	SiteID     uint      `json:"siteId" gorm:"not null;default:1;uniqueIndex:idx_slug_histories_site_slug,priority:1"`
+	EntityType string    `json:"entityType" gorm:"type:varchar(20);not null;uniqueIndex:idx_slug_histories_site_slug,priority:2;index:idx_slug_histories_entity,priority:1"`
+	Slug       string    `json:"slug" gorm:"type:varchar(220);not null;uniqueIndex:idx_slug_histories_site_slug,priority:3"`
+	EntityID   uint      `json:"entityId" gorm:"not null;index:idx_slug_histories_entity,priority:2"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+func (SlugHistory) TableName() string {
+	return "slug_histories"
+}
+
+// Redirect sends requests for FromPath to ToPath (a path or absolute URL) with StatusCode. Hits counts
+// the lookups that matched.
+type Redirect struct {
+	ID         uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	SiteID     uint       `json:"siteId" gorm:"not null;default:1;uniqueIndex:idx_redirects_site_from,priority:1"`
+	FromPath   string     `json:"fromPath" gorm:"type:varchar(512);not null;uniqueIndex:idx_redirects_site_from,priority:2"`
+	ToPath     string     `json:"toPath" gorm:"type:varchar(1024);not null"`
+	StatusCode int        `json:"statusCode" gorm:"not null;default:301"`
+	Hits       int64      `json:"hits" gorm:"not null;default:0"`
+	LastHitAt  *time.Time `json:"lastHitAt,omitempty"`
+
+	CreatedBy uint `json:"createdBy" gorm:"not null"`
+	UpdatedBy uint `json:"updatedBy" gorm:"not null"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+func (Redirect) TableName() string {
+	return "redirects"
+}
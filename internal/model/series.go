@@ -0,0 +1,59 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Series ties posts together as ordered chapters. A post belongs to at most one series; its place is
+// Post.Chapter, numbered 1..n without gaps.
+type Series struct {
+	ID          uint   `json:"id" gorm:"primaryKey;autoIncrement"`
+	SiteID      uint   `json:"siteId" gorm:"not null;default:1;uniqueIndex:idx_series_site_slug,priority:1"`
+	Title       string `json:"title" gorm:"type:varchar(200);not null"`
+	Slug        string `json:"slug" gorm:"type:varchar(220);not null;uniqueIndex:idx_series_site_slug,priority:2"`
+	Description string `json:"description,omitempty" gorm:"type:text"`
+
+	// UserID owns the series: only they change it or its chapter list.
+	UserID uint `json:"userId" gorm:"not null;index"`
+
+	CreatedBy uint `json:"createdBy" gorm:"not null"`
+	UpdatedBy uint `json:"updatedBy" gorm:"not null"`
+
+	CreatedAt time.Time      `json:"createdAt"`
+	UpdatedAt time.Time      `json:"updatedAt"`
+	DeletedAt gorm.DeletedAt `json:"deletedAt,omitempty" gorm:"index"`
+}
+
+func (Series) TableName() string {
+	return TableSeries
+}
+
+func (s *Series) BeforeCreate(tx *gorm.DB) error {
+	s.CreatedAt = time.Now()
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+func (s *Series) BeforeUpdate(tx *gorm.DB) error {
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+// SeriesChapter is a short reference to a post in a series, used for navigation and tables of contents.
+type SeriesChapter struct {
+	ID        uint       `json:"id"`
+	Title     string     `json:"title"`
+	Slug      string     `json:"slug"`
+	Chapter   int        `json:"chapter"`
+	PublishAt *time.Time `json:"publishAt,omitempty"`
+}
+
+// SeriesNavigation places a post within its series: the neighbouring live chapters and how many
+// chapters are live.
+type SeriesNavigation struct {
+	Published int64          `json:"published"`
+	Prev      *SeriesChapter `json:"prev,omitempty"`
+	Next      *SeriesChapter `json:"next,omitempty"`
+}
@@ -6,12 +6,13 @@ import (
 	"gorm.io/gorm"
 )
 
-// Setting is a key/value row for application settings stored in the database.
+// Setting is a key/value row for per-site application settings stored in the database.
 // Only rows with IsPublic=true are exposed on the unauthenticated GET /settings API.
 type Setting struct {
-	ID    uint   `json:"id" gorm:"primaryKey;autoIncrement"`
-	Key   string `json:"key" gorm:"column:setting_key;type:varchar(190);not null;uniqueIndex"`
-	Value string `json:"value" gorm:"type:text;not null"`
+	ID     uint   `json:"id" gorm:"primaryKey;autoIncrement"`
+	SiteID uint   `json:"siteId" gorm:"not null;default:1;uniqueIndex:idx_settings_site_key,priority:1"`
+	Key    string `json:"key" gorm:"column:setting_key;type:varchar(190);not null;uniqueIndex:idx_settings_site_key,priority:2"`
+	Value  string `json:"value" gorm:"type:text;not null"`
 	// IsPublic controls visibility on the public settings endpoint.
 	IsPublic bool `json:"isPublic" gorm:"not null;default:true;index"`
 
@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Site is one tenant (blog). Requests resolve a site from the X-Site header (Key) or the Host header (Host);
+// posts, categories, tags, comments, media, settings and role assignments carry its id in site_id.
+type Site struct {
+	ID   uint   `json:"id" gorm:"primaryKey;autoIncrement"`
+	Key  string `json:"key" gorm:"type:varchar(64);not null;uniqueIndex"`
+	Name string `json:"name" gorm:"type:varchar(255);not null"`
+	// Host is matched case-insensitively without port (e.g. blog.example.com); nil = header-only site.
+	Host *string `json:"host,omitempty" gorm:"type:varchar(255);uniqueIndex"`
+
+	CreatedAt time.Time      `json:"createdAt"`
+	UpdatedAt time.Time      `json:"updatedAt"`
+	DeletedAt gorm.DeletedAt `json:"deletedAt,omitempty" gorm:"index"`
+}
+
+func (Site) TableName() string {
+	return "sites"
+}
+
+func (s *Site) BeforeCreate(tx *gorm.DB) error {
+	s.CreatedAt = time.Now()
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+func (s *Site) BeforeUpdate(tx *gorm.DB) error {
+	s.UpdatedAt = time.Now()
+	return nil
+}
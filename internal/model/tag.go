@@ -7,9 +7,12 @@ import (
 )
 
 type Tag struct {
-	ID   uint   `json:"id" gorm:"primaryKey;autoIncrement"`
-	Name string `json:"name" gorm:"type:varchar(100);not null"`
-	Slug string `json:"slug" gorm:"type:varchar(120);not null;uniqueIndex"`
+	ID     uint   `json:"id" gorm:"primaryKey;autoIncrement"`
+	SiteID uint   `json:"siteId" gorm:"not null;default:1;uniqueIndex:idx_tags_site_slug,priority:1"`
+	Name   string `json:"name" gorm:"type:varchar(100);not null"`
+	Slug   string `json:"slug" gorm:"type:varchar(120);not null;uniqueIndex:idx_tags_site_slug,priority:2"`
+
+	DeletedBy *uint `json:"deletedBy,omitempty" gorm:"index"`
 
 	CreatedAt time.Time      `json:"createdAt"`
 	UpdatedAt time.Time      `json:"updatedAt"`
@@ -0,0 +1,65 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CategoryTranslation is a category's name and slug in one locale. A category has at most one
+// translation per locale, and translated slugs are unique per site and locale.
+type CategoryTranslation struct {
+	ID         uint   `json:"id" gorm:"primaryKey;autoIncrement"`
+	SiteID     uint   `json:"siteId" gorm:"not null;default:1;uniqueIndex:idx_category_translations_site_locale_slug,priority:1"`
+	CategoryID uint   `json:"categoryId" gorm:"not null;uniqueIndex:idx_category_translations_category_locale,priority:1"`
+	Locale     string `json:"locale" gorm:"type:varchar(35);not null;uniqueIndex:idx_category_translations_category_locale,priority:2;uniqueIndex:idx_category_translations_site_locale_slug,priority:2"`
+	Name       string `json:"name" gorm:"type:varchar(255);not null"`
+	Slug       string `json:"slug" gorm:"type:varchar(255);not null;uniqueIndex:idx_category_translations_site_locale_slug,priority:3"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+func (CategoryTranslation) TableName() string {
+	return "category_translations"
+}
+
+func (t *CategoryTranslation) BeforeCreate(tx *gorm.DB) error {
+	t.CreatedAt = time.Now()
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+func (t *CategoryTranslation) BeforeUpdate(tx *gorm.DB) error {
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// TagTranslation is a tag's name and slug in one locale, with the same uniqueness rules as
+// CategoryTranslation.
+type TagTranslation struct {
+	ID     uint   `json:"id" gorm:"primaryKey;autoIncrement"`
+	SiteID uint   `json:"siteId" gorm:"not null;default:1;uniqueIndex:idx_tag_translations_site_locale_slug,priority:1"`
+	TagID  uint   `json:"tagId" gorm:"not null;uniqueIndex:idx_tag_translations_tag_locale,priority:1"`
+	Locale string `json:"locale" gorm:"type:varchar(35);not null;uniqueIndex:idx_tag_translations_tag_locale,priority:2;uniqueIndex:idx_tag_translations_site_locale_slug,priority:2"`
+	Name   string `json:"name" gorm:"type:varchar(100);not null"`
+	Slug   string `json:"slug" gorm:"type:varchar(120);not null;uniqueIndex:idx_tag_translations_site_locale_slug,priority:3"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+func (TagTranslation) TableName() string {
+	return "tag_translations"
+}
+
+func (t *TagTranslation) BeforeCreate(tx *gorm.DB) error {
+	t.CreatedAt = time.Now()
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+func (t *TagTranslation) BeforeUpdate(tx *gorm.DB) error {
+	t.UpdatedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,280 @@
+package rbac
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	casbinmodel "github.com/casbin/casbin/v3/model"
+	"github.com/casbin/casbin/v3/persist"
+	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/internal/tenant"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrSiteScopedPolicy   = errors.New("permission policies must use the any-site domain")
+	ErrMalformedPolicy    = errors.New("malformed policy rule")
+	ErrInvalidGrantDomain = errors.New("user role grants need a numeric site domain")
+)
+
+// Adapter stores Casbin policies in the RBAC tables instead of a separate rules table:
+//
+//	p, role, *, obj, act   <->  roles + permissions ("obj:act") + role_permissions
+//	g, user, role, site    <->  user_roles (currently valid grants only)
+//	g, role, parent, *     <->  role_inheritances
+//
+// so the enforcer and the DB-backed RBACService always read the same rows.
+type Adapter struct {
+	db *gorm.DB
+}
+
+var _ persist.Adapter = (*Adapter)(nil)
+
+func NewAdapter(db *gorm.DB) *Adapter {
+	return &Adapter{db: db}
+}
+
+// conn bypasses the tenant filter: grants for every site are loaded into one enforcer, keyed by domain.
+func (a *Adapter) conn() *gorm.DB {
+	return a.db.WithContext(tenant.WithAllSites(context.Background()))
+}
+
+// isUserSubject reports whether a grouping rule's subject is a user id rather than a role name.
+func isUserSubject(sub string) bool {
+	_, err := strconv.ParseUint(sub, 10, 64)
+	return err == nil
+}
+
+// SplitPermissionKey splits a stored "obj:act" key on its first ':'.
+func SplitPermissionKey(key string) (obj, act string, ok bool) {
+	parts := strings.SplitN(key, ":", 2)
+	if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+		return "", "", false
+	}
+	return strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]), true
+}
+
+// Rules returns every policy stored in the RBAC tables, each prefixed with its ptype ("p" or "g").
+// Permissions with malformed keys and grants outside their validity window are skipped.
+func (a *Adapter) Rules() ([][]string, error) {
+	return storedRules(a.conn(), true)
+}
+
+func storedRules(db *gorm.DB, activeOnly bool) ([][]string, error) {
+	var out [][]string
+	seen := map[string]struct{}{}
+	add := func(rule ...string) {
+		k := strings.Join(rule, "\x00")
+		if _, ok := seen[k]; ok {
+			return
+		}
+		seen[k] = struct{}{}
+		out = append(out, rule)
+	}
+
+	var perms []struct {
+		Role string
+		Key  string
+	}
+	if err := db.Table("role_permissions").
+		Select("roles.name AS role, permissions.`key` AS `key`").
+		Joins("JOIN roles ON roles.id = role_permissions.role_id AND roles.deleted_at IS NULL").
+		Joins("JOIN permissions ON permissions.id = role_permissions.permission_id AND permissions.deleted_at IS NULL").
+		Order("role_permissions.id asc").
+		Scan(&perms).Error; err != nil {
+		return nil, err
+	}
+	for _, p := range perms {
+		if obj, act, ok := SplitPermissionKey(p.Key); ok {
+			add("p", p.Role, AnyDomain, obj, act)
+		}
+	}
+
+	var grants []struct {
+		UserID uint
+		Role   string
+		SiteID uint
+	}
+	q := db.Table("user_roles").
+		Select("user_roles.user_id, roles.name AS role, user_roles.site_id").
+		Joins("JOIN roles ON roles.id = user_roles.role_id AND roles.deleted_at IS NULL")
+	if activeOnly {
+		now := time.Now()
+		q = q.Where("(user_roles.valid_from IS NULL OR user_roles.valid_from <= ?) AND (user_roles.valid_until IS NULL OR user_roles.valid_until > ?)", now, now)
+	}
+	if err := q.Order("user_roles.id asc").Scan(&grants).Error; err != nil {
+		return nil, err
+	}
+	for _, g := range grants {
+		add("g", strconv.FormatUint(uint64(g.UserID), 10), g.Role, tenant.Domain(g.SiteID))
+	}
+
+	var edges []struct {
+		Role   string
+		Parent string
+	}
+	if err := db.Table("role_inheritances").
+		Select("child.name AS role, parent.name AS parent").
+		Joins("JOIN roles child ON child.id = role_inheritances.role_id AND child.deleted_at IS NULL").
+		Joins("JOIN roles parent ON parent.id = role_inheritances.parent_role_id AND parent.deleted_at IS NULL").
+		Order("role_inheritances.id asc").
+		Scan(&edges).Error; err != nil {
+		return nil, err
+	}
+	for _, e := range edges {
+		add("g", e.Role, e.Parent, AnyDomain)
+	}
+	return out, nil
+}
+
+func (a *Adapter) LoadPolicy(m casbinmodel.Model) error {
+	rules, err := a.Rules()
+	if err != nil {
+		return err
+	}
+	for _, r := range rules {
+		if err := persist.LoadPolicyArray(r, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SavePolicy writes every rule of the model that is missing from the tables. It never deletes:
+// user_roles rows carry validity windows and category scopes the Casbin model does not represent.
+func (a *Adapter) SavePolicy(m casbinmodel.Model) error {
+	for _, ptype := range []string{"p", "g"} {
+		rules, err := m.GetPolicy(ptype, ptype)
+		if err != nil {
+			return err
+		}
+		for _, r := range rules {
+			if err := a.AddPolicy(ptype, ptype, r); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (a *Adapter) AddPolicy(sec string, ptype string, rule []string) error {
+	return a.conn().Transaction(func(tx *gorm.DB) error {
+		return addRule(tx, ptype, rule)
+	})
+}
+
+func addRule(tx *gorm.DB, ptype string, rule []string) error {
+	switch {
+	case ptype == "p" && len(rule) >= 4:
+		if rule[1] != AnyDomain {
+			return ErrSiteScopedPolicy
+		}
+		role, err := firstOrCreateRole(tx, rule[0])
+		if err != nil {
+			return err
+		}
+		key := strings.TrimSpace(rule[2]) + ":" + strings.TrimSpace(rule[3])
+		p := model.Permission{Key: key}
+		if err := tx.Where("`key` = ?", key).FirstOrCreate(&p).Error; err != nil {
+			return err
+		}
+		rp := model.RolePermission{RoleID: role.ID, PermissionID: p.ID}
+		return tx.Where("role_id = ? AND permission_id = ?", role.ID, p.ID).FirstOrCreate(&rp).Error
+	case ptype == "g" && len(rule) >= 3 && isUserSubject(rule[0]):
+		userID, _ := strconv.ParseUint(rule[0], 10, 64)
+		siteID, err := strconv.ParseUint(rule[2], 10, 64)
+		if err != nil || siteID == 0 {
+			return ErrInvalidGrantDomain
+		}
+		role, err := firstOrCreateRole(tx, rule[1])
+		if err != nil {
+			return err
+		}
+		ur := model.UserRole{SiteID: uint(siteID), UserID: uint(userID), RoleID: role.ID}
+		return tx.Where("site_id = ? AND user_id = ? AND role_id = ? AND category_id IS NULL", siteID, userID, role.ID).FirstOrCreate(&ur).Error
+	case ptype == "g" && len(rule) >= 3:
+		if rule[2] != AnyDomain {
+			return ErrSiteScopedPolicy
+		}
+		child, err := firstOrCreateRole(tx, rule[0])
+		if err != nil {
+			return err
+		}
+		parent, err := firstOrCreateRole(tx, rule[1])
+		if err != nil {
+			return err
+		}
+		ri := model.RoleInheritance{RoleID: child.ID, ParentRoleID: parent.ID}
+		return tx.Where("role_id = ? AND parent_role_id = ?", child.ID, parent.ID).FirstOrCreate(&ri).Error
+	}
+	return fmt.Errorf("%w: %s %v", ErrMalformedPolicy, ptype, rule)
+}
+
+func firstOrCreateRole(tx *gorm.DB, name string) (model.Role, error) {
+	name = strings.TrimSpace(name)
+	r := model.Role{Name: name}
+	if name == "" {
+		return r, ErrMalformedPolicy
+	}
+	err := tx.Where("name = ?", name).FirstOrCreate(&r).Error
+	return r, err
+}
+
+// RemovePolicy deletes the rows behind a rule. Removing "g, user, role, site" drops every grant of
+// that role on the site, including category-scoped and time-bound ones.
+func (a *Adapter) RemovePolicy(sec string, ptype string, rule []string) error {
+	db := a.conn()
+	switch {
+	case ptype == "p" && len(rule) >= 4:
+		key := strings.TrimSpace(rule[2]) + ":" + strings.TrimSpace(rule[3])
+		return db.Exec(
+			"DELETE FROM role_permissions WHERE role_id IN (SELECT id FROM roles WHERE name = ?) AND permission_id IN (SELECT id FROM permissions WHERE `key` = ?)",
+			rule[0], key,
+		).Error
+	case ptype == "g" && len(rule) >= 3 && isUserSubject(rule[0]):
+		return db.Exec(
+			"DELETE FROM user_roles WHERE user_id = ? AND site_id = ? AND role_id IN (SELECT id FROM roles WHERE name = ?)",
+			rule[0], rule[2], rule[1],
+		).Error
+	case ptype == "g" && len(rule) >= 3:
+		return db.Exec(
+			"DELETE FROM role_inheritances WHERE role_id IN (SELECT id FROM roles WHERE name = ?) AND parent_role_id IN (SELECT id FROM roles WHERE name = ?)",
+			rule[0], rule[1],
+		).Error
+	}
+	return fmt.Errorf("%w: %s %v", ErrMalformedPolicy, ptype, rule)
+}
+
+// RemoveFilteredPolicy removes every stored rule of ptype whose fields from fieldIndex on match
+// fieldValues ("" matches anything).
+func (a *Adapter) RemoveFilteredPolicy(sec string, ptype string, fieldIndex int, fieldValues ...string) error {
+	rules, err := a.Rules()
+	if err != nil {
+		return err
+	}
+	for _, r := range rules {
+		if r[0] != ptype || !matchesFilter(r[1:], fieldIndex, fieldValues) {
+			continue
+		}
+		if err := a.RemovePolicy(sec, ptype, r[1:]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func matchesFilter(rule []string, fieldIndex int, fieldValues []string) bool {
+	for i, v := range fieldValues {
+		if v == "" {
+			continue
+		}
+		if fieldIndex+i >= len(rule) || rule[fieldIndex+i] != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,123 @@
+package rbac
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	gormadapter "github.com/casbin/gorm-adapter/v3"
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/turahe/go-restfull/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const testModelPath = "../../configs/casbin_model.conf"
+
+func openRBACTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open("file:"+url.QueryEscape(t.Name())+"?mode=memory&cache=shared"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&model.Role{}, &model.Permission{}, &model.RolePermission{}, &model.UserRole{}, &model.RoleInheritance{}))
+	return db
+}
+
+func TestAdapter_EnforcerReadsRBACTables(t *testing.T) {
+	db := openRBACTestDB(t)
+	editor := model.Role{Name: "editor"}
+	writer := model.Role{Name: "writer"}
+	require.NoError(t, db.Create(&editor).Error)
+	require.NoError(t, db.Create(&writer).Error)
+	perm := model.Permission{Key: "/api/v1/posts/*:PUT"}
+	require.NoError(t, db.Create(&perm).Error)
+	require.NoError(t, db.Create(&model.RolePermission{RoleID: writer.ID, PermissionID: perm.ID}).Error)
+	require.NoError(t, db.Create(&model.RoleInheritance{RoleID: editor.ID, ParentRoleID: writer.ID}).Error)
+	require.NoError(t, db.Create(&model.UserRole{SiteID: 1, UserID: 7, RoleID: editor.ID}).Error)
+	later := time.Now().Add(time.Hour)
+	require.NoError(t, db.Create(&model.UserRole{SiteID: 1, UserID: 8, RoleID: editor.ID, ValidFrom: &later}).Error)
+
+	e, err := NewEnforcer(db, testModelPath)
+	require.NoError(t, err)
+
+	ok, err := e.Enforce("7", "1", "/api/v1/posts/3", "PUT")
+	require.NoError(t, err)
+	assert.True(t, ok, "inherited permission on the granted site")
+	ok, err = e.Enforce("7", "2", "/api/v1/posts/3", "PUT")
+	require.NoError(t, err)
+	assert.False(t, ok, "grant belongs to site 1 only")
+	ok, err = e.Enforce("8", "1", "/api/v1/posts/3", "PUT")
+	require.NoError(t, err)
+	assert.False(t, ok, "grant window has not opened")
+
+	// Writes through the enforcer land in the same tables.
+	added, err := e.AddPolicy("writer", AnyDomain, "/api/v1/tags", "POST")
+	require.NoError(t, err)
+	assert.True(t, added)
+	var n int64
+	require.NoError(t, db.Model(&model.Permission{}).Where("`key` = ?", "/api/v1/tags:POST").Count(&n).Error)
+	assert.Equal(t, int64(1), n)
+
+	_, err = e.AddPolicy("writer", "2", "/api/v1/tags", "DELETE")
+	assert.ErrorIs(t, err, ErrSiteScopedPolicy)
+
+	_, err = e.DeleteRoleForUser("7", "editor", "1")
+	require.NoError(t, err)
+	require.NoError(t, db.Model(&model.UserRole{}).Where("user_id = ?", 7).Count(&n).Error)
+	assert.Equal(t, int64(0), n)
+}
+
+func TestConsistency_ReportsAndRepairs(t *testing.T) {
+	db := openRBACTestDB(t)
+	ctx := context.Background()
+	require.NoError(t, db.Table(LegacyRulesTable).AutoMigrate(&gormadapter.CasbinRule{}))
+	legacy := []gormadapter.CasbinRule{
+		{Ptype: "p", V0: "user", V1: "/api/v1/posts", V2: "GET"},               // pre-domain layout
+		{Ptype: "p", V0: "user", V1: AnyDomain, V2: "/api/v1/tags", V3: "GET"}, // domain layout
+		{Ptype: "g", V0: "5", V1: "user"},
+		{Ptype: "p", V0: "user", V1: "2", V2: "/api/v1/media", V3: "GET"}, // per-site policy: manual
+	}
+	require.NoError(t, db.Table(LegacyRulesTable).Create(&legacy).Error)
+
+	role := model.Role{Name: "user"}
+	require.NoError(t, db.Create(&role).Error)
+	perm := model.Permission{Key: "/api/v1/tags:GET"}
+	require.NoError(t, db.Create(&perm).Error)
+	require.NoError(t, db.Create(&model.RolePermission{RoleID: role.ID, PermissionID: perm.ID}).Error)
+	require.NoError(t, db.Create(&model.RolePermission{RoleID: role.ID, PermissionID: perm.ID}).Error) // duplicate
+	require.NoError(t, db.Create(&model.RolePermission{RoleID: 999, PermissionID: perm.ID}).Error)     // orphan
+	require.NoError(t, db.Create(&model.Permission{Key: "no-action"}).Error)
+
+	issues, err := CheckConsistency(ctx, db)
+	require.NoError(t, err)
+	kinds := map[string]int{}
+	for _, is := range issues {
+		kinds[is.Kind]++
+	}
+	assert.Equal(t, map[string]int{
+		IssueLegacyRuleMissing:      3,
+		IssueDuplicateRolePerm:      1,
+		IssueOrphanRolePermission:   1,
+		IssueMalformedPermissionKey: 1,
+	}, kinds)
+
+	_, err = RepairConsistency(ctx, db)
+	require.NoError(t, err)
+
+	issues, err = CheckConsistency(ctx, db)
+	require.NoError(t, err)
+	for _, is := range issues {
+		assert.False(t, is.Repairable, "left after repair: %s %s", is.Kind, is.Detail)
+	}
+	assert.Len(t, issues, 2)
+
+	e, err := NewEnforcer(db, testModelPath)
+	require.NoError(t, err)
+	ok, err := e.Enforce("5", "1", "/api/v1/posts", "GET")
+	require.NoError(t, err)
+	assert.True(t, ok, "imported legacy grant and permission")
+}
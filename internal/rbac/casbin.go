@@ -5,10 +5,14 @@ import (
 
 	"github.com/casbin/casbin/v3"
 	"github.com/casbin/casbin/v3/model"
-	gormadapter "github.com/casbin/gorm-adapter/v3"
+	"github.com/casbin/casbin/v3/util"
 	"gorm.io/gorm"
 )
 
+// AnyDomain is the domain of policies and role inheritance edges that apply on every site.
+// User role assignments use the site id (tenant.Domain) as their domain.
+const AnyDomain = "*"
+
 type Enforcer struct {
 	*casbin.Enforcer
 }
@@ -21,17 +25,45 @@ func NewEnforcer(db *gorm.DB, modelPath string) (*Enforcer, error) {
 	if err != nil {
 		return nil, err
 	}
-	adapter, err := gormadapter.NewAdapterByDBUseTableName(db, "", "casbin_rules")
-	if err != nil {
-		return nil, err
-	}
-	e, err := casbin.NewEnforcer(m, adapter)
+	e, err := casbin.NewEnforcer(m, NewAdapter(db))
 	if err != nil {
 		return nil, err
 	}
+	// Grouping rules stored with AnyDomain (role inheritance) apply in every site domain.
+	e.AddNamedDomainMatchingFunc("g", "keyMatch", util.KeyMatch)
 	if err := e.LoadPolicy(); err != nil {
 		return nil, err
 	}
 	return &Enforcer{Enforcer: e}, nil
 }
 
+// PermissionsInDomain returns the policies (sub, dom, obj, act) that apply to sub on dom,
+// directly or through any role sub holds there.
+func (e *Enforcer) PermissionsInDomain(sub, dom string) ([][]string, error) {
+	roles, err := e.GetImplicitRolesForUser(sub, dom)
+	if err != nil {
+		return nil, err
+	}
+	subjects := map[string]struct{}{sub: {}}
+	for _, r := range roles {
+		subjects[r] = struct{}{}
+	}
+	policies, err := e.GetPolicy()
+	if err != nil {
+		return nil, err
+	}
+	var out [][]string
+	for _, p := range policies {
+		if len(p) < 4 {
+			continue
+		}
+		if _, ok := subjects[p[0]]; !ok {
+			continue
+		}
+		if p[1] != AnyDomain && p[1] != dom {
+			continue
+		}
+		out = append(out, p)
+	}
+	return out, nil
+}
@@ -0,0 +1,170 @@
+package rbac
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	gormadapter "github.com/casbin/gorm-adapter/v3"
+	"github.com/turahe/go-restfull/internal/tenant"
+	"gorm.io/gorm"
+)
+
+// LegacyRulesTable is where policies lived before the enforcer read the RBAC tables (gorm-adapter).
+const LegacyRulesTable = "casbin_rules"
+
+// Issue kinds reported by CheckConsistency.
+const (
+	IssueLegacyRuleMissing      = "legacy_rule_missing"       // rule in casbin_rules with no matching table rows
+	IssueOrphanRolePermission   = "orphan_role_permission"    // role_permissions row pointing at a missing role or permission
+	IssueDuplicateRolePerm      = "duplicate_role_permission" // same (role, permission) pair stored more than once
+	IssueOrphanUserRole         = "orphan_user_role"          // user_roles row pointing at a missing role
+	IssueOrphanRoleInheritance  = "orphan_role_inheritance"   // role_inheritances edge with a missing end
+	IssueMalformedPermissionKey = "malformed_permission_key"  // permissions.key is not "obj:act"
+)
+
+// Issue is one divergence found by CheckConsistency. Repairable issues are fixed by RepairConsistency;
+// the rest need a manual decision.
+type Issue struct {
+	Kind       string `json:"kind"`
+	Detail     string `json:"detail"`
+	Repairable bool   `json:"repairable"`
+
+	rule  []string // legacy rule (ptype first) to import
+	rowID uint     // row to delete
+	table string
+}
+
+// CheckConsistency compares the legacy casbin_rules table (when present) with the RBAC tables and
+// looks for dangling or duplicate rows in the tables themselves.
+func CheckConsistency(ctx context.Context, db *gorm.DB) ([]Issue, error) {
+	db = db.WithContext(tenant.WithAllSites(ctx))
+	var issues []Issue
+
+	legacy, err := legacyRules(db)
+	if err != nil {
+		return nil, err
+	}
+	if len(legacy) > 0 {
+		// Grants outside their window still count as present: importing them again would widen them.
+		current, err := storedRules(db, false)
+		if err != nil {
+			return nil, err
+		}
+		have := make(map[string]struct{}, len(current))
+		for _, r := range current {
+			have[strings.Join(r, ", ")] = struct{}{}
+		}
+		for _, r := range legacy {
+			key := strings.Join(r, ", ")
+			if _, ok := have[key]; ok {
+				continue
+			}
+			// Per-site permission policies have no table representation.
+			repairable := r[0] != "p" || r[2] == AnyDomain
+			issues = append(issues, Issue{Kind: IssueLegacyRuleMissing, Detail: key, Repairable: repairable, rule: r})
+		}
+	}
+
+	orphans := []struct {
+		kind, table, where string
+	}{
+		{IssueOrphanRolePermission, "role_permissions",
+			"role_id NOT IN (SELECT id FROM roles WHERE deleted_at IS NULL) OR permission_id NOT IN (SELECT id FROM permissions WHERE deleted_at IS NULL)"},
+		{IssueDuplicateRolePerm, "role_permissions",
+			"id NOT IN (SELECT MIN(id) FROM role_permissions GROUP BY role_id, permission_id)"},
+		{IssueOrphanUserRole, "user_roles",
+			"role_id NOT IN (SELECT id FROM roles WHERE deleted_at IS NULL)"},
+		{IssueOrphanRoleInheritance, "role_inheritances",
+			"role_id NOT IN (SELECT id FROM roles WHERE deleted_at IS NULL) OR parent_role_id NOT IN (SELECT id FROM roles WHERE deleted_at IS NULL)"},
+	}
+	for _, o := range orphans {
+		var ids []uint
+		if err := db.Table(o.table).Where(o.where).Order("id asc").Pluck("id", &ids).Error; err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			issues = append(issues, Issue{Kind: o.kind, Detail: fmt.Sprintf("%s id=%d", o.table, id), Repairable: true, rowID: id, table: o.table})
+		}
+	}
+
+	var perms []struct {
+		ID  uint
+		Key string
+	}
+	if err := db.Table("permissions").Select("id, `key`").Where("deleted_at IS NULL").Order("id asc").Scan(&perms).Error; err != nil {
+		return nil, err
+	}
+	for _, p := range perms {
+		if _, _, ok := SplitPermissionKey(p.Key); !ok {
+			issues = append(issues, Issue{Kind: IssueMalformedPermissionKey, Detail: fmt.Sprintf("permissions id=%d key=%q", p.ID, p.Key)})
+		}
+	}
+	return issues, nil
+}
+
+// RepairConsistency runs CheckConsistency and, in one transaction, imports legacy-only rules into the
+// RBAC tables and deletes orphaned and duplicate rows. It returns the issues found (including any
+// that are not repairable); casbin_rules itself is left untouched.
+func RepairConsistency(ctx context.Context, db *gorm.DB) ([]Issue, error) {
+	issues, err := CheckConsistency(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	err = db.WithContext(tenant.WithAllSites(ctx)).Transaction(func(tx *gorm.DB) error {
+		for _, is := range issues {
+			switch {
+			case !is.Repairable:
+				continue
+			case is.rule != nil:
+				if err := addRule(tx, is.rule[0], is.rule[1:]); err != nil {
+					return fmt.Errorf("import %s: %w", is.Detail, err)
+				}
+			default:
+				if err := tx.Exec("DELETE FROM "+is.table+" WHERE id = ?", is.rowID).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return issues, nil
+}
+
+// legacyRules reads casbin_rules in either layout: before domains ("p, role, obj, act" and
+// "g, sub, role") and after ("p, role, dom, obj, act" and "g, sub, role, dom"). Legacy user grants
+// without a domain belong to the default site.
+func legacyRules(db *gorm.DB) ([][]string, error) {
+	if !db.Migrator().HasTable(LegacyRulesTable) {
+		return nil, nil
+	}
+	var rows []gormadapter.CasbinRule
+	if err := db.Table(LegacyRulesTable).Order("id asc").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make([][]string, 0, len(rows))
+	for _, r := range rows {
+		switch r.Ptype {
+		case "p":
+			if r.V3 == "" {
+				out = append(out, []string{"p", r.V0, AnyDomain, r.V1, r.V2})
+			} else {
+				out = append(out, []string{"p", r.V0, r.V1, r.V2, r.V3})
+			}
+		case "g":
+			dom := r.V2
+			if dom == "" {
+				dom = AnyDomain
+				if _, err := strconv.ParseUint(r.V0, 10, 64); err == nil {
+					dom = tenant.Domain(tenant.DefaultSiteID)
+				}
+			}
+			out = append(out, []string{"g", r.V0, r.V1, dom})
+		}
+	}
+	return out, nil
+}
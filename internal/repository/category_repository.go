@@ -10,6 +10,7 @@ import (
 
 	"github.com/turahe/go-restfull/internal/handler/request"
 	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/internal/tenant"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -28,7 +29,7 @@ func categorySlugify(s string) string {
 	return s
 }
 
-// nextCategorySlug returns a unique slug for categories (global uniqueness), excluding excludeID when > 0.
+// nextCategorySlug returns a unique slug for categories (unique per site), excluding excludeID when > 0.
 func nextCategorySlug(db *gorm.DB, name string, excludeID uint) (string, error) {
 	base := categorySlugify(name)
 	if base == "" {
@@ -55,9 +56,13 @@ func nextCategorySlug(db *gorm.DB, name string, excludeID uint) (string, error)
 // ErrCategorySubtreeHasPosts is returned when delete would remove categories still referenced by posts.category_id.
 var ErrCategorySubtreeHasPosts = errors.New("posts reference this category or its descendants")
 
-// MySQL advisory lock name: serializes nested-set mutations to prevent concurrent insert/shift races.
+// MySQL advisory lock name prefix: serializes nested-set mutations (per site) to prevent concurrent insert/shift races.
 const categoryNestedSetLockName = "go_restfull_categories_nested_set"
 
+func categoryLockName(siteID uint) string {
+	return fmt.Sprintf("%s_%d", categoryNestedSetLockName, siteID)
+}
+
 type CategoryRepository struct {
 	db  *gorm.DB
 	log *zap.Logger
@@ -71,14 +76,14 @@ func lockNestedSet(tx *gorm.DB) error {
 	if tx.Dialector.Name() != "mysql" {
 		return nil
 	}
-	return tx.Exec("SELECT GET_LOCK(?, -1)", categoryNestedSetLockName).Error
+	return tx.Exec("SELECT GET_LOCK(?, -1)", categoryLockName(tenant.SiteID(tx.Statement.Context))).Error
 }
 
 func unlockNestedSet(tx *gorm.DB) {
 	if tx.Dialector.Name() != "mysql" {
 		return
 	}
-	_ = tx.Exec("SELECT RELEASE_LOCK(?)", categoryNestedSetLockName).Error
+	_ = tx.Exec("SELECT RELEASE_LOCK(?)", categoryLockName(tenant.SiteID(tx.Statement.Context))).Error
 }
 
 // CreateRoot inserts a root category (depth 0). First root uses lft=1, rgt=2; further roots append after max(rgt).
@@ -165,10 +170,11 @@ func (r *CategoryRepository) CreateChild(ctx context.Context, parentID uint, nam
 			return err
 		}
 		parentRgt := parent.Rgt
-		if err := tx.Exec("UPDATE categories SET rgt = rgt + 2 WHERE deleted_at IS NULL AND rgt >= ?", parentRgt).Error; err != nil {
+		siteID := tenant.SiteID(ctx)
+		if err := tx.Exec("UPDATE categories SET rgt = rgt + 2 WHERE site_id = ? AND deleted_at IS NULL AND rgt >= ?", siteID, parentRgt).Error; err != nil {
 			return err
 		}
-		if err := tx.Exec("UPDATE categories SET lft = lft + 2 WHERE deleted_at IS NULL AND lft > ?", parentRgt).Error; err != nil {
+		if err := tx.Exec("UPDATE categories SET lft = lft + 2 WHERE site_id = ? AND deleted_at IS NULL AND lft > ?", siteID, parentRgt).Error; err != nil {
 			return err
 		}
 		slug, err := nextCategorySlug(tx, name, 0)
@@ -195,7 +201,16 @@ func (r *CategoryRepository) CreateChild(ctx context.Context, parentID uint, nam
 	return out, nil
 }
 
-// List returns a paginated flat list of categories ordered by lft (tree order).
+var categoryKeyset = keyset[model.CategoryModel]{
+	idColumn: "id",
+	id:       func(c *model.CategoryModel) uint { return c.ID },
+	orders: []keysetOrder[model.CategoryModel]{
+		{name: "tree", column: "lft", kind: keyInt, key: func(c *model.CategoryModel) any { return c.Lft }},
+		{name: "name", column: "name", kind: keyString, key: func(c *model.CategoryModel) any { return c.Name }},
+	},
+}
+
+// List returns a keyset-paginated flat list of categories, in tree (lft) order by default.
 func (r *CategoryRepository) List(ctx context.Context, req request.CategoryListRequest) (CursorPage, error) {
 	limit := req.Limit
 	if limit <= 0 {
@@ -204,57 +219,23 @@ func (r *CategoryRepository) List(ctx context.Context, req request.CategoryListR
 	if limit > 200 {
 		limit = 200
 	}
-	page := req.Page
-	if page <= 0 {
-		page = 1
-	}
-	offset := (page - 1) * limit
 
-	countQ := r.db.WithContext(ctx).Model(&model.CategoryModel{})
-	if req.Name != "" {
-		countQ = countQ.Where("name LIKE ?", "%"+req.Name+"%")
-	}
-	if s := strings.TrimSpace(req.Search); s != "" {
-		countQ = countQ.Where("name LIKE ?", "%"+s+"%")
-	}
-
-	var totalRows int64
-	if err := countQ.Count(&totalRows).Error; err != nil {
-		r.log.Error("failed to count categories", zap.Error(err))
-		return CursorPage{}, err
-	}
-
-	q := r.db.WithContext(ctx).Model(&model.CategoryModel{})
-	if req.Name != "" {
-		q = q.Where("name LIKE ?", "%"+req.Name+"%")
-	}
-	if s := strings.TrimSpace(req.Search); s != "" {
-		q = q.Where("name LIKE ?", "%"+s+"%")
+	build := func() *gorm.DB {
+		q := r.db.WithContext(ctx).Model(&model.CategoryModel{})
+		if req.Name != "" {
+			q = q.Where("name LIKE ?", "%"+req.Name+"%")
+		}
+		if s := strings.TrimSpace(req.Search); s != "" {
+			q = q.Where("name LIKE ?", "%"+s+"%")
+		}
+		return q
 	}
 
-	var rows []model.CategoryModel
-	if err := q.Order("lft asc").Limit(limit).Offset(offset).Find(&rows).Error; err != nil {
+	_, page, err := categoryKeyset.page(build, nil, req.Sort, req.CursorRequest, limit)
+	if err != nil && !errors.Is(err, ErrInvalidCursor) {
 		r.log.Error("failed to list categories", zap.Error(err))
-		return CursorPage{}, err
-	}
-
-	if len(rows) == 0 {
-		return CursorPage{Items: []model.CategoryModel{}, NextCursor: nil, PrevCursor: nil}, nil
-	}
-
-	var nextCursor *uint
-	if int64(offset)+int64(limit) < totalRows {
-		tmp := rows[len(rows)-1].ID
-		nextCursor = &tmp
-	}
-
-	var prevCursor *uint
-	if offset > 0 {
-		tmp := rows[0].ID
-		prevCursor = &tmp
 	}
-
-	return CursorPage{Items: rows, NextCursor: nextCursor, PrevCursor: prevCursor}, nil
+	return page, err
 }
 
 // GetTree returns all categories ordered by lft ascending (single query).
@@ -286,6 +267,25 @@ func (r *CategoryRepository) GetSubtree(ctx context.Context, categoryID uint) ([
 	return rows, nil
 }
 
+// GetAncestors returns the path from the root down to categoryID (inclusive), ordered by lft (single query).
+func (r *CategoryRepository) GetAncestors(ctx context.Context, categoryID uint) ([]model.CategoryModel, error) {
+	var anchor model.CategoryModel
+	if err := r.db.WithContext(ctx).First(&anchor, categoryID).Error; err != nil {
+		r.log.Error("get ancestors anchor failed", zap.Error(err))
+		return nil, err
+	}
+	var rows []model.CategoryModel
+	err := r.db.WithContext(ctx).
+		Where("lft <= ? AND rgt >= ?", anchor.Lft, anchor.Rgt).
+		Order("lft ASC").
+		Find(&rows).Error
+	if err != nil {
+		r.log.Error("get category ancestors failed", zap.Error(err))
+		return nil, err
+	}
+	return rows, nil
+}
+
 // GetByID returns a category by primary key.
 func (r *CategoryRepository) GetByID(ctx context.Context, id uint) (*model.CategoryModel, error) {
 	var c model.CategoryModel
@@ -296,6 +296,16 @@ func (r *CategoryRepository) GetByID(ctx context.Context, id uint) (*model.Categ
 	return &c, nil
 }
 
+// FindBySlug returns a category by slug.
+func (r *CategoryRepository) FindBySlug(ctx context.Context, slug string) (*model.CategoryModel, error) {
+	var c model.CategoryModel
+	if err := r.db.WithContext(ctx).Where("slug = ?", slug).First(&c).Error; err != nil {
+		r.log.Error("find category by slug failed", zap.Error(err))
+		return nil, err
+	}
+	return &c, nil
+}
+
 // FindByIDs returns categories for the given ids (used by posts and other features).
 func (r *CategoryRepository) FindByIDs(ctx context.Context, ids []uint) ([]model.CategoryModel, error) {
 	if len(ids) == 0 {
@@ -310,7 +320,34 @@ func (r *CategoryRepository) FindByIDs(ctx context.Context, ids []uint) ([]model
 	return rows, nil
 }
 
-// UpdateName updates the category display name (nested-set lft/rgt/depth unchanged).
+// ScopedRangesForUser returns the categories the user's currently valid category-scoped role grants point at.
+// scoped reports whether any such grant exists; a scoped user whose granted categories were all deleted gets no ranges.
+func (r *CategoryRepository) ScopedRangesForUser(ctx context.Context, userID uint) (scoped bool, ranges []model.CategoryModel, err error) {
+	now := time.Now()
+	var ids []uint
+	err = r.db.WithContext(ctx).
+		Model(&model.UserRole{}).
+		Joins("JOIN roles ON roles.id = user_roles.role_id AND roles.deleted_at IS NULL").
+		Where("user_roles.user_id = ? AND user_roles.category_id IS NOT NULL", userID).
+		Where("(user_roles.valid_from IS NULL OR user_roles.valid_from <= ?) AND (user_roles.valid_until IS NULL OR user_roles.valid_until > ?)", now, now).
+		Distinct().
+		Pluck("user_roles.category_id", &ids).Error
+	if err != nil {
+		r.log.Error("find scoped category grants failed", zap.Error(err))
+		return false, nil, err
+	}
+	if len(ids) == 0 {
+		return false, nil, nil
+	}
+	if err := r.db.WithContext(ctx).Where("id IN ?", ids).Order("lft ASC").Find(&ranges).Error; err != nil {
+		r.log.Error("find scoped categories failed", zap.Error(err))
+		return true, nil, err
+	}
+	return true, ranges, nil
+}
+
+// UpdateName updates the category display name and slug (nested-set lft/rgt/depth unchanged); the old
+// slug is kept in the slug history.
 func (r *CategoryRepository) UpdateName(ctx context.Context, id uint, name string, actorUserID uint) (*model.CategoryModel, error) {
 	var c model.CategoryModel
 	if err := r.db.WithContext(ctx).First(&c, id).Error; err != nil {
@@ -343,6 +380,9 @@ func (r *CategoryRepository) UpdateName(ctx context.Context, id uint, name strin
 		r.log.Error("update category name failed", zap.Error(err))
 		return nil, err
 	}
+	if err := r.slugHistory().record(ctx, id, c.Slug, newSlug); err != nil {
+		return nil, err
+	}
 	return r.GetByID(ctx, id)
 }
 
@@ -383,10 +423,11 @@ func (r *CategoryRepository) DeleteSubtree(ctx context.Context, id uint, deleted
 		}).Error; err != nil {
 			return err
 		}
-		if err := tx.Exec("UPDATE categories SET rgt = rgt - ? WHERE deleted_at IS NULL AND rgt > ?", width, anchor.Rgt).Error; err != nil {
+		siteID := tenant.SiteID(ctx)
+		if err := tx.Exec("UPDATE categories SET rgt = rgt - ? WHERE site_id = ? AND deleted_at IS NULL AND rgt > ?", width, siteID, anchor.Rgt).Error; err != nil {
 			return err
 		}
-		if err := tx.Exec("UPDATE categories SET lft = lft - ? WHERE deleted_at IS NULL AND lft > ?", width, anchor.Rgt).Error; err != nil {
+		if err := tx.Exec("UPDATE categories SET lft = lft - ? WHERE site_id = ? AND deleted_at IS NULL AND lft > ?", width, siteID, anchor.Rgt).Error; err != nil {
 			return err
 		}
 		return nil
@@ -76,7 +76,7 @@ func TestCategoryRepository_List_Pagination(t *testing.T) {
 	require.NoError(t, err)
 
 	page, err := repo.List(ctx, request.CategoryListRequest{
-		PageRequest: request.PageRequest{Page: 1, Limit: 1},
+		CursorRequest: request.CursorRequest{Limit: 1},
 	})
 	require.NoError(t, err)
 	items := page.Items.([]model.CategoryModel)
@@ -85,12 +85,22 @@ func TestCategoryRepository_List_Pagination(t *testing.T) {
 	assert.Nil(t, page.PrevCursor)
 
 	page2, err := repo.List(ctx, request.CategoryListRequest{
-		PageRequest: request.PageRequest{Page: 2, Limit: 1},
+		CursorRequest: request.CursorRequest{Limit: 1, After: *page.NextCursor},
 	})
 	require.NoError(t, err)
 	items2 := page2.Items.([]model.CategoryModel)
 	require.Len(t, items2, 1)
 	assert.NotEqual(t, items[0].ID, items2[0].ID)
+	assert.Nil(t, page2.NextCursor)
+	require.NotNil(t, page2.PrevCursor)
+
+	back, err := repo.List(ctx, request.CategoryListRequest{
+		CursorRequest: request.CursorRequest{Limit: 1, Before: *page2.PrevCursor},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, items[0].ID, back.Items.([]model.CategoryModel)[0].ID)
+	assert.Nil(t, back.PrevCursor)
+	assert.NotNil(t, back.NextCursor)
 }
 
 func TestCategoryRepository_GetTree_GetSubtree_FindByIDs(t *testing.T) {
@@ -128,7 +138,7 @@ func TestCategoryRepository_FindByIDs_Empty(t *testing.T) {
 }
 
 func TestCategoryRepository_UpdateName(t *testing.T) {
-	db := openTestDB(t, &model.CategoryModel{})
+	db := openTestDB(t, &model.CategoryModel{}, &model.SlugHistory{})
 	repo := NewCategoryRepository(db, zap.NewNop())
 	ctx := context.Background()
 	r, err := repo.CreateRoot(ctx, "A", testActor)
@@ -138,6 +148,10 @@ func TestCategoryRepository_UpdateName(t *testing.T) {
 	assert.Equal(t, "Alpha", up.Name)
 	assert.Equal(t, "alpha", up.Slug)
 	assert.Equal(t, testActor, up.UpdatedBy)
+
+	id, err := repo.ResolveOldSlug(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, r.ID, id)
 }
 
 func TestCategoryRepository_DeleteSubtree_EmptyTree(t *testing.T) {
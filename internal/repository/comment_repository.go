@@ -268,6 +268,16 @@ func (r *CommentRepository) DeleteSubtree(ctx context.Context, postID uint, comm
 	return nil
 }
 
+var commentKeyset = keyset[model.Comment]{
+	idColumn: "id",
+	id:       func(c *model.Comment) uint { return c.ID },
+	orders: []keysetOrder[model.Comment]{
+		{name: "tree", column: "lft", kind: keyInt, key: func(c *model.Comment) any { return c.Lft }},
+		{name: "newest", desc: true},
+		{name: "oldest"},
+	},
+}
+
 func (r *CommentRepository) List(ctx context.Context, req request.CommentListRequest) (CursorPage, error) {
 	limit := req.Limit
 	if limit <= 0 {
@@ -276,65 +286,32 @@ func (r *CommentRepository) List(ctx context.Context, req request.CommentListReq
 	if limit > 200 {
 		limit = 200
 	}
-	page := req.Page
-	if page <= 0 {
-		page = 1
-	}
-
-	offset := (page - 1) * limit
-
-	countQ := r.db.WithContext(ctx).
-		Model(&model.Comment{}).
-		Where("post_id = ?", req.PostID)
-	if s := strings.TrimSpace(req.Search); s != "" {
-		countQ = countQ.Where("content LIKE ?", "%"+s+"%")
-	}
 
-	var totalRows int64
-	if err := countQ.Count(&totalRows).Error; err != nil {
-		r.log.Error("failed to count comments", zap.Error(err))
-		return CursorPage{}, err
+	build := func() *gorm.DB {
+		q := r.db.WithContext(ctx).
+			Model(&model.Comment{}).
+			Where("post_id = ?", req.PostID)
+		if s := strings.TrimSpace(req.Search); s != "" {
+			q = q.Where("content LIKE ?", "%"+s+"%")
+		}
+		return q
 	}
-
-	q := r.db.WithContext(ctx).
-		Model(&model.Comment{}).
-		Preload("Tags").
-		Preload("Media").
-		Where("post_id = ?", req.PostID)
-	if s := strings.TrimSpace(req.Search); s != "" {
-		q = q.Where("content LIKE ?", "%"+s+"%")
+	preload := func(q *gorm.DB) *gorm.DB {
+		return q.Preload("Tags").Preload("Media")
 	}
 
-	var rows []model.Comment
-	if err := q.Order("lft asc").Limit(limit).Offset(offset).Find(&rows).Error; err != nil {
+	_, page, err := commentKeyset.page(build, preload, req.Sort, req.CursorRequest, limit)
+	if err != nil && !errors.Is(err, ErrInvalidCursor) {
 		r.log.Error("failed to list comments", zap.Error(err))
-		return CursorPage{}, err
-	}
-
-	if len(rows) == 0 {
-		return CursorPage{Items: []model.Comment{}, NextCursor: nil, PrevCursor: nil}, nil
-	}
-
-	var nextCursor *uint
-	if int64(offset)+int64(limit) < totalRows {
-		tmp := rows[len(rows)-1].ID
-		nextCursor = &tmp
-	}
-
-	var prevCursor *uint
-	if offset > 0 {
-		tmp := rows[0].ID
-		prevCursor = &tmp
 	}
-
-	return CursorPage{Items: rows, NextCursor: nextCursor, PrevCursor: prevCursor}, nil
+	return page, err
 }
 
 // ListByPostID is a backward-compatible helper for older tests/callers.
 func (r *CommentRepository) ListByPostID(ctx context.Context, postID uint, limit int) ([]model.Comment, error) {
 	page, err := r.List(ctx, request.CommentListRequest{
-		PostID:      postID,
-		PageRequest: request.PageRequest{Page: 1, Limit: limit},
+		PostID:        postID,
+		CursorRequest: request.CursorRequest{Limit: limit},
 	})
 	if err != nil {
 		return nil, err
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/model"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// importTargets is the model each ImportRef kind points at.
+var importTargets = map[string]any{
+	model.ImportKindUser:     &model.User{},
+	model.ImportKindCategory: &model.CategoryModel{},
+	model.ImportKindTag:      &model.Tag{},
+	model.ImportKindPost:     &model.Post{},
+	model.ImportKindComment:  &model.Comment{},
+	model.ImportKindMedia:    &model.Media{},
+}
+
+type ImportRepository struct {
+	db  *gorm.DB
+	log *zap.Logger
+}
+
+func NewImportRepository(db *gorm.DB, log *zap.Logger) *ImportRepository {
+	return &ImportRepository{db: db, log: log}
+}
+
+func importTarget(kind string) (any, error) {
+	m, ok := importTargets[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown import kind %q", kind)
+	}
+	return m, nil
+}
+
+// Find returns the row an imported item became. Rows deleted since still count (the item is not
+// imported again); a ref whose row was purged is dropped and reported as missing.
+func (r *ImportRepository) Find(ctx context.Context, source, kind, externalID string) (uint, bool, error) {
+	target, err := importTarget(kind)
+	if err != nil {
+		return 0, false, err
+	}
+	var ref model.ImportRef
+	err = r.db.WithContext(ctx).
+		Where("source = ? AND kind = ? AND external_id = ?", source, kind, externalID).
+		First(&ref).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, false, nil
+	}
+	if err != nil {
+		r.log.Error("failed to find import ref", zap.Error(err))
+		return 0, false, err
+	}
+	var n int64
+	if err := r.db.WithContext(ctx).Unscoped().Model(target).Where("id = ?", ref.LocalID).Count(&n).Error; err != nil {
+		r.log.Error("failed to check imported row", zap.Error(err))
+		return 0, false, err
+	}
+	if n == 0 {
+		if err := r.db.WithContext(ctx).Delete(&model.ImportRef{}, ref.ID).Error; err != nil {
+			r.log.Error("failed to drop stale import ref", zap.Error(err))
+			return 0, false, err
+		}
+		return 0, false, nil
+	}
+	return ref.LocalID, true, nil
+}
+
+// Save records that the item externalID of source became row localID.
+func (r *ImportRepository) Save(ctx context.Context, source, kind, externalID string, localID uint) error {
+	if _, err := importTarget(kind); err != nil {
+		return err
+	}
+	ref := model.ImportRef{Source: source, Kind: kind, ExternalID: externalID, LocalID: localID}
+	if err := r.db.WithContext(ctx).Create(&ref).Error; err != nil {
+		r.log.Error("failed to save import ref", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// Backdate sets the created_at and updated_at of an imported row to the source's timestamps.
+func (r *ImportRepository) Backdate(ctx context.Context, kind string, id uint, createdAt, updatedAt time.Time) error {
+	target, err := importTarget(kind)
+	if err != nil {
+		return err
+	}
+	err = r.db.WithContext(ctx).Model(target).Where("id = ?", id).
+		UpdateColumns(map[string]any{"created_at": createdAt, "updated_at": updatedAt}).Error
+	if err != nil {
+		r.log.Error("failed to backdate imported row", zap.Error(err))
+		return err
+	}
+	return nil
+}
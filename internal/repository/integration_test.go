@@ -88,5 +88,5 @@ var _ = []any{
 	&model.User{}, &model.Role{}, &model.Permission{}, &model.UserRole{}, &model.RolePermission{},
 	&model.AuthSession{}, &model.RefreshToken{}, &model.RevokedJTI{}, &model.ImpersonationAudit{},
 	&model.UserTwoFactor{}, &model.TwoFactorChallenge{},
-	&model.CategoryModel{}, &model.Tag{}, &model.Post{}, &model.PostSEO{}, &model.PostMedia{}, &model.PostTag{}, &model.Comment{}, &model.Media{},
+	&model.CategoryModel{}, &model.Tag{}, &model.Post{}, &model.PostSEO{}, &model.PostMedia{}, &model.PostTag{}, &model.PostContributor{}, &model.Comment{}, &model.Media{},
 }
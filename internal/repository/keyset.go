@@ -0,0 +1,238 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/handler/request"
+
+	"gorm.io/gorm"
+)
+
+// ErrInvalidCursor is returned for cursors that do not decode or belong to another sort order.
+var ErrInvalidCursor = errors.New("invalid pagination cursor")
+
+// CursorPage is one keyset page. NextCursor/PrevCursor are nil at either end of the list; Total is
+// set only when the request asked for it.
+type CursorPage struct {
+	Items      any
+	NextCursor *string
+	PrevCursor *string
+	Total      *int64
+}
+
+type keyKind uint8
+
+const (
+	keyInt keyKind = iota
+	keyString
+	keyTime
+)
+
+// keysetOrder is a sort order a list can be paged by: column (empty for id only, which is also
+// creation order), then the primary key as tie-breaker, both in the same direction.
+type keysetOrder[T any] struct {
+	name   string
+	column string
+	kind   keyKind
+	desc   bool
+	key    func(*T) any
+}
+
+// keyset pages rows of T by one of its orders; the first order is the default.
+type keyset[T any] struct {
+	idColumn string
+	id       func(*T) uint
+	orders   []keysetOrder[T]
+}
+
+// cursor is the decoded form of an opaque cursor: order name, sort key and id of a boundary row.
+type cursor struct {
+	Order string          `json:"o"`
+	Key   json.RawMessage `json:"k,omitempty"`
+	ID    uint            `json:"i"`
+}
+
+func (k keyset[T]) order(name string) (keysetOrder[T], bool) {
+	if name == "" {
+		return k.orders[0], true
+	}
+	i := slices.IndexFunc(k.orders, func(o keysetOrder[T]) bool { return o.name == name })
+	if i < 0 {
+		return keysetOrder[T]{}, false
+	}
+	return k.orders[i], true
+}
+
+func (k keyset[T]) encode(o keysetOrder[T], row *T) (*string, error) {
+	c := cursor{Order: o.name, ID: k.id(row)}
+	if o.column != "" {
+		raw, err := json.Marshal(o.key(row))
+		if err != nil {
+			return nil, err
+		}
+		c.Key = raw
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	s := base64.RawURLEncoding.EncodeToString(b)
+	return &s, nil
+}
+
+func (k keyset[T]) decode(o keysetOrder[T], s string) (key any, id uint, err error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, 0, ErrInvalidCursor
+	}
+	var c cursor
+	if err := json.Unmarshal(b, &c); err != nil || c.Order != o.name || c.ID == 0 {
+		return nil, 0, ErrInvalidCursor
+	}
+	if o.column == "" {
+		return nil, c.ID, nil
+	}
+	switch o.kind {
+	case keyInt:
+		var v int64
+		err = json.Unmarshal(c.Key, &v)
+		key = v
+	case keyString:
+		var v string
+		err = json.Unmarshal(c.Key, &v)
+		key = v
+	case keyTime:
+		var v time.Time
+		err = json.Unmarshal(c.Key, &v)
+		key = v
+	}
+	if err != nil {
+		return nil, 0, ErrInvalidCursor
+	}
+	return key, c.ID, nil
+}
+
+// seek restricts q to rows strictly after (forward) or before the boundary row in order o.
+func (k keyset[T]) seek(q *gorm.DB, o keysetOrder[T], key any, id uint, forward bool) *gorm.DB {
+	op := ">"
+	if o.desc == forward {
+		op = "<"
+	}
+	if o.column == "" {
+		return q.Where(fmt.Sprintf("%s %s ?", k.idColumn, op), id)
+	}
+	return q.Where(fmt.Sprintf("(%s %s ? OR (%s = ? AND %s %s ?))", o.column, op, o.column, k.idColumn, op), key, key, id)
+}
+
+// sort orders q by o, or by its reverse.
+func (k keyset[T]) sort(q *gorm.DB, o keysetOrder[T], reverse bool) *gorm.DB {
+	dir := "ASC"
+	if o.desc != reverse {
+		dir = "DESC"
+	}
+	if o.column != "" {
+		q = q.Order(o.column + " " + dir)
+	}
+	return q.Order(k.idColumn + " " + dir)
+}
+
+// page loads one page. build must return a fresh, filtered query each call (it also backs the
+// existence probe and the optional count); preload, when set, adds what only the page rows need.
+func (k keyset[T]) page(build func() *gorm.DB, preload func(*gorm.DB) *gorm.DB, sortName string, req request.CursorRequest, limit int) ([]T, CursorPage, error) {
+	o, ok := k.order(sortName)
+	if !ok {
+		return nil, CursorPage{}, ErrInvalidCursor
+	}
+	backward := req.Before != ""
+	token := req.After
+	if backward {
+		token = req.Before
+	}
+
+	q := build()
+	var (
+		key any
+		id  uint
+	)
+	if token != "" {
+		var err error
+		if key, id, err = k.decode(o, token); err != nil {
+			return nil, CursorPage{}, err
+		}
+		q = k.seek(q, o, key, id, !backward)
+	}
+	if preload != nil {
+		q = preload(q)
+	}
+	var rows []T
+	if err := k.sort(q, o, backward).Limit(limit + 1).Find(&rows).Error; err != nil {
+		return nil, CursorPage{}, err
+	}
+	more := len(rows) > limit
+	if more {
+		rows = rows[:limit]
+	}
+	if backward {
+		slices.Reverse(rows)
+	}
+
+	var page CursorPage
+	if len(rows) > 0 {
+		first, last := &rows[0], &rows[len(rows)-1]
+		// Past the requested side, "more" answers whether another page exists. On the side we came
+		// from, probe for a row beyond the page edge: the cursor row may since have been deleted.
+		hasNext, hasPrev := more, more
+		var err error
+		switch {
+		case token == "":
+			hasPrev = false // the first page
+		case backward:
+			hasNext, err = k.exists(build, o, last, true)
+		default:
+			hasPrev, err = k.exists(build, o, first, false)
+		}
+		if err != nil {
+			return nil, CursorPage{}, err
+		}
+		if hasNext {
+			if page.NextCursor, err = k.encode(o, last); err != nil {
+				return nil, CursorPage{}, err
+			}
+		}
+		if hasPrev {
+			if page.PrevCursor, err = k.encode(o, first); err != nil {
+				return nil, CursorPage{}, err
+			}
+		}
+	}
+	if req.WithTotal {
+		var total int64
+		if err := build().Count(&total).Error; err != nil {
+			return nil, CursorPage{}, err
+		}
+		page.Total = &total
+	}
+	if rows == nil {
+		rows = []T{}
+	}
+	page.Items = rows
+	return rows, page, nil
+}
+
+// exists reports whether a row lies beyond edge in the given direction.
+func (k keyset[T]) exists(build func() *gorm.DB, o keysetOrder[T], edge *T, forward bool) (bool, error) {
+	var key any
+	if o.column != "" {
+		key = o.key(edge)
+	}
+	var ids []uint
+	if err := k.seek(build(), o, key, k.id(edge), forward).Limit(1).Pluck(k.idColumn, &ids).Error; err != nil {
+		return false, err
+	}
+	return len(ids) > 0, nil
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/turahe/go-restfull/internal/handler/request"
+	"github.com/turahe/go-restfull/internal/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func tagNames(t *testing.T, page CursorPage) []string {
+	t.Helper()
+	items, ok := page.Items.([]model.Tag)
+	require.True(t, ok)
+	names := make([]string, len(items))
+	for i, tag := range items {
+		names[i] = tag.Name
+	}
+	return names
+}
+
+func TestKeyset_WalksBothWaysPerSort(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	db := openTestDB(t, &model.Tag{})
+	repo := NewTagRepository(db, zap.NewNop())
+	// Insertion order differs from name order; "bb" twice checks the id tie-breaker.
+	for i, name := range []string{"dd", "bb", "aa", "ee", "bb", "cc"} {
+		require.NoError(t, repo.Create(ctx, &model.Tag{Name: name, Slug: fmt.Sprintf("t%d", i)}))
+	}
+
+	list := func(sort string, cur request.CursorRequest) CursorPage {
+		cur.Limit = 4
+		page, err := repo.List(ctx, request.TagListRequest{CursorRequest: cur, Sort: sort})
+		require.NoError(t, err)
+		return page
+	}
+
+	first := list("name", request.CursorRequest{WithTotal: true})
+	assert.Equal(t, []string{"aa", "bb", "bb", "cc"}, tagNames(t, first))
+	require.NotNil(t, first.Total)
+	assert.Equal(t, int64(6), *first.Total)
+	assert.Nil(t, first.PrevCursor)
+	require.NotNil(t, first.NextCursor)
+
+	second := list("name", request.CursorRequest{After: *first.NextCursor})
+	assert.Equal(t, []string{"dd", "ee"}, tagNames(t, second))
+	assert.Nil(t, second.NextCursor)
+	assert.Nil(t, second.Total, "total is only counted on request")
+	require.NotNil(t, second.PrevCursor)
+
+	back := list("name", request.CursorRequest{Before: *second.PrevCursor})
+	assert.Equal(t, tagNames(t, first), tagNames(t, back))
+	assert.Nil(t, back.PrevCursor)
+	assert.NotNil(t, back.NextCursor)
+
+	newest := list("newest", request.CursorRequest{})
+	assert.Equal(t, []string{"cc", "bb", "ee", "aa"}, tagNames(t, newest))
+	older := list("newest", request.CursorRequest{After: *newest.NextCursor})
+	assert.Equal(t, []string{"bb", "dd"}, tagNames(t, older))
+
+	for name, cur := range map[string]request.CursorRequest{
+		"garbage":    {After: "not-a-cursor"},
+		"other sort": {After: *newest.NextCursor},
+	} {
+		_, err := repo.List(ctx, request.TagListRequest{CursorRequest: cur, Sort: "name"})
+		assert.ErrorIs(t, err, ErrInvalidCursor, name)
+	}
+}
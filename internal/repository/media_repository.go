@@ -9,28 +9,29 @@ import (
 
 	"github.com/turahe/go-restfull/internal/handler/request"
 	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/internal/tenant"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
-func mediaUserLockName(userID uint) string {
-	return fmt.Sprintf("go_restfull_media_user_%d", userID)
+func mediaUserLockName(siteID, userID uint) string {
+	return fmt.Sprintf("go_restfull_media_site_%d_user_%d", siteID, userID)
 }
 
 func lockMediaUser(tx *gorm.DB, userID uint) error {
 	if tx.Dialector.Name() != "mysql" {
 		return nil
 	}
-	return tx.Exec("SELECT GET_LOCK(?, -1)", mediaUserLockName(userID)).Error
+	return tx.Exec("SELECT GET_LOCK(?, -1)", mediaUserLockName(tenant.SiteID(tx.Statement.Context), userID)).Error
 }
 
 func unlockMediaUser(tx *gorm.DB, userID uint) {
 	if tx.Dialector.Name() != "mysql" {
 		return
 	}
-	_ = tx.Exec("SELECT RELEASE_LOCK(?)", mediaUserLockName(userID)).Error
+	_ = tx.Exec("SELECT RELEASE_LOCK(?)", mediaUserLockName(tenant.SiteID(tx.Statement.Context), userID)).Error
 }
 
 type MediaRepository struct {
@@ -133,10 +134,10 @@ func (r *MediaRepository) CreateFolderChild(ctx context.Context, userID uint, pa
 		}
 
 		parentRgt := parent.Rgt
-		if err := tx.Exec("UPDATE media SET rgt = rgt + 2 WHERE user_id = ? AND deleted_at IS NULL AND rgt >= ?", userID, parentRgt).Error; err != nil {
+		if err := tx.Exec("UPDATE media SET rgt = rgt + 2 WHERE site_id = ? AND user_id = ? AND deleted_at IS NULL AND rgt >= ?", tenant.SiteID(ctx), userID, parentRgt).Error; err != nil {
 			return err
 		}
-		if err := tx.Exec("UPDATE media SET lft = lft + 2 WHERE user_id = ? AND deleted_at IS NULL AND lft > ?", userID, parentRgt).Error; err != nil {
+		if err := tx.Exec("UPDATE media SET lft = lft + 2 WHERE site_id = ? AND user_id = ? AND deleted_at IS NULL AND lft > ?", tenant.SiteID(ctx), userID, parentRgt).Error; err != nil {
 			return err
 		}
 
@@ -245,10 +246,10 @@ func (r *MediaRepository) CreateFileChild(ctx context.Context, userID uint, pare
 		}
 
 		parentRgt := parent.Rgt
-		if err := tx.Exec("UPDATE media SET rgt = rgt + 2 WHERE user_id = ? AND deleted_at IS NULL AND rgt >= ?", userID, parentRgt).Error; err != nil {
+		if err := tx.Exec("UPDATE media SET rgt = rgt + 2 WHERE site_id = ? AND user_id = ? AND deleted_at IS NULL AND rgt >= ?", tenant.SiteID(ctx), userID, parentRgt).Error; err != nil {
 			return err
 		}
-		if err := tx.Exec("UPDATE media SET lft = lft + 2 WHERE user_id = ? AND deleted_at IS NULL AND lft > ?", userID, parentRgt).Error; err != nil {
+		if err := tx.Exec("UPDATE media SET lft = lft + 2 WHERE site_id = ? AND user_id = ? AND deleted_at IS NULL AND lft > ?", tenant.SiteID(ctx), userID, parentRgt).Error; err != nil {
 			return err
 		}
 
@@ -346,6 +347,16 @@ func (r *MediaRepository) FindByIDAndUserID(ctx context.Context, id uint, userID
 	return &m, nil
 }
 
+var mediaKeyset = keyset[model.Media]{
+	idColumn: "id",
+	id:       func(m *model.Media) uint { return m.ID },
+	orders: []keysetOrder[model.Media]{
+		{name: "tree", column: "lft", kind: keyInt, key: func(m *model.Media) any { return m.Lft }},
+		{name: "newest", desc: true},
+		{name: "name", column: "name", kind: keyString, key: func(m *model.Media) any { return m.Name }},
+	},
+}
+
 func (r *MediaRepository) List(ctx context.Context, userID uint, req request.MediaListRequest) (CursorPage, error) {
 	limit := req.Limit
 	if limit <= 0 {
@@ -355,71 +366,30 @@ func (r *MediaRepository) List(ctx context.Context, userID uint, req request.Med
 		limit = 200
 	}
 
-	page := req.Page
-	if page <= 0 {
-		page = 1
-	}
-	offset := (page - 1) * limit
-
-	countQ := r.db.WithContext(ctx).Model(&model.Media{}).Where("user_id = ?", userID)
-	if req.Name != "" {
-		n := "%" + req.Name + "%"
-		countQ = countQ.Where("(name LIKE ? OR original_name LIKE ?)", n, n)
-	}
-	if s := strings.TrimSpace(req.Search); s != "" {
-		n := "%" + s + "%"
-		countQ = countQ.Where("(name LIKE ? OR original_name LIKE ?)", n, n)
-	}
-
-	var totalRows int64
-	if err := countQ.Count(&totalRows).Error; err != nil {
-		r.log.Error("failed to count media", zap.Error(err))
-		return CursorPage{}, err
-	}
-
-	dataQ := r.db.WithContext(ctx).Model(&model.Media{}).Where("user_id = ?", userID)
-	if req.Name != "" {
-		n := "%" + req.Name + "%"
-		dataQ = dataQ.Where("(name LIKE ? OR original_name LIKE ?)", n, n)
-	}
-	if s := strings.TrimSpace(req.Search); s != "" {
-		n := "%" + s + "%"
-		dataQ = dataQ.Where("(name LIKE ? OR original_name LIKE ?)", n, n)
+	build := func() *gorm.DB {
+		q := r.db.WithContext(ctx).Model(&model.Media{}).Where("user_id = ?", userID)
+		if req.Name != "" {
+			n := "%" + req.Name + "%"
+			q = q.Where("(name LIKE ? OR original_name LIKE ?)", n, n)
+		}
+		if s := strings.TrimSpace(req.Search); s != "" {
+			n := "%" + s + "%"
+			q = q.Where("(name LIKE ? OR original_name LIKE ?)", n, n)
+		}
+		return q
 	}
 
-	var rows []model.Media
-	if err := dataQ.
-		Order("lft asc").
-		Limit(limit).
-		Offset(offset).
-		Find(&rows).Error; err != nil {
+	_, page, err := mediaKeyset.page(build, nil, req.Sort, req.CursorRequest, limit)
+	if err != nil && !errors.Is(err, ErrInvalidCursor) {
 		r.log.Error("failed to list media", zap.Error(err))
-		return CursorPage{}, err
-	}
-
-	if len(rows) == 0 {
-		return CursorPage{Items: []model.Media{}, NextCursor: nil, PrevCursor: nil}, nil
-	}
-
-	var nextCursor *uint
-	if int64(offset)+int64(limit) < totalRows {
-		tmp := rows[len(rows)-1].ID
-		nextCursor = &tmp
-	}
-
-	var prevCursor *uint
-	if offset > 0 {
-		tmp := rows[0].ID
-		prevCursor = &tmp
 	}
-
-	return CursorPage{Items: rows, NextCursor: nextCursor, PrevCursor: prevCursor}, nil
+	return page, err
 }
 
 // ListByUserID keeps older callers/tests working.
 func (r *MediaRepository) ListByUserID(ctx context.Context, userID uint, limit int) ([]model.Media, error) {
 	page, err := r.List(ctx, userID, request.MediaListRequest{
-		PageRequest: request.PageRequest{Page: 1, Limit: limit},
+		CursorRequest: request.CursorRequest{Limit: limit},
 	})
 	if err != nil {
 		return nil, err
@@ -490,10 +460,10 @@ func (r *MediaRepository) DeleteSubtree(ctx context.Context, userID uint, mediaI
 		}).Error; err != nil {
 			return err
 		}
-		if err := tx.Exec("UPDATE media SET rgt = rgt - ? WHERE user_id = ? AND deleted_at IS NULL AND rgt > ?", width, userID, anchor.Rgt).Error; err != nil {
+		if err := tx.Exec("UPDATE media SET rgt = rgt - ? WHERE site_id = ? AND user_id = ? AND deleted_at IS NULL AND rgt > ?", width, tenant.SiteID(ctx), userID, anchor.Rgt).Error; err != nil {
 			return err
 		}
-		if err := tx.Exec("UPDATE media SET lft = lft - ? WHERE user_id = ? AND deleted_at IS NULL AND lft > ?", width, userID, anchor.Rgt).Error; err != nil {
+		if err := tx.Exec("UPDATE media SET lft = lft - ? WHERE site_id = ? AND user_id = ? AND deleted_at IS NULL AND lft > ?", width, tenant.SiteID(ctx), userID, anchor.Rgt).Error; err != nil {
 			return err
 		}
 		return nil
@@ -571,8 +541,9 @@ func (r *MediaRepository) UserAvatar(ctx context.Context, user *model.User) (*st
 		return nil, errors.New("invalid user id")
 	}
 
+	// Users are shared across sites, so their avatar is too.
 	var avatar model.Media
-	err := r.db.WithContext(ctx).
+	err := r.db.WithContext(tenant.WithAllSites(ctx)).
 		Model(&model.Media{}).
 		Joins("INNER JOIN user_media ON media.id = user_media.media_id").
 		Where("user_media.user_id = ? AND user_media.type = ? AND media.media_type <> ?", user.ID, "avatar", "folder").
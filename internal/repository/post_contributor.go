@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/turahe/go-restfull/internal/model"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// preloadContributors loads a post's contributors in display order, with their users.
+func preloadContributors(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Contributors", func(db *gorm.DB) *gorm.DB { return db.Order("post_contributors.position asc") }).
+		Preload("Contributors.User")
+}
+
+// ReplaceContributors swaps the contributor list of a post for cs, whose Position fields give the order.
+func (r *PostRepository) ReplaceContributors(ctx context.Context, postID uint, cs []model.PostContributor) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("post_id = ?", postID).Delete(&model.PostContributor{}).Error; err != nil {
+			return err
+		}
+		if len(cs) == 0 {
+			return nil
+		}
+		rows := make([]model.PostContributor, len(cs))
+		for i, c := range cs {
+			rows[i] = model.PostContributor{PostID: postID, UserID: c.UserID, Role: c.Role, Position: c.Position}
+		}
+		return tx.Create(&rows).Error
+	})
+	if err != nil {
+		r.log.Error("failed to replace post contributors", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// ListContributors returns the contributors of a post in display order, with their users.
+func (r *PostRepository) ListContributors(ctx context.Context, postID uint) ([]model.PostContributor, error) {
+	var rows []model.PostContributor
+	err := r.db.WithContext(ctx).
+		Preload("User").
+		Where("post_id = ?", postID).
+		Order("position asc").
+		Find(&rows).Error
+	if err != nil {
+		r.log.Error("failed to list post contributors", zap.Error(err))
+		return nil, err
+	}
+	return rows, nil
+}
+
+// IsAuthor reports whether userID is listed as an author of the post.
+func (r *PostRepository) IsAuthor(ctx context.Context, postID, userID uint) (bool, error) {
+	var n int64
+	err := r.db.WithContext(ctx).
+		Model(&model.PostContributor{}).
+		Where("post_id = ? AND user_id = ? AND role = ?", postID, userID, model.ContributorAuthor).
+		Count(&n).Error
+	if err != nil {
+		r.log.Error("failed to check post author", zap.Error(err))
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// CountUsers returns how many of ids belong to existing users.
+func (r *PostRepository) CountUsers(ctx context.Context, ids []uint) (int64, error) {
+	var n int64
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	err := r.db.WithContext(ctx).Model(&model.User{}).Where("id IN ?", ids).Count(&n).Error
+	if err != nil {
+		r.log.Error("failed to count users", zap.Error(err))
+		return 0, err
+	}
+	return n, nil
+}
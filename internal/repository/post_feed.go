@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/model"
+
+	"go.uber.org/zap"
+)
+
+// FeedFilter narrows a feed to posts in any of CategoryIDs, tagged TagID or authored by AuthorID
+// (zero values do not filter). Locale and DefaultLocale work as in PostListRequest.
+type FeedFilter struct {
+	CategoryIDs   []uint
+	TagID         uint
+	AuthorID      uint
+	Locale        string
+	DefaultLocale string
+}
+
+// ListFeed returns up to limit posts live at now matching f, most recently published first, with SEO,
+// category, tags and contributors loaded.
+func (r *PostRepository) ListFeed(ctx context.Context, f FeedFilter, now time.Time, limit int) ([]model.Post, error) {
+	db := livePosts(r.db.WithContext(ctx).Model(&model.Post{}), now)
+	if len(f.CategoryIDs) > 0 {
+		db = db.Where("posts.category_id IN ?", f.CategoryIDs)
+	}
+	if f.TagID != 0 {
+		db = db.Where("EXISTS (SELECT 1 FROM post_tags pt WHERE pt.post_id = posts.id AND pt.tag_id = ?)", f.TagID)
+	}
+	if f.AuthorID != 0 {
+		db = db.Where("EXISTS (SELECT 1 FROM post_contributors pc WHERE pc.post_id = posts.id AND pc.user_id = ? AND pc.role = ?)",
+			f.AuthorID, model.ContributorAuthor)
+	}
+	if f.Locale != "" {
+		db = localePosts(db, f.Locale, f.DefaultLocale, &now)
+	}
+	var rows []model.Post
+	err := db.
+		Preload("PostSEO").
+		Preload("Category").
+		Preload("Tags").
+		Scopes(preloadContributors).
+		Order("COALESCE(posts.publish_at, posts.created_at) desc").
+		Order("posts.id desc").
+		Limit(limit).
+		Find(&rows).Error
+	if err != nil {
+		r.log.Error("failed to list feed posts", zap.Error(err))
+		return nil, err
+	}
+	return rows, nil
+}
+
+// FindUser returns a user by id (feeds and bylines only need the name).
+func (r *PostRepository) FindUser(ctx context.Context, id uint) (*model.User, error) {
+	var u model.User
+	if err := r.db.WithContext(ctx).First(&u, id).Error; err != nil {
+		r.log.Error("failed to find user", zap.Error(err))
+		return nil, err
+	}
+	return &u, nil
+}
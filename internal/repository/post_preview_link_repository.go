@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/model"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type PostPreviewLinkRepository struct {
+	db  *gorm.DB
+	log *zap.Logger
+}
+
+func NewPostPreviewLinkRepository(db *gorm.DB, log *zap.Logger) *PostPreviewLinkRepository {
+	return &PostPreviewLinkRepository{db: db, log: log}
+}
+
+func (r *PostPreviewLinkRepository) Create(ctx context.Context, l *model.PostPreviewLink) error {
+	err := r.db.WithContext(ctx).Create(l).Error
+	if err != nil {
+		r.log.Error("failed to create post preview link", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// ListByPost returns a post's preview links, newest first, including expired and revoked ones.
+func (r *PostPreviewLinkRepository) ListByPost(ctx context.Context, postID uint) ([]model.PostPreviewLink, error) {
+	var rows []model.PostPreviewLink
+	err := r.db.WithContext(ctx).Where("post_id = ?", postID).Order("id desc").Find(&rows).Error
+	if err != nil {
+		r.log.Error("failed to list post preview links", zap.Error(err))
+		return nil, err
+	}
+	return rows, nil
+}
+
+// FindActiveByHash loads the link with this token hash if it is neither revoked nor expired at now.
+func (r *PostPreviewLinkRepository) FindActiveByHash(ctx context.Context, hash string, now time.Time) (*model.PostPreviewLink, error) {
+	var l model.PostPreviewLink
+	err := r.db.WithContext(ctx).
+		Where("token_hash = ? AND revoked_at IS NULL AND expires_at > ?", hash, now).
+		First(&l).Error
+	if err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// Revoke marks a post's link revoked. It reports false when no such unrevoked link exists.
+func (r *PostPreviewLinkRepository) Revoke(ctx context.Context, postID, id uint, at time.Time) (bool, error) {
+	res := r.db.WithContext(ctx).
+		Model(&model.PostPreviewLink{}).
+		Where("id = ? AND post_id = ? AND revoked_at IS NULL", id, postID).
+		Update("revoked_at", at)
+	if res.Error != nil {
+		r.log.Error("failed to revoke post preview link", zap.Error(res.Error))
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
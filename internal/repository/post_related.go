@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/internal/tenant"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	// RelatedPostsMax is how many related posts are ranked and cached per post.
+	RelatedPostsMax = 20
+	// relatedCandidates bounds the tag/category matches and the recent posts considered for ranking.
+	relatedCandidates = 200
+	relatedRecent     = 50
+	relatedCacheTTL   = 10 * time.Minute
+	relatedCacheSize  = 10000
+)
+
+// RelatedWeights tunes related-post ranking. A candidate scores
+//
+//	Tags*sharedTags + Category*(1 same category, 0.5 ancestor category) + Recency*0.5^(ageDays/HalfLifeDays)
+type RelatedWeights struct {
+	Tags         float64 `json:"tags"`
+	Category     float64 `json:"category"`
+	Recency      float64 `json:"recency"`
+	HalfLifeDays float64 `json:"halfLifeDays"`
+}
+
+// ScoredPost is a post id ranked by RelatedPostIDs.
+type ScoredPost struct {
+	ID    uint
+	Score float64
+}
+
+type relatedEntry struct {
+	ids     []ScoredPost
+	expires time.Time
+}
+
+// relatedCache keeps ranked related posts per site, post and weights. It is per process: entries
+// expire after relatedCacheTTL and are dropped whenever a post's tags are replaced.
+type relatedCache struct {
+	mu      sync.Mutex
+	entries map[string]relatedEntry
+}
+
+func newRelatedCache() *relatedCache {
+	return &relatedCache{entries: map[string]relatedEntry{}}
+}
+
+func (c *relatedCache) get(key string, now time.Time) ([]ScoredPost, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok || now.After(e.expires) {
+		return nil, false
+	}
+	return e.ids, true
+}
+
+func (c *relatedCache) put(key string, ids []ScoredPost, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= relatedCacheSize {
+		c.entries = map[string]relatedEntry{}
+	}
+	c.entries[key] = relatedEntry{ids: ids, expires: now.Add(relatedCacheTTL)}
+}
+
+// reset drops every entry: a tag change on one post can reorder the related lists of many others.
+func (c *relatedCache) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = map[string]relatedEntry{}
+}
+
+type relatedCandidate struct {
+	ID         uint
+	CategoryID uint
+	SharedTags int
+	PublishAt  *time.Time
+	CreatedAt  time.Time
+}
+
+// RelatedPostIDs ranks up to RelatedPostsMax live posts related to p at now, best first. Results are
+// cached; callers should still check that the posts are live when they load them.
+func (r *PostRepository) RelatedPostIDs(ctx context.Context, p *model.Post, w RelatedWeights, now time.Time) ([]ScoredPost, error) {
+	key := fmt.Sprintf("%d:%d:%g:%g:%g:%g", tenant.SiteID(ctx), p.ID, w.Tags, w.Category, w.Recency, w.HalfLifeDays)
+	if ids, ok := r.related.get(key, now); ok {
+		return ids, nil
+	}
+	ids, err := r.rankRelated(ctx, p, w, now)
+	if err != nil {
+		r.log.Error("failed to rank related posts", zap.Error(err))
+		return nil, err
+	}
+	r.related.put(key, ids, now)
+	return ids, nil
+}
+
+func (r *PostRepository) rankRelated(ctx context.Context, p *model.Post, w RelatedWeights, now time.Time) ([]ScoredPost, error) {
+	db := r.db.WithContext(ctx)
+	tagIDs, err := r.TagIDs(ctx, p.ID)
+	if err != nil {
+		return nil, err
+	}
+	var ancestors []uint
+	var cat model.CategoryModel
+	if err := db.Select("id", "lft", "rgt").First(&cat, p.CategoryID).Error; err == nil {
+		if err := db.Model(&model.CategoryModel{}).
+			Where("lft < ? AND rgt > ?", cat.Lft, cat.Rgt).
+			Pluck("id", &ancestors).Error; err != nil {
+			return nil, err
+		}
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	shared := "0"
+	var args []any
+	if len(tagIDs) > 0 {
+		shared = "(SELECT COUNT(*) FROM " + model.TablePostTags + " pt WHERE pt.post_id = posts.id AND pt.tag_id IN ?)"
+		args = append(args, tagIDs)
+	}
+	candidates := func() *gorm.DB {
+		return livePosts(db.Model(&model.Post{}), now).
+			Select("posts.id, posts.category_id, posts.publish_at, posts.created_at, "+shared+" AS shared_tags", args...).
+			Where("posts.id <> ?", p.ID)
+	}
+
+	var matched []relatedCandidate
+	related := append([]uint{p.CategoryID}, ancestors...)
+	q := candidates()
+	if len(tagIDs) > 0 {
+		q = q.Where("(posts.category_id IN ? OR posts.id IN (SELECT pt2.post_id FROM "+model.TablePostTags+" pt2 WHERE pt2.tag_id IN ?))", related, tagIDs)
+	} else {
+		q = q.Where("posts.category_id IN ?", related)
+	}
+	if err := q.Order("shared_tags desc, posts.id desc").Limit(relatedCandidates).Scan(&matched).Error; err != nil {
+		return nil, err
+	}
+	var recent []relatedCandidate
+	if w.Recency > 0 {
+		if err := candidates().Order("posts.id desc").Limit(relatedRecent).Scan(&recent).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	ancestor := make(map[uint]bool, len(ancestors))
+	for _, id := range ancestors {
+		ancestor[id] = true
+	}
+	halfLife := w.HalfLifeDays
+	if halfLife <= 0 {
+		halfLife = 30
+	}
+	seen := make(map[uint]bool, len(matched)+len(recent))
+	var out []ScoredPost
+	for _, c := range append(matched, recent...) {
+		if seen[c.ID] {
+			continue
+		}
+		seen[c.ID] = true
+		score := w.Tags * float64(c.SharedTags)
+		switch {
+		case c.CategoryID == p.CategoryID:
+			score += w.Category
+		case ancestor[c.CategoryID]:
+			score += w.Category / 2
+		}
+		published := c.CreatedAt
+		if c.PublishAt != nil {
+			published = *c.PublishAt
+		}
+		age := math.Max(now.Sub(published).Hours()/24, 0)
+		score += w.Recency * math.Pow(0.5, age/halfLife)
+		if score > 0 {
+			out = append(out, ScoredPost{ID: c.ID, Score: math.Round(score*1000) / 1000})
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Score != out[j].Score {
+			return out[i].Score > out[j].Score
+		}
+		return out[i].ID > out[j].ID
+	})
+	if len(out) > RelatedPostsMax {
+		out = out[:RelatedPostsMax]
+	}
+	return out, nil
+}
@@ -2,11 +2,14 @@ package repository
 
 import (
 	"context"
-	"math"
+	"errors"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/turahe/go-restfull/internal/handler/request"
 	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/internal/tenant"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -15,10 +18,12 @@ import (
 type PostRepository struct {
 	db  *gorm.DB
 	log *zap.Logger
+	// related caches RelatedPostIDs; ReplaceTags clears it.
+	related *relatedCache
 }
 
 func NewPostRepository(db *gorm.DB, log *zap.Logger) *PostRepository {
-	return &PostRepository{db: db, log: log}
+	return &PostRepository{db: db, log: log, related: newRelatedCache()}
 }
 
 func (r *PostRepository) Create(ctx context.Context, p *model.Post) error {
@@ -39,6 +44,19 @@ func (r *PostRepository) Update(ctx context.Context, p *model.Post) error {
 	return nil
 }
 
+// SaveRender stores the rendered content fields of p without touching updated_at.
+func (r *PostRepository) SaveRender(ctx context.Context, p *model.Post) error {
+	err := r.db.WithContext(ctx).
+		Model(&model.Post{ID: p.ID}).
+		Select("content_html", "toc", "word_count", "reading_time_minutes", "render_version").
+		UpdateColumns(p).Error
+	if err != nil {
+		r.log.Error("failed to save post render", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
 func (r *PostRepository) SavePostSEO(ctx context.Context, seo *model.PostSEO) error {
 	if seo == nil {
 		return nil
@@ -69,8 +87,21 @@ func (r *PostRepository) DeleteByID(ctx context.Context, id uint) error {
 	return nil
 }
 
+// SoftDeleteByID soft-deletes the post, recording who deleted it, and takes it out of its series.
 func (r *PostRepository) SoftDeleteByID(ctx context.Context, id uint, deletedBy uint) error {
 	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var p model.Post
+		if err := tx.Select("id", "series_id", "chapter").First(&p, id).Error; err != nil {
+			return err
+		}
+		if p.SeriesID != nil {
+			if err := detachChapter(tx, *p.SeriesID, p.ID, p.Chapter); err != nil {
+				return err
+			}
+		}
+		if err := detachReadingListItems(tx, p.ID); err != nil {
+			return err
+		}
 		if err := tx.Model(&model.Post{}).Where("id = ?", id).Update("deleted_by", deletedBy).Error; err != nil {
 			r.log.Error("failed to update post deleted by", zap.Error(err))
 			return err
@@ -111,7 +142,8 @@ func (r *PostRepository) FindBySlugWithCategory(ctx context.Context, slug string
 		Preload("Category").
 		Preload("Tags").
 		Preload("Media").
-		Preload("User").
+		Scopes(preloadContributors).
+		Preload("Series").
 		Where("slug = ?", slug).
 		First(&p).Error
 	if err != nil {
@@ -121,6 +153,97 @@ func (r *PostRepository) FindBySlugWithCategory(ctx context.Context, slug string
 	return &p, nil
 }
 
+// livePosts restricts a posts query to rows publicly visible at now: published and inside their
+// publish window. It does not rely on the scheduler having run yet.
+func livePosts(db *gorm.DB, now time.Time) *gorm.DB {
+	return db.Where("posts.status = ? AND (posts.publish_at IS NULL OR posts.publish_at <= ?) AND (posts.unpublish_at IS NULL OR posts.unpublish_at > ?)",
+		model.PostStatusPublished, now, now)
+}
+
+// SeriesNavigation returns the live chapters just before and after chapter in the series, and how many
+// of its chapters are live at now.
+func (r *PostRepository) SeriesNavigation(ctx context.Context, seriesID uint, chapter int, now time.Time) (prev, next *model.SeriesChapter, published int64, err error) {
+	db := r.db.WithContext(ctx)
+	neighbour := func(cmp, order string) (*model.SeriesChapter, error) {
+		var rows []model.SeriesChapter
+		err := livePosts(db.Model(&model.Post{}), now).
+			Select("id", "title", "slug", "chapter", "publish_at").
+			Where("series_id = ? AND chapter "+cmp+" ?", seriesID, chapter).
+			Order("chapter " + order).
+			Limit(1).
+			Find(&rows).Error
+		if err != nil || len(rows) == 0 {
+			return nil, err
+		}
+		return &rows[0], nil
+	}
+	if prev, err = neighbour("<", "desc"); err == nil {
+		if next, err = neighbour(">", "asc"); err == nil {
+			err = livePosts(db.Model(&model.Post{}), now).Where("series_id = ?", seriesID).Count(&published).Error
+		}
+	}
+	if err != nil {
+		r.log.Error("failed to load series navigation", zap.Error(err))
+		return nil, nil, 0, err
+	}
+	return prev, next, published, nil
+}
+
+const postSchedulerLockName = "go_restfull_post_scheduler"
+
+// ApplySchedule publishes scheduled posts whose publish_at has passed and archives published posts
+// whose unpublish_at has passed, on every site. On MySQL it takes a named lock without waiting, so
+// when several replicas tick at once only one does the work; the others return ran=false. published
+// and unpublished list the ids of the posts it published and archived.
+func (r *PostRepository) ApplySchedule(ctx context.Context, now time.Time) (published, unpublished []uint, ran bool, err error) {
+	ctx = tenant.WithAllSites(ctx)
+	err = r.db.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+		// The named lock belongs to this connection; NewDB keeps the statements below independent.
+		conn = conn.Session(&gorm.Session{NewDB: true})
+		if conn.Dialector.Name() == "mysql" {
+			var got *int
+			if err := conn.Raw("SELECT GET_LOCK(?, 0)", postSchedulerLockName).Scan(&got).Error; err != nil {
+				return err
+			}
+			if got == nil || *got != 1 {
+				return nil
+			}
+			defer func() { _ = conn.Exec("SELECT RELEASE_LOCK(?)", postSchedulerLockName).Error }()
+		}
+		ran = true
+
+		if err := conn.Model(&model.Post{}).
+			Where("status = ? AND publish_at IS NOT NULL AND publish_at <= ?", model.PostStatusScheduled, now).
+			Pluck("id", &published).Error; err != nil {
+			return err
+		}
+		if len(published) > 0 {
+			if err := conn.Model(&model.Post{}).
+				Where("id IN ?", published).
+				Updates(map[string]any{"status": model.PostStatusPublished, "updated_at": now}).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := conn.Model(&model.Post{}).
+			Where("status = ? AND unpublish_at IS NOT NULL AND unpublish_at <= ?", model.PostStatusPublished, now).
+			Pluck("id", &unpublished).Error; err != nil {
+			return err
+		}
+		if len(unpublished) > 0 {
+			return conn.Model(&model.Post{}).
+				Where("id IN ?", unpublished).
+				Updates(map[string]any{"status": model.PostStatusArchived, "updated_at": now}).Error
+		}
+		return nil
+	})
+	if err != nil {
+		r.log.Error("failed to apply post schedule", zap.Error(err))
+		return nil, nil, false, err
+	}
+	return published, unpublished, ran, nil
+}
+
 func (r *PostRepository) SetCategory(ctx context.Context, postID uint, categoryID uint) error {
 	err := r.db.WithContext(ctx).Model(&model.Post{}).Where("id = ?", postID).Update("category_id", categoryID).Error
 	if err != nil {
@@ -137,6 +260,7 @@ func (r *PostRepository) ReplaceTags(ctx context.Context, postID uint, tags []mo
 		r.log.Error("failed to replace tags", zap.Error(err))
 		return err
 	}
+	r.related.reset()
 	return nil
 }
 
@@ -155,18 +279,17 @@ func (r *PostRepository) SlugExists(ctx context.Context, slug string) (bool, err
 	return id != 0, nil
 }
 
-type CursorPage struct {
-	Items      any
-	NextCursor *uint
-	PrevCursor *uint
+var postKeyset = keyset[model.Post]{
+	idColumn: "posts.id",
+	id:       func(p *model.Post) uint { return p.ID },
+	orders: []keysetOrder[model.Post]{
+		{name: "oldest"},
+		{name: "newest", desc: true},
+		{name: "title", column: "posts.title", kind: keyString, key: func(p *model.Post) any { return p.Title }},
+	},
 }
 
 func (r *PostRepository) ListCursor(ctx context.Context, req request.PostListRequest) (CursorPage, error) {
-	// Normalize pagination inputs (offset/limit style).
-	page := req.Page
-	if page <= 0 {
-		page = 1
-	}
 	limit := req.Limit
 	if limit <= 0 {
 		limit = 10
@@ -175,13 +298,14 @@ func (r *PostRepository) ListCursor(ctx context.Context, req request.PostListReq
 		limit = 50
 	}
 
-	offset := (page - 1) * limit
-
-	applyFilters := func(db *gorm.DB) *gorm.DB {
+	build := func() *gorm.DB {
+		db := r.db.WithContext(ctx).Model(&model.Post{})
 		if req.Title != "" {
 			db = db.Where("title LIKE ?", "%"+req.Title+"%")
 		}
-		if s := strings.TrimSpace(req.Search); s != "" {
+		if req.MatchIDs != nil {
+			db = db.Where("id IN ?", append([]uint{0}, req.MatchIDs...))
+		} else if s := strings.TrimSpace(req.Search); s != "" {
 			pat := "%" + s + "%"
 			db = db.Where("(title LIKE ? OR content LIKE ?)", pat, pat)
 		}
@@ -194,57 +318,90 @@ func (r *PostRepository) ListCursor(ctx context.Context, req request.PostListReq
 		if req.Status != "" {
 			db = db.Where("status = ?", req.Status)
 		}
+		if req.ReadingListID != nil {
+			db = db.Where("id IN (?)", readingListPostIDs(r.db.WithContext(ctx), *req.ReadingListID, req.ViewerID))
+		}
+		if req.LiveAt != nil {
+			db = livePosts(db, *req.LiveAt)
+		}
+		if req.Locale != "" {
+			db = localePosts(db, req.Locale, req.DefaultLocale, req.LiveAt)
+		}
 		return db
 	}
-
-	// Count total rows for page metadata and next/prev existence.
-	var totalRows int64
-	countQ := applyFilters(r.db.WithContext(ctx).Model(&model.Post{}))
-	if err := countQ.Count(&totalRows).Error; err != nil {
-		r.log.Error("failed to count posts", zap.Error(err))
-		return CursorPage{}, err
-	}
-
-	totalPages := 0
-	if totalRows > 0 {
-		totalPages = int(math.Ceil(float64(totalRows) / float64(limit)))
-	}
-
-	// Fetch page items.
-	var rows []model.Post
-	dataQ := applyFilters(
-		r.db.WithContext(ctx).
-			Model(&model.Post{}).
+	preload := func(db *gorm.DB) *gorm.DB {
+		return db.
 			Preload("PostSEO").
 			Preload("Media").
 			Preload("Tags").
 			Preload("Category").
-			Preload("User"),
-	)
+			Scopes(preloadContributors).
+			Preload("Series")
+	}
 
-	if err := dataQ.
-		Order("id asc").
-		Limit(limit).
-		Offset(offset).
-		Find(&rows).Error; err != nil {
+	_, page, err := postKeyset.page(build, preload, req.Sort, req.CursorRequest, limit)
+	if err != nil && !errors.Is(err, ErrInvalidCursor) {
 		r.log.Error("failed to list posts", zap.Error(err))
-		return CursorPage{}, err
 	}
+	return page, err
+}
 
-	// Handler only checks for non-nil cursors to decide next/prev.
-	var nextCursor *uint
-	var prevCursor *uint
+// TagIDs returns the ids of the tags attached to a post, ascending.
+func (r *PostRepository) TagIDs(ctx context.Context, postID uint) ([]uint, error) {
+	var ids []uint
+	err := r.db.WithContext(ctx).
+		Model(&model.PostTag{}).
+		Where("post_id = ?", postID).
+		Order("tag_id asc").
+		Pluck("tag_id", &ids).Error
+	if err != nil {
+		r.log.Error("failed to list post tag ids", zap.Error(err))
+		return nil, err
+	}
+	return ids, nil
+}
 
-	if len(rows) > 0 {
-		if totalPages > 0 && page < totalPages {
-			tmp := rows[len(rows)-1].ID
-			nextCursor = &tmp
-		}
-		if page > 1 && page <= totalPages {
-			tmp := rows[0].ID
-			prevCursor = &tmp
-		}
+// FindByIDs loads posts with the same relations as list pages, in the order of ids.
+func (r *PostRepository) FindByIDs(ctx context.Context, ids []uint) ([]model.Post, error) {
+	var rows []model.Post
+	if len(ids) == 0 {
+		return rows, nil
+	}
+	err := r.db.WithContext(ctx).
+		Preload("PostSEO").
+		Preload("Media").
+		Preload("Tags").
+		Preload("Category").
+		Scopes(preloadContributors).
+		Preload("Series").
+		Where("id IN ?", ids).
+		Find(&rows).Error
+	if err != nil {
+		r.log.Error("failed to find posts by ids", zap.Error(err))
+		return nil, err
+	}
+	pos := make(map[uint]int, len(ids))
+	for i, id := range ids {
+		pos[id] = i
 	}
+	sort.Slice(rows, func(i, j int) bool { return pos[rows[i].ID] < pos[rows[j].ID] })
+	return rows, nil
+}
 
-	return CursorPage{Items: rows, NextCursor: nextCursor, PrevCursor: prevCursor}, nil
+// ListBatch returns up to limit posts with id > afterID, ascending, with SEO and tags loaded. Callers
+// walking every site pass a tenant.WithAllSites context.
+func (r *PostRepository) ListBatch(ctx context.Context, afterID uint, limit int) ([]model.Post, error) {
+	var rows []model.Post
+	err := r.db.WithContext(ctx).
+		Preload("PostSEO").
+		Preload("Tags").
+		Where("id > ?", afterID).
+		Order("id asc").
+		Limit(limit).
+		Find(&rows).Error
+	if err != nil {
+		r.log.Error("failed to list post batch", zap.Error(err))
+		return nil, err
+	}
+	return rows, nil
 }
@@ -15,7 +15,7 @@ import (
 func TestPostRepository_CRUD_SlugExists_ListCursor(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
-	db := openTestDB(t, &model.User{}, &model.CategoryModel{}, &model.Post{}, &model.PostSEO{}, &model.Media{})
+	db := openTestDB(t, &model.User{}, &model.CategoryModel{}, &model.Post{}, &model.PostSEO{}, &model.PostContributor{}, &model.Media{})
 	repo := NewPostRepository(db, zap.NewNop())
 
 	u := &model.User{Name: "A", Email: "a@b.com", Password: "x"}
@@ -45,10 +45,63 @@ func TestPostRepository_CRUD_SlugExists_ListCursor(t *testing.T) {
 	assert.Equal(t, p.ID, got.ID)
 
 	page, err := repo.ListCursor(ctx, request.PostListRequest{
-		PageRequest: request.PageRequest{Page: 1, Limit: 10},
+		CursorRequest: request.CursorRequest{Limit: 10},
 	})
 	assert.NoError(t, err)
 	items, ok := page.Items.([]model.Post)
 	assert.True(t, ok)
 	assert.Len(t, items, 1)
 }
+
+func TestPostRepository_ApplySchedule_LiveFilter(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	db := openTestDB(t, &model.User{}, &model.CategoryModel{}, &model.Post{}, &model.PostSEO{}, &model.PostContributor{}, &model.Media{}, &model.Tag{})
+	repo := NewPostRepository(db, zap.NewNop())
+
+	u := &model.User{Name: "A", Email: "a@b.com", Password: "x"}
+	assert.NoError(t, db.WithContext(ctx).Create(u).Error)
+	cat := &model.CategoryModel{Name: "Tech", Slug: "tech", Lft: 1, Rgt: 2, Depth: 0, CreatedBy: u.ID, UpdatedBy: u.ID}
+	assert.NoError(t, db.WithContext(ctx).Create(cat).Error)
+
+	now := time.Now()
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+	mk := func(slug string, status model.PostStatus, publishAt, unpublishAt *time.Time) *model.Post {
+		p := &model.Post{Title: slug, Slug: slug, Content: "c", UserID: u.ID, CategoryID: cat.ID, CreatedBy: u.ID, UpdatedBy: u.ID,
+			Status: status, PublishAt: publishAt, UnpublishAt: unpublishAt}
+		assert.NoError(t, repo.Create(ctx, p))
+		return p
+	}
+	live := mk("live", model.PostStatusPublished, nil, nil)
+	due := mk("due", model.PostStatusScheduled, &past, nil)
+	mk("later", model.PostStatusScheduled, &future, nil)
+	expired := mk("expired", model.PostStatusPublished, nil, &past)
+	mk("draft", model.PostStatusDraft, nil, nil)
+
+	listLive := func() []string {
+		page, err := repo.ListCursor(ctx, request.PostListRequest{CursorRequest: request.CursorRequest{Limit: 10}, LiveAt: &now})
+		assert.NoError(t, err)
+		var slugs []string
+		for _, p := range page.Items.([]model.Post) {
+			slugs = append(slugs, p.Slug)
+		}
+		return slugs
+	}
+	// Before the scheduler runs, expired posts are already hidden but due ones are not yet published.
+	assert.Equal(t, []string{"live"}, listLive())
+
+	published, unpublished, ran, err := repo.ApplySchedule(ctx, now)
+	assert.NoError(t, err)
+	assert.True(t, ran)
+	assert.Equal(t, []uint{due.ID}, published)
+	assert.Equal(t, []uint{expired.ID}, unpublished)
+	assert.Equal(t, []string{"live", "due"}, listLive())
+
+	got, err := repo.FindByID(ctx, expired.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, model.PostStatusArchived, got.Status)
+	got, err = repo.FindByID(ctx, due.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, model.PostStatusPublished, got.Status)
+	assert.NotZero(t, live.ID)
+}
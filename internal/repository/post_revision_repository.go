@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/turahe/go-restfull/internal/model"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type PostRevisionRepository struct {
+	db  *gorm.DB
+	log *zap.Logger
+}
+
+func NewPostRevisionRepository(db *gorm.DB, log *zap.Logger) *PostRevisionRepository {
+	return &PostRevisionRepository{db: db, log: log}
+}
+
+// Create numbers rev after the post's latest revision and stores it.
+func (r *PostRevisionRepository) Create(ctx context.Context, rev *model.PostRevision) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var last uint
+		if err := tx.Model(&model.PostRevision{}).
+			Select("COALESCE(MAX(number), 0)").
+			Where("post_id = ?", rev.PostID).
+			Scan(&last).Error; err != nil {
+			return err
+		}
+		rev.Number = last + 1
+		return tx.Create(rev).Error
+	})
+	if err != nil {
+		r.log.Error("failed to create post revision", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// Count returns how many revisions are stored for a post.
+func (r *PostRevisionRepository) Count(ctx context.Context, postID uint) (int64, error) {
+	var n int64
+	err := r.db.WithContext(ctx).Model(&model.PostRevision{}).Where("post_id = ?", postID).Count(&n).Error
+	if err != nil {
+		r.log.Error("failed to count post revisions", zap.Error(err))
+		return 0, err
+	}
+	return n, nil
+}
+
+// List returns a post's revisions, newest first, without their content.
+func (r *PostRevisionRepository) List(ctx context.Context, postID uint) ([]model.PostRevision, error) {
+	var rows []model.PostRevision
+	err := r.db.WithContext(ctx).
+		Omit("content").
+		Where("post_id = ?", postID).
+		Order("number desc").
+		Find(&rows).Error
+	if err != nil {
+		r.log.Error("failed to list post revisions", zap.Error(err))
+		return nil, err
+	}
+	return rows, nil
+}
+
+// FindByID loads one revision of a post, content included.
+func (r *PostRevisionRepository) FindByID(ctx context.Context, postID, id uint) (*model.PostRevision, error) {
+	var rev model.PostRevision
+	err := r.db.WithContext(ctx).Where("post_id = ?", postID).First(&rev, id).Error
+	if err != nil {
+		r.log.Error("failed to find post revision by id", zap.Error(err))
+		return nil, err
+	}
+	return &rev, nil
+}
+
+// FindLatest loads a post's newest revision without its content, or gorm.ErrRecordNotFound when it has none.
+func (r *PostRevisionRepository) FindLatest(ctx context.Context, postID uint) (*model.PostRevision, error) {
+	var rev model.PostRevision
+	err := r.db.WithContext(ctx).
+		Omit("content").
+		Where("post_id = ?", postID).
+		Order("number desc").
+		First(&rev).Error
+	if err != nil {
+		r.log.Error("failed to find latest post revision", zap.Error(err))
+		return nil, err
+	}
+	return &rev, nil
+}
+
+// FindPrevious loads the revision numbered just before number, or gorm.ErrRecordNotFound for the first one.
+func (r *PostRevisionRepository) FindPrevious(ctx context.Context, postID, number uint) (*model.PostRevision, error) {
+	var rev model.PostRevision
+	err := r.db.WithContext(ctx).
+		Where("post_id = ? AND number < ?", postID, number).
+		Order("number desc").
+		First(&rev).Error
+	if err != nil {
+		r.log.Error("failed to find previous post revision", zap.Error(err))
+		return nil, err
+	}
+	return &rev, nil
+}
+
+// Prune keeps the newest keep revisions of a post and deletes the rest.
+func (r *PostRevisionRepository) Prune(ctx context.Context, postID uint, keep int) error {
+	if keep <= 0 {
+		return nil
+	}
+	var last uint
+	db := r.db.WithContext(ctx)
+	if err := db.Model(&model.PostRevision{}).
+		Select("COALESCE(MAX(number), 0)").
+		Where("post_id = ?", postID).
+		Scan(&last).Error; err != nil {
+		r.log.Error("failed to prune post revisions", zap.Error(err))
+		return err
+	}
+	if last <= uint(keep) {
+		return nil
+	}
+	err := db.Where("post_id = ? AND number <= ?", postID, last-uint(keep)).Delete(&model.PostRevision{}).Error
+	if err != nil {
+		r.log.Error("failed to prune post revisions", zap.Error(err))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/model"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PostViewCount is the number of views a post got over some span of days.
+type PostViewCount struct {
+	PostID uint  `json:"postId"`
+	Views  int64 `json:"views"`
+}
+
+// AddDailyViews adds each row's Views to the stored count of its post and day, creating missing rows.
+// Rows keep the SiteID they carry, so the flusher can write every site's counts in one call.
+func (r *PostRepository) AddDailyViews(ctx context.Context, rows []model.PostStatDaily) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i := range rows {
+			err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "post_id"}, {Name: "day"}},
+				DoUpdates: clause.Assignments(map[string]any{"views": gorm.Expr("views + ?", rows[i].Views)}),
+			}).Create(&rows[i]).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		r.log.Error("failed to add daily post views", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// DailyViews returns the post's per-day counts from since (YYYY-MM-DD) on, oldest first. Days without
+// views have no row.
+func (r *PostRepository) DailyViews(ctx context.Context, postID uint, since string) ([]model.PostStatDaily, error) {
+	var rows []model.PostStatDaily
+	err := r.db.WithContext(ctx).
+		Where("post_id = ? AND day >= ?", postID, since).
+		Order("day asc").
+		Find(&rows).Error
+	if err != nil {
+		r.log.Error("failed to list daily post views", zap.Error(err))
+		return nil, err
+	}
+	return rows, nil
+}
+
+// TotalViews returns every view the post has had.
+func (r *PostRepository) TotalViews(ctx context.Context, postID uint) (int64, error) {
+	var total int64
+	err := r.db.WithContext(ctx).Model(&model.PostStatDaily{}).
+		Select("COALESCE(SUM(views), 0)").
+		Where("post_id = ?", postID).
+		Scan(&total).Error
+	if err != nil {
+		r.log.Error("failed to sum post views", zap.Error(err))
+		return 0, err
+	}
+	return total, nil
+}
+
+// PopularPosts returns up to limit posts live at now with the most views from since (YYYY-MM-DD) on,
+// most viewed first.
+func (r *PostRepository) PopularPosts(ctx context.Context, since string, now time.Time, limit int) ([]PostViewCount, error) {
+	var rows []PostViewCount
+	err := livePosts(r.db.WithContext(ctx).Model(&model.Post{}), now).
+		Select("posts.id AS post_id, SUM(s.views) AS views").
+		Joins("JOIN post_stats_daily s ON s.post_id = posts.id AND s.day >= ?", since).
+		Group("posts.id").
+		Order("views desc").
+		Order("posts.id desc").
+		Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
+		r.log.Error("failed to list popular posts", zap.Error(err))
+		return nil, err
+	}
+	return rows, nil
+}
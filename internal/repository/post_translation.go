@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/model"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// localePosts restricts a posts query to one locale. Posts in def are kept when their translation group
+// has no variant in locale that is live at now (or has no group at all), so untranslated content falls
+// back to the default locale.
+func localePosts(db *gorm.DB, locale, def string, now *time.Time) *gorm.DB {
+	if def == "" || def == locale {
+		return db.Where("posts.locale = ?", locale)
+	}
+	variant := "SELECT 1 FROM posts t WHERE t.translation_group_id = posts.translation_group_id AND t.locale = ? AND t.deleted_at IS NULL"
+	args := []any{locale, def, locale}
+	if now != nil {
+		variant += " AND t.status = ? AND (t.publish_at IS NULL OR t.publish_at <= ?) AND (t.unpublish_at IS NULL OR t.unpublish_at > ?)"
+		args = append(args, model.PostStatusPublished, *now, *now)
+	}
+	return db.Where("(posts.locale = ? OR (posts.locale = ? AND (posts.translation_group_id IS NULL OR NOT EXISTS ("+variant+"))))", args...)
+}
+
+// TranslationVariants returns the id, slug, locale, group and publication fields of every post in the
+// given translation groups, by locale.
+func (r *PostRepository) TranslationVariants(ctx context.Context, groupIDs []uint) ([]model.Post, error) {
+	var rows []model.Post
+	if len(groupIDs) == 0 {
+		return rows, nil
+	}
+	err := r.db.WithContext(ctx).
+		Select("id", "slug", "locale", "translation_group_id", "status", "publish_at", "unpublish_at").
+		Where("translation_group_id IN ?", groupIDs).
+		Order("locale asc").
+		Find(&rows).Error
+	if err != nil {
+		r.log.Error("failed to load translation variants", zap.Error(err))
+		return nil, err
+	}
+	return rows, nil
+}
+
+// Locales returns the distinct locales of the site's posts.
+func (r *PostRepository) Locales(ctx context.Context) ([]string, error) {
+	var locales []string
+	err := r.db.WithContext(ctx).
+		Model(&model.Post{}).
+		Distinct().
+		Order("locale asc").
+		Pluck("locale", &locales).Error
+	if err != nil {
+		r.log.Error("failed to list post locales", zap.Error(err))
+		return nil, err
+	}
+	return locales, nil
+}
+
+// LocaleTaken reports whether the translation group already has a post in locale, including soft-deleted
+// ones (they still hold the unique index).
+func (r *PostRepository) LocaleTaken(ctx context.Context, groupID uint, locale string) (bool, error) {
+	var n int64
+	err := r.db.WithContext(ctx).
+		Unscoped().
+		Model(&model.Post{}).
+		Where("translation_group_id = ? AND locale = ?", groupID, locale).
+		Count(&n).Error
+	if err != nil {
+		r.log.Error("failed to check translation locale", zap.Error(err))
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// SetTranslationGroup puts a post in a translation group without touching updated_at.
+func (r *PostRepository) SetTranslationGroup(ctx context.Context, postID, groupID uint) error {
+	err := r.db.WithContext(ctx).
+		Model(&model.Post{}).
+		Where("id = ?", postID).
+		UpdateColumn("translation_group_id", groupID).Error
+	if err != nil {
+		r.log.Error("failed to set translation group", zap.Error(err))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/turahe/go-restfull/internal/model"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PostWorkflowRepository stores the editorial workflow of posts: assigned reviewers, review comments
+// and the transition history.
+type PostWorkflowRepository struct {
+	db  *gorm.DB
+	log *zap.Logger
+}
+
+func NewPostWorkflowRepository(db *gorm.DB, log *zap.Logger) *PostWorkflowRepository {
+	return &PostWorkflowRepository{db: db, log: log}
+}
+
+// AddReviewer assigns a reviewer; assigning one twice keeps the first assignment.
+func (r *PostWorkflowRepository) AddReviewer(ctx context.Context, rv *model.PostReviewer) error {
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(rv).Error
+	if err != nil {
+		r.log.Error("failed to add post reviewer", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// RemoveReviewer unassigns a reviewer and reports whether one was assigned.
+func (r *PostWorkflowRepository) RemoveReviewer(ctx context.Context, postID, userID uint) (bool, error) {
+	res := r.db.WithContext(ctx).Where("post_id = ? AND user_id = ?", postID, userID).Delete(&model.PostReviewer{})
+	if res.Error != nil {
+		r.log.Error("failed to remove post reviewer", zap.Error(res.Error))
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
+
+// ListReviewers returns a post's reviewers in assignment order, with their users.
+func (r *PostWorkflowRepository) ListReviewers(ctx context.Context, postID uint) ([]model.PostReviewer, error) {
+	var rows []model.PostReviewer
+	err := r.db.WithContext(ctx).
+		Preload("User").
+		Where("post_id = ?", postID).
+		Order("created_at asc, user_id asc").
+		Find(&rows).Error
+	if err != nil {
+		r.log.Error("failed to list post reviewers", zap.Error(err))
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (r *PostWorkflowRepository) IsReviewer(ctx context.Context, postID, userID uint) (bool, error) {
+	var n int64
+	err := r.db.WithContext(ctx).Model(&model.PostReviewer{}).Where("post_id = ? AND user_id = ?", postID, userID).Count(&n).Error
+	if err != nil {
+		r.log.Error("failed to check post reviewer", zap.Error(err))
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func (r *PostWorkflowRepository) CreateReviewComment(ctx context.Context, c *model.PostReviewComment) error {
+	if err := r.db.WithContext(ctx).Create(c).Error; err != nil {
+		r.log.Error("failed to create post review comment", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// ListReviewComments returns a post's review comments, oldest first, with their authors.
+func (r *PostWorkflowRepository) ListReviewComments(ctx context.Context, postID uint) ([]model.PostReviewComment, error) {
+	var rows []model.PostReviewComment
+	err := r.db.WithContext(ctx).
+		Preload("User").
+		Where("post_id = ?", postID).
+		Order("id asc").
+		Find(&rows).Error
+	if err != nil {
+		r.log.Error("failed to list post review comments", zap.Error(err))
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (r *PostWorkflowRepository) CreateTransition(ctx context.Context, t *model.PostTransition) error {
+	if err := r.db.WithContext(ctx).Create(t).Error; err != nil {
+		r.log.Error("failed to record post transition", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// ListTransitions returns a post's transition history, newest first.
+func (r *PostWorkflowRepository) ListTransitions(ctx context.Context, postID uint) ([]model.PostTransition, error) {
+	var rows []model.PostTransition
+	err := r.db.WithContext(ctx).Where("post_id = ?", postID).Order("id desc").Find(&rows).Error
+	if err != nil {
+		r.log.Error("failed to list post transitions", zap.Error(err))
+		return nil, err
+	}
+	return rows, nil
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/model"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// reactionStore reads and writes the reactions of one target type and keeps the totals on the
+// target rows (the reaction_counts column of model's table) in step.
+type reactionStore struct {
+	db     *gorm.DB
+	log    *zap.Logger
+	target string
+	model  any
+}
+
+// set adds (on) or removes userID's reaction typ to target id and returns the target's new totals.
+// Both directions are idempotent. gorm.ErrRecordNotFound when the target does not exist.
+func (s reactionStore) set(ctx context.Context, id, userID uint, typ string, on bool) (model.ReactionCounts, error) {
+	var counts model.ReactionCounts
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock the target row so concurrent reactions recount one after another.
+		q := tx.Model(s.model).Select("id").Where("id = ?", id)
+		if tx.Dialector.Name() == "mysql" {
+			q = q.Clauses(clause.Locking{Strength: "UPDATE"})
+		}
+		var found uint
+		if err := q.Limit(1).Scan(&found).Error; err != nil {
+			return err
+		}
+		if found == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		if on {
+			row := model.Reaction{TargetType: s.target, TargetID: id, UserID: userID, Type: typ, CreatedAt: time.Now()}
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&row).Error; err != nil {
+				return err
+			}
+		} else {
+			if err := tx.Where("target_type = ? AND target_id = ? AND user_id = ? AND type = ?", s.target, id, userID, typ).
+				Delete(&model.Reaction{}).Error; err != nil {
+				return err
+			}
+		}
+
+		var rows []struct {
+			Type  string
+			Total int64
+		}
+		if err := tx.Model(&model.Reaction{}).
+			Select("type, COUNT(*) AS total").
+			Where("target_type = ? AND target_id = ?", s.target, id).
+			Group("type").
+			Scan(&rows).Error; err != nil {
+			return err
+		}
+		counts = make(model.ReactionCounts, len(rows))
+		for _, r := range rows {
+			counts[r.Type] = r.Total
+		}
+		// UpdateColumn leaves updated_at alone: reactions are not edits of the content.
+		return tx.Model(s.model).Where("id = ?", id).UpdateColumn("reaction_counts", counts).Error
+	})
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			s.log.Error("failed to set reaction", zap.String("target", s.target), zap.Error(err))
+		}
+		return nil, err
+	}
+	return counts, nil
+}
+
+// mine returns userID's reaction types per target id, for the ids that have any.
+func (s reactionStore) mine(ctx context.Context, ids []uint, userID uint) (map[uint][]string, error) {
+	out := map[uint][]string{}
+	if len(ids) == 0 || userID == 0 {
+		return out, nil
+	}
+	var rows []model.Reaction
+	if err := s.db.WithContext(ctx).
+		Select("target_id", "type").
+		Where("target_type = ? AND user_id = ? AND target_id IN ?", s.target, userID, ids).
+		Order("target_id, type").
+		Find(&rows).Error; err != nil {
+		s.log.Error("failed to load viewer reactions", zap.String("target", s.target), zap.Error(err))
+		return nil, err
+	}
+	for _, r := range rows {
+		out[r.TargetID] = append(out[r.TargetID], r.Type)
+	}
+	return out, nil
+}
+
+func (r *PostRepository) reactions() reactionStore {
+	return reactionStore{db: r.db, log: r.log, target: model.ReactionTargetPost, model: &model.Post{}}
+}
+
+// SetReaction adds (on) or removes userID's reaction typ to post id and returns the post's totals.
+func (r *PostRepository) SetReaction(ctx context.Context, id, userID uint, typ string, on bool) (model.ReactionCounts, error) {
+	return r.reactions().set(ctx, id, userID, typ, on)
+}
+
+// ViewerReactions returns userID's reaction types per post, for the given post ids.
+func (r *PostRepository) ViewerReactions(ctx context.Context, ids []uint, userID uint) (map[uint][]string, error) {
+	return r.reactions().mine(ctx, ids, userID)
+}
+
+func (r *CommentRepository) reactions() reactionStore {
+	return reactionStore{db: r.db, log: r.log, target: model.ReactionTargetComment, model: &model.Comment{}}
+}
+
+// SetReaction adds (on) or removes userID's reaction typ to comment id and returns the comment's totals.
+func (r *CommentRepository) SetReaction(ctx context.Context, id, userID uint, typ string, on bool) (model.ReactionCounts, error) {
+	return r.reactions().set(ctx, id, userID, typ, on)
+}
+
+// ViewerReactions returns userID's reaction types per comment, for the given comment ids.
+func (r *CommentRepository) ViewerReactions(ctx context.Context, ids []uint, userID uint) (map[uint][]string, error) {
+	return r.reactions().mine(ctx, ids, userID)
+}
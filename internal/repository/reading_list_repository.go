@@ -0,0 +1,340 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/model"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrReadingListExists is returned when the owner already has a list with that name.
+	ErrReadingListExists = errors.New("reading list name already in use")
+	// ErrReadingListSetMismatch is returned by Reorder when the ids given are not exactly the list's posts.
+	ErrReadingListSetMismatch = errors.New("post ids must list every post in the reading list exactly once")
+)
+
+type ReadingListRepository struct {
+	db  *gorm.DB
+	log *zap.Logger
+}
+
+func NewReadingListRepository(db *gorm.DB, log *zap.Logger) *ReadingListRepository {
+	return &ReadingListRepository{db: db, log: log}
+}
+
+func (r *ReadingListRepository) Create(ctx context.Context, l *model.ReadingList) error {
+	if err := r.nameFree(ctx, l); err != nil {
+		return err
+	}
+	err := r.db.WithContext(ctx).Create(l).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return ErrReadingListExists
+	}
+	if err != nil {
+		r.log.Error("failed to create reading list", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *ReadingListRepository) Update(ctx context.Context, l *model.ReadingList) error {
+	if err := r.nameFree(ctx, l); err != nil {
+		return err
+	}
+	err := r.db.WithContext(ctx).Save(l).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return ErrReadingListExists
+	}
+	if err != nil {
+		r.log.Error("failed to update reading list", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// nameFree reports ErrReadingListExists when another list of the owner has l's name.
+func (r *ReadingListRepository) nameFree(ctx context.Context, l *model.ReadingList) error {
+	var id uint
+	err := r.db.WithContext(ctx).Model(&model.ReadingList{}).Select("id").
+		Where("user_id = ? AND name = ? AND id <> ?", l.UserID, l.Name, l.ID).
+		Limit(1).Scan(&id).Error
+	if err != nil {
+		r.log.Error("failed to check reading list name", zap.Error(err))
+		return err
+	}
+	if id != 0 {
+		return ErrReadingListExists
+	}
+	return nil
+}
+
+// DeleteByID removes the list and its entries; the posts are untouched.
+func (r *ReadingListRepository) DeleteByID(ctx context.Context, id uint) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("list_id = ?", id).Delete(&model.ReadingListItem{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model.ReadingList{}, id).Error
+	})
+	if err != nil {
+		r.log.Error("failed to delete reading list", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *ReadingListRepository) FindByID(ctx context.Context, id uint) (*model.ReadingList, error) {
+	var l model.ReadingList
+	if err := r.db.WithContext(ctx).First(&l, id).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			r.log.Error("failed to find reading list by id", zap.Error(err))
+		}
+		return nil, err
+	}
+	return &l, nil
+}
+
+func (r *ReadingListRepository) FindByShareSlug(ctx context.Context, slug string) (*model.ReadingList, error) {
+	var l model.ReadingList
+	if err := r.db.WithContext(ctx).Where("share_slug = ?", slug).First(&l).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			r.log.Error("failed to find reading list by share slug", zap.Error(err))
+		}
+		return nil, err
+	}
+	return &l, nil
+}
+
+// ListByUser returns the user's lists by name, with their entry counts.
+func (r *ReadingListRepository) ListByUser(ctx context.Context, userID uint) ([]model.ReadingList, error) {
+	var rows []model.ReadingList
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("name asc, id asc").Find(&rows).Error; err != nil {
+		r.log.Error("failed to list reading lists", zap.Error(err))
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return rows, nil
+	}
+	ids := make([]uint, len(rows))
+	for i := range rows {
+		ids[i] = rows[i].ID
+	}
+	var counts []struct {
+		ListID uint
+		N      int64
+	}
+	if err := r.db.WithContext(ctx).Model(&model.ReadingListItem{}).
+		Select("list_id, COUNT(*) AS n").
+		Where("list_id IN ?", ids).
+		Group("list_id").
+		Scan(&counts).Error; err != nil {
+		r.log.Error("failed to count reading list items", zap.Error(err))
+		return nil, err
+	}
+	byList := make(map[uint]int64, len(counts))
+	for _, c := range counts {
+		byList[c.ListID] = c.N
+	}
+	for i := range rows {
+		rows[i].ItemCount = byList[rows[i].ID]
+	}
+	return rows, nil
+}
+
+// Items returns the list's entries in order, each with its post.
+func (r *ReadingListRepository) Items(ctx context.Context, listID uint) ([]model.ReadingListItem, error) {
+	var rows []model.ReadingListItem
+	err := r.db.WithContext(ctx).
+		Joins("Post").
+		Preload("Post.PostSEO").
+		Preload("Post.Category").
+		Where(model.TableReadingListItems+".list_id = ?", listID).
+		Order(model.TableReadingListItems + ".position asc").
+		Find(&rows).Error
+	if err != nil {
+		r.log.Error("failed to list reading list items", zap.Error(err))
+		return nil, err
+	}
+	return rows, nil
+}
+
+// CountItems returns how many entries the list has.
+func (r *ReadingListRepository) CountItems(ctx context.Context, listID uint) (int64, error) {
+	var n int64
+	if err := r.db.WithContext(ctx).Model(&model.ReadingListItem{}).Where("list_id = ?", listID).Count(&n).Error; err != nil {
+		r.log.Error("failed to count reading list items", zap.Error(err))
+		return 0, err
+	}
+	return n, nil
+}
+
+// AddItem puts the post into the list at position (1-based; 0 or past the end appends), shifting later
+// entries down. A post already in the list moves to position and keeps its read marker.
+func (r *ReadingListRepository) AddItem(ctx context.Context, listID, postID uint, position int) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockReadingList(tx, listID); err != nil {
+			return err
+		}
+		item := model.ReadingListItem{ListID: listID, PostID: postID, CreatedAt: time.Now()}
+		var existing model.ReadingListItem
+		err := tx.Where("list_id = ? AND post_id = ?", listID, postID).First(&existing).Error
+		switch {
+		case err == nil:
+			item = existing
+			if err := closeReadingListGap(tx, listID, existing.Position); err != nil {
+				return err
+			}
+			if err := tx.Where("list_id = ? AND post_id = ?", listID, postID).Delete(&model.ReadingListItem{}).Error; err != nil {
+				return err
+			}
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			return err
+		}
+		var n int64
+		if err := tx.Model(&model.ReadingListItem{}).Where("list_id = ?", listID).Count(&n).Error; err != nil {
+			return err
+		}
+		if position <= 0 || int64(position) > n {
+			position = int(n) + 1
+		}
+		if err := tx.Model(&model.ReadingListItem{}).Where("list_id = ? AND position >= ?", listID, position).
+			UpdateColumn("position", gorm.Expr("position + 1")).Error; err != nil {
+			return err
+		}
+		item.Position = position
+		item.Post = nil
+		return tx.Create(&item).Error
+	})
+	if err != nil {
+		r.log.Error("failed to add reading list item", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// RemoveItem takes the post out of the list and closes the gap. It returns gorm.ErrRecordNotFound
+// when the post is not in the list.
+func (r *ReadingListRepository) RemoveItem(ctx context.Context, listID, postID uint) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockReadingList(tx, listID); err != nil {
+			return err
+		}
+		var item model.ReadingListItem
+		if err := tx.Where("list_id = ? AND post_id = ?", listID, postID).First(&item).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("list_id = ? AND post_id = ?", listID, postID).Delete(&model.ReadingListItem{}).Error; err != nil {
+			return err
+		}
+		return closeReadingListGap(tx, listID, item.Position)
+	})
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		r.log.Error("failed to remove reading list item", zap.Error(err))
+	}
+	return err
+}
+
+// Reorder renumbers the list so postIDs[i] is at position i+1. postIDs must hold every post in the
+// list exactly once.
+func (r *ReadingListRepository) Reorder(ctx context.Context, listID uint, postIDs []uint) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockReadingList(tx, listID); err != nil {
+			return err
+		}
+		var current []uint
+		if err := tx.Model(&model.ReadingListItem{}).Where("list_id = ?", listID).Pluck("post_id", &current).Error; err != nil {
+			return err
+		}
+		if len(current) != len(postIDs) {
+			return ErrReadingListSetMismatch
+		}
+		member := make(map[uint]bool, len(current))
+		for _, id := range current {
+			member[id] = true
+		}
+		for _, id := range postIDs {
+			if !member[id] {
+				return ErrReadingListSetMismatch
+			}
+			delete(member, id)
+		}
+		for i, id := range postIDs {
+			if err := tx.Model(&model.ReadingListItem{}).Where("list_id = ? AND post_id = ?", listID, id).
+				UpdateColumn("position", i+1).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, ErrReadingListSetMismatch) {
+		r.log.Error("failed to reorder reading list", zap.Error(err))
+	}
+	return err
+}
+
+// SetRead sets the entry's read marker to at (nil marks it unread). It returns gorm.ErrRecordNotFound
+// when the post is not in the list.
+func (r *ReadingListRepository) SetRead(ctx context.Context, listID, postID uint, at *time.Time) error {
+	res := r.db.WithContext(ctx).Model(&model.ReadingListItem{}).
+		Where("list_id = ? AND post_id = ?", listID, postID).
+		UpdateColumn("read_at", at)
+	if res.Error != nil {
+		r.log.Error("failed to mark reading list item", zap.Error(res.Error))
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// lockReadingList serializes position changes to one list. It returns gorm.ErrRecordNotFound when the
+// list does not exist.
+func lockReadingList(tx *gorm.DB, listID uint) error {
+	q := tx.Select("id")
+	if tx.Dialector.Name() == "mysql" {
+		q = q.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+	return q.First(&model.ReadingList{}, listID).Error
+}
+
+// closeReadingListGap moves the entries after position up by one.
+func closeReadingListGap(tx *gorm.DB, listID uint, position int) error {
+	return tx.Model(&model.ReadingListItem{}).Where("list_id = ? AND position > ?", listID, position).
+		UpdateColumn("position", gorm.Expr("position - 1")).Error
+}
+
+// readingListPostIDs selects the post ids in list listID when viewerID owns it or it is shared;
+// otherwise it selects none.
+func readingListPostIDs(db *gorm.DB, listID, viewerID uint) *gorm.DB {
+	return db.Table(model.TableReadingListItems+" AS i").
+		Select("i.post_id").
+		Joins("JOIN "+model.TableReadingLists+" AS l ON l.id = i.list_id").
+		Where("i.list_id = ? AND (l.user_id = ? OR l.share_slug IS NOT NULL)", listID, viewerID)
+}
+
+// detachReadingListItems removes the post from every reading list, closing the gaps it leaves.
+func detachReadingListItems(tx *gorm.DB, postID uint) error {
+	var items []model.ReadingListItem
+	if err := tx.Select("list_id", "position").Where("post_id = ?", postID).Find(&items).Error; err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		return nil
+	}
+	if err := tx.Where("post_id = ?", postID).Delete(&model.ReadingListItem{}).Error; err != nil {
+		return err
+	}
+	for _, it := range items {
+		if err := closeReadingListGap(tx, it.ListID, it.Position); err != nil {
+			return err
+		}
+	}
+	return nil
+}
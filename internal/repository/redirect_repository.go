@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/handler/request"
+	"github.com/turahe/go-restfull/internal/model"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type RedirectRepository struct {
+	db  *gorm.DB
+	log *zap.Logger
+}
+
+func NewRedirectRepository(db *gorm.DB, log *zap.Logger) *RedirectRepository {
+	return &RedirectRepository{db: db, log: log}
+}
+
+func (r *RedirectRepository) Create(ctx context.Context, rd *model.Redirect) error {
+	err := r.db.WithContext(ctx).Create(rd).Error
+	if err != nil {
+		r.log.Error("failed to create redirect", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *RedirectRepository) Update(ctx context.Context, rd *model.Redirect) error {
+	err := r.db.WithContext(ctx).Save(rd).Error
+	if err != nil {
+		r.log.Error("failed to update redirect", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// DeleteByID removes the redirect for good; redirects are not soft-deleted.
+func (r *RedirectRepository) DeleteByID(ctx context.Context, id uint) error {
+	res := r.db.WithContext(ctx).Delete(&model.Redirect{}, id)
+	if res.Error != nil {
+		r.log.Error("failed to delete redirect by id", zap.Error(res.Error))
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *RedirectRepository) FindByID(ctx context.Context, id uint) (*model.Redirect, error) {
+	var rd model.Redirect
+	if err := r.db.WithContext(ctx).First(&rd, id).Error; err != nil {
+		r.log.Error("failed to find redirect by id", zap.Error(err))
+		return nil, err
+	}
+	return &rd, nil
+}
+
+// FindByFromPath returns the redirect for path; gorm.ErrRecordNotFound when there is none.
+func (r *RedirectRepository) FindByFromPath(ctx context.Context, path string) (*model.Redirect, error) {
+	var rd model.Redirect
+	if err := r.db.WithContext(ctx).Where("from_path = ?", path).First(&rd).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			r.log.Error("failed to find redirect by path", zap.Error(err))
+		}
+		return nil, err
+	}
+	return &rd, nil
+}
+
+// Hit counts one lookup that matched the redirect.
+func (r *RedirectRepository) Hit(ctx context.Context, id uint, at time.Time) error {
+	err := r.db.WithContext(ctx).Model(&model.Redirect{}).Where("id = ?", id).
+		UpdateColumns(map[string]any{"hits": gorm.Expr("hits + 1"), "last_hit_at": at}).Error
+	if err != nil {
+		r.log.Error("failed to count redirect hit", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+var redirectKeyset = keyset[model.Redirect]{
+	idColumn: "id",
+	id:       func(rd *model.Redirect) uint { return rd.ID },
+	orders: []keysetOrder[model.Redirect]{
+		{name: "newest", desc: true},
+		{name: "oldest"},
+		{name: "hits", column: "hits", kind: keyInt, desc: true, key: func(rd *model.Redirect) any { return rd.Hits }},
+	},
+}
+
+func (r *RedirectRepository) List(ctx context.Context, req request.RedirectListRequest) (CursorPage, error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	build := func() *gorm.DB {
+		q := r.db.WithContext(ctx).Model(&model.Redirect{})
+		if req.Search != "" {
+			q = q.Where("from_path LIKE ? OR to_path LIKE ?", "%"+req.Search+"%", "%"+req.Search+"%")
+		}
+		return q
+	}
+
+	_, page, err := redirectKeyset.page(build, nil, req.Sort, req.CursorRequest, limit)
+	if err != nil && !errors.Is(err, ErrInvalidCursor) {
+		r.log.Error("failed to list redirects", zap.Error(err))
+	}
+	return page, err
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 
 	"github.com/turahe/go-restfull/internal/handler/request"
 	"github.com/turahe/go-restfull/internal/model"
@@ -55,6 +56,15 @@ func (r *RoleRepository) FindByName(ctx context.Context, name string) (*model.Ro
 	return &role, nil
 }
 
+var roleKeyset = keyset[model.Role]{
+	idColumn: "id",
+	id:       func(r *model.Role) uint { return r.ID },
+	orders: []keysetOrder[model.Role]{
+		{name: "oldest"},
+		{name: "name", column: "name", kind: keyString, key: func(r *model.Role) any { return r.Name }},
+	},
+}
+
 func (r *RoleRepository) List(ctx context.Context, req request.RoleListRequest) (CursorPage, error) {
 	limit := req.Limit
 	if limit <= 0 {
@@ -63,53 +73,18 @@ func (r *RoleRepository) List(ctx context.Context, req request.RoleListRequest)
 	if limit > 500 {
 		limit = 500
 	}
-	page := req.Page
-	if page <= 0 {
-		page = 1
-	}
-
-	offset := (page - 1) * limit
 
-	// Count total rows for filtered query (for cursor existence).
-	countQ := r.db.WithContext(ctx).Model(&model.Role{})
-	if req.Name != "" {
-		countQ = countQ.Where("name LIKE ?", "%"+req.Name+"%")
-	}
-	var totalRows int64
-	if err := countQ.Count(&totalRows).Error; err != nil {
-		return CursorPage{}, err
+	build := func() *gorm.DB {
+		q := r.db.WithContext(ctx).Model(&model.Role{})
+		if req.Name != "" {
+			q = q.Where("name LIKE ?", "%"+req.Name+"%")
+		}
+		return q
 	}
 
-	// Fetch current page.
-	dataQ := r.db.WithContext(ctx).Order("id asc")
-	if req.Name != "" {
-		dataQ = dataQ.Where("name LIKE ?", "%"+req.Name+"%")
-	}
-	var rows []model.Role
-	if err := dataQ.
-		Limit(limit).
-		Offset(offset).
-		Find(&rows).Error; err != nil {
+	_, page, err := roleKeyset.page(build, nil, req.Sort, req.CursorRequest, limit)
+	if err != nil && !errors.Is(err, ErrInvalidCursor) {
 		r.log.Error("failed to list roles", zap.Error(err))
-		return CursorPage{}, err
 	}
-
-	if len(rows) == 0 {
-		return CursorPage{Items: []model.Role{}, NextCursor: nil, PrevCursor: nil}, nil
-	}
-
-	// Next/Prev cursors based on classic page existence.
-	var nextCursor *uint
-	if int64(offset)+int64(limit) < totalRows {
-		tmp := rows[len(rows)-1].ID
-		nextCursor = &tmp
-	}
-
-	var prevCursor *uint
-	if offset > 0 {
-		tmp := rows[0].ID
-		prevCursor = &tmp
-	}
-
-	return CursorPage{Items: rows, NextCursor: nextCursor, PrevCursor: prevCursor}, nil
+	return page, err
 }
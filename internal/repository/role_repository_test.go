@@ -27,7 +27,7 @@ func TestRoleRepository_CRUD_FindByName_List(t *testing.T) {
 	assert.Equal(t, r.ID, got.ID)
 
 	page, err := repo.List(ctx, request.RoleListRequest{
-		PageRequest: request.PageRequest{Page: 1, Limit: 10},
+		CursorRequest: request.CursorRequest{Limit: 10},
 	})
 	assert.NoError(t, err)
 	items, ok := page.Items.([]model.Role)
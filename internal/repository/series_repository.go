@@ -0,0 +1,257 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/turahe/go-restfull/internal/handler/request"
+	"github.com/turahe/go-restfull/internal/model"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrChapterSetMismatch is returned by Reorder when the ids given are not exactly the series' posts.
+var ErrChapterSetMismatch = errors.New("post ids must list every post in the series exactly once")
+
+type SeriesRepository struct {
+	db  *gorm.DB
+	log *zap.Logger
+}
+
+func NewSeriesRepository(db *gorm.DB, log *zap.Logger) *SeriesRepository {
+	return &SeriesRepository{db: db, log: log}
+}
+
+func (r *SeriesRepository) Create(ctx context.Context, s *model.Series) error {
+	err := r.db.WithContext(ctx).Create(s).Error
+	if err != nil {
+		r.log.Error("failed to create series", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *SeriesRepository) Update(ctx context.Context, s *model.Series) error {
+	err := r.db.WithContext(ctx).Save(s).Error
+	if err != nil {
+		r.log.Error("failed to update series", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// DeleteByID soft-deletes the series and detaches its posts, which keep existing on their own.
+func (r *SeriesRepository) DeleteByID(ctx context.Context, id uint) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Post{}).Where("series_id = ?", id).
+			UpdateColumns(map[string]any{"series_id": nil, "chapter": 0}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model.Series{}, id).Error
+	})
+	if err != nil {
+		r.log.Error("failed to delete series by id", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *SeriesRepository) FindByID(ctx context.Context, id uint) (*model.Series, error) {
+	var s model.Series
+	if err := r.db.WithContext(ctx).First(&s, id).Error; err != nil {
+		r.log.Error("failed to find series by id", zap.Error(err))
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *SeriesRepository) FindBySlug(ctx context.Context, slug string) (*model.Series, error) {
+	var s model.Series
+	if err := r.db.WithContext(ctx).Where("slug = ?", slug).First(&s).Error; err != nil {
+		r.log.Error("failed to find series by slug", zap.Error(err))
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *SeriesRepository) SlugExists(ctx context.Context, slug string) (bool, error) {
+	var id uint
+	err := r.db.WithContext(ctx).
+		Model(&model.Series{}).
+		Select("id").
+		Where("slug = ?", slug).
+		Limit(1).
+		Scan(&id).Error
+	if err != nil {
+		r.log.Error("failed to check if slug exists", zap.Error(err))
+		return false, err
+	}
+	return id != 0, nil
+}
+
+var seriesKeyset = keyset[model.Series]{
+	idColumn: "id",
+	id:       func(s *model.Series) uint { return s.ID },
+	orders: []keysetOrder[model.Series]{
+		{name: "oldest"},
+		{name: "newest", desc: true},
+		{name: "title", column: "title", kind: keyString, key: func(s *model.Series) any { return s.Title }},
+	},
+}
+
+func (r *SeriesRepository) List(ctx context.Context, req request.SeriesListRequest) (CursorPage, error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	build := func() *gorm.DB {
+		q := r.db.WithContext(ctx).Model(&model.Series{})
+		if req.UserID != nil {
+			q = q.Where("user_id = ?", *req.UserID)
+		}
+		if req.Search != "" {
+			q = q.Where("title LIKE ?", "%"+req.Search+"%")
+		}
+		return q
+	}
+
+	_, page, err := seriesKeyset.page(build, nil, req.Sort, req.CursorRequest, limit)
+	if err != nil && !errors.Is(err, ErrInvalidCursor) {
+		r.log.Error("failed to list series", zap.Error(err))
+	}
+	return page, err
+}
+
+// Chapters returns every post in the series in chapter order, whatever its status.
+func (r *SeriesRepository) Chapters(ctx context.Context, seriesID uint) ([]model.Post, error) {
+	var rows []model.Post
+	err := r.db.WithContext(ctx).
+		Preload("PostSEO").
+		Where("series_id = ?", seriesID).
+		Order("chapter asc, id asc").
+		Find(&rows).Error
+	if err != nil {
+		r.log.Error("failed to list series chapters", zap.Error(err))
+		return nil, err
+	}
+	return rows, nil
+}
+
+// AddPost puts the post into the series at chapter (1-based; 0 or past the end appends), shifting
+// later chapters down. A post already in a series is moved out of it first.
+func (r *SeriesRepository) AddPost(ctx context.Context, seriesID, postID uint, chapter int) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockSeries(tx, seriesID); err != nil {
+			return err
+		}
+		var p model.Post
+		if err := tx.Select("id", "series_id", "chapter").First(&p, postID).Error; err != nil {
+			return err
+		}
+		if p.SeriesID != nil {
+			if err := detachChapter(tx, *p.SeriesID, p.ID, p.Chapter); err != nil {
+				return err
+			}
+		}
+		var n int64
+		if err := tx.Model(&model.Post{}).Where("series_id = ?", seriesID).Count(&n).Error; err != nil {
+			return err
+		}
+		if chapter <= 0 || int64(chapter) > n {
+			chapter = int(n) + 1
+		}
+		if err := tx.Model(&model.Post{}).Where("series_id = ? AND chapter >= ?", seriesID, chapter).
+			UpdateColumn("chapter", gorm.Expr("chapter + 1")).Error; err != nil {
+			return err
+		}
+		return tx.Model(&model.Post{}).Where("id = ?", postID).
+			UpdateColumns(map[string]any{"series_id": seriesID, "chapter": chapter}).Error
+	})
+	if err != nil {
+		r.log.Error("failed to add post to series", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// RemovePost takes the post out of the series and closes the gap it leaves. It returns
+// gorm.ErrRecordNotFound when the post is not in the series.
+func (r *SeriesRepository) RemovePost(ctx context.Context, seriesID, postID uint) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockSeries(tx, seriesID); err != nil {
+			return err
+		}
+		var p model.Post
+		if err := tx.Select("id", "chapter").Where("series_id = ?", seriesID).First(&p, postID).Error; err != nil {
+			return err
+		}
+		return detachChapter(tx, seriesID, p.ID, p.Chapter)
+	})
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		r.log.Error("failed to remove post from series", zap.Error(err))
+	}
+	return err
+}
+
+// Reorder renumbers the series so postIDs[i] becomes chapter i+1. postIDs must hold every post in
+// the series exactly once.
+func (r *SeriesRepository) Reorder(ctx context.Context, seriesID uint, postIDs []uint) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockSeries(tx, seriesID); err != nil {
+			return err
+		}
+		var current []uint
+		if err := tx.Model(&model.Post{}).Where("series_id = ?", seriesID).Pluck("id", &current).Error; err != nil {
+			return err
+		}
+		if len(current) != len(postIDs) {
+			return ErrChapterSetMismatch
+		}
+		member := make(map[uint]bool, len(current))
+		for _, id := range current {
+			member[id] = true
+		}
+		for _, id := range postIDs {
+			if !member[id] {
+				return ErrChapterSetMismatch
+			}
+			delete(member, id)
+		}
+		for i, id := range postIDs {
+			if err := tx.Model(&model.Post{}).Where("id = ?", id).UpdateColumn("chapter", i+1).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, ErrChapterSetMismatch) {
+		r.log.Error("failed to reorder series", zap.Error(err))
+	}
+	return err
+}
+
+// lockSeries serializes chapter changes to one series. It returns gorm.ErrRecordNotFound when the
+// series does not exist.
+func lockSeries(tx *gorm.DB, seriesID uint) error {
+	q := tx.Select("id")
+	if tx.Dialector.Name() == "mysql" {
+		q = q.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+	return q.First(&model.Series{}, seriesID).Error
+}
+
+// detachChapter clears the post's series and moves the chapters after it up by one.
+func detachChapter(tx *gorm.DB, seriesID, postID uint, chapter int) error {
+	if err := tx.Model(&model.Post{}).Where("id = ?", postID).
+		UpdateColumns(map[string]any{"series_id": nil, "chapter": 0}).Error; err != nil {
+		return err
+	}
+	return tx.Model(&model.Post{}).Where("series_id = ? AND chapter > ?", seriesID, chapter).
+		UpdateColumn("chapter", gorm.Expr("chapter - 1")).Error
+}
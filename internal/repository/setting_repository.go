@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/internal/tenant"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -64,7 +65,9 @@ func (r *SettingRepository) Upsert(ctx context.Context, key, value string, isPub
 	err := r.db.WithContext(ctx).Where("setting_key = ?", key).First(&s).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		// Use a map so is_public=false is persisted (GORM skips zero-value struct fields on Create).
+		// Map creates bypass the tenant plugin, so site_id is set explicitly.
 		row := map[string]any{
+			"site_id":     tenant.SiteID(ctx),
 			"setting_key": key,
 			"value":       value,
 			"is_public":   isPublic,
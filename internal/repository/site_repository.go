@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/turahe/go-restfull/internal/model"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type SiteRepository struct {
+	db  *gorm.DB
+	log *zap.Logger
+}
+
+func NewSiteRepository(db *gorm.DB, log *zap.Logger) *SiteRepository {
+	return &SiteRepository{db: db, log: log}
+}
+
+func (r *SiteRepository) Create(ctx context.Context, s *model.Site) error {
+	if err := r.db.WithContext(ctx).Create(s).Error; err != nil {
+		r.log.Error("failed to create site", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *SiteRepository) List(ctx context.Context) ([]model.Site, error) {
+	var rows []model.Site
+	if err := r.db.WithContext(ctx).Order("id asc").Find(&rows).Error; err != nil {
+		r.log.Error("failed to list sites", zap.Error(err))
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (r *SiteRepository) FindByID(ctx context.Context, id uint) (*model.Site, error) {
+	var s model.Site
+	if err := r.db.WithContext(ctx).First(&s, id).Error; err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *SiteRepository) FindByKey(ctx context.Context, key string) (*model.Site, error) {
+	var s model.Site
+	if err := r.db.WithContext(ctx).Where("`key` = ?", key).First(&s).Error; err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *SiteRepository) FindByHost(ctx context.Context, host string) (*model.Site, error) {
+	var s model.Site
+	if err := r.db.WithContext(ctx).Where("host = ?", host).First(&s).Error; err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// ExistsKeyOrHost reports whether another site already uses key or host.
+func (r *SiteRepository) ExistsKeyOrHost(ctx context.Context, key string, host *string) (bool, error) {
+	q := r.db.WithContext(ctx).Model(&model.Site{}).Where("`key` = ?", key)
+	if host != nil {
+		q = q.Or("host = ?", *host)
+	}
+	var n int64
+	if err := q.Count(&n).Error; err != nil {
+		r.log.Error("failed to check site uniqueness", zap.Error(err))
+		return false, err
+	}
+	return n > 0, nil
+}
@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/model"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// SitemapPage summarizes one sitemap page: the rows whose id falls in [(Page-1)*size, Page*size).
+// Count and LastMod change whenever a row of the page is added, removed or updated, so together they
+// fingerprint the page. Pages are id ranges rather than offsets so an insert only touches the last page.
+type SitemapPage struct {
+	Page    int
+	Count   int64
+	LastMod time.Time
+}
+
+// SitemapEntry is one sitemap URL source. Canonical is the post's SEO canonical URL, if any.
+type SitemapEntry struct {
+	ID        uint
+	Slug      string
+	Canonical string
+	UpdatedAt time.Time
+}
+
+// sitemapTime scans MAX(updated_at), which SQLite returns as text rather than a time.
+type sitemapTime struct{ time.Time }
+
+func (t *sitemapTime) Scan(v any) error {
+	switch v := v.(type) {
+	case nil:
+		t.Time = time.Time{}
+	case time.Time:
+		t.Time = v
+	case string:
+		return t.parse(v)
+	case []byte:
+		return t.parse(string(v))
+	default:
+		return fmt.Errorf("sitemap: cannot scan %T as time", v)
+	}
+	return nil
+}
+
+func (t *sitemapTime) parse(s string) error {
+	for _, layout := range []string{"2006-01-02 15:04:05.999999999-07:00", time.RFC3339Nano, "2006-01-02 15:04:05.999999999", "2006-01-02 15:04:05"} {
+		if parsed, err := time.Parse(layout, s); err == nil {
+			t.Time = parsed
+			return nil
+		}
+	}
+	return fmt.Errorf("sitemap: unparseable time %q", s)
+}
+
+func (t sitemapTime) Value() (driver.Value, error) { return t.Time, nil }
+
+// sitemapPages groups the rows of q into id pages of size.
+func sitemapPages(q *gorm.DB, table string, size int) ([]SitemapPage, error) {
+	bucket := fmt.Sprintf("%s.id / %d", table, size)
+	if q.Dialector.Name() == "mysql" {
+		bucket = fmt.Sprintf("%s.id DIV %d", table, size)
+	}
+	var rows []struct {
+		Bucket  int
+		N       int64
+		LastMod sitemapTime
+	}
+	err := q.
+		Select(bucket + " AS bucket, COUNT(*) AS n, MAX(" + table + ".updated_at) AS last_mod").
+		Group("bucket").
+		Order("bucket asc").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	out := make([]SitemapPage, len(rows))
+	for i, r := range rows {
+		out[i] = SitemapPage{Page: r.Bucket + 1, Count: r.N, LastMod: r.LastMod.Time}
+	}
+	return out, nil
+}
+
+// inSitemapPage restricts q to the ids of one page (see SitemapPage).
+func inSitemapPage(q *gorm.DB, table string, page, size int) *gorm.DB {
+	lo := (page - 1) * size
+	return q.Where(table+".id >= ? AND "+table+".id < ?", lo, lo+size).Order(table + ".id asc")
+}
+
+// indexablePosts restricts a posts query to rows live at now whose robots meta does not say noindex.
+func indexablePosts(db *gorm.DB, now time.Time) *gorm.DB {
+	return livePosts(db, now).
+		Joins("LEFT JOIN post_seo ON post_seo.post_id = posts.id").
+		Where("(post_seo.robots_meta IS NULL OR LOWER(post_seo.robots_meta) NOT LIKE ?)", "%noindex%")
+}
+
+// SitemapPages summarizes the sitemap pages of indexable posts live at now.
+func (r *PostRepository) SitemapPages(ctx context.Context, now time.Time, size int) ([]SitemapPage, error) {
+	pages, err := sitemapPages(indexablePosts(r.db.WithContext(ctx).Model(&model.Post{}), now), model.TablePosts, size)
+	if err != nil {
+		r.log.Error("failed to summarize post sitemap", zap.Error(err))
+		return nil, err
+	}
+	return pages, nil
+}
+
+// SitemapEntries returns the indexable posts live at now in one sitemap page, by id.
+func (r *PostRepository) SitemapEntries(ctx context.Context, now time.Time, page, size int) ([]SitemapEntry, error) {
+	var rows []SitemapEntry
+	q := indexablePosts(r.db.WithContext(ctx).Model(&model.Post{}), now).
+		Select("posts.id, posts.slug, COALESCE(post_seo.canonical_url, '') AS canonical, posts.updated_at")
+	if err := inSitemapPage(q, model.TablePosts, page, size).Scan(&rows).Error; err != nil {
+		r.log.Error("failed to list post sitemap entries", zap.Error(err))
+		return nil, err
+	}
+	return rows, nil
+}
+
+// SitemapPages summarizes the sitemap pages of the site's categories.
+func (r *CategoryRepository) SitemapPages(ctx context.Context, size int) ([]SitemapPage, error) {
+	pages, err := sitemapPages(r.db.WithContext(ctx).Model(&model.CategoryModel{}), model.CategoryModel{}.TableName(), size)
+	if err != nil {
+		r.log.Error("failed to summarize category sitemap", zap.Error(err))
+		return nil, err
+	}
+	return pages, nil
+}
+
+// SitemapEntries returns the categories in one sitemap page, by id.
+func (r *CategoryRepository) SitemapEntries(ctx context.Context, page, size int) ([]SitemapEntry, error) {
+	table := model.CategoryModel{}.TableName()
+	var rows []SitemapEntry
+	q := r.db.WithContext(ctx).Model(&model.CategoryModel{}).Select("id, slug, updated_at")
+	if err := inSitemapPage(q, table, page, size).Scan(&rows).Error; err != nil {
+		r.log.Error("failed to list category sitemap entries", zap.Error(err))
+		return nil, err
+	}
+	return rows, nil
+}
+
+// SitemapPages summarizes the sitemap pages of the site's tags.
+func (r *TagRepository) SitemapPages(ctx context.Context, size int) ([]SitemapPage, error) {
+	pages, err := sitemapPages(r.db.WithContext(ctx).Model(&model.Tag{}), model.Tag{}.TableName(), size)
+	if err != nil {
+		r.log.Error("failed to summarize tag sitemap", zap.Error(err))
+		return nil, err
+	}
+	return pages, nil
+}
+
+// SitemapEntries returns the tags in one sitemap page, by id.
+func (r *TagRepository) SitemapEntries(ctx context.Context, page, size int) ([]SitemapEntry, error) {
+	table := model.Tag{}.TableName()
+	var rows []SitemapEntry
+	q := r.db.WithContext(ctx).Model(&model.Tag{}).Select("id, slug, updated_at")
+	if err := inSitemapPage(q, table, page, size).Scan(&rows).Error; err != nil {
+		r.log.Error("failed to list tag sitemap entries", zap.Error(err))
+		return nil, err
+	}
+	return rows, nil
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/model"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// slugHistoryStore reads and writes the slug_histories rows of one entity type.
+type slugHistoryStore struct {
+	db     *gorm.DB
+	log    *zap.Logger
+	entity string
+}
+
+// record notes that id moved from oldSlug to newSlug. oldSlug now redirects to id (taking it over from
+// any earlier owner); newSlug stops redirecting, as it is live again.
+func (s slugHistoryStore) record(ctx context.Context, id uint, oldSlug, newSlug string) error {
+	if oldSlug == newSlug || oldSlug == "" {
+		return nil
+	}
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("entity_type = ? AND slug = ?", s.entity, newSlug).Delete(&model.SlugHistory{}).Error; err != nil {
+			return err
+		}
+		row := model.SlugHistory{EntityType: s.entity, Slug: oldSlug, EntityID: id, CreatedAt: time.Now()}
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "site_id"}, {Name: "entity_type"}, {Name: "slug"}},
+			DoUpdates: clause.AssignmentColumns([]string{"entity_id", "created_at"}),
+		}).Create(&row).Error
+	})
+	if err != nil {
+		s.log.Error("failed to record slug history", zap.String("entity", s.entity), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// resolve returns the id of the entity that last used slug; gorm.ErrRecordNotFound when none did.
+func (s slugHistoryStore) resolve(ctx context.Context, slug string) (uint, error) {
+	var row model.SlugHistory
+	err := s.db.WithContext(ctx).Where("entity_type = ? AND slug = ?", s.entity, slug).First(&row).Error
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			s.log.Error("failed to resolve slug history", zap.String("entity", s.entity), zap.Error(err))
+		}
+		return 0, err
+	}
+	return row.EntityID, nil
+}
+
+func (r *PostRepository) slugHistory() slugHistoryStore {
+	return slugHistoryStore{db: r.db, log: r.log, entity: model.SlugEntityPost}
+}
+
+// RecordSlugChange keeps oldSlug redirecting to post id after it moved to newSlug.
+func (r *PostRepository) RecordSlugChange(ctx context.Context, id uint, oldSlug, newSlug string) error {
+	return r.slugHistory().record(ctx, id, oldSlug, newSlug)
+}
+
+// ResolveOldSlug returns the id of the post that last used slug; gorm.ErrRecordNotFound when none did.
+func (r *PostRepository) ResolveOldSlug(ctx context.Context, slug string) (uint, error) {
+	return r.slugHistory().resolve(ctx, slug)
+}
+
+func (r *CategoryRepository) slugHistory() slugHistoryStore {
+	return slugHistoryStore{db: r.db, log: r.log, entity: model.SlugEntityCategory}
+}
+
+// ResolveOldSlug returns the id of the category that last used slug; gorm.ErrRecordNotFound when none did.
+func (r *CategoryRepository) ResolveOldSlug(ctx context.Context, slug string) (uint, error) {
+	return r.slugHistory().resolve(ctx, slug)
+}
+
+func (r *TagRepository) slugHistory() slugHistoryStore {
+	return slugHistoryStore{db: r.db, log: r.log, entity: model.SlugEntityTag}
+}
+
+// RecordSlugChange keeps oldSlug redirecting to tag id after it moved to newSlug.
+func (r *TagRepository) RecordSlugChange(ctx context.Context, id uint, oldSlug, newSlug string) error {
+	return r.slugHistory().record(ctx, id, oldSlug, newSlug)
+}
+
+// ResolveOldSlug returns the id of the tag that last used slug; gorm.ErrRecordNotFound when none did.
+func (r *TagRepository) ResolveOldSlug(ctx context.Context, slug string) (uint, error) {
+	return r.slugHistory().resolve(ctx, slug)
+}
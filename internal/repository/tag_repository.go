@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 
 	"github.com/turahe/go-restfull/internal/handler/request"
 	"github.com/turahe/go-restfull/internal/model"
@@ -46,6 +47,21 @@ func (r *TagRepository) DeleteByID(ctx context.Context, id uint) error {
 	return nil
 }
 
+// SoftDeleteByID soft-deletes the tag, recording who deleted it.
+func (r *TagRepository) SoftDeleteByID(ctx context.Context, id uint, deletedBy uint) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Tag{}).Where("id = ?", id).Update("deleted_by", deletedBy).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model.Tag{}, id).Error
+	})
+	if err != nil {
+		r.log.Error("failed to soft delete tag by id", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
 func (r *TagRepository) FindByID(ctx context.Context, id uint) (*model.Tag, error) {
 	var t model.Tag
 	if err := r.db.WithContext(ctx).First(&t, id).Error; err != nil {
@@ -79,6 +95,16 @@ func (r *TagRepository) SlugExists(ctx context.Context, slug string) (bool, erro
 	return id != 0, nil
 }
 
+var tagKeyset = keyset[model.Tag]{
+	idColumn: "id",
+	id:       func(t *model.Tag) uint { return t.ID },
+	orders: []keysetOrder[model.Tag]{
+		{name: "oldest"},
+		{name: "newest", desc: true},
+		{name: "name", column: "name", kind: keyString, key: func(t *model.Tag) any { return t.Name }},
+	},
+}
+
 func (r *TagRepository) List(ctx context.Context, req request.TagListRequest) (CursorPage, error) {
 	limit := req.Limit
 	if limit <= 0 {
@@ -88,51 +114,19 @@ func (r *TagRepository) List(ctx context.Context, req request.TagListRequest) (C
 		limit = 200
 	}
 
-	page := req.Page
-	if page <= 0 {
-		page = 1
-	}
-
-	offset := (page - 1) * limit
-
-	countQ := r.db.WithContext(ctx).Model(&model.Tag{})
-	if req.Name != "" {
-		countQ = countQ.Where("name LIKE ?", "%"+req.Name+"%")
-	}
-	var totalRows int64
-	if err := countQ.Count(&totalRows).Error; err != nil {
-		r.log.Error("failed to count tags", zap.Error(err))
-		return CursorPage{}, err
+	build := func() *gorm.DB {
+		q := r.db.WithContext(ctx).Model(&model.Tag{})
+		if req.Name != "" {
+			q = q.Where("name LIKE ?", "%"+req.Name+"%")
+		}
+		return q
 	}
 
-	var rows []model.Tag
-	dataQ := r.db.WithContext(ctx).Order("id asc")
-	if req.Name != "" {
-		dataQ = dataQ.Where("name LIKE ?", "%"+req.Name+"%")
-	}
-	err := dataQ.Limit(limit).Offset(offset).Find(&rows).Error
-	if err != nil {
+	_, page, err := tagKeyset.page(build, nil, req.Sort, req.CursorRequest, limit)
+	if err != nil && !errors.Is(err, ErrInvalidCursor) {
 		r.log.Error("failed to list tags", zap.Error(err))
-		return CursorPage{}, err
-	}
-
-	if len(rows) == 0 {
-		return CursorPage{Items: []model.Tag{}, NextCursor: nil, PrevCursor: nil}, nil
 	}
-
-	var nextCursor *uint
-	if int64(offset)+int64(limit) < totalRows {
-		tmp := rows[len(rows)-1].ID
-		nextCursor = &tmp
-	}
-
-	var prevCursor *uint
-	if offset > 0 {
-		tmp := rows[0].ID
-		prevCursor = &tmp
-	}
-
-	return CursorPage{Items: rows, NextCursor: nextCursor, PrevCursor: prevCursor}, nil
+	return page, err
 }
 
 func (r *TagRepository) FindByIDs(ctx context.Context, ids []uint) ([]model.Tag, error) {
@@ -37,7 +37,7 @@ func TestTagRepository_CRUD_SlugExists_FindByIDs(t *testing.T) {
 	assert.Equal(t, "Golang", got2.Name)
 
 	page, err := repo.List(ctx, request.TagListRequest{
-		PageRequest: request.PageRequest{Page: 1, Limit: 10},
+		CursorRequest: request.CursorRequest{Limit: 10},
 	})
 	assert.NoError(t, err)
 	items, ok := page.Items.([]model.Tag)
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/internal/tenant"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func TestTenantPlugin_IsolatesSites(t *testing.T) {
+	t.Parallel()
+	db := openTestDB(t, &model.Tag{}, &model.Setting{})
+	require.NoError(t, db.Use(tenant.Plugin{}))
+	tags := NewTagRepository(db, zap.NewNop())
+	settings := NewSettingRepository(db, zap.NewNop())
+
+	site1 := tenant.WithSiteID(context.Background(), 1)
+	site2 := tenant.WithSiteID(context.Background(), 2)
+
+	// The same slug may exist once per site.
+	a := &model.Tag{Name: "Go", Slug: "go"}
+	b := &model.Tag{Name: "Go", Slug: "go"}
+	require.NoError(t, tags.Create(site1, a))
+	require.NoError(t, tags.Create(site2, b))
+	assert.Equal(t, uint(1), a.SiteID)
+	assert.Equal(t, uint(2), b.SiteID)
+
+	got, err := tags.FindBySlug(site2, "go")
+	require.NoError(t, err)
+	assert.Equal(t, b.ID, got.ID)
+
+	_, err = tags.FindByID(site1, b.ID)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	// Deleting by id from the wrong site is a no-op.
+	require.NoError(t, tags.DeleteByID(site1, b.ID))
+	_, err = tags.FindByID(site2, b.ID)
+	require.NoError(t, err)
+
+	require.NoError(t, settings.Upsert(site1, "siteTitle", "One", true))
+	require.NoError(t, settings.Upsert(site2, "siteTitle", "Two", true))
+	s, err := settings.FindByKey(site2, "siteTitle")
+	require.NoError(t, err)
+	assert.Equal(t, "Two", s.Value)
+
+	all, err := settings.ListAll(tenant.WithAllSites(context.Background()))
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+}
+
+func TestTenantPlugin_PostViewsPerSite(t *testing.T) {
+	t.Parallel()
+	db := openTestDB(t, &model.Post{}, &model.PostStatDaily{})
+	require.NoError(t, db.Use(tenant.Plugin{}))
+	posts := NewPostRepository(db, zap.NewNop())
+
+	site1 := tenant.WithSiteID(context.Background(), 1)
+	site2 := tenant.WithSiteID(context.Background(), 2)
+	p1 := &model.Post{Title: "One", Slug: "one", Status: model.PostStatusPublished}
+	p2 := &model.Post{Title: "Two", Slug: "two", Status: model.PostStatusPublished}
+	require.NoError(t, db.WithContext(site1).Create(p1).Error)
+	require.NoError(t, db.WithContext(site2).Create(p2).Error)
+
+	// The flusher writes every site's rows at once; each keeps its own site.
+	require.NoError(t, posts.AddDailyViews(tenant.WithAllSites(context.Background()), []model.PostStatDaily{
+		{SiteID: 1, PostID: p1.ID, Day: "2026-03-01", Views: 3},
+		{SiteID: 2, PostID: p2.ID, Day: "2026-03-01", Views: 5},
+	}))
+
+	now := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	got, err := posts.PopularPosts(site2, "2026-03-01", now, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []PostViewCount{{PostID: p2.ID, Views: 5}}, got)
+	total, err := posts.TotalViews(site1, p2.ID)
+	require.NoError(t, err)
+	assert.Zero(t, total)
+}
@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/turahe/go-restfull/internal/model"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// translationStore reads and writes one translation table (category_translations, tag_translations);
+// owner is the column naming the translated row.
+type translationStore[T any] struct {
+	db    *gorm.DB
+	log   *zap.Logger
+	owner string
+}
+
+func (s translationStore[T]) list(ctx context.Context, ownerID uint) ([]T, error) {
+	var rows []T
+	err := s.db.WithContext(ctx).Where(s.owner+" = ?", ownerID).Order("locale asc").Find(&rows).Error
+	if err != nil {
+		s.log.Error("failed to list translations", zap.String("owner", s.owner), zap.Error(err))
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (s translationStore[T]) forLocale(ctx context.Context, ownerIDs []uint, locale string) ([]T, error) {
+	var rows []T
+	if len(ownerIDs) == 0 {
+		return rows, nil
+	}
+	err := s.db.WithContext(ctx).Where(s.owner+" IN ? AND locale = ?", ownerIDs, locale).Find(&rows).Error
+	if err != nil {
+		s.log.Error("failed to load translations", zap.String("owner", s.owner), zap.Error(err))
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (s translationStore[T]) find(ctx context.Context, ownerID uint, locale string) (*T, error) {
+	var row T
+	err := s.db.WithContext(ctx).Where(s.owner+" = ? AND locale = ?", ownerID, locale).First(&row).Error
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			s.log.Error("failed to find translation", zap.String("owner", s.owner), zap.Error(err))
+		}
+		return nil, err
+	}
+	return &row, nil
+}
+
+func (s translationStore[T]) findBySlug(ctx context.Context, slug string) (*T, error) {
+	var row T
+	err := s.db.WithContext(ctx).Where("slug = ?", slug).Order("id asc").First(&row).Error
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			s.log.Error("failed to find translation by slug", zap.String("owner", s.owner), zap.Error(err))
+		}
+		return nil, err
+	}
+	return &row, nil
+}
+
+func (s translationStore[T]) save(ctx context.Context, row *T) error {
+	if err := s.db.WithContext(ctx).Save(row).Error; err != nil {
+		s.log.Error("failed to save translation", zap.String("owner", s.owner), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (s translationStore[T]) delete(ctx context.Context, ownerID uint, locale string) (bool, error) {
+	var row T
+	res := s.db.WithContext(ctx).Where(s.owner+" = ? AND locale = ?", ownerID, locale).Delete(&row)
+	if res.Error != nil {
+		s.log.Error("failed to delete translation", zap.String("owner", s.owner), zap.Error(res.Error))
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
+
+// slugExists reports whether slug is taken in locale by a translation of another row than exceptOwnerID.
+func (s translationStore[T]) slugExists(ctx context.Context, locale, slug string, exceptOwnerID uint) (bool, error) {
+	var n int64
+	var row T
+	err := s.db.WithContext(ctx).
+		Model(&row).
+		Where("locale = ? AND slug = ? AND "+s.owner+" <> ?", locale, slug, exceptOwnerID).
+		Count(&n).Error
+	if err != nil {
+		s.log.Error("failed to check translation slug", zap.String("owner", s.owner), zap.Error(err))
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func (r *CategoryRepository) translations() translationStore[model.CategoryTranslation] {
+	return translationStore[model.CategoryTranslation]{db: r.db, log: r.log, owner: "category_id"}
+}
+
+// ListTranslations returns every translation of a category, by locale.
+func (r *CategoryRepository) ListTranslations(ctx context.Context, categoryID uint) ([]model.CategoryTranslation, error) {
+	return r.translations().list(ctx, categoryID)
+}
+
+// TranslationsForLocale returns the translations in locale of the given categories (missing ones are skipped).
+func (r *CategoryRepository) TranslationsForLocale(ctx context.Context, ids []uint, locale string) ([]model.CategoryTranslation, error) {
+	return r.translations().forLocale(ctx, ids, locale)
+}
+
+// FindTranslation returns gorm.ErrRecordNotFound when the category has no translation in locale.
+func (r *CategoryRepository) FindTranslation(ctx context.Context, categoryID uint, locale string) (*model.CategoryTranslation, error) {
+	return r.translations().find(ctx, categoryID, locale)
+}
+
+func (r *CategoryRepository) SaveTranslation(ctx context.Context, t *model.CategoryTranslation) error {
+	return r.translations().save(ctx, t)
+}
+
+func (r *CategoryRepository) DeleteTranslation(ctx context.Context, categoryID uint, locale string) (bool, error) {
+	return r.translations().delete(ctx, categoryID, locale)
+}
+
+func (r *CategoryRepository) TranslationSlugExists(ctx context.Context, locale, slug string, exceptCategoryID uint) (bool, error) {
+	return r.translations().slugExists(ctx, locale, slug, exceptCategoryID)
+}
+
+func (r *TagRepository) translations() translationStore[model.TagTranslation] {
+	return translationStore[model.TagTranslation]{db: r.db, log: r.log, owner: "tag_id"}
+}
+
+// ListTranslations returns every translation of a tag, by locale.
+func (r *TagRepository) ListTranslations(ctx context.Context, tagID uint) ([]model.TagTranslation, error) {
+	return r.translations().list(ctx, tagID)
+}
+
+// TranslationsForLocale returns the translations in locale of the given tags (missing ones are skipped).
+func (r *TagRepository) TranslationsForLocale(ctx context.Context, ids []uint, locale string) ([]model.TagTranslation, error) {
+	return r.translations().forLocale(ctx, ids, locale)
+}
+
+// FindTranslation returns gorm.ErrRecordNotFound when the tag has no translation in locale.
+func (r *TagRepository) FindTranslation(ctx context.Context, tagID uint, locale string) (*model.TagTranslation, error) {
+	return r.translations().find(ctx, tagID, locale)
+}
+
+// FindTranslationBySlug returns the oldest translation with slug, in any locale.
+func (r *TagRepository) FindTranslationBySlug(ctx context.Context, slug string) (*model.TagTranslation, error) {
+	return r.translations().findBySlug(ctx, slug)
+}
+
+func (r *TagRepository) SaveTranslation(ctx context.Context, t *model.TagTranslation) error {
+	return r.translations().save(ctx, t)
+}
+
+func (r *TagRepository) DeleteTranslation(ctx context.Context, tagID uint, locale string) (bool, error) {
+	return r.translations().delete(ctx, tagID, locale)
+}
+
+func (r *TagRepository) TranslationSlugExists(ctx context.Context, locale, slug string, exceptTagID uint) (bool, error) {
+	return r.translations().slugExists(ctx, locale, slug, exceptTagID)
+}
@@ -0,0 +1,493 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/handler/request"
+	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/internal/tenant"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Trash types, as named in /trash paths and queries.
+const (
+	TrashPosts      = "posts"
+	TrashCategories = "categories"
+	TrashComments   = "comments"
+	TrashTags       = "tags"
+	TrashMedia      = "media"
+)
+
+var (
+	// ErrInvalidTrashType is returned for a type that has no trash.
+	ErrInvalidTrashType = errors.New("invalid trash type")
+	// ErrTrashParentDeleted is returned when restoring an entry whose parent (category, folder,
+	// comment or post) was deleted on its own and is still in the trash or gone.
+	ErrTrashParentDeleted = errors.New("the parent of this item is deleted; restore it first")
+	// ErrTrashInUse is returned when purging categories that posts, deleted ones included, still use.
+	ErrTrashInUse = errors.New("posts still use this category; purge or move them first")
+	// ErrTrashConflict is returned when a restored item would collide with a live one.
+	ErrTrashConflict = errors.New("a live item already uses this item's unique fields")
+)
+
+// TrashEntry is a deleted item that can be restored or purged: a post or tag, or the top of a
+// category, comment or media subtree that was deleted in one go.
+type TrashEntry struct {
+	Type   string `json:"type" gorm:"-"`
+	ID     uint   `json:"id"`
+	SiteID uint   `json:"-"`
+	Title  string `json:"title"`
+	// PostID is set on comments.
+	PostID *uint `json:"postId,omitempty"`
+	// Items counts the rows a restore brings back: the entry and the descendants deleted with it.
+	Items     int       `json:"items" gorm:"-"`
+	DeletedAt time.Time `json:"deletedAt"`
+	DeletedBy *uint     `json:"deletedBy,omitempty"`
+	// PurgeAt is when the entry is purged automatically; absent when auto-purge is off.
+	PurgeAt *time.Time `json:"purgeAt,omitempty" gorm:"-"`
+
+	Lft int `json:"-"`
+	Rgt int `json:"-"`
+}
+
+var trashKeyset = keyset[TrashEntry]{
+	idColumn: "id",
+	id:       func(e *TrashEntry) uint { return e.ID },
+	orders: []keysetOrder[TrashEntry]{
+		{name: "recent", column: "deleted_at", kind: keyTime, desc: true, key: func(e *TrashEntry) any { return e.DeletedAt }},
+		{name: "oldest", column: "deleted_at", kind: keyTime, key: func(e *TrashEntry) any { return e.DeletedAt }},
+	},
+}
+
+// trashTable describes one soft-deleted table. Nested tables keep their nested set per forest:
+// scope names the column that splits the table into forests (besides the site).
+type trashTable struct {
+	model  any
+	table  string
+	title  string
+	nested bool
+	scope  string
+}
+
+var trashTables = map[string]trashTable{
+	TrashPosts:      {model: &model.Post{}, table: model.TablePosts, title: "title"},
+	TrashCategories: {model: &model.CategoryModel{}, table: "categories", title: "name", nested: true},
+	TrashComments:   {model: &model.Comment{}, table: "comments", title: "SUBSTR(content, 1, 120)", nested: true, scope: "post_id"},
+	TrashTags:       {model: &model.Tag{}, table: "tags", title: "name"},
+	TrashMedia:      {model: &model.Media{}, table: "media", title: "name", nested: true, scope: "user_id"},
+}
+
+func trashTableOf(typ string) (trashTable, error) {
+	t, ok := trashTables[typ]
+	if !ok {
+		return trashTable{}, ErrInvalidTrashType
+	}
+	return t, nil
+}
+
+// entries selects the table's trash entries. In nested tables a deleted row whose parent was deleted
+// in the same statement belongs to its parent's entry.
+func (t trashTable) entries(db *gorm.DB) *gorm.DB {
+	q := db.Unscoped().Model(t.model).Where(t.table + ".deleted_at IS NOT NULL")
+	if t.nested {
+		q = q.Where(fmt.Sprintf("NOT EXISTS (SELECT 1 FROM %[1]s AS p WHERE p.id = %[1]s.parent_id AND p.deleted_at = %[1]s.deleted_at)", t.table))
+	}
+	return q
+}
+
+func (t trashTable) columns(q *gorm.DB) *gorm.DB {
+	cols := []string{"id", "site_id", t.title + " AS title", "deleted_at", "deleted_by"}
+	if t.nested {
+		cols = append(cols, "lft", "rgt")
+	}
+	if t.scope == "post_id" {
+		cols = append(cols, "post_id")
+	}
+	return q.Select(cols)
+}
+
+// trashNode is the part of a deleted row that restoring and purging need.
+type trashNode struct {
+	ID         uint
+	ParentID   *uint
+	PostID     uint
+	UserID     uint
+	CategoryID uint
+	Lft        int
+	Rgt        int
+	Depth      int
+}
+
+// forest restricts q to the nested set n belongs to.
+func (t trashTable) forest(n trashNode) func(*gorm.DB) *gorm.DB {
+	return func(q *gorm.DB) *gorm.DB {
+		switch t.scope {
+		case "post_id":
+			return q.Where("post_id = ?", n.PostID)
+		case "user_id":
+			return q.Where("user_id = ?", n.UserID)
+		}
+		return q
+	}
+}
+
+type TrashRepository struct {
+	db  *gorm.DB
+	log *zap.Logger
+}
+
+func NewTrashRepository(db *gorm.DB, log *zap.Logger) *TrashRepository {
+	return &TrashRepository{db: db, log: log}
+}
+
+func (r *TrashRepository) List(ctx context.Context, req request.TrashListRequest) (CursorPage, error) {
+	t, err := trashTableOf(req.Type)
+	if err != nil {
+		return CursorPage{}, err
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	build := func() *gorm.DB { return t.entries(r.db.WithContext(ctx)) }
+	rows, page, err := trashKeyset.page(build, t.columns, req.Sort, req.CursorRequest, limit)
+	if err != nil {
+		if !errors.Is(err, ErrInvalidCursor) {
+			r.log.Error("failed to list trash", zap.String("type", req.Type), zap.Error(err))
+		}
+		return page, err
+	}
+	for i := range rows {
+		rows[i].Type = req.Type
+		rows[i].Items = 1
+		if t.nested {
+			rows[i].Items = (rows[i].Rgt - rows[i].Lft + 1) / 2
+		}
+	}
+	return page, nil
+}
+
+// Expired returns up to limit entries of typ deleted before cutoff, on every site, oldest first.
+func (r *TrashRepository) Expired(ctx context.Context, typ string, cutoff time.Time, limit int) ([]TrashEntry, error) {
+	t, err := trashTableOf(typ)
+	if err != nil {
+		return nil, err
+	}
+	var rows []TrashEntry
+	err = t.columns(t.entries(r.db.WithContext(tenant.WithAllSites(ctx)))).
+		Where(t.table+".deleted_at < ?", cutoff).
+		Order(t.table + ".deleted_at ASC").Order(t.table + ".id ASC").
+		Limit(limit).Find(&rows).Error
+	if err != nil {
+		r.log.Error("failed to list expired trash", zap.String("type", typ), zap.Error(err))
+		return nil, err
+	}
+	for i := range rows {
+		rows[i].Type = typ
+	}
+	return rows, nil
+}
+
+// entry loads trash entry id of t. It returns gorm.ErrRecordNotFound unless the row is deleted and
+// is an entry of its own (not part of a subtree deleted with its parent).
+func (r *TrashRepository) entry(tx *gorm.DB, t trashTable, id uint) (trashNode, error) {
+	cols := []string{"id"}
+	switch {
+	case t.nested:
+		cols = append(cols, "parent_id", "lft", "rgt", "depth")
+		if t.scope != "" {
+			cols = append(cols, t.scope)
+		}
+	case t.table == model.TablePosts:
+		cols = append(cols, "category_id")
+	}
+	var n trashNode
+	if err := t.entries(tx).Select(cols).Where(t.table+".id = ?", id).Take(&n).Error; err != nil {
+		return trashNode{}, err
+	}
+	return n, nil
+}
+
+// Restore brings back trash entry id of typ. A category, comment or media entry comes back with the
+// whole subtree deleted with it, as the last child of its parent (or the last root).
+func (r *TrashRepository) Restore(ctx context.Context, typ string, id uint, actorUserID uint) error {
+	t, err := trashTableOf(typ)
+	if err != nil {
+		return err
+	}
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		n, err := r.entry(tx, t, id)
+		if err != nil {
+			return err
+		}
+		switch typ {
+		case TrashPosts:
+			var live int64
+			if err := tx.Model(&model.CategoryModel{}).Where("id = ?", n.CategoryID).Count(&live).Error; err != nil {
+				return err
+			}
+			if live == 0 {
+				return ErrTrashParentDeleted
+			}
+			return tx.Unscoped().Model(&model.Post{}).Where("id = ?", id).UpdateColumns(map[string]any{
+				"deleted_at": nil, "deleted_by": nil, "updated_by": actorUserID, "updated_at": time.Now(),
+			}).Error
+		case TrashTags:
+			return tx.Unscoped().Model(&model.Tag{}).Where("id = ?", id).UpdateColumns(map[string]any{
+				"deleted_at": nil, "deleted_by": nil, "updated_at": time.Now(),
+			}).Error
+		}
+		unlock, err := lockTrashForest(tx, typ, n)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+		if typ == TrashComments {
+			var live int64
+			if err := tx.Model(&model.Post{}).Where("id = ?", n.PostID).Count(&live).Error; err != nil {
+				return err
+			}
+			if live == 0 {
+				return ErrTrashParentDeleted
+			}
+		}
+		return restoreSubtree(tx, t, n, actorUserID)
+	})
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return ErrTrashConflict
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && !errors.Is(err, ErrTrashParentDeleted) {
+		r.log.Error("failed to restore from trash", zap.String("type", typ), zap.Uint("id", id), zap.Error(err))
+	}
+	return err
+}
+
+func lockTrashForest(tx *gorm.DB, typ string, n trashNode) (func(), error) {
+	switch typ {
+	case TrashCategories:
+		if err := lockNestedSet(tx); err != nil {
+			return nil, err
+		}
+		return func() { unlockNestedSet(tx) }, nil
+	case TrashComments:
+		if err := lockCommentPost(tx, n.PostID); err != nil {
+			return nil, err
+		}
+		return func() { unlockCommentPost(tx, n.PostID) }, nil
+	default:
+		if err := lockMediaUser(tx, n.UserID); err != nil {
+			return nil, err
+		}
+		return func() { unlockMediaUser(tx, n.UserID) }, nil
+	}
+}
+
+// restoreSubtree undoes DeleteSubtree for entry n. The rows it deleted kept their lft/rgt/depth and
+// share n's deleted_at; they are renumbered into a gap opened after the parent's last child.
+func restoreSubtree(tx *gorm.DB, t trashTable, n trashNode, actorUserID uint) error {
+	forest := t.forest(n)
+	var ids []uint
+	err := tx.Unscoped().Model(t.model).Scopes(forest).
+		Where(fmt.Sprintf("deleted_at = (SELECT d.deleted_at FROM %s AS d WHERE d.id = ?)", t.table), n.ID).
+		Where("lft BETWEEN ? AND ?", n.Lft, n.Rgt).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return err
+	}
+
+	lft, depth := 1, 0
+	if n.ParentID != nil {
+		var parent trashNode
+		err := tx.Model(t.model).Scopes(forest).Select("id", "rgt", "depth").Where("id = ?", *n.ParentID).Take(&parent).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrTrashParentDeleted
+		}
+		if err != nil {
+			return err
+		}
+		lft, depth = parent.Rgt, parent.Depth+1
+		width := n.Rgt - n.Lft + 1
+		if err := tx.Model(t.model).Scopes(forest).Where("rgt >= ?", lft).UpdateColumn("rgt", gorm.Expr("rgt + ?", width)).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(t.model).Scopes(forest).Where("lft > ?", lft).UpdateColumn("lft", gorm.Expr("lft + ?", width)).Error; err != nil {
+			return err
+		}
+	} else {
+		var maxRgt *int
+		if err := tx.Model(t.model).Scopes(forest).Select("MAX(rgt)").Scan(&maxRgt).Error; err != nil {
+			return err
+		}
+		if maxRgt != nil {
+			lft = *maxRgt + 1
+		}
+	}
+
+	shift := lft - n.Lft
+	return tx.Unscoped().Model(t.model).Where("id IN ?", ids).UpdateColumns(map[string]any{
+		"lft":        gorm.Expr("lft + ?", shift),
+		"rgt":        gorm.Expr("rgt + ?", shift),
+		"depth":      gorm.Expr("depth + ?", depth-n.Depth),
+		"deleted_at": nil,
+		"deleted_by": nil,
+		"updated_by": actorUserID,
+		"updated_at": time.Now(),
+	}).Error
+}
+
+// Purge permanently removes trash entry id of typ with everything that hangs off it: a post's
+// comments, SEO, tags, stats and history; a subtree's descendants, including ones deleted earlier.
+// For media it returns the storage keys of the removed files, which the caller deletes.
+func (r *TrashRepository) Purge(ctx context.Context, typ string, id uint) ([]string, error) {
+	t, err := trashTableOf(typ)
+	if err != nil {
+		return nil, err
+	}
+	var keys []string
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		n, err := r.entry(tx, t, id)
+		if err != nil {
+			return err
+		}
+		switch typ {
+		case TrashPosts:
+			return purgePost(tx, id)
+		case TrashTags:
+			return purgeTag(tx, id)
+		}
+		ids, err := subtreeIDs(tx, t, n)
+		if err != nil {
+			return err
+		}
+		switch typ {
+		case TrashCategories:
+			return purgeCategories(tx, ids)
+		case TrashComments:
+			return purgeComments(tx, ids)
+		default:
+			keys, err = purgeMedia(tx, ids)
+			return err
+		}
+	})
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) && !errors.Is(err, ErrTrashInUse) {
+			r.log.Error("failed to purge from trash", zap.String("type", typ), zap.Uint("id", id), zap.Error(err))
+		}
+		return nil, err
+	}
+	return keys, nil
+}
+
+// subtreeIDs returns n and every row below it by parent_id; all of them are deleted, as a live row
+// cannot sit under a deleted one.
+func subtreeIDs(tx *gorm.DB, t trashTable, n trashNode) ([]uint, error) {
+	ids := []uint{n.ID}
+	for frontier := ids; len(frontier) > 0; {
+		var next []uint
+		if err := tx.Unscoped().Model(t.model).Scopes(t.forest(n)).Where("parent_id IN ?", frontier).Pluck("id", &next).Error; err != nil {
+			return nil, err
+		}
+		ids = append(ids, next...)
+		frontier = next
+	}
+	return ids, nil
+}
+
+func purgePost(tx *gorm.DB, id uint) error {
+	var commentIDs []uint
+	if err := tx.Unscoped().Model(&model.Comment{}).Where("post_id = ?", id).Pluck("id", &commentIDs).Error; err != nil {
+		return err
+	}
+	if err := purgeComments(tx, commentIDs); err != nil {
+		return err
+	}
+	for _, m := range []any{&model.PostSEO{}, &model.PostMedia{}, &model.PostTag{}, &model.PostContributor{}, &model.PostRevision{},
+		&model.PostPreviewLink{}, &model.PostStatDaily{}, &model.ReadingListItem{}, &model.PostReviewer{}, &model.PostReviewComment{}, &model.PostTransition{}} {
+		if err := tx.Where("post_id = ?", id).Delete(m).Error; err != nil {
+			return err
+		}
+	}
+	if err := tx.Where("target_type = ? AND target_id = ?", model.ReactionTargetPost, id).Delete(&model.Reaction{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("entity_type = ? AND entity_id = ?", model.SlugEntityPost, id).Delete(&model.SlugHistory{}).Error; err != nil {
+		return err
+	}
+	return tx.Unscoped().Delete(&model.Post{}, id).Error
+}
+
+func purgeTag(tx *gorm.DB, id uint) error {
+	if err := tx.Where("tag_id = ?", id).Delete(&model.PostTag{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Exec("DELETE FROM comment_tags WHERE tag_id = ?", id).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("tag_id = ?", id).Delete(&model.TagTranslation{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("entity_type = ? AND entity_id = ?", model.SlugEntityTag, id).Delete(&model.SlugHistory{}).Error; err != nil {
+		return err
+	}
+	return tx.Unscoped().Delete(&model.Tag{}, id).Error
+}
+
+func purgeCategories(tx *gorm.DB, ids []uint) error {
+	var used int64
+	if err := tx.Unscoped().Model(&model.Post{}).Where("category_id IN ?", ids).Count(&used).Error; err != nil {
+		return err
+	}
+	if used > 0 {
+		return ErrTrashInUse
+	}
+	if err := tx.Exec("DELETE FROM category_media WHERE category_id IN ?", ids).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("category_id IN ?", ids).Delete(&model.CategoryTranslation{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("entity_type = ? AND entity_id IN ?", model.SlugEntityCategory, ids).Delete(&model.SlugHistory{}).Error; err != nil {
+		return err
+	}
+	return tx.Unscoped().Where("id IN ?", ids).Delete(&model.CategoryModel{}).Error
+}
+
+func purgeComments(tx *gorm.DB, ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	if err := tx.Exec("DELETE FROM comment_tags WHERE comment_id IN ?", ids).Error; err != nil {
+		return err
+	}
+	if err := tx.Exec("DELETE FROM comment_media WHERE comment_id IN ?", ids).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("target_type = ? AND target_id IN ?", model.ReactionTargetComment, ids).Delete(&model.Reaction{}).Error; err != nil {
+		return err
+	}
+	return tx.Unscoped().Where("id IN ?", ids).Delete(&model.Comment{}).Error
+}
+
+func purgeMedia(tx *gorm.DB, ids []uint) ([]string, error) {
+	var keys []string
+	if err := tx.Unscoped().Model(&model.Media{}).
+		Where("id IN ? AND media_type <> ? AND storage_path <> ''", ids, "folder").
+		Pluck("storage_path", &keys).Error; err != nil {
+		return nil, err
+	}
+	if err := deleteMediaJoinRows(tx, ids); err != nil {
+		return nil, err
+	}
+	if err := tx.Unscoped().Where("id IN ?", ids).Delete(&model.Media{}).Error; err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
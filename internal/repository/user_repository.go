@@ -6,6 +6,7 @@ import (
 
 	"github.com/turahe/go-restfull/internal/handler/request"
 	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/internal/tenant"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -21,8 +22,9 @@ func NewUserRepository(db *gorm.DB, log *zap.Logger) *UserRepository {
 }
 
 func (r *UserRepository) loadAvatar(ctx context.Context, user *model.User) (*model.Media, error) {
+	// Users are shared across sites, so their avatar is too.
 	var avatar model.Media
-	err := r.db.WithContext(ctx).
+	err := r.db.WithContext(tenant.WithAllSites(ctx)).
 		Model(&model.Media{}).
 		Joins("INNER JOIN user_media ON media.id = user_media.media_id").
 		Where("user_media.user_id = ? AND user_media.type = ?", user.ID, "avatar").
@@ -89,6 +91,16 @@ func (r *UserRepository) FindByID(ctx context.Context, id uint) (*model.User, er
 	return &u, nil
 }
 
+var userKeyset = keyset[model.User]{
+	idColumn: "id",
+	id:       func(u *model.User) uint { return u.ID },
+	orders: []keysetOrder[model.User]{
+		{name: "oldest"},
+		{name: "newest", desc: true},
+		{name: "name", column: "name", kind: keyString, key: func(u *model.User) any { return u.Name }},
+	},
+}
+
 func (r *UserRepository) List(ctx context.Context, req request.UserListRequest) (CursorPage, error) {
 	limit := req.Limit
 	if limit <= 0 {
@@ -98,62 +110,24 @@ func (r *UserRepository) List(ctx context.Context, req request.UserListRequest)
 		limit = 500
 	}
 
-	page := req.Page
-	if page <= 0 {
-		page = 1
-	}
-	offset := (page - 1) * limit
-
-	// Count total rows for pagination existence.
-	countQ := r.db.WithContext(ctx).Model(&model.User{})
-	if req.Name != "" {
-		countQ = countQ.Where("name LIKE ?", "%"+req.Name+"%")
-	}
-	if req.Email != "" {
-		countQ = countQ.Where("email = ?", req.Email)
-	}
-	// req.Role is currently ignored here because User model does not include role directly.
-	var totalRows int64
-	if err := countQ.Count(&totalRows).Error; err != nil {
-		r.log.Error("failed to count users", zap.Error(err))
-		return CursorPage{}, err
-	}
-
-	// Fetch page items.
-	var rows []model.User
-	dataQ := r.db.WithContext(ctx)
-
-	if req.Name != "" {
-		dataQ = dataQ.Where("name LIKE ?", "%"+req.Name+"%")
-	}
-	if req.Email != "" {
-		dataQ = dataQ.Where("email = ?", req.Email)
-	}
-
-	if err := dataQ.
-		Preload("Roles").
-		Order("id asc").
-		Limit(limit).
-		Offset(offset).
-		Find(&rows).Error; err != nil {
-		r.log.Error("failed to list users", zap.Error(err))
-		return CursorPage{}, err
-	}
-
-	if len(rows) == 0 {
-		return CursorPage{Items: []model.User{}, NextCursor: nil, PrevCursor: nil}, nil
-	}
-
-	var nextCursor *uint
-	if int64(offset)+int64(limit) < totalRows {
-		tmp := rows[len(rows)-1].ID
-		nextCursor = &tmp
+	build := func() *gorm.DB {
+		q := r.db.WithContext(ctx).Model(&model.User{})
+		if req.Name != "" {
+			q = q.Where("name LIKE ?", "%"+req.Name+"%")
+		}
+		if req.Email != "" {
+			q = q.Where("email = ?", req.Email)
+		}
+		return q
 	}
+	preload := func(q *gorm.DB) *gorm.DB { return q.Preload("Roles") }
 
-	var prevCursor *uint
-	if page > 1 {
-		tmp := rows[0].ID
-		prevCursor = &tmp
+	rows, page, err := userKeyset.page(build, preload, req.Sort, req.CursorRequest, limit)
+	if err != nil {
+		if !errors.Is(err, ErrInvalidCursor) {
+			r.log.Error("failed to list users", zap.Error(err))
+		}
+		return page, err
 	}
 
 	// Attach avatar for each returned user row.
@@ -165,8 +139,7 @@ func (r *UserRepository) List(ctx context.Context, req request.UserListRequest)
 		}
 		rows[i].Avatar = &avatar.DownloadURL
 	}
-
-	return CursorPage{Items: rows, NextCursor: nextCursor, PrevCursor: prevCursor}, nil
+	return page, nil
 }
 
 func (r *UserRepository) UpdatePassword(ctx context.Context, userID uint, newHash string) error {
@@ -66,7 +66,7 @@ func BenchmarkUserRepository_List(b *testing.B) {
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		_, _ = repo.List(ctx, request.UserListRequest{
-			PageRequest: request.PageRequest{Page: 1, Limit: 20},
+			CursorRequest: request.CursorRequest{Limit: 20},
 		})
 	}
 }
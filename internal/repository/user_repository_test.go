@@ -43,7 +43,7 @@ func TestUserRepository_List_LimitClamp(t *testing.T) {
 	}
 
 	page, err := repo.List(ctx, request.UserListRequest{
-		PageRequest: request.PageRequest{Page: 1, Limit: 2},
+		CursorRequest: request.CursorRequest{Limit: 2},
 	})
 	assert.NoError(t, err)
 	items, ok := page.Items.([]model.User)
@@ -51,7 +51,7 @@ func TestUserRepository_List_LimitClamp(t *testing.T) {
 	assert.Len(t, items, 2)
 
 	page2, err := repo.List(ctx, request.UserListRequest{
-		PageRequest: request.PageRequest{Page: 1, Limit: -1},
+		CursorRequest: request.CursorRequest{Limit: -1},
 	})
 	assert.NoError(t, err)
 	items2, ok := page2.Items.([]model.User)
@@ -0,0 +1,255 @@
+package search
+
+import (
+	"context"
+	"encoding/gob"
+	"errors"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"sync"
+)
+
+// BM25 parameters; title terms count titleBoost times.
+const (
+	bm25K1     = 1.2
+	bm25B      = 0.75
+	titleBoost = 3
+	snippetLen = 160
+)
+
+type memDoc struct {
+	doc    Document
+	tf     map[string]float64
+	length float64
+}
+
+// Memory is an embedded BM25 index kept in process memory. It can be saved to and loaded from a
+// file so restarts need not rebuild it; each replica holds its own copy.
+type Memory struct {
+	mu       sync.RWMutex
+	docs     map[uint]*memDoc
+	postings map[uint]map[string]map[uint]struct{} // site -> term -> doc ids
+	totalLen map[uint]float64                      // site -> summed document length
+}
+
+var _ Index = (*Memory)(nil)
+
+func NewMemory() *Memory {
+	return &Memory{
+		docs:     map[uint]*memDoc{},
+		postings: map[uint]map[string]map[uint]struct{}{},
+		totalLen: map[uint]float64{},
+	}
+}
+
+func (m *Memory) Engine() string { return EngineMemory }
+
+func (m *Memory) Index(_ context.Context, docs ...Document) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, d := range docs {
+		m.remove(d.ID)
+		md := &memDoc{doc: d, tf: map[string]float64{}}
+		for _, t := range tokens(d.Title) {
+			md.tf[t.term] += titleBoost
+			md.length += titleBoost
+		}
+		for _, text := range []string{d.Excerpt, d.Content} {
+			for _, t := range tokens(plainText(text)) {
+				md.tf[t.term]++
+				md.length++
+			}
+		}
+		m.docs[d.ID] = md
+		site := m.postings[d.SiteID]
+		if site == nil {
+			site = map[string]map[uint]struct{}{}
+			m.postings[d.SiteID] = site
+		}
+		for term := range md.tf {
+			if site[term] == nil {
+				site[term] = map[uint]struct{}{}
+			}
+			site[term][d.ID] = struct{}{}
+		}
+		m.totalLen[d.SiteID] += md.length
+	}
+	return nil
+}
+
+func (m *Memory) Delete(_ context.Context, ids ...uint) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, id := range ids {
+		m.remove(id)
+	}
+	return nil
+}
+
+func (m *Memory) remove(id uint) {
+	md, ok := m.docs[id]
+	if !ok {
+		return
+	}
+	site := m.postings[md.doc.SiteID]
+	for term := range md.tf {
+		delete(site[term], id)
+		if len(site[term]) == 0 {
+			delete(site, term)
+		}
+	}
+	m.totalLen[md.doc.SiteID] -= md.length
+	delete(m.docs, id)
+}
+
+func (m *Memory) Reset(_ context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.docs = map[uint]*memDoc{}
+	m.postings = map[uint]map[string]map[uint]struct{}{}
+	m.totalLen = map[uint]float64{}
+	return nil
+}
+
+func (m *Memory) Search(_ context.Context, q Query) (Result, error) {
+	terms := Terms(q.Text)
+	if len(terms) == 0 {
+		return Result{}, ErrEmptyQuery
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	site := m.postings[q.SiteID]
+	siteDocs := 0
+	for _, md := range m.docs {
+		if md.doc.SiteID == q.SiteID {
+			siteDocs++
+		}
+	}
+	if siteDocs == 0 {
+		return Result{Hits: []Hit{}}, nil
+	}
+	avgLen := m.totalLen[q.SiteID] / float64(siteDocs)
+
+	scores := map[uint]float64{}
+	for _, term := range terms {
+		ids := site[term]
+		if len(ids) == 0 {
+			continue
+		}
+		n := float64(len(ids))
+		idf := math.Log(1 + (float64(siteDocs)-n+0.5)/(n+0.5))
+		for id := range ids {
+			md := m.docs[id]
+			if !matches(&md.doc, q) {
+				continue
+			}
+			tf := md.tf[term]
+			scores[id] += idf * tf * (bm25K1 + 1) / (tf + bm25K1*(1-bm25B+bm25B*md.length/avgLen))
+		}
+	}
+
+	ids := make([]uint, 0, len(scores))
+	for id := range scores {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if scores[ids[i]] != scores[ids[j]] {
+			return scores[ids[i]] > scores[ids[j]]
+		}
+		return ids[i] > ids[j]
+	})
+
+	res := Result{Total: len(ids), Hits: []Hit{}}
+	start := min(max(q.Offset, 0), len(ids))
+	end := len(ids)
+	if q.Limit > 0 {
+		end = min(start+q.Limit, len(ids))
+	}
+	for _, id := range ids[start:end] {
+		d := &m.docs[id].doc
+		res.Hits = append(res.Hits, Hit{
+			ID:      id,
+			Score:   math.Round(scores[id]*1e4) / 1e4,
+			Title:   Highlight(d.Title, terms),
+			Snippet: Snippet(d.Content, terms, snippetLen),
+		})
+	}
+	return res, nil
+}
+
+func matches(d *Document, q Query) bool {
+	if d.SiteID != q.SiteID || !d.liveAt(q.LiveAt) {
+		return false
+	}
+	if q.CategoryID != 0 && d.CategoryID != q.CategoryID {
+		return false
+	}
+	if q.AuthorID != 0 && d.AuthorID != q.AuthorID {
+		return false
+	}
+	if q.From != nil && d.PublishedAt.Before(*q.From) {
+		return false
+	}
+	if q.To != nil && d.PublishedAt.After(*q.To) {
+		return false
+	}
+	for _, tag := range q.TagIDs {
+		if !slices.Contains(d.TagIDs, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+// Save writes every indexed document to path (atomically, via a temporary file).
+func (m *Memory) Save(path string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".search-*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if err := m.write(tmp); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (m *Memory) write(w io.Writer) error {
+	m.mu.RLock()
+	docs := make([]Document, 0, len(m.docs))
+	for _, md := range m.docs {
+		docs = append(docs, md.doc)
+	}
+	m.mu.RUnlock()
+	return gob.NewEncoder(w).Encode(docs)
+}
+
+// Load replaces the index with the documents saved at path. It reports false, without error, when
+// the file does not exist.
+func (m *Memory) Load(ctx context.Context, path string) (bool, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = f.Close() }()
+	var docs []Document
+	if err := gob.NewDecoder(f).Decode(&docs); err != nil {
+		return false, err
+	}
+	if err := m.Reset(ctx); err != nil {
+		return false, err
+	}
+	return true, m.Index(ctx, docs...)
+}
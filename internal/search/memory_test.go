@@ -0,0 +1,96 @@
+package search
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemory_SearchRanksAndFilters(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	future, past := now.Add(time.Hour), now.Add(-time.Hour)
+	m := NewMemory()
+	require.NoError(t, m.Index(ctx,
+		Document{ID: 1, SiteID: 1, Title: "Go generics", Content: "A tour of <b>type parameters</b> in Go.", AuthorID: 7, CategoryID: 3, TagIDs: []uint{1, 2}, Status: model.PostStatusPublished, PublishedAt: now.AddDate(0, -2, 0)},
+		Document{ID: 2, SiteID: 1, Title: "Cooking pasta", Content: "Boil water. Mention go once.", AuthorID: 8, CategoryID: 4, Status: model.PostStatusPublished, PublishedAt: now},
+		Document{ID: 3, SiteID: 1, Title: "Go draft", Content: "go go go", Status: model.PostStatusDraft},
+		Document{ID: 4, SiteID: 1, Title: "Go later", Content: "go", Status: model.PostStatusScheduled, PublishAt: &future},
+		Document{ID: 5, SiteID: 2, Title: "Go elsewhere", Content: "go", Status: model.PostStatusPublished},
+		Document{ID: 6, SiteID: 1, Title: "Go due", Content: "go", Status: model.PostStatusScheduled, PublishAt: &past},
+	))
+
+	res, err := m.Search(ctx, Query{SiteID: 1, Text: "go", LiveAt: now, Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, 2, res.Total, "drafts, scheduled posts (due or not) and other sites are excluded")
+	assert.Equal(t, uint(1), res.Hits[0].ID, "title matches rank first")
+	assert.Equal(t, "<mark>Go</mark> generics", res.Hits[0].Title)
+	assert.Contains(t, res.Hits[0].Snippet, "type parameters in <mark>Go</mark>.", "markup is stripped from snippets")
+
+	filters := []struct {
+		name string
+		q    Query
+		want []uint
+	}{
+		{"tag", Query{TagIDs: []uint{2}}, []uint{1}},
+		{"all tags required", Query{TagIDs: []uint{1, 9}}, nil},
+		{"category", Query{CategoryID: 4}, []uint{2}},
+		{"author", Query{AuthorID: 7}, []uint{1}},
+		{"from", Query{From: ptr(now.AddDate(0, -1, 0))}, []uint{2}},
+		{"to", Query{To: ptr(now.AddDate(0, -1, 0))}, []uint{1}},
+	}
+	for _, f := range filters {
+		f.q.SiteID, f.q.Text, f.q.LiveAt = 1, "go", now
+		res, err := m.Search(ctx, f.q)
+		require.NoError(t, err, f.name)
+		var got []uint
+		for _, h := range res.Hits {
+			got = append(got, h.ID)
+		}
+		assert.Equal(t, f.want, got, f.name)
+	}
+
+	require.NoError(t, m.Delete(ctx, 1))
+	res, err = m.Search(ctx, Query{SiteID: 1, Text: "generics", LiveAt: now})
+	require.NoError(t, err)
+	assert.Zero(t, res.Total)
+
+	_, err = m.Search(ctx, Query{SiteID: 1, Text: " !! "})
+	assert.ErrorIs(t, err, ErrEmptyQuery)
+}
+
+func TestMemory_SaveLoad(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "search.gob")
+	m := NewMemory()
+	ok, err := m.Load(ctx, path)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, m.Index(ctx, Document{ID: 1, SiteID: 1, Title: "Saved", Status: model.PostStatusPublished}))
+	require.NoError(t, m.Save(path))
+
+	loaded := NewMemory()
+	ok, err = loaded.Load(ctx, path)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	res, err := loaded.Search(ctx, Query{SiteID: 1, Text: "saved", LiveAt: time.Now()})
+	require.NoError(t, err)
+	assert.Equal(t, 1, res.Total)
+}
+
+func TestSnippet(t *testing.T) {
+	text := "Lorem ipsum dolor sit amet, consectetur adipiscing elit. The needle & thread are here. Sed do eiusmod tempor incididunt ut labore."
+	s := Snippet(text, []string{"needle"}, 40)
+	assert.Contains(t, s, "<mark>needle</mark> &amp; thread")
+	assert.True(t, len([]rune(s)) < len([]rune(text)))
+	assert.Equal(t, "…", string([]rune(s)[0]))
+}
+
+func ptr[T any](v T) *T { return &v }
@@ -0,0 +1,105 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/turahe/go-restfull/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// FullTextIndexName is the FULLTEXT index on posts(title, content) that MySQL searches use.
+const FullTextIndexName = "ft_posts_title_content"
+
+// MySQL searches posts with MATCH ... AGAINST in natural language mode. InnoDB keeps the FULLTEXT
+// index current on every write, so Index and Delete have nothing to do.
+type MySQL struct {
+	db *gorm.DB
+}
+
+var _ Index = (*MySQL)(nil)
+
+func NewMySQL(db *gorm.DB) *MySQL {
+	return &MySQL{db: db}
+}
+
+func (m *MySQL) Engine() string { return EngineMySQL }
+
+func (m *MySQL) Index(context.Context, ...Document) error { return nil }
+
+func (m *MySQL) Delete(context.Context, ...uint) error { return nil }
+
+// Reset creates the FULLTEXT index when it is missing.
+func (m *MySQL) Reset(ctx context.Context) error {
+	db := m.db.WithContext(ctx)
+	if db.Migrator().HasIndex(&model.Post{}, FullTextIndexName) {
+		return nil
+	}
+	return db.Exec(fmt.Sprintf("ALTER TABLE %s ADD FULLTEXT INDEX %s (title, content)", model.TablePosts, FullTextIndexName)).Error
+}
+
+const matchExpr = "MATCH(posts.title, posts.content) AGAINST (? IN NATURAL LANGUAGE MODE)"
+
+func (m *MySQL) Search(ctx context.Context, q Query) (Result, error) {
+	terms := Terms(q.Text)
+	if len(terms) == 0 {
+		return Result{}, ErrEmptyQuery
+	}
+	filtered := func() *gorm.DB {
+		db := m.db.WithContext(ctx).Model(&model.Post{}).
+			Where(matchExpr, q.Text).
+			Where("posts.status = ?", model.PostStatusPublished).
+			Where("(posts.publish_at IS NULL OR posts.publish_at <= ?)", q.LiveAt).
+			Where("(posts.unpublish_at IS NULL OR posts.unpublish_at > ?)", q.LiveAt)
+		if q.CategoryID != 0 {
+			db = db.Where("posts.category_id = ?", q.CategoryID)
+		}
+		if q.AuthorID != 0 {
+			db = db.Where("posts.user_id = ?", q.AuthorID)
+		}
+		if q.From != nil {
+			db = db.Where("COALESCE(posts.publish_at, posts.created_at) >= ?", *q.From)
+		}
+		if q.To != nil {
+			db = db.Where("COALESCE(posts.publish_at, posts.created_at) <= ?", *q.To)
+		}
+		for _, tag := range q.TagIDs {
+			db = db.Where("EXISTS (SELECT 1 FROM post_tags WHERE post_tags.post_id = posts.id AND post_tags.tag_id = ?)", tag)
+		}
+		return db
+	}
+
+	var total int64
+	if err := filtered().Count(&total).Error; err != nil {
+		return Result{}, err
+	}
+	var rows []struct {
+		ID      uint
+		Title   string
+		Content string
+		Score   float64
+	}
+	find := filtered().
+		Select("posts.id, posts.title, posts.content, "+matchExpr+" AS score", q.Text).
+		Order("score DESC").Order("posts.id DESC").
+		Offset(max(q.Offset, 0))
+	if q.Limit > 0 {
+		find = find.Limit(q.Limit)
+	}
+	if err := find.Scan(&rows).Error; err != nil {
+		return Result{}, err
+	}
+
+	res := Result{Total: int(total), Hits: make([]Hit, 0, len(rows))}
+	for _, r := range rows {
+		res.Hits = append(res.Hits, Hit{
+			ID:      r.ID,
+			Score:   math.Round(r.Score*1e4) / 1e4,
+			Title:   Highlight(r.Title, terms),
+			Snippet: Snippet(r.Content, terms, snippetLen),
+		})
+	}
+	return res, nil
+}
@@ -0,0 +1,127 @@
+// Package search indexes posts for full-text queries. Index has two implementations: MySQL (a
+// FULLTEXT index on posts, maintained by InnoDB) and Memory (an embedded BM25 index for deployments
+// without MySQL FULLTEXT, e.g. tests or single-instance setups).
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/model"
+
+	"gorm.io/gorm"
+)
+
+const (
+	EngineMySQL  = "mysql"
+	EngineMemory = "memory"
+)
+
+var ErrEmptyQuery = errors.New("search query has no searchable terms")
+
+// Document is the searchable view of a post.
+type Document struct {
+	ID         uint
+	SiteID     uint
+	Title      string
+	Content    string
+	Excerpt    string
+	CategoryID uint
+	AuthorID   uint
+	TagIDs     []uint
+
+	Status      model.PostStatus
+	PublishAt   *time.Time
+	UnpublishAt *time.Time
+	// PublishedAt is what date filters compare against: PublishAt, or CreatedAt when unset.
+	PublishedAt time.Time
+}
+
+// DocumentFromPost builds the document for p; tagIDs are the post's current tags.
+func DocumentFromPost(p *model.Post, tagIDs []uint) Document {
+	d := Document{
+		ID:          p.ID,
+		SiteID:      p.SiteID,
+		Title:       p.Title,
+		Content:     p.Content,
+		CategoryID:  p.CategoryID,
+		AuthorID:    p.UserID,
+		TagIDs:      tagIDs,
+		Status:      p.Status,
+		PublishAt:   p.PublishAt,
+		UnpublishAt: p.UnpublishAt,
+		PublishedAt: p.CreatedAt,
+	}
+	if p.PublishAt != nil {
+		d.PublishedAt = *p.PublishAt
+	}
+	if p.PostSEO != nil {
+		d.Excerpt = p.PostSEO.Excerpt
+	}
+	return d
+}
+
+// liveAt mirrors the public visibility rule: published and inside the publish window. Scheduled posts
+// stay hidden until the scheduler publishes them, as they do on GET /posts/slug/:slug and with MySQL.
+func (d *Document) liveAt(now time.Time) bool {
+	if d.Status != model.PostStatusPublished {
+		return false
+	}
+	if d.PublishAt != nil && d.PublishAt.After(now) {
+		return false
+	}
+	return d.UnpublishAt == nil || d.UnpublishAt.After(now)
+}
+
+// Query is a full-text search over one site's live posts. Zero-valued filters are ignored; TagIDs
+// must all be present on a hit.
+type Query struct {
+	SiteID     uint
+	Text       string
+	TagIDs     []uint
+	CategoryID uint
+	AuthorID   uint
+	From, To   *time.Time
+	LiveAt     time.Time
+	Limit      int
+	Offset     int
+}
+
+// Hit is one result, best first. Title and Snippet are HTML-escaped with matches wrapped in <mark>.
+type Hit struct {
+	ID      uint    `json:"id"`
+	Score   float64 `json:"score"`
+	Title   string  `json:"title"`
+	Snippet string  `json:"snippet"`
+}
+
+type Result struct {
+	Total int   `json:"total"`
+	Hits  []Hit `json:"hits"`
+}
+
+// Index is a full-text index of posts. Writes are best effort from the caller's point of view: the
+// posts table stays the source of truth and Reset plus a full reindex repairs any drift.
+type Index interface {
+	Engine() string
+	Index(ctx context.Context, docs ...Document) error
+	Delete(ctx context.Context, ids ...uint) error
+	Search(ctx context.Context, q Query) (Result, error)
+	// Reset prepares a full reindex: the memory index drops every document, MySQL (re)creates its
+	// FULLTEXT index.
+	Reset(ctx context.Context) error
+}
+
+// New returns the index for engine ("" means mysql).
+func New(engine string, db *gorm.DB) (Index, error) {
+	switch engine {
+	case "", EngineMySQL:
+		return NewMySQL(db), nil
+	case EngineMemory:
+		return NewMemory(), nil
+	default:
+		return nil, fmt.Errorf("search: unknown engine %q", engine)
+	}
+}
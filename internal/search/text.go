@@ -0,0 +1,130 @@
+package search
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var markup = regexp.MustCompile(`<[^>]*>`)
+
+// plainText strips HTML tags and collapses whitespace, so markup is neither indexed nor shown in snippets.
+func plainText(s string) string {
+	s = html.UnescapeString(markup.ReplaceAllString(s, " "))
+	return strings.Join(strings.Fields(s), " ")
+}
+
+type token struct {
+	term       string
+	start, end int // byte offsets in the source text
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// tokens splits s into lower-cased runs of letters and digits.
+func tokens(s string) []token {
+	var out []token
+	start := -1
+	for i, r := range s {
+		if isWordRune(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 {
+			out = append(out, token{term: strings.ToLower(s[start:i]), start: start, end: i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		out = append(out, token{term: strings.ToLower(s[start:]), start: start, end: len(s)})
+	}
+	return out
+}
+
+// Terms returns the distinct search terms of a query, in order.
+func Terms(q string) []string {
+	seen := map[string]struct{}{}
+	var out []string
+	for _, t := range tokens(q) {
+		if _, ok := seen[t.term]; ok {
+			continue
+		}
+		seen[t.term] = struct{}{}
+		out = append(out, t.term)
+	}
+	return out
+}
+
+// Highlight HTML-escapes text and wraps every occurrence of a term in <mark>.
+func Highlight(text string, terms []string) string {
+	want := make(map[string]struct{}, len(terms))
+	for _, t := range terms {
+		want[t] = struct{}{}
+	}
+	var b strings.Builder
+	last := 0
+	for _, t := range tokens(text) {
+		if _, ok := want[t.term]; !ok {
+			continue
+		}
+		b.WriteString(html.EscapeString(text[last:t.start]))
+		b.WriteString("<mark>")
+		b.WriteString(html.EscapeString(text[t.start:t.end]))
+		b.WriteString("</mark>")
+		last = t.end
+	}
+	b.WriteString(html.EscapeString(text[last:]))
+	return b.String()
+}
+
+// Snippet returns about width runes of plain text around the first matched term, highlighted, with
+// "…" where it was cut.
+func Snippet(text string, terms []string, width int) string {
+	text = plainText(text)
+	if utf8.RuneCountInString(text) <= width {
+		return Highlight(text, terms)
+	}
+	want := make(map[string]struct{}, len(terms))
+	for _, t := range terms {
+		want[t] = struct{}{}
+	}
+	first := 0
+	for _, t := range tokens(text) {
+		if _, ok := want[t.term]; ok {
+			first = t.start
+			break
+		}
+	}
+	runes := []rune(text)
+	center := utf8.RuneCountInString(text[:first])
+	start := center - width/3
+	if start < 0 {
+		start = 0
+	}
+	end := start + width
+	if end > len(runes) {
+		end = len(runes)
+		start = max(0, end-width)
+	}
+	// Do not cut words in half.
+	for start > 0 && isWordRune(runes[start-1]) && isWordRune(runes[start]) {
+		start++
+	}
+	for end < len(runes) && end > start && isWordRune(runes[end-1]) && isWordRune(runes[end]) {
+		end--
+	}
+	out := Highlight(strings.TrimSpace(string(runes[start:end])), terms)
+	if start > 0 {
+		out = "…" + out
+	}
+	if end < len(runes) {
+		out += "…"
+	}
+	return out
+}
@@ -39,9 +39,21 @@ func SeedDefaultRBAC(ctx context.Context, db *gorm.DB, enf *rbac.Enforcer) error
 		{Role: entities.RoleSupport, Obj: "/api/v1/posts*", Act: "(GET|POST|PUT|DELETE)", Desc: "Manage posts"},
 		{Role: entities.RoleSupport, Obj: "/api/v1/categories*", Act: "(GET|POST|PUT|DELETE)", Desc: "Manage categories"},
 		{Role: entities.RoleSupport, Obj: "/api/v1/tags*", Act: "(GET|POST|PUT|DELETE)", Desc: "Manage tags"},
+		{Role: entities.RoleSupport, Obj: "/api/v1/series", Act: "POST", Desc: "Create series"},
+		{Role: entities.RoleSupport, Obj: "/api/v1/series/*", Act: "(GET|POST|PUT|DELETE)", Desc: "Manage series"},
+		{Role: entities.RoleSupport, Obj: "/api/v1/redirects", Act: "(GET|POST)", Desc: "List and create redirects"},
+		{Role: entities.RoleSupport, Obj: "/api/v1/redirects/*", Act: "(PUT|DELETE)", Desc: "Manage redirects"},
 		{Role: entities.RoleSupport, Obj: "/api/v1/media*", Act: "(GET|POST|DELETE)", Desc: "Manage media"},
+		{Role: entities.RoleSupport, Obj: "/api/v1/trash", Act: "GET", Desc: "List deleted items"},
+		{Role: entities.RoleSupport, Obj: "/api/v1/trash/*", Act: "(POST|DELETE)", Desc: "Restore and purge deleted items"},
 		{Role: entities.RoleSupport, Obj: "/api/v1/posts/*/comments", Act: "POST", Desc: "Create comments"},
 		{Role: entities.RoleSupport, Obj: "/api/v1/posts/*/comments", Act: "GET", Desc: "List comments"},
+		{Role: entities.RoleSupport, Obj: "/api/v1/posts/workflow", Act: "GET", Desc: "Get post workflow"},
+		{Role: entities.RoleSupport, Obj: "/api/v1/posts/*/transitions", Act: "(GET|POST)", Desc: "Post workflow history and transitions"},
+		{Role: entities.RoleSupport, Obj: "/api/v1/posts/*/transitions/*", Act: "POST", Desc: "Run any post workflow transition"},
+		{Role: entities.RoleSupport, Obj: "/api/v1/posts/*/reviewers", Act: "(GET|POST)", Desc: "Assign post reviewers"},
+		{Role: entities.RoleSupport, Obj: "/api/v1/posts/*/reviewers/*", Act: "DELETE", Desc: "Remove post reviewers"},
+		{Role: entities.RoleSupport, Obj: "/api/v1/posts/*/review-comments", Act: "(GET|POST)", Desc: "Post review comments"},
 
 		// User (basic CRUD; ownership checks are handled elsewhere)
 		{Role: entities.RoleUser, Obj: "/api/v1/posts", Act: "GET", Desc: "List posts"},
@@ -56,12 +68,34 @@ func SeedDefaultRBAC(ctx context.Context, db *gorm.DB, enf *rbac.Enforcer) error
 		{Role: entities.RoleUser, Obj: "/api/v1/posts", Act: "POST", Desc: "Create post"},
 		{Role: entities.RoleUser, Obj: "/api/v1/posts/*", Act: "PUT", Desc: "Update post"},
 		{Role: entities.RoleUser, Obj: "/api/v1/posts/*", Act: "DELETE", Desc: "Delete post"},
+		{Role: entities.RoleUser, Obj: "/api/v1/posts/*/revisions", Act: "GET", Desc: "List post revisions"},
+		{Role: entities.RoleUser, Obj: "/api/v1/posts/*/revisions/*", Act: "(GET|POST)", Desc: "Diff and restore post revisions"},
+		{Role: entities.RoleUser, Obj: "/api/v1/posts/*/preview-links", Act: "(GET|POST)", Desc: "Post preview links"},
+		{Role: entities.RoleUser, Obj: "/api/v1/posts/*/preview-links/*", Act: "DELETE", Desc: "Revoke post preview links"},
+		{Role: entities.RoleUser, Obj: "/api/v1/posts/*/stats", Act: "GET", Desc: "Post view stats"},
+		// Workflow: authors submit and withdraw, assigned reviewers approve or request changes; publishing
+		// an approved post is left to support and admins.
+		{Role: entities.RoleUser, Obj: "/api/v1/posts/workflow", Act: "GET", Desc: "Get post workflow"},
+		{Role: entities.RoleUser, Obj: "/api/v1/posts/*/transitions", Act: "(GET|POST)", Desc: "Post workflow history and transitions"},
+		{Role: entities.RoleUser, Obj: "/api/v1/posts/*/transitions/submit", Act: "POST", Desc: "Submit post for review"},
+		{Role: entities.RoleUser, Obj: "/api/v1/posts/*/transitions/withdraw", Act: "POST", Desc: "Withdraw post from review"},
+		{Role: entities.RoleUser, Obj: "/api/v1/posts/*/transitions/approve", Act: "POST", Desc: "Approve reviewed post"},
+		{Role: entities.RoleUser, Obj: "/api/v1/posts/*/transitions/request_changes", Act: "POST", Desc: "Request changes to reviewed post"},
+		{Role: entities.RoleUser, Obj: "/api/v1/posts/*/reviewers", Act: "GET", Desc: "List post reviewers"},
+		{Role: entities.RoleUser, Obj: "/api/v1/posts/*/review-comments", Act: "(GET|POST)", Desc: "Post review comments"},
+		{Role: entities.RoleUser, Obj: "/api/v1/series", Act: "POST", Desc: "Create series"},
+		{Role: entities.RoleUser, Obj: "/api/v1/series/*", Act: "(GET|POST|PUT|DELETE)", Desc: "Manage own series"},
 		{Role: entities.RoleUser, Obj: "/api/v1/posts/*/comments", Act: "POST", Desc: "Create comment"},
 		{Role: entities.RoleUser, Obj: "/api/v1/posts/*/comments", Act: "GET", Desc: "List comments"},
+		{Role: entities.RoleUser, Obj: "/api/v1/posts/*/reactions/*", Act: "(PUT|DELETE)", Desc: "React to posts"},
+		{Role: entities.RoleUser, Obj: "/api/v1/posts/*/comments/*/reactions/*", Act: "(PUT|DELETE)", Desc: "React to comments"},
+		{Role: entities.RoleUser, Obj: "/api/v1/reading-lists", Act: "(GET|POST)", Desc: "List and create reading lists"},
+		{Role: entities.RoleUser, Obj: "/api/v1/reading-lists/*", Act: "(GET|POST|PUT|DELETE)", Desc: "Manage own reading lists"},
 	}
 
-	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// Seed Role + Permission tables (optional but useful for admin UI).
+	// The enforcer reads these tables (rbac.Adapter); reload it once they are seeded.
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Seed Role + Permission tables.
 		roleByName := map[string]model.Role{}
 		for _, r := range roles {
 			name := strings.TrimSpace(r)
@@ -105,17 +139,10 @@ func SeedDefaultRBAC(ctx context.Context, db *gorm.DB, enf *rbac.Enforcer) error
 			}
 		}
 
-		// Seed Casbin policies.
-		// Note: matcher uses keyMatch2(obj) and regexMatch(act), so patterns are allowed.
-		enf.EnableAutoSave(false)
-		for _, s := range seeds {
-			_, _ = enf.AddPolicy(s.Role, s.Obj, s.Act)
-		}
-		if err := enf.SavePolicy(); err != nil {
-			return err
-		}
-		enf.EnableAutoSave(true)
-
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+	return enf.LoadPolicy()
 }
@@ -21,6 +21,15 @@ var defaultSettings = []struct {
 	{Key: "siteDescription", Value: "Blog API powered by Go, Gin, and GORM.", IsPublic: true},
 	{Key: "maintenanceMode", Value: "false", IsPublic: true},
 	{Key: "defaultLocale", Value: "en", IsPublic: true},
+	// Public site base URL for links in feeds and sitemaps; empty uses the API's origin.
+	{Key: "siteUrl", Value: "", IsPublic: true},
+	// Share image for posts without one (absolute URL), and the site's Twitter handle (@name) for cards.
+	{Key: "defaultShareImage", Value: "", IsPublic: true},
+	{Key: "twitterSite", Value: "", IsPublic: true},
+	// Revisions kept per post; 0 keeps all.
+	{Key: "postRevisionRetention", Value: "50", IsPublic: false},
+	// Posts per RSS/Atom/JSON feed (1-100).
+	{Key: "feedSize", Value: "20", IsPublic: false},
 }
 
 // SeedDefaultSettings ensures baseline `settings` rows exist (public site metadata and flags).
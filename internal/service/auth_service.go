@@ -10,6 +10,7 @@ import (
 	"github.com/turahe/go-restfull/internal/domain/entities"
 	"github.com/turahe/go-restfull/internal/model"
 	"github.com/turahe/go-restfull/internal/service/dto"
+	"github.com/turahe/go-restfull/internal/tenant"
 
 	"github.com/golang-jwt/jwt/v5"
 	"go.uber.org/zap"
@@ -296,6 +297,7 @@ func (s *AuthService) VerifyTwoFAChallenge(ctx context.Context, challengeID stri
 	rc.ID = accessJTI
 	claims := dto.AccessClaims{
 		RegisteredClaims: rc,
+		SiteID:           tenant.SiteID(ctx),
 		UserID:           u.ID,
 		Role:             role,
 		Permissions:      perms,
@@ -412,6 +414,7 @@ func (s *AuthService) Login(ctx context.Context, email, password string, meta dt
 	rc.ID = accessJTI
 	claims := dto.AccessClaims{
 		RegisteredClaims: rc,
+		SiteID:           tenant.SiteID(ctx),
 		UserID:           u.ID,
 		Role:             role,
 		Permissions:      perms,
@@ -513,6 +516,7 @@ func (s *AuthService) Refresh(ctx context.Context, refreshToken string, meta dto
 	rc.ID = jti
 	claims := dto.AccessClaims{
 		RegisteredClaims: rc,
+		SiteID:           tenant.SiteID(ctx),
 		UserID:           u.ID,
 		Role:             role,
 		Permissions:      perms,
@@ -632,6 +636,7 @@ func (s *AuthService) Impersonate(ctx context.Context, impersonatorID uint, targ
 	rc.ID = jti
 	claims := dto.AccessClaims{
 		RegisteredClaims:    rc,
+		SiteID:              tenant.SiteID(ctx),
 		UserID:              target.ID,
 		Role:                role,
 		Permissions:         perms,
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/turahe/go-restfull/internal/handler/request"
+	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/internal/service/dto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCategoryScopedEditor(t *testing.T) {
+	ctx := context.Background()
+	env := newPostTestEnv(t)
+	log, catRepo, rbacSvc, posts := env.log, env.categories, env.rbac, env.posts
+	cats := NewCategoryService(catRepo, log)
+
+	const admin, editor, author = uint(1), uint(2), uint(3)
+	news, err := cats.CreateRoot(ctx, "News", admin)
+	require.NoError(t, err)
+	local, err := cats.CreateChild(ctx, news.ID, "Local", admin)
+	require.NoError(t, err)
+	sport, err := cats.CreateRoot(ctx, "Sport", admin)
+	require.NoError(t, err)
+
+	_, err = rbacSvc.GrantRole(ctx, editor, "editor", dto.RoleGrantOptions{CategoryID: &news.ID})
+	require.NoError(t, err)
+	zero := uint(0)
+	_, err = rbacSvc.GrantRole(ctx, editor, "editor", dto.RoleGrantOptions{CategoryID: &zero})
+	assert.ErrorIs(t, err, ErrCategoryNotFound)
+
+	inLocal, err := posts.Create(ctx, author, request.CreatePostRequest{Title: "City council", Content: "x", CategoryID: local.ID})
+	require.NoError(t, err)
+	inSport, err := posts.Create(ctx, author, request.CreatePostRequest{Title: "Cup final", Content: "x", CategoryID: sport.ID})
+	require.NoError(t, err)
+
+	t.Run("create is confined to the subtree", func(t *testing.T) {
+		_, err := posts.Create(ctx, editor, request.CreatePostRequest{Title: "Desk note", Content: "x", CategoryID: local.ID})
+		assert.NoError(t, err)
+		_, err = posts.Create(ctx, editor, request.CreatePostRequest{Title: "Off desk", Content: "x", CategoryID: sport.ID})
+		assert.ErrorIs(t, err, ErrCategoryOutOfScope)
+	})
+
+	t.Run("editor manages other authors' posts in scope only", func(t *testing.T) {
+		title := "City council update"
+		_, err := posts.Update(ctx, inLocal.ID, editor, request.UpdatePostRequest{Title: title})
+		assert.NoError(t, err)
+		_, err = posts.Update(ctx, inSport.ID, editor, request.UpdatePostRequest{Title: title})
+		assert.ErrorIs(t, err, ErrCategoryOutOfScope)
+		_, err = posts.Update(ctx, inLocal.ID, editor, request.UpdatePostRequest{CategoryID: &sport.ID})
+		assert.ErrorIs(t, err, ErrCategoryOutOfScope)
+		assert.ErrorIs(t, posts.Delete(ctx, inSport.ID, editor), ErrCategoryOutOfScope)
+		assert.NoError(t, posts.Delete(ctx, inLocal.ID, editor))
+	})
+
+	t.Run("unscoped users keep owner-only rules", func(t *testing.T) {
+		_, err := posts.Update(ctx, inSport.ID, admin, request.UpdatePostRequest{Title: "Cup final recap"})
+		assert.ErrorIs(t, err, ErrNotPostOwner)
+		_, err = posts.Update(ctx, inSport.ID, author, request.UpdatePostRequest{Title: "Cup final recap"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("category mutations", func(t *testing.T) {
+		_, err := cats.CreateRoot(ctx, "Opinion", editor)
+		assert.ErrorIs(t, err, ErrCategoryOutOfScope)
+		_, err = cats.CreateChild(ctx, sport.ID, "Tennis", editor)
+		assert.ErrorIs(t, err, ErrCategoryOutOfScope)
+		weather, err := cats.CreateChild(ctx, news.ID, "Weather", editor)
+		require.NoError(t, err)
+		_, err = cats.Update(ctx, news.ID, "World news", editor)
+		assert.NoError(t, err)
+		assert.ErrorIs(t, cats.Delete(ctx, news.ID, editor), ErrCategoryOutOfScope)
+		assert.NoError(t, cats.Delete(ctx, weather.ID, editor))
+	})
+}
+
+func TestCategoryScopedGrantRoutes(t *testing.T) {
+	ctx := context.Background()
+	env := newPostTestEnv(t)
+	db, catRepo, rbacSvc := env.db, env.categories, env.rbac
+
+	const editor = uint(2)
+	news, err := catRepo.CreateRoot(ctx, "News", 1)
+	require.NoError(t, err)
+	for _, obj := range []string{"/api/v1/posts/*", "/api/v1/categories/*", "/api/v1/series/*"} {
+		_, err = rbacSvc.AddPermissionToRole(ctx, "editor", obj, "PUT")
+		require.NoError(t, err)
+	}
+	_, err = rbacSvc.GrantRole(ctx, editor, "editor", dto.RoleGrantOptions{CategoryID: &news.ID})
+	require.NoError(t, err)
+
+	enforce := func(obj string) bool {
+		ok, err := rbacSvc.Enforce(ctx, editor, obj, "PUT")
+		require.NoError(t, err)
+		return ok
+	}
+	assert.True(t, enforce("/api/v1/posts/:id"))
+	assert.True(t, enforce("/api/v1/categories/:id"))
+	assert.False(t, enforce("/api/v1/series/:id"), "series do not apply category scopes, so scoped grants do not open them")
+	d, err := rbacSvc.Explain(ctx, editor, "/api/v1/series/:id", "PUT")
+	require.NoError(t, err)
+	assert.False(t, d.Allowed)
+
+	_, err = rbacSvc.AssignRole(ctx, editor, "editor")
+	require.NoError(t, err)
+	assert.True(t, enforce("/api/v1/series/:id"), "an unscoped grant of the same role opens every route")
+
+	scoped, _, err := catRepo.ScopedRangesForUser(ctx, editor)
+	require.NoError(t, err)
+	assert.True(t, scoped)
+	require.NoError(t, db.Where("name = ?", "editor").Delete(&model.Role{}).Error)
+	scoped, _, err = catRepo.ScopedRangesForUser(ctx, editor)
+	require.NoError(t, err)
+	assert.False(t, scoped, "grants of deleted roles no longer scope the user")
+}
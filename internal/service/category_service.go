@@ -41,6 +41,13 @@ func (u *CategoryService) CreateRoot(ctx context.Context, name string, actorUser
 	if name == "" {
 		return nil, ErrInvalidName
 	}
+	scope, err := loadCategoryScope(ctx, u.repo, actorUserID)
+	if err != nil {
+		return nil, err
+	}
+	if scope.restricted {
+		return nil, ErrCategoryOutOfScope
+	}
 	out, err := u.repo.CreateRoot(ctx, name, actorUserID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrDuplicatedKey) {
@@ -59,6 +66,9 @@ func (u *CategoryService) CreateChild(ctx context.Context, parentID uint, name s
 	if name == "" {
 		return nil, ErrInvalidName
 	}
+	if err := u.checkScope(ctx, parentID, actorUserID, false); err != nil {
+		return nil, err
+	}
 	out, err := u.repo.CreateChild(ctx, parentID, name, actorUserID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -110,6 +120,9 @@ func (u *CategoryService) Update(ctx context.Context, id uint, name string, acto
 	if name == "" {
 		return nil, ErrInvalidName
 	}
+	if err := u.checkScope(ctx, id, actorUserID, false); err != nil {
+		return nil, err
+	}
 	c, err := u.repo.UpdateName(ctx, id, name, actorUserID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -128,6 +141,9 @@ func (u *CategoryService) Delete(ctx context.Context, id uint, actorUserID uint)
 	if id == 0 {
 		return ErrCategoryNotFound
 	}
+	if err := u.checkScope(ctx, id, actorUserID, true); err != nil {
+		return err
+	}
 	err := u.repo.DeleteSubtree(ctx, id, actorUserID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -141,6 +157,26 @@ func (u *CategoryService) Delete(ctx context.Context, id uint, actorUserID uint)
 	return nil
 }
 
+// checkScope rejects mutations of categories outside the actor's category-scoped grants (no-op for unscoped users).
+// With strict, the granted subtree roots themselves are out of scope.
+func (u *CategoryService) checkScope(ctx context.Context, id uint, actorUserID uint, strict bool) error {
+	scope, err := loadCategoryScope(ctx, u.repo, actorUserID)
+	if err != nil || !scope.restricted {
+		return err
+	}
+	c, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrCategoryNotFound
+		}
+		return err
+	}
+	if strict && !scope.coversStrictly(c) || !strict && !scope.covers(c) {
+		return ErrCategoryOutOfScope
+	}
+	return nil
+}
+
 // buildCategoryTree builds a forest from a flat list ordered by lft (O(n)).
 func buildCategoryTree(rows []model.CategoryModel) []CategoryTreeNode {
 	type stackItem struct {
@@ -23,11 +23,14 @@ var (
 	ErrCommentInvalidContent  = errors.New("invalid comment content")
 )
 
-// CommentTreeNode is the JSON shape for tree responses (id, name from content, children).
+// CommentTreeNode is the JSON shape for tree responses (id, name from content, reactions, children).
+// MyReactions is filled for signed-in viewers.
 type CommentTreeNode struct {
-	ID       uint              `json:"id"`
-	Name     string            `json:"name"`
-	Children []CommentTreeNode `json:"children"`
+	ID          uint                 `json:"id"`
+	Name        string               `json:"name"`
+	Reactions   model.ReactionCounts `json:"reactions"`
+	MyReactions []string             `json:"myReactions,omitempty"`
+	Children    []CommentTreeNode    `json:"children"`
 }
 
 type CommentService struct {
@@ -129,15 +132,17 @@ func (u *CommentService) applyTags(ctx context.Context, commentID uint, tagIDs [
 	return nil
 }
 
-func (u *CommentService) GetTree(ctx context.Context, postID uint) ([]CommentTreeNode, error) {
+// GetTree returns the post's comment tree; viewerID (0 when anonymous) marks the viewer's reactions.
+func (u *CommentService) GetTree(ctx context.Context, postID uint, viewerID uint) ([]CommentTreeNode, error) {
 	rows, err := u.comments.GetTree(ctx, postID)
 	if err != nil {
 		return nil, err
 	}
-	return buildCommentTree(rows), nil
+	return buildCommentTree(rows, u.viewerReactions(ctx, rows, viewerID)), nil
 }
 
-func (u *CommentService) GetSubtree(ctx context.Context, postID uint, commentID uint) ([]CommentTreeNode, error) {
+// GetSubtree returns the subtree rooted at commentID; viewerID (0 when anonymous) marks the viewer's reactions.
+func (u *CommentService) GetSubtree(ctx context.Context, postID uint, commentID uint, viewerID uint) ([]CommentTreeNode, error) {
 	if commentID == 0 {
 		return nil, ErrCommentNotFound
 	}
@@ -151,7 +156,24 @@ func (u *CommentService) GetSubtree(ctx context.Context, postID uint, commentID
 	if len(rows) == 0 {
 		return nil, ErrCommentNotFound
 	}
-	return buildCommentTree(rows), nil
+	return buildCommentTree(rows, u.viewerReactions(ctx, rows, viewerID)), nil
+}
+
+// viewerReactions loads viewerID's reactions to rows. Failures only leave them unmarked.
+func (u *CommentService) viewerReactions(ctx context.Context, rows []model.Comment, viewerID uint) map[uint][]string {
+	if viewerID == 0 || len(rows) == 0 {
+		return nil
+	}
+	ids := make([]uint, len(rows))
+	for i := range rows {
+		ids[i] = rows[i].ID
+	}
+	mine, err := u.comments.ViewerReactions(ctx, ids, viewerID)
+	if err != nil {
+		u.log.Warn("failed to load viewer reactions", zap.Error(err))
+		return nil
+	}
+	return mine
 }
 
 func (u *CommentService) Update(ctx context.Context, postID uint, commentID uint, userID uint, req request.UpdateCommentBody) (*model.Comment, error) {
@@ -192,7 +214,7 @@ func (u *CommentService) List(ctx context.Context, req request.CommentListReques
 	return page, nil
 }
 
-func buildCommentTree(rows []model.Comment) []CommentTreeNode {
+func buildCommentTree(rows []model.Comment, mine map[uint][]string) []CommentTreeNode {
 	type stackItem struct {
 		node *CommentTreeNode
 		rgt  int
@@ -204,7 +226,7 @@ func buildCommentTree(rows []model.Comment) []CommentTreeNode {
 		if len(label) > 500 {
 			label = label[:500] + "…"
 		}
-		n := CommentTreeNode{ID: row.ID, Name: label, Children: []CommentTreeNode{}}
+		n := CommentTreeNode{ID: row.ID, Name: label, Reactions: row.Reactions, MyReactions: mine[row.ID], Children: []CommentTreeNode{}}
 		for len(stack) > 0 && stack[len(stack)-1].rgt < row.Lft {
 			stack = stack[:len(stack)-1]
 		}
@@ -10,6 +10,8 @@ type AccessClaims struct {
 	Permissions []string `json:"permissions"`
 	SessionID   string   `json:"session_id"`
 	DeviceID    string   `json:"device_id"`
+	// SiteID is the site the token was issued on; Role/Permissions are that site's assignments.
+	SiteID uint `json:"site_id,omitempty"`
 
 	Impersonation       bool   `json:"impersonation,omitempty"`
 	ImpersonatedUserID  *uint  `json:"impersonated_user_id,omitempty"`
@@ -0,0 +1,49 @@
+package dto
+
+// PostMeta is everything a frontend needs in a post page's <head>: the document title and description,
+// the canonical and hreflang links, Open Graph and Twitter card fields, and schema.org JSON-LD blocks
+// (embed each in a <script type="application/ld+json">).
+type PostMeta struct {
+	Title       string           `json:"title"`
+	Description string           `json:"description"`
+	Canonical   string           `json:"canonical"`
+	Robots      string           `json:"robots,omitempty"`
+	Locale      string           `json:"locale"`
+	Alternates  []MetaAlternate  `json:"alternates,omitempty"`
+	OpenGraph   OpenGraphMeta    `json:"openGraph"`
+	Twitter     TwitterCardMeta  `json:"twitter"`
+	JSONLD      []map[string]any `json:"jsonLd"`
+}
+
+// MetaAlternate is a <link rel="alternate" hreflang> target; Hreflang is "x-default" for the site's
+// default locale variant.
+type MetaAlternate struct {
+	Hreflang string `json:"hreflang"`
+	Href     string `json:"href"`
+}
+
+// OpenGraphMeta holds the og:* and article:* properties. Locales use the Open Graph form (en_US).
+type OpenGraphMeta struct {
+	Type             string   `json:"type"`
+	Title            string   `json:"title"`
+	Description      string   `json:"description"`
+	URL              string   `json:"url"`
+	SiteName         string   `json:"siteName"`
+	Image            string   `json:"image,omitempty"`
+	Locale           string   `json:"locale"`
+	LocaleAlternates []string `json:"localeAlternates,omitempty"`
+	PublishedTime    string   `json:"publishedTime"`
+	ModifiedTime     string   `json:"modifiedTime"`
+	Section          string   `json:"section,omitempty"`
+	Tags             []string `json:"tags,omitempty"`
+	Authors          []string `json:"authors,omitempty"`
+}
+
+// TwitterCardMeta holds the twitter:* properties.
+type TwitterCardMeta struct {
+	Card        string `json:"card"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Image       string `json:"image,omitempty"`
+	Site        string `json:"site,omitempty"`
+}
@@ -0,0 +1,9 @@
+package dto
+
+import "github.com/turahe/go-restfull/internal/model"
+
+// RelatedPost is a post recommended next to another one, with its ranking score.
+type RelatedPost struct {
+	Score float64     `json:"score"`
+	Post  *model.Post `json:"post"`
+}
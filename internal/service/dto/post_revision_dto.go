@@ -0,0 +1,21 @@
+package dto
+
+import "github.com/turahe/go-restfull/pkg/textdiff"
+
+// FieldChange is one scalar post field that differs between two revisions.
+type FieldChange struct {
+	Field string `json:"field"`
+	From  any    `json:"from"`
+	To    any    `json:"to"`
+}
+
+// PostRevisionDiff compares revision From (older side) with revision To.
+type PostRevisionDiff struct {
+	PostID     uint            `json:"postId"`
+	FromID     uint            `json:"fromId"`
+	FromNumber uint            `json:"fromNumber"`
+	ToID       uint            `json:"toId"`
+	ToNumber   uint            `json:"toNumber"`
+	Fields     []FieldChange   `json:"fields"`
+	Content    []textdiff.Line `json:"content"`
+}
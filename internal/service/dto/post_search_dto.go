@@ -0,0 +1,22 @@
+package dto
+
+import "github.com/turahe/go-restfull/internal/model"
+
+// SearchHighlights are HTML-escaped fragments with matched terms wrapped in <mark>.
+type SearchHighlights struct {
+	Title   string `json:"title"`
+	Content string `json:"content"`
+}
+
+type PostSearchHit struct {
+	Score      float64          `json:"score"`
+	Highlights SearchHighlights `json:"highlights"`
+	Post       *model.Post      `json:"post"`
+}
+
+type PostSearchResult struct {
+	Total int             `json:"total"`
+	Page  int             `json:"page"`
+	Limit int             `json:"limit"`
+	Hits  []PostSearchHit `json:"hits"`
+}
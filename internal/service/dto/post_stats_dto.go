@@ -0,0 +1,23 @@
+package dto
+
+import "github.com/turahe/go-restfull/internal/model"
+
+// PopularPost is a post with the views it got over the requested window.
+type PopularPost struct {
+	Views int64       `json:"views"`
+	Post  *model.Post `json:"post"`
+}
+
+// DailyViews is a post's view count on one UTC day (YYYY-MM-DD).
+type DailyViews struct {
+	Day   string `json:"day"`
+	Views int64  `json:"views"`
+}
+
+// PostStats is a post's view history: every day of the requested span (zero-filled) and the all-time
+// total. Views buffered since the last flush are not included yet.
+type PostStats struct {
+	PostID uint         `json:"postId"`
+	Total  int64        `json:"total"`
+	Days   []DailyViews `json:"days"`
+}
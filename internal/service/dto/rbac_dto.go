@@ -0,0 +1,47 @@
+package dto
+
+import "time"
+
+// Decision sources reported by RBACService.Explain.
+const (
+	RBACSourceDB     = "db"     // user_roles -> role_permissions -> permissions
+	RBACSourceCasbin = "casbin" // Casbin grouping + policy rules (used when no DB is wired)
+)
+
+// RoleGrantOptions narrows a role assignment. The zero value is a permanent, site-wide grant.
+type RoleGrantOptions struct {
+	// CategoryID confines the grant to that category's subtree (post and category mutations).
+	CategoryID *uint
+	// ValidFrom/ValidUntil bound the grant in time; nil bounds are open.
+	ValidFrom  *time.Time
+	ValidUntil *time.Time
+}
+
+// RBACPermissionCheck is one permission key evaluated against (obj, act).
+type RBACPermissionCheck struct {
+	Role       string `json:"role,omitempty"`
+	Key        string `json:"key"`
+	ObjPattern string `json:"objPattern"`
+	ActPattern string `json:"actPattern"`
+	ObjMatched bool   `json:"objMatched"` // keyMatch2(obj, objPattern)
+	ActMatched bool   `json:"actMatched"` // regexMatch(act, actPattern)
+	Matched    bool   `json:"matched"`
+	Reason     string `json:"reason,omitempty"`
+}
+
+// RBACDecision is a full trace of one authorization decision.
+type RBACDecision struct {
+	ID     string   `json:"id,omitempty"`
+	UserID uint     `json:"userId"`
+	Obj    string   `json:"obj"`
+	Act    string   `json:"act"`
+	Source string   `json:"source"`
+	Roles  []string `json:"roles"`
+	// InheritedRoles are reached through role_inheritances from Roles (DB path only).
+	InheritedRoles []string              `json:"inheritedRoles,omitempty"`
+	Checks         []RBACPermissionCheck `json:"checks"`
+	Allowed        bool                  `json:"allowed"`
+	MatchedKey     string                `json:"matchedKey,omitempty"`
+	Reason         string                `json:"reason"`
+	EvaluatedAt    time.Time             `json:"evaluatedAt"`
+}
@@ -0,0 +1,9 @@
+package dto
+
+import "github.com/turahe/go-restfull/internal/model"
+
+// Reactions is a post's or comment's reaction totals by type and the caller's own reaction types.
+type Reactions struct {
+	Counts model.ReactionCounts `json:"reactions"`
+	Mine   []string             `json:"myReactions"`
+}
@@ -0,0 +1,9 @@
+package dto
+
+import "github.com/turahe/go-restfull/internal/model"
+
+// ReadingListView is a reading list with its entries in order, each carrying its post.
+type ReadingListView struct {
+	List  *model.ReadingList      `json:"list"`
+	Items []model.ReadingListItem `json:"items"`
+}
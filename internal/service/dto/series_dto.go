@@ -0,0 +1,30 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/turahe/go-restfull/internal/model"
+)
+
+// SeriesChapterSummary is a live chapter on a series landing page.
+type SeriesChapterSummary struct {
+	model.SeriesChapter
+	Excerpt            string `json:"excerpt,omitempty"`
+	ReadingTimeMinutes int    `json:"readingTimeMinutes"`
+}
+
+// SeriesProgress counts a series' chapters: Published are live now, Total includes drafts and
+// scheduled ones. NextPublishAt is the earliest upcoming scheduled chapter, if any.
+type SeriesProgress struct {
+	Published     int        `json:"published"`
+	Total         int        `json:"total"`
+	Percent       int        `json:"percent"`
+	NextPublishAt *time.Time `json:"nextPublishAt,omitempty"`
+}
+
+// SeriesLanding is a series with its live chapters in order.
+type SeriesLanding struct {
+	Series   *model.Series          `json:"series"`
+	Chapters []SeriesChapterSummary `json:"chapters"`
+	Progress SeriesProgress         `json:"progress"`
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/internal/repository"
+	"github.com/turahe/go-restfull/pkg/frontmatter"
+
+	"go.uber.org/zap"
+)
+
+const exportBatchSize = 200
+
+// ExportService writes the site's content out in formats other engines read.
+type ExportService struct {
+	posts *repository.PostRepository
+	log   *zap.Logger
+}
+
+func NewExportService(posts *repository.PostRepository, log *zap.Logger) *ExportService {
+	return &ExportService{posts: posts, log: log}
+}
+
+// markdownFrontMatter is the front matter of an exported post. Keys follow Hugo, so the files work
+// as a Hugo content directory; ImportMarkdown reads them back, SEO fields included.
+type markdownFrontMatter struct {
+	Title         string       `yaml:"title"`
+	Slug          string       `yaml:"slug"`
+	Date          *time.Time   `yaml:"date,omitempty"`
+	Lastmod       time.Time    `yaml:"lastmod"`
+	ExpiryDate    *time.Time   `yaml:"expiryDate,omitempty"`
+	Draft         bool         `yaml:"draft,omitempty"`
+	Status        string       `yaml:"status"`
+	Locale        string       `yaml:"locale,omitempty"`
+	ContentFormat string       `yaml:"contentFormat,omitempty"`
+	Author        string       `yaml:"author,omitempty"`
+	AuthorEmail   string       `yaml:"authorEmail,omitempty"`
+	Categories    []string     `yaml:"categories,omitempty"`
+	Tags          []string     `yaml:"tags,omitempty"`
+	Description   string       `yaml:"description,omitempty"`
+	Summary       string       `yaml:"summary,omitempty"`
+	SEO           *markdownSEO `yaml:"seo,omitempty"`
+}
+
+type markdownSEO struct {
+	MetaTitle    string `yaml:"metaTitle,omitempty"`
+	CanonicalURL string `yaml:"canonicalUrl,omitempty"`
+	OgImageURL   string `yaml:"ogImageUrl,omitempty"`
+	RobotsMeta   string `yaml:"robotsMeta,omitempty"`
+}
+
+// ExportMarkdown writes every post of the site in ctx to dir as <slug>.md with YAML front matter,
+// and returns how many it wrote. Existing files with the same names are overwritten.
+func (s *ExportService) ExportMarkdown(ctx context.Context, dir string) (int, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return 0, err
+	}
+	written := 0
+	var afterID uint
+	for {
+		batch, err := s.posts.ListBatch(ctx, afterID, exportBatchSize)
+		if err != nil {
+			return written, err
+		}
+		if len(batch) == 0 {
+			return written, nil
+		}
+		ids := make([]uint, len(batch))
+		for i, p := range batch {
+			ids[i] = p.ID
+		}
+		afterID = ids[len(ids)-1]
+		posts, err := s.posts.FindByIDs(ctx, ids)
+		if err != nil {
+			return written, err
+		}
+		for i := range posts {
+			doc, err := frontmatter.Write(markdownFrontMatterOf(&posts[i]), []byte(posts[i].Content))
+			if err != nil {
+				return written, err
+			}
+			if err := os.WriteFile(filepath.Join(dir, posts[i].Slug+".md"), doc, 0o644); err != nil {
+				return written, err
+			}
+			written++
+		}
+	}
+}
+
+func markdownFrontMatterOf(p *model.Post) markdownFrontMatter {
+	fm := markdownFrontMatter{
+		Title:   p.Title,
+		Slug:    p.Slug,
+		Lastmod: p.UpdatedAt.UTC(),
+		Draft:   p.Status == model.PostStatusDraft,
+		Status:  string(p.Status),
+		Locale:  p.Locale,
+	}
+	if p.ContentFormat != model.ContentFormatMarkdown {
+		fm.ContentFormat = string(p.ContentFormat)
+	}
+	date := p.CreatedAt.UTC()
+	if p.PublishAt != nil {
+		date = p.PublishAt.UTC()
+	}
+	fm.Date = &date
+	if p.UnpublishAt != nil {
+		expiry := p.UnpublishAt.UTC()
+		fm.ExpiryDate = &expiry
+	}
+	for _, c := range p.Contributors {
+		if c.UserID == p.UserID && c.User != nil {
+			fm.Author, fm.AuthorEmail = c.User.Name, c.User.Email
+		}
+	}
+	if p.Category != nil {
+		fm.Categories = []string{p.Category.Name}
+	}
+	for _, t := range p.Tags {
+		fm.Tags = append(fm.Tags, t.Name)
+	}
+	if seo := p.PostSEO; seo != nil {
+		fm.Description, fm.Summary = seo.MetaDescription, seo.Excerpt
+		if seo.MetaTitle != "" || seo.CanonicalURL != "" || seo.OgImageURL != "" || seo.RobotsMeta != "" {
+			fm.SEO = &markdownSEO{MetaTitle: seo.MetaTitle, CanonicalURL: seo.CanonicalURL, OgImageURL: seo.OgImageURL, RobotsMeta: seo.RobotsMeta}
+		}
+	}
+	return fm
+}
@@ -0,0 +1,284 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/handler/request"
+	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/pkg/frontmatter"
+)
+
+// jekyllPostName matches Jekyll post file names: YYYY-MM-DD-slug.
+var jekyllPostName = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})-(.+)$`)
+
+var markdownExts = map[string]bool{".md": true, ".markdown": true, ".mdown": true}
+
+// frontMatterTimeLayouts are the date formats Hugo and Jekyll accept, besides native YAML/TOML dates.
+var frontMatterTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02 15:04:05 -07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// ImportMarkdown imports a Hugo content directory or a Jekyll site: every Markdown file with its
+// front matter (YAML or TOML) becomes a post. Hidden and underscore directories are ignored, except
+// Jekyll's _posts and _drafts; Hugo section pages (_index.md) are not posts.
+func (s *ImportService) ImportMarkdown(ctx context.Context, dir string, opts ImportOptions) (*ImportReport, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name := d.Name()
+		if d.IsDir() {
+			if p != dir && (strings.HasPrefix(name, ".") || (strings.HasPrefix(name, "_") && name != "_posts" && name != "_drafts")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if markdownExts[strings.ToLower(filepath.Ext(name))] {
+			files = append(files, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.run(ctx, ImportSourceMarkdown, opts, func(run *importRun) error {
+		for _, f := range files {
+			rel, err := filepath.Rel(dir, f)
+			if err != nil {
+				return err
+			}
+			if err := run.markdownFile(ctx, f, filepath.ToSlash(rel)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *importRun) markdownFile(ctx context.Context, file, rel string) error {
+	base := path.Base(rel)
+	stem := strings.TrimSuffix(base, path.Ext(base))
+	if strings.EqualFold(stem, "_index") {
+		r.report.Count(model.ImportKindPost).Skipped++
+		r.report.note("%s: section page, not a post", rel)
+		return nil
+	}
+	doc, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	meta, body, err := frontmatter.Parse(doc)
+	if err != nil {
+		r.report.Count(model.ImportKindPost).Skipped++
+		r.report.note("%s: %v", rel, err)
+		return nil
+	}
+	if strings.TrimSpace(string(body)) == "" {
+		r.report.Count(model.ImportKindPost).Skipped++
+		r.report.note("%s: empty body", rel)
+		return nil
+	}
+
+	slug := stem
+	if strings.EqualFold(stem, "index") && path.Dir(rel) != "." {
+		slug = path.Base(path.Dir(rel))
+	}
+	date := metaTime(meta, "date", "publishdate")
+	if m := jekyllPostName.FindStringSubmatch(slug); m != nil {
+		slug = m[2]
+		if date.IsZero() {
+			date, _ = time.Parse("2006-01-02", m[1])
+		}
+	}
+	if v := metaString(meta, "slug"); v != "" {
+		slug = v
+	}
+	title := metaString(meta, "title")
+	if title == "" {
+		title = humanizeSlug(slug)
+	}
+
+	status := model.PostStatus(metaString(meta, "status"))
+	switch status {
+	case model.PostStatusDraft, model.PostStatusPublished, model.PostStatusArchived, model.PostStatusScheduled:
+	default:
+		status = model.PostStatusPublished
+		if metaBool(meta, "draft") || meta["published"] == false || strings.HasPrefix(rel, "_drafts/") {
+			status = model.PostStatusDraft
+		}
+	}
+
+	var categoryID uint
+	if cats := metaStrings(meta, "categories", "category"); len(cats) > 0 {
+		categoryID, err = r.namedCategory(ctx, cats[0])
+	} else {
+		categoryID, err = r.defaultCategory(ctx)
+	}
+	if err != nil {
+		return err
+	}
+	var tagIDs []uint
+	for _, t := range metaStrings(meta, "tags", "tag") {
+		id, err := r.tag(ctx, t, "")
+		if err != nil {
+			return err
+		}
+		if id != 0 {
+			tagIDs = append(tagIDs, id)
+		}
+	}
+
+	req := request.CreatePostRequest{
+		Title:           title,
+		Slug:            slug,
+		Content:         string(body),
+		ContentFormat:   metaString(meta, "contentformat"),
+		CategoryID:      categoryID,
+		Status:          string(status),
+		Excerpt:         metaString(meta, "summary", "excerpt"),
+		MetaDescription: metaString(meta, "description"),
+		Locale:          metaString(meta, "locale"),
+		TagIDs:          tagIDs,
+	}
+	switch model.ContentFormat(req.ContentFormat) {
+	case model.ContentFormatMarkdown, model.ContentFormatHTML, model.ContentFormatPlain:
+	default:
+		req.ContentFormat = string(model.ContentFormatMarkdown)
+	}
+	if seo, ok := meta["seo"].(map[string]any); ok {
+		req.MetaTitle = metaString(seo, "metaTitle", "metatitle")
+		req.CanonicalURL = metaString(seo, "canonicalUrl", "canonicalurl")
+		req.OgImageURL = metaString(seo, "ogImageUrl", "ogimageurl")
+		req.RobotsMeta = metaString(seo, "robotsMeta", "robotsmeta")
+	}
+	if status != model.PostStatusDraft && !date.IsZero() {
+		req.PublishAt = &date
+	}
+	if expiry := metaTime(meta, "expirydate"); !expiry.IsZero() {
+		req.UnpublishAt = &expiry
+		if status == model.PostStatusPublished && !expiry.After(time.Now()) {
+			req.Status = string(model.PostStatusArchived)
+		}
+	}
+
+	authorID := r.actor
+	email := metaString(meta, "authoremail")
+	name := metaString(meta, "author")
+	if email == "" && strings.Contains(name, "@") {
+		email, name = name, ""
+	}
+	if email != "" {
+		if authorID, err = r.user(ctx, email, name); err != nil {
+			return err
+		}
+	}
+
+	_, err = r.post(ctx, rel, authorID, req, date, metaTime(meta, "lastmod"))
+	return err
+}
+
+// metaString returns the first of keys that holds a scalar, as a string.
+func metaString(meta map[string]any, keys ...string) string {
+	for _, k := range keys {
+		switch v := meta[k].(type) {
+		case nil:
+		case string:
+			if v = strings.TrimSpace(v); v != "" {
+				return v
+			}
+		case []any, map[string]any:
+		default:
+			return fmt.Sprint(v)
+		}
+	}
+	return ""
+}
+
+// metaStrings returns the first of keys that is set, as a list: YAML/TOML lists, or a string of
+// comma- or space-separated values (Jekyll).
+func metaStrings(meta map[string]any, keys ...string) []string {
+	for _, k := range keys {
+		var out []string
+		switch v := meta[k].(type) {
+		case []any:
+			for _, e := range v {
+				if s := strings.TrimSpace(fmt.Sprint(e)); s != "" && e != nil {
+					out = append(out, s)
+				}
+			}
+		case string:
+			sep := " "
+			if strings.Contains(v, ",") {
+				sep = ","
+			}
+			for _, s := range strings.Split(v, sep) {
+				if s = strings.TrimSpace(s); s != "" {
+					out = append(out, s)
+				}
+			}
+		}
+		if len(out) > 0 {
+			return out
+		}
+	}
+	return nil
+}
+
+func metaBool(meta map[string]any, key string) bool {
+	switch v := meta[key].(type) {
+	case bool:
+		return v
+	case string:
+		return strings.EqualFold(v, "true")
+	}
+	return false
+}
+
+// metaTime returns the first of keys that holds a date: a native YAML/TOML date, a TOML local date
+// (which formats itself), or a string in one of frontMatterTimeLayouts.
+func metaTime(meta map[string]any, keys ...string) time.Time {
+	for _, k := range keys {
+		var s string
+		switch v := meta[k].(type) {
+		case time.Time:
+			return v.UTC()
+		case string:
+			s = v
+		case fmt.Stringer:
+			s = v.String()
+		default:
+			continue
+		}
+		s = strings.TrimSpace(s)
+		for _, layout := range frontMatterTimeLayouts {
+			if t, err := time.Parse(layout, s); err == nil {
+				return t.UTC()
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// humanizeSlug turns "my-first-post" into "My first post".
+func humanizeSlug(slug string) string {
+	s := strings.TrimSpace(strings.NewReplacer("-", " ", "_", " ").Replace(slug))
+	if s == "" {
+		return ""
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
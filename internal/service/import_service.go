@@ -0,0 +1,508 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/turahe/go-restfull/internal/handler/request"
+	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/internal/repository"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Import sources recorded in import refs.
+const (
+	ImportSourceWordPress = "wordpress"
+	ImportSourceMarkdown  = "markdown"
+)
+
+const (
+	defaultImportCategory = "Uncategorized"
+	// importedPassword is not a bcrypt hash, so imported users cannot sign in with any password.
+	importedPassword = "!"
+	// maxImportAttachmentBytes caps downloads when no upload limit is configured.
+	maxImportAttachmentBytes = 1 << 30
+)
+
+var (
+	ErrImportUserNotFound = errors.New("import user not found")
+	errImportDryRun       = errors.New("dry run")
+)
+
+// ImportKinds lists the kinds an ImportReport counts, in display order.
+var ImportKinds = []string{model.ImportKindUser, model.ImportKindCategory, model.ImportKindTag, model.ImportKindPost, model.ImportKindComment, model.ImportKindMedia}
+
+// ImportOptions tunes an import.
+type ImportOptions struct {
+	// UserEmail is the existing user the import runs as: it creates categories and tags, and posts
+	// without a known author are credited to it.
+	UserEmail string
+	// Category names the category of posts that have none (default Uncategorized); it is created as
+	// a root when missing.
+	Category string
+	// DryRun imports inside a transaction that is rolled back and downloads no attachments, so the
+	// report shows what a real run would do.
+	DryRun bool
+	// SkipAttachments leaves WordPress attachments out.
+	SkipAttachments bool
+}
+
+// ImportCount is what an import did with the items of one kind: created new rows, found them
+// already imported (or matched an existing row), or left them out.
+type ImportCount struct {
+	Created  int `json:"created"`
+	Existing int `json:"existing"`
+	Skipped  int `json:"skipped"`
+}
+
+// ImportReport sums up an import run; Notes explain items left out.
+type ImportReport struct {
+	Source string                  `json:"source"`
+	DryRun bool                    `json:"dryRun"`
+	Counts map[string]*ImportCount `json:"counts"`
+	Notes  []string                `json:"notes,omitempty"`
+}
+
+// Count returns the counters of kind.
+func (r *ImportReport) Count(kind string) *ImportCount {
+	c, ok := r.Counts[kind]
+	if !ok {
+		c = &ImportCount{}
+		r.Counts[kind] = c
+	}
+	return c
+}
+
+func (r *ImportReport) note(format string, args ...any) {
+	r.Notes = append(r.Notes, fmt.Sprintf(format, args...))
+}
+
+// ImportService brings content from other blog engines in through the regular services. Every item
+// is recorded in import_refs, so re-running an import skips what is already there and picks up where
+// a failed run stopped.
+type ImportService struct {
+	db *gorm.DB
+	// media stores attachments; nil leaves them out.
+	media  *MediaService
+	client *http.Client
+	log    *zap.Logger
+}
+
+func NewImportService(db *gorm.DB, media *MediaService, log *zap.Logger) *ImportService {
+	return &ImportService{db: db, media: media, client: &http.Client{Timeout: 2 * time.Minute}, log: log}
+}
+
+// importRun is one import: services bound to the run's database handle (a transaction for dry
+// runs), the acting user and the report.
+type importRun struct {
+	source string
+	opts   ImportOptions
+	report *ImportReport
+	actor  uint
+	// seen holds the rows resolved so far, by kind and external id, so each item is counted once.
+	seen map[string]uint
+
+	refs         *repository.ImportRepository
+	users        *repository.UserRepository
+	categoryRepo *repository.CategoryRepository
+	categories   *CategoryService
+	tagRepo      *repository.TagRepository
+	tags         *TagService
+	posts        *PostService
+	comments     *CommentService
+	mediaRepo    *repository.MediaRepository
+	media        *MediaService
+	client       *http.Client
+}
+
+func (s *ImportService) run(ctx context.Context, source string, opts ImportOptions, fn func(*importRun) error) (*ImportReport, error) {
+	report := &ImportReport{Source: source, DryRun: opts.DryRun, Counts: map[string]*ImportCount{}}
+	exec := func(db *gorm.DB) error {
+		r, err := s.newRun(ctx, db, source, opts, report)
+		if err != nil {
+			return err
+		}
+		return fn(r)
+	}
+	if !opts.DryRun {
+		return report, exec(s.db)
+	}
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := exec(tx); err != nil {
+			return err
+		}
+		return errImportDryRun
+	})
+	if errors.Is(err, errImportDryRun) {
+		err = nil
+	}
+	return report, err
+}
+
+func (s *ImportService) newRun(ctx context.Context, db *gorm.DB, source string, opts ImportOptions, report *ImportReport) (*importRun, error) {
+	log := s.log
+	categories := repository.NewCategoryRepository(db, log)
+	tags := repository.NewTagRepository(db, log)
+	r := &importRun{
+		source:       source,
+		opts:         opts,
+		report:       report,
+		seen:         map[string]uint{},
+		refs:         repository.NewImportRepository(db, log),
+		users:        repository.NewUserRepository(db, log),
+		categoryRepo: categories,
+		categories:   NewCategoryService(categories, log),
+		tagRepo:      tags,
+		tags:         NewTagService(tags, log),
+		posts:        NewPostService(repository.NewPostRepository(db, log), categories, tags, repository.NewPostRevisionRepository(db, log), repository.NewSettingRepository(db, log), log),
+		comments:     NewCommentService(repository.NewCommentRepository(db, log), tags, log),
+		client:       s.client,
+	}
+	if s.media != nil && !opts.SkipAttachments {
+		r.mediaRepo = repository.NewMediaRepository(db, log)
+		r.media = &MediaService{repo: r.mediaRepo, maxBytes: s.media.maxBytes, store: s.media.store, log: log}
+	}
+
+	u, err := r.users.FindByEmail(ctx, strings.ToLower(strings.TrimSpace(opts.UserEmail)))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrImportUserNotFound
+		}
+		return nil, err
+	}
+	r.actor = u.ID
+	return r, nil
+}
+
+// resolve returns the row item ext of kind became: in this run, in an earlier one, or through
+// create, which reports whether it made a new row rather than matching an existing one.
+func (r *importRun) resolve(ctx context.Context, kind, ext string, create func() (uint, bool, error)) (uint, error) {
+	key := kind + "\x00" + ext
+	if id, ok := r.seen[key]; ok {
+		return id, nil
+	}
+	id, ok, err := r.refs.Find(ctx, r.source, kind, ext)
+	if err != nil {
+		return 0, err
+	}
+	if ok {
+		r.report.Count(kind).Existing++
+	} else {
+		created := false
+		if id, created, err = create(); err != nil {
+			return 0, err
+		}
+		if created {
+			r.report.Count(kind).Created++
+		} else {
+			r.report.Count(kind).Existing++
+		}
+		if err := r.refs.Save(ctx, r.source, kind, ext, id); err != nil {
+			return 0, err
+		}
+	}
+	r.seen[key] = id
+	return id, nil
+}
+
+// known reports whether item ext of kind was imported before, counting it if so.
+func (r *importRun) known(ctx context.Context, kind, ext string) (bool, error) {
+	key := kind + "\x00" + ext
+	if _, ok := r.seen[key]; ok {
+		return true, nil
+	}
+	id, ok, err := r.refs.Find(ctx, r.source, kind, ext)
+	if err != nil || !ok {
+		return false, err
+	}
+	r.report.Count(kind).Existing++
+	r.seen[key] = id
+	return true, nil
+}
+
+// user finds the user with email, or creates one that cannot sign in. Users are global, so a
+// matching account on any site is reused.
+func (r *importRun) user(ctx context.Context, email, name string) (uint, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	return r.resolve(ctx, model.ImportKindUser, email, func() (uint, bool, error) {
+		u, err := r.users.FindByEmail(ctx, email)
+		if err == nil {
+			return u.ID, false, nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, false, err
+		}
+		name = strings.TrimSpace(name)
+		if name == "" {
+			name, _, _ = strings.Cut(email, "@")
+		}
+		u = &model.User{Name: clip(name, 100), Email: email, Password: importedPassword}
+		if err := r.users.Create(ctx, u); err != nil {
+			return 0, false, err
+		}
+		return u.ID, true, nil
+	})
+}
+
+// guestEmail stands in for commenters who left no address: one per name, in a reserved domain.
+func guestEmail(name string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(name))))
+	return "guest-" + hex.EncodeToString(sum[:6]) + "@import.invalid"
+}
+
+// category finds the category with slug, or creates name under parentID (nil for a root). A
+// sibling with the same name is reused.
+func (r *importRun) category(ctx context.Context, ext, name, slug string, parentID *uint) (uint, error) {
+	return r.resolve(ctx, model.ImportKindCategory, ext, func() (uint, bool, error) {
+		if slug != "" {
+			c, err := r.categoryRepo.FindBySlug(ctx, slug)
+			if err == nil {
+				return c.ID, false, nil
+			}
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return 0, false, err
+			}
+		}
+		name = clip(strings.TrimSpace(name), 255)
+		if name == "" {
+			name = slug
+		}
+		var c *model.CategoryModel
+		var err error
+		if parentID == nil {
+			c, err = r.categories.CreateRoot(ctx, name, r.actor)
+		} else {
+			c, err = r.categories.CreateChild(ctx, *parentID, name, r.actor)
+		}
+		if errors.Is(err, ErrCategoryDuplicateName) {
+			id, err := r.siblingCategory(ctx, parentID, name)
+			return id, false, err
+		}
+		if err != nil {
+			return 0, false, err
+		}
+		return c.ID, true, nil
+	})
+}
+
+func (r *importRun) siblingCategory(ctx context.Context, parentID *uint, name string) (uint, error) {
+	rows, err := r.categoryRepo.GetTree(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for _, c := range rows {
+		sameParent := (c.ParentID == nil && parentID == nil) || (c.ParentID != nil && parentID != nil && *c.ParentID == *parentID)
+		if sameParent && strings.EqualFold(c.Name, name) {
+			return c.ID, nil
+		}
+	}
+	return 0, ErrCategoryDuplicateName
+}
+
+// namedCategory is the root category called name, for sources without category ids.
+func (r *importRun) namedCategory(ctx context.Context, name string) (uint, error) {
+	slug := slugify(name)
+	return r.category(ctx, slug, name, slug, nil)
+}
+
+// defaultCategory is where posts without a category go.
+func (r *importRun) defaultCategory(ctx context.Context) (uint, error) {
+	name := strings.TrimSpace(r.opts.Category)
+	if name == "" {
+		name = defaultImportCategory
+	}
+	return r.namedCategory(ctx, name)
+}
+
+// tag finds the tag with slug (derived from name when empty), or creates it. Tags without a usable
+// slug are left out (0).
+func (r *importRun) tag(ctx context.Context, name, slug string) (uint, error) {
+	name = clip(strings.TrimSpace(name), 100)
+	if slug = slugify(slug); slug == "" {
+		slug = slugify(name)
+	}
+	if slug == "" {
+		return 0, nil
+	}
+	if name == "" {
+		name = slug
+	}
+	return r.resolve(ctx, model.ImportKindTag, slug, func() (uint, bool, error) {
+		t, err := r.tagRepo.FindBySlug(ctx, slug)
+		if err == nil {
+			return t.ID, false, nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, false, err
+		}
+		if t, err = r.tags.Create(ctx, r.actor, request.CreateTagRequest{Name: name}); err != nil {
+			return 0, false, err
+		}
+		return t.ID, true, nil
+	})
+}
+
+// post creates req through PostService as authorID and backdates it to created and updated (when
+// known). Posts the services reject as invalid are left out with a note (0).
+func (r *importRun) post(ctx context.Context, ext string, authorID uint, req request.CreatePostRequest, created, updated time.Time) (uint, error) {
+	req.Title = clip(strings.TrimSpace(req.Title), 200)
+	if utf8.RuneCountInString(req.Title) < 3 {
+		req.Title = "Untitled"
+	}
+	if slugify(req.Slug) == "" {
+		req.Slug = ""
+	}
+	req.Excerpt = clip(strings.TrimSpace(req.Excerpt), 2000)
+	req.MetaTitle = clip(strings.TrimSpace(req.MetaTitle), 200)
+	req.MetaDescription = clip(strings.TrimSpace(req.MetaDescription), 320)
+	req.CanonicalURL = clip(strings.TrimSpace(req.CanonicalURL), 512)
+	req.OgImageURL = clip(strings.TrimSpace(req.OgImageURL), 512)
+	req.RobotsMeta = clip(strings.TrimSpace(req.RobotsMeta), 100)
+
+	id, err := r.resolve(ctx, model.ImportKindPost, ext, func() (uint, bool, error) {
+		p, err := r.posts.Create(ctx, authorID, req)
+		if err != nil {
+			return 0, false, err
+		}
+		if !created.IsZero() {
+			if updated.Before(created) {
+				updated = created
+			}
+			if err := r.refs.Backdate(ctx, model.ImportKindPost, p.ID, created, updated); err != nil {
+				return 0, false, err
+			}
+		}
+		return p.ID, true, nil
+	})
+	switch {
+	case errors.Is(err, ErrInvalidLocale), errors.Is(err, ErrInvalidSchedule), errors.Is(err, ErrInvalidSlug), errors.Is(err, ErrTranslationExists):
+		r.report.Count(model.ImportKindPost).Skipped++
+		r.report.note("post %s: %v", ext, err)
+		return 0, nil
+	case err != nil:
+		return 0, fmt.Errorf("post %s: %w", ext, err)
+	}
+	return id, nil
+}
+
+// comment creates a comment (a reply when parentID is set) and backdates it.
+func (r *importRun) comment(ctx context.Context, ext string, postID, parentID, userID uint, content string, at time.Time) (uint, error) {
+	return r.resolve(ctx, model.ImportKindComment, ext, func() (uint, bool, error) {
+		req := request.CreateCommentRequest{Content: content}
+		var c *model.Comment
+		var err error
+		if parentID == 0 {
+			c, err = r.comments.CreateRoot(ctx, postID, userID, req)
+		} else {
+			c, err = r.comments.CreateChild(ctx, postID, parentID, userID, req)
+		}
+		if err != nil {
+			return 0, false, err
+		}
+		if !at.IsZero() {
+			if err := r.refs.Backdate(ctx, model.ImportKindComment, c.ID, at, at); err != nil {
+				return 0, false, err
+			}
+		}
+		return c.ID, true, nil
+	})
+}
+
+// fetchError is a download that failed; the attachment is left out and the import goes on.
+type fetchError struct{ err error }
+
+func (e *fetchError) Error() string { return e.err.Error() }
+
+// attachment downloads rawURL into ownerID's media and links it to postIDs. Dry runs only count it.
+func (r *importRun) attachment(ctx context.Context, ext, rawURL string, ownerID uint, postIDs []uint, at time.Time) error {
+	if r.media == nil {
+		r.report.Count(model.ImportKindMedia).Skipped++
+		return nil
+	}
+	if r.opts.DryRun {
+		known, err := r.known(ctx, model.ImportKindMedia, ext)
+		if err == nil && !known {
+			r.report.Count(model.ImportKindMedia).Created++
+		}
+		return err
+	}
+	id, err := r.resolve(ctx, model.ImportKindMedia, ext, func() (uint, bool, error) {
+		name, data, mimeType, err := r.fetch(ctx, rawURL)
+		if err != nil {
+			return 0, false, &fetchError{err: err}
+		}
+		m, err := r.media.Store(ctx, ownerID, name, mimeType, strings.NewReader(string(data)), int64(len(data)), nil)
+		if err != nil {
+			return 0, false, err
+		}
+		if !at.IsZero() {
+			if err := r.refs.Backdate(ctx, model.ImportKindMedia, m.ID, at, at); err != nil {
+				return 0, false, err
+			}
+		}
+		return m.ID, true, nil
+	})
+	var fe *fetchError
+	if errors.As(err, &fe) {
+		r.report.Count(model.ImportKindMedia).Skipped++
+		r.report.note("attachment %s: %v", ext, fe.err)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, pid := range UniqueUint(postIDs) {
+		if err := r.mediaRepo.AttachMedia(ctx, id, "Post", pid); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *importRun) fetch(ctx context.Context, rawURL string) (name string, data []byte, mimeType string, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return "", nil, "", err
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return "", nil, "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return "", nil, "", fmt.Errorf("GET %s: %s", rawURL, resp.Status)
+	}
+	limit := r.media.maxBytes
+	if limit <= 0 {
+		limit = maxImportAttachmentBytes
+	}
+	if data, err = io.ReadAll(io.LimitReader(resp.Body, limit+1)); err != nil {
+		return "", nil, "", err
+	}
+	if int64(len(data)) > limit {
+		return "", nil, "", ErrMediaTooLarge
+	}
+	if mt, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type")); err == nil {
+		mimeType = mt
+	}
+	return urlFileName(req.URL.Path), data, mimeType, nil
+}
+
+// clip shortens s to at most n runes.
+func clip(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
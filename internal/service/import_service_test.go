@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/internal/repository"
+	"github.com/turahe/go-restfull/pkg/frontmatter"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const importTestWXR = `<?xml version="1.0" encoding="UTF-8" ?>
+<rss version="2.0" xmlns:excerpt="http://wordpress.org/export/1.2/excerpt/" xmlns:content="http://purl.org/rss/1.0/modules/content/"
+	xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:wp="http://wordpress.org/export/1.2/">
+<channel>
+	<title>Notes</title>
+	<wp:base_site_url>https://notes.example</wp:base_site_url>
+	<wp:author><wp:author_id>1</wp:author_id><wp:author_login>ada</wp:author_login><wp:author_email>ada@notes.example</wp:author_email><wp:author_display_name>Ada L.</wp:author_display_name></wp:author>
+	<wp:category><wp:term_id>3</wp:term_id><wp:category_nicename>asia</wp:category_nicename><wp:category_parent>travel</wp:category_parent><wp:cat_name>Asia</wp:cat_name></wp:category>
+	<wp:category><wp:term_id>2</wp:term_id><wp:category_nicename>travel</wp:category_nicename><wp:cat_name>Travel</wp:cat_name></wp:category>
+	<wp:tag><wp:term_id>4</wp:term_id><wp:tag_slug>food</wp:tag_slug><wp:tag_name>Food</wp:tag_name></wp:tag>
+	<item>
+		<title>Ramen in Tokyo</title>
+		<dc:creator>ada</dc:creator>
+		<content:encoded><![CDATA[Broth and noodles.
+
+Second paragraph.]]></content:encoded>
+		<excerpt:encoded><![CDATA[Short]]></excerpt:encoded>
+		<wp:post_id>10</wp:post_id>
+		<wp:post_date_gmt>2024-05-01 09:30:00</wp:post_date_gmt>
+		<wp:post_name>ramen</wp:post_name>
+		<wp:status>publish</wp:status>
+		<wp:post_type>post</wp:post_type>
+		<category domain="category" nicename="asia">Asia</category>
+		<category domain="post_tag" nicename="food">Food</category>
+		<wp:postmeta><wp:meta_key>_yoast_wpseo_metadesc</wp:meta_key><wp:meta_value>Best ramen</wp:meta_value></wp:postmeta>
+		<wp:postmeta><wp:meta_key>_yoast_wpseo_title</wp:meta_key><wp:meta_value>%%title%% %%sep%%</wp:meta_value></wp:postmeta>
+		<wp:comment><wp:comment_id>5</wp:comment_id><wp:comment_author>Bob</wp:comment_author><wp:comment_author_email>bob@example.com</wp:comment_author_email>
+			<wp:comment_date_gmt>2024-05-03 10:00:00</wp:comment_date_gmt><wp:comment_content>Looks great</wp:comment_content><wp:comment_approved>1</wp:comment_approved><wp:comment_parent>0</wp:comment_parent></wp:comment>
+		<wp:comment><wp:comment_id>6</wp:comment_id><wp:comment_author>ada</wp:comment_author><wp:comment_content>Thanks!</wp:comment_content>
+			<wp:comment_approved>1</wp:comment_approved><wp:comment_parent>5</wp:comment_parent><wp:comment_user_id>1</wp:comment_user_id></wp:comment>
+		<wp:comment><wp:comment_id>7</wp:comment_id><wp:comment_author>Spammer</wp:comment_author><wp:comment_content>Buy now</wp:comment_content>
+			<wp:comment_approved>spam</wp:comment_approved></wp:comment>
+	</item>
+	<item>
+		<title>Old draft</title>
+		<dc:creator>ada</dc:creator>
+		<content:encoded><![CDATA[<p>Unfinished</p>]]></content:encoded>
+		<wp:post_id>12</wp:post_id>
+		<wp:status>trash</wp:status>
+		<wp:post_type>post</wp:post_type>
+	</item>
+	<item>
+		<title>About</title>
+		<wp:post_id>13</wp:post_id>
+		<wp:status>publish</wp:status>
+		<wp:post_type>page</wp:post_type>
+	</item>
+	<item>
+		<title>bowl.jpg</title>
+		<dc:creator>ada</dc:creator>
+		<wp:post_id>11</wp:post_id>
+		<wp:post_parent>10</wp:post_parent>
+		<wp:post_type>attachment</wp:post_type>
+		<wp:attachment_url>ATTACHMENT_URL</wp:attachment_url>
+	</item>
+</channel>
+</rss>`
+
+func openImportTestDB(t *testing.T) (*gorm.DB, *zap.Logger) {
+	t.Helper()
+	_, db := openMigratedTestDB(t)
+	require.NoError(t, db.Create(&model.User{Name: "Admin", Email: "admin@import.test", Password: "x"}).Error)
+	return db, zap.NewNop()
+}
+
+func TestImportWordPress(t *testing.T) {
+	ctx := context.Background()
+	db, log := openImportTestDB(t)
+	fetches := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches++
+		w.Header().Set("Content-Type", "image/jpeg")
+		_, _ = w.Write([]byte("\xff\xd8\xff\xe0jpeg"))
+	}))
+	defer srv.Close()
+	wxrDoc := strings.ReplaceAll(importTestWXR, "ATTACHMENT_URL", srv.URL+"/uploads/bowl.jpg")
+	svc := NewImportService(db, &MediaService{repo: repository.NewMediaRepository(db, log), store: &trashTestStore{}, log: log}, log)
+	opts := ImportOptions{UserEmail: "admin@import.test"}
+
+	t.Run("dry run changes nothing", func(t *testing.T) {
+		report, err := svc.ImportWordPress(ctx, strings.NewReader(wxrDoc), ImportOptions{UserEmail: opts.UserEmail, DryRun: true})
+		require.NoError(t, err)
+		assert.True(t, report.DryRun)
+		assert.Equal(t, ImportCount{Created: 1, Skipped: 1}, *report.Count(model.ImportKindPost))
+		assert.Equal(t, ImportCount{Created: 1}, *report.Count(model.ImportKindMedia))
+		assert.Zero(t, fetches)
+		var n int64
+		require.NoError(t, db.Model(&model.Post{}).Count(&n).Error)
+		assert.Zero(t, n)
+		require.NoError(t, db.Model(&model.ImportRef{}).Count(&n).Error)
+		assert.Zero(t, n)
+	})
+
+	report, err := svc.ImportWordPress(ctx, strings.NewReader(wxrDoc), opts)
+	require.NoError(t, err)
+	assert.Equal(t, ImportCount{Created: 2}, *report.Count(model.ImportKindUser), "ada and bob")
+	assert.Equal(t, ImportCount{Created: 2}, *report.Count(model.ImportKindCategory))
+	assert.Equal(t, ImportCount{Created: 1}, *report.Count(model.ImportKindTag))
+	assert.Equal(t, ImportCount{Created: 1, Skipped: 1}, *report.Count(model.ImportKindPost))
+	assert.Equal(t, ImportCount{Created: 2, Skipped: 1}, *report.Count(model.ImportKindComment))
+	assert.Equal(t, ImportCount{Created: 1}, *report.Count(model.ImportKindMedia))
+	assert.Contains(t, report.Notes, "1 page item(s) not imported: only posts are")
+
+	var post model.Post
+	require.NoError(t, db.Preload("PostSEO").Preload("Tags").Preload("Category").Preload("Media").Preload("User").Where("slug = ?", "ramen").First(&post).Error)
+	assert.Equal(t, "Ramen in Tokyo", post.Title)
+	assert.Equal(t, model.PostStatusPublished, post.Status)
+	assert.Equal(t, "<p>Broth and noodles.</p>\n<p>Second paragraph.</p>\n", post.Content)
+	assert.Equal(t, "ada@notes.example", post.User.Email)
+	assert.Equal(t, "Asia", post.Category.Name)
+	require.NotNil(t, post.Category.ParentID)
+	assert.Equal(t, 2024, post.CreatedAt.Year(), "backdated")
+	require.NotNil(t, post.PostSEO)
+	assert.Equal(t, "Short", post.PostSEO.Excerpt)
+	assert.Equal(t, "Best ramen", post.PostSEO.MetaDescription)
+	assert.Empty(t, post.PostSEO.MetaTitle, "Yoast templates are not copied")
+	require.Len(t, post.Tags, 1)
+	assert.Equal(t, "food", post.Tags[0].Slug)
+	require.Len(t, post.Media, 1)
+	assert.Equal(t, "bowl.jpg", post.Media[0].OriginalName)
+
+	var comments []model.Comment
+	require.NoError(t, db.Order("id").Find(&comments).Error)
+	require.Len(t, comments, 2)
+	assert.Nil(t, comments[0].ParentID)
+	require.NotNil(t, comments[1].ParentID)
+	assert.Equal(t, comments[0].ID, *comments[1].ParentID)
+	assert.Equal(t, post.UserID, comments[1].UserID, "replies by authors are credited to their user")
+
+	var imported model.User
+	require.NoError(t, db.Where("email = ?", "bob@example.com").First(&imported).Error)
+	assert.Equal(t, importedPassword, imported.Password)
+
+	t.Run("re-run finds everything imported", func(t *testing.T) {
+		report, err := svc.ImportWordPress(ctx, strings.NewReader(wxrDoc), opts)
+		require.NoError(t, err)
+		for _, kind := range ImportKinds {
+			assert.Zero(t, report.Count(kind).Created, kind)
+		}
+		assert.Equal(t, 1, report.Count(model.ImportKindPost).Existing)
+		assert.Equal(t, 2, report.Count(model.ImportKindComment).Existing)
+		assert.Equal(t, 1, fetches)
+	})
+
+	_, err = svc.ImportWordPress(ctx, strings.NewReader(wxrDoc), ImportOptions{UserEmail: "nobody@import.test"})
+	assert.ErrorIs(t, err, ErrImportUserNotFound)
+}
+
+func TestImportMarkdownAndExport(t *testing.T) {
+	ctx := context.Background()
+	db, log := openImportTestDB(t)
+	src := t.TempDir()
+	write := func(rel, doc string) {
+		p := filepath.Join(src, filepath.FromSlash(rel))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+		require.NoError(t, os.WriteFile(p, []byte(doc), 0o644))
+	}
+	write("_posts/2023-02-01-hello-world.md", "---\ntitle: Hello World\ncategories: [Travel, Food]\ntags: go web\ndescription: First post\nauthor: jo@import.test\nseo:\n  metaTitle: Hello | Notes\n---\n\n# Hello\n")
+	write("_drafts/wip.md", "---\ntitle: Work in progress\n---\nNot yet\n")
+	write("posts/trip/index.md", "+++\ntitle = \"Trip\"\ndate = 2024-06-01\ndraft = false\n+++\nAway\n")
+	write("posts/_index.md", "---\ntitle: Posts\n---\nSection\n")
+	write(".git/notes.md", "ignored")
+	svc := NewImportService(db, nil, log)
+
+	report, err := svc.ImportMarkdown(ctx, src, ImportOptions{UserEmail: "admin@import.test", Category: "Notes"})
+	require.NoError(t, err)
+	assert.Equal(t, ImportCount{Created: 3, Skipped: 1}, *report.Count(model.ImportKindPost))
+	assert.Equal(t, ImportCount{Created: 2}, *report.Count(model.ImportKindCategory), "Travel and the default Notes")
+	assert.Equal(t, ImportCount{Created: 2}, *report.Count(model.ImportKindTag))
+
+	posts := repository.NewPostRepository(db, log)
+	var hello model.Post
+	require.NoError(t, db.Preload("PostSEO").Preload("Category").Preload("User").Where("slug = ?", "hello-world").First(&hello).Error)
+	assert.Equal(t, "Hello World", hello.Title)
+	assert.Equal(t, "Travel", hello.Category.Name)
+	assert.Equal(t, "jo@import.test", hello.User.Email)
+	assert.Equal(t, "2023-02-01", hello.PublishAt.Format("2006-01-02"))
+	assert.Equal(t, "First post", hello.PostSEO.MetaDescription)
+	assert.Equal(t, "Hello | Notes", hello.PostSEO.MetaTitle)
+	var wip, trip model.Post
+	require.NoError(t, db.Where("slug = ?", "wip").First(&wip).Error)
+	assert.Equal(t, model.PostStatusDraft, wip.Status)
+	require.NoError(t, db.Where("slug = ?", "trip").First(&trip).Error)
+	assert.Equal(t, model.PostStatusPublished, trip.Status)
+	assert.Equal(t, 2024, trip.CreatedAt.Year())
+
+	again, err := svc.ImportMarkdown(ctx, src, ImportOptions{UserEmail: "admin@import.test", Category: "Notes"})
+	require.NoError(t, err)
+	assert.Equal(t, ImportCount{Existing: 3, Skipped: 1}, *again.Count(model.ImportKindPost))
+
+	out := t.TempDir()
+	n, err := NewExportService(posts, log).ExportMarkdown(ctx, out)
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+	doc, err := os.ReadFile(filepath.Join(out, "hello-world.md"))
+	require.NoError(t, err)
+	meta, body, err := frontmatter.Parse(doc)
+	require.NoError(t, err)
+	assert.Equal(t, "# Hello\n", string(body))
+	assert.Equal(t, "Hello World", meta["title"])
+	assert.Equal(t, "jo@import.test", meta["authoremail"])
+	assert.Equal(t, []any{"Travel"}, meta["categories"])
+	assert.Equal(t, "First post", meta["description"])
+	assert.Equal(t, map[string]any{"metaTitle": "Hello | Notes"}, meta["seo"])
+}
@@ -0,0 +1,312 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/turahe/go-restfull/internal/handler/request"
+	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/pkg/wxr"
+)
+
+// wordPressStatuses maps WordPress post statuses onto ours; other statuses (trash, auto-draft) are
+// left out.
+var wordPressStatuses = map[string]model.PostStatus{
+	"publish": model.PostStatusPublished,
+	"future":  model.PostStatusScheduled,
+	"draft":   model.PostStatusDraft,
+	"pending": model.PostStatusDraft,
+	"private": model.PostStatusDraft,
+}
+
+// ImportWordPress imports a WordPress export (WXR): authors become users, categories keep their
+// hierarchy, posts keep their tags, comments their threading, and attachments are copied into the
+// object store and linked to their posts. Pages and other post types are not imported.
+func (s *ImportService) ImportWordPress(ctx context.Context, r io.Reader, opts ImportOptions) (*ImportReport, error) {
+	ex, err := wxr.Parse(r)
+	if err != nil {
+		return nil, err
+	}
+	return s.run(ctx, ImportSourceWordPress, opts, func(run *importRun) error {
+		return (&wordPressImport{importRun: run, ex: ex}).do(ctx)
+	})
+}
+
+type wordPressImport struct {
+	*importRun
+	ex *wxr.Export
+	// authors maps author logins to users; posts maps WordPress post ids to ours.
+	authors    map[string]uint
+	authorIDs  map[int]uint
+	categories map[string]wxr.Category
+	posts      map[int]uint
+}
+
+func (w *wordPressImport) do(ctx context.Context) error {
+	w.authors, w.authorIDs, w.posts = map[string]uint{}, map[int]uint{}, map[int]uint{}
+	for _, a := range w.ex.Authors {
+		if a.Email == "" {
+			w.report.note("author %s: no email, posts are credited to the importing user", a.Login)
+			continue
+		}
+		name := a.DisplayName
+		if name == "" {
+			name = a.Login
+		}
+		id, err := w.user(ctx, a.Email, name)
+		if err != nil {
+			return err
+		}
+		w.authors[a.Login] = id
+		w.authorIDs[a.ID] = id
+	}
+
+	w.categories = make(map[string]wxr.Category, len(w.ex.Categories))
+	for _, c := range w.ex.Categories {
+		w.categories[c.Slug] = c
+	}
+	for _, c := range w.ex.Categories {
+		if _, err := w.wpCategory(ctx, c.Slug, 0); err != nil {
+			return err
+		}
+	}
+	for _, t := range w.ex.Tags {
+		if _, err := w.tag(ctx, t.Name, t.Slug); err != nil {
+			return err
+		}
+	}
+
+	others := map[string]int{}
+	for _, it := range w.ex.Items {
+		switch it.Type {
+		case "post":
+			if err := w.post(ctx, it); err != nil {
+				return err
+			}
+		case "attachment":
+		default:
+			others[it.Type]++
+		}
+	}
+	types := make([]string, 0, len(others))
+	for t := range others {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	for _, t := range types {
+		w.report.note("%d %s item(s) not imported: only posts are", others[t], t)
+	}
+
+	if w.opts.SkipAttachments {
+		return nil
+	}
+	thumbnails := map[int][]int{}
+	for _, it := range w.ex.Items {
+		if id, err := strconv.Atoi(it.Meta["_thumbnail_id"]); err == nil && it.Type == "post" {
+			thumbnails[id] = append(thumbnails[id], it.ID)
+		}
+	}
+	for _, it := range w.ex.Items {
+		if it.Type != "attachment" || it.AttachmentURL == "" {
+			continue
+		}
+		var postIDs []uint
+		for _, wpID := range append([]int{it.Parent}, thumbnails[it.ID]...) {
+			if id, ok := w.posts[wpID]; ok {
+				postIDs = append(postIDs, id)
+			}
+		}
+		owner, ok := w.authors[it.Creator]
+		if !ok {
+			owner = w.actor
+		}
+		if err := w.attachment(ctx, strconv.Itoa(it.ID), it.AttachmentURL, owner, postIDs, it.Date); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// wpCategory imports the category with slug after its ancestors.
+func (w *wordPressImport) wpCategory(ctx context.Context, slug string, depth int) (uint, error) {
+	c, ok := w.categories[slug]
+	if !ok || depth > len(w.categories) {
+		return 0, fmt.Errorf("category %s: unknown or cyclic parent", slug)
+	}
+	var parentID *uint
+	if c.Parent != "" {
+		id, err := w.wpCategory(ctx, c.Parent, depth+1)
+		if err != nil {
+			return 0, err
+		}
+		parentID = &id
+	}
+	return w.category(ctx, c.Slug, c.Name, c.Slug, parentID)
+}
+
+func (w *wordPressImport) post(ctx context.Context, it wxr.Item) error {
+	ext := strconv.Itoa(it.ID)
+	status, ok := wordPressStatuses[it.Status]
+	if !ok {
+		w.report.Count(model.ImportKindPost).Skipped++
+		w.report.note("post %s: status %q not imported", ext, it.Status)
+		return nil
+	}
+
+	var categoryID uint
+	var err error
+	if cats := it.TermsIn("category"); len(cats) > 0 {
+		if _, known := w.categories[cats[0].Slug]; known {
+			categoryID, err = w.wpCategory(ctx, cats[0].Slug, 0)
+		} else {
+			categoryID, err = w.category(ctx, cats[0].Slug, cats[0].Name, cats[0].Slug, nil)
+		}
+	} else {
+		categoryID, err = w.defaultCategory(ctx)
+	}
+	if err != nil {
+		return err
+	}
+	var tagIDs []uint
+	for _, t := range it.TermsIn("post_tag") {
+		id, err := w.tag(ctx, t.Name, t.Slug)
+		if err != nil {
+			return err
+		}
+		if id != 0 {
+			tagIDs = append(tagIDs, id)
+		}
+	}
+
+	content := it.Content
+	if !strings.Contains(content, "<p") && !strings.Contains(content, "<!-- wp:") {
+		content = wpAutoP(content)
+	}
+	if strings.TrimSpace(content) == "" {
+		content = "<p></p>"
+	}
+	req := request.CreatePostRequest{
+		Title:         it.Title,
+		Slug:          it.Slug,
+		Content:       content,
+		ContentFormat: string(model.ContentFormatHTML),
+		CategoryID:    categoryID,
+		Status:        string(status),
+		Excerpt:       it.Excerpt,
+		TagIDs:        tagIDs,
+		CanonicalURL:  it.Meta["_yoast_wpseo_canonical"],
+		OgImageURL:    it.Meta["_yoast_wpseo_opengraph-image"],
+	}
+	if v := it.Meta["_yoast_wpseo_title"]; !strings.Contains(v, "%%") {
+		req.MetaTitle = v
+	}
+	if v := it.Meta["_yoast_wpseo_metadesc"]; !strings.Contains(v, "%%") {
+		req.MetaDescription = v
+	}
+	var robots []string
+	if it.Meta["_yoast_wpseo_meta-robots-noindex"] == "1" {
+		robots = append(robots, "noindex")
+	}
+	if it.Meta["_yoast_wpseo_meta-robots-nofollow"] == "1" {
+		robots = append(robots, "nofollow")
+	}
+	req.RobotsMeta = strings.Join(robots, ", ")
+	if status != model.PostStatusDraft && !it.Date.IsZero() {
+		at := it.Date
+		req.PublishAt = &at
+	}
+
+	authorID, ok := w.authors[it.Creator]
+	if !ok {
+		authorID = w.actor
+	}
+	postID, err := w.importRun.post(ctx, ext, authorID, req, it.Date, it.Modified)
+	if err != nil || postID == 0 {
+		return err
+	}
+	w.posts[it.ID] = postID
+
+	byID := make(map[int]wxr.Comment, len(it.Comments))
+	for _, c := range it.Comments {
+		byID[c.ID] = c
+	}
+	for _, c := range it.Comments {
+		if _, err := w.comment(ctx, it.ID, postID, c, byID, 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// comment imports c after its parent; replies to comments that are left out become top-level.
+func (w *wordPressImport) comment(ctx context.Context, wpPostID int, postID uint, c wxr.Comment, byID map[int]wxr.Comment, depth int) (uint, error) {
+	ext := strconv.Itoa(wpPostID) + "/" + strconv.Itoa(c.ID)
+	if c.Approved != "1" || (c.Type != "" && c.Type != "comment") || strings.TrimSpace(c.Content) == "" {
+		if _, counted := w.seen[model.ImportKindComment+"\x00"+ext]; !counted {
+			w.seen[model.ImportKindComment+"\x00"+ext] = 0
+			w.report.Count(model.ImportKindComment).Skipped++
+		}
+		return 0, nil
+	}
+	var parentID uint
+	if p, ok := byID[c.Parent]; ok && c.Parent != 0 && depth < len(byID) {
+		id, err := w.comment(ctx, wpPostID, postID, p, byID, depth+1)
+		if err != nil {
+			return 0, err
+		}
+		parentID = id
+	}
+
+	userID, ok := w.authorIDs[c.UserID]
+	if !ok || c.UserID == 0 {
+		email := c.AuthorEmail
+		if email == "" {
+			email = guestEmail(c.Author)
+		}
+		name := c.Author
+		if name == "" {
+			name = "Guest"
+		}
+		id, err := w.user(ctx, email, name)
+		if err != nil {
+			return 0, err
+		}
+		userID = id
+	}
+	return w.importRun.comment(ctx, ext, postID, parentID, userID, c.Content, c.Date)
+}
+
+// wpAutoP wraps the blank-line separated paragraphs of classic-editor content in <p>, turning
+// single newlines into <br>, as WordPress does when it renders such posts.
+func wpAutoP(s string) string {
+	s = strings.ReplaceAll(strings.ReplaceAll(s, "\r\n", "\n"), "\r", "\n")
+	var b strings.Builder
+	for _, para := range strings.Split(s, "\n\n") {
+		para = strings.TrimSpace(para)
+		if para == "" {
+			continue
+		}
+		b.WriteString("<p>")
+		b.WriteString(strings.ReplaceAll(para, "\n", "<br>\n"))
+		b.WriteString("</p>\n")
+	}
+	return b.String()
+}
+
+// urlFileName is the last element of a URL path, unescaped; "file" when there is none.
+func urlFileName(p string) string {
+	name := path.Base(p)
+	if u, err := url.PathUnescape(name); err == nil {
+		name = u
+	}
+	if name == "" || name == "." || name == "/" {
+		return "file"
+	}
+	return name
+}
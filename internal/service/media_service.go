@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"mime"
 	"mime/multipart"
 	"path/filepath"
@@ -92,14 +93,54 @@ func (u *MediaService) Upload(
 		return nil, ErrMediaTooLarge
 	}
 
-	origName := strings.TrimSpace(fh.Filename)
+	f, err := fh.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	m, err := u.Store(ctx, actorUserID, fh.Filename, fh.Header.Get("Content-Type"), f, fh.Size, parentID)
+	if err != nil {
+		return nil, err
+	}
+	downloadURL, err := u.PresignGet(ctx, m.StoragePath, 1*time.Hour)
+	if err != nil {
+		return nil, err
+	}
+	m.DownloadURL = downloadURL
+	if strings.TrimSpace(m.DownloadURL) == "" {
+		return nil, errors.New("failed to presign get object")
+	}
+
+	return m, nil
+}
+
+// Store writes size bytes from r to storage and creates a Media row for them in the actor's tree
+// (optional parent folder id). The name gets a numeric suffix when a sibling already uses it.
+func (u *MediaService) Store(
+	ctx context.Context,
+	actorUserID uint,
+	origName string,
+	mimeType string,
+	r io.Reader,
+	size int64,
+	parentID *uint,
+) (*model.Media, error) {
+	if actorUserID == 0 {
+		return nil, ErrMediaInvalidActor
+	}
+	if u.maxBytes > 0 && size > u.maxBytes {
+		return nil, ErrMediaTooLarge
+	}
+
+	origName = strings.TrimSpace(origName)
 	if origName == "" {
 		origName = "upload"
 	}
 	ext := strings.ToLower(filepath.Ext(origName))
 	if ext == "" {
-		if mt := fh.Header.Get("Content-Type"); mt != "" {
-			if exts, _ := mime.ExtensionsByType(mt); len(exts) > 0 {
+		if mimeType != "" {
+			if exts, _ := mime.ExtensionsByType(mimeType); len(exts) > 0 {
 				ext = exts[0]
 			}
 		}
@@ -108,7 +149,6 @@ func (u *MediaService) Upload(
 		ext = ".bin"
 	}
 
-	mimeType := fh.Header.Get("Content-Type")
 	if mimeType == "" {
 		if mt := mime.TypeByExtension(ext); mt != "" {
 			mimeType = mt
@@ -122,12 +162,6 @@ func (u *MediaService) Upload(
 		mediaType = "image"
 	}
 
-	f, err := fh.Open()
-	if err != nil {
-		return nil, err
-	}
-	defer func() { _ = f.Close() }()
-
 	id, err := ids.New()
 	if err != nil {
 		u.log.Error("failed to generate id", zap.Error(err))
@@ -138,25 +172,24 @@ func (u *MediaService) Upload(
 	storageFilename := id + ext
 	objectKey := filepath.ToSlash(filepath.Join(relDir, storageFilename))
 
-	if err := u.store.Put(ctx, objectKey, f, fh.Size, mimeType); err != nil {
+	if err := u.store.Put(ctx, objectKey, r, size, mimeType); err != nil {
 		return nil, err
 	}
 
 	baseName := filepath.Base(origName)
-	var m *model.Media
 	for attempt := 0; attempt < 10; attempt++ {
 		name := baseName
 		if attempt > 0 {
 			name = fmt.Sprintf("%s_%d", filepath.Base(origName), attempt)
 		}
 
-		m = &model.Media{
+		m := &model.Media{
 			UserID:       actorUserID,
 			Name:         name,
 			MediaType:    mediaType,
 			OriginalName: origName,
 			MimeType:     mimeType,
-			Size:         fh.Size,
+			Size:         size,
 			StoragePath:  objectKey,
 			CreatedBy:    actorUserID,
 			UpdatedBy:    actorUserID,
@@ -169,7 +202,7 @@ func (u *MediaService) Upload(
 			insErr = u.repo.CreateFileChild(ctx, actorUserID, *parentID, m)
 		}
 		if insErr == nil {
-			goto done
+			return m, nil
 		}
 		if errors.Is(insErr, gorm.ErrDuplicatedKey) {
 			continue
@@ -183,18 +216,6 @@ func (u *MediaService) Upload(
 	}
 	_ = u.store.Delete(ctx, objectKey)
 	return nil, errors.New("could not allocate unique media name")
-
-done:
-	downloadURL, err := u.PresignGet(ctx, objectKey, 1*time.Hour)
-	if err != nil {
-		return nil, err
-	}
-	m.DownloadURL = downloadURL
-	if strings.TrimSpace(m.DownloadURL) == "" {
-		return nil, errors.New("failed to presign get object")
-	}
-
-	return m, nil
 }
 
 func (u *MediaService) CreateFolderRoot(ctx context.Context, actorUserID uint, name string) (*model.Media, error) {
@@ -367,27 +388,17 @@ func (u *MediaService) UserAvatar(ctx context.Context, user *model.User) (*strin
 	return u.repo.UserAvatar(ctx, user)
 }
 
+// Delete moves the media node and its subtree to the trash. Stored files stay until the trash is
+// purged, so a restore brings them back intact.
 func (u *MediaService) Delete(ctx context.Context, actorUserID, id uint) error {
 	if actorUserID == 0 || id == 0 {
 		return ErrMediaInvalid
 	}
-	rows, err := u.repo.GetSubtree(ctx, actorUserID, id)
-	if err != nil {
+	if err := u.repo.DeleteSubtree(ctx, actorUserID, id, actorUserID); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return ErrMediaNotFound
 		}
 		return err
 	}
-	if len(rows) == 0 {
-		return ErrMediaNotFound
-	}
-	for i := range rows {
-		if rows[i].MediaType == "folder" || rows[i].StoragePath == "" {
-			continue
-		}
-		if err := u.store.Delete(ctx, rows[i].StoragePath); err != nil {
-			return err
-		}
-	}
-	return u.repo.DeleteSubtree(ctx, actorUserID, id, actorUserID)
+	return nil
 }
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/turahe/go-restfull/internal/handler/request"
+	"github.com/turahe/go-restfull/internal/model"
+
+	"go.uber.org/zap"
+)
+
+var (
+	ErrPostNeedsAuthor      = errors.New("contributors must include at least one author")
+	ErrDuplicateContributor = errors.New("a user can be listed only once among a post's contributors")
+	ErrContributorNotFound  = errors.New("one or more contributors not found")
+)
+
+// contributorsFromRequest turns a contributor list into rows in request order and returns the first
+// author, who becomes the post's UserID. An empty list credits fallbackUserID as sole author.
+func (s *PostService) contributorsFromRequest(ctx context.Context, reqs []request.PostContributorRequest, fallbackUserID uint) ([]model.PostContributor, uint, error) {
+	if len(reqs) == 0 {
+		return []model.PostContributor{{UserID: fallbackUserID, Role: model.ContributorAuthor}}, fallbackUserID, nil
+	}
+	rows := make([]model.PostContributor, 0, len(reqs))
+	ids := make([]uint, 0, len(reqs))
+	seen := make(map[uint]bool, len(reqs))
+	var primary uint
+	for i, c := range reqs {
+		role := model.ContributorRole(c.Role)
+		if c.UserID == 0 || !role.IsValid() {
+			return nil, 0, ErrInvalidPayload
+		}
+		if seen[c.UserID] {
+			return nil, 0, ErrDuplicateContributor
+		}
+		seen[c.UserID] = true
+		if role == model.ContributorAuthor && primary == 0 {
+			primary = c.UserID
+		}
+		rows = append(rows, model.PostContributor{UserID: c.UserID, Role: role, Position: i})
+		ids = append(ids, c.UserID)
+	}
+	if primary == 0 {
+		return nil, 0, ErrPostNeedsAuthor
+	}
+	n, err := s.posts.CountUsers(ctx, ids)
+	if err != nil {
+		s.log.Error("failed to count contributor users", zap.Error(err))
+		return nil, 0, err
+	}
+	if n != int64(len(ids)) {
+		return nil, 0, ErrContributorNotFound
+	}
+	return rows, primary, nil
+}
+
+// saveContributors replaces the post's contributors and loads them back (with users) onto p.
+func (s *PostService) saveContributors(ctx context.Context, p *model.Post, rows []model.PostContributor) error {
+	if err := s.posts.ReplaceContributors(ctx, p.ID, rows); err != nil {
+		s.log.Error("failed to replace post contributors", zap.Error(err))
+		return err
+	}
+	return s.loadContributors(ctx, p)
+}
+
+func (s *PostService) loadContributors(ctx context.Context, p *model.Post) error {
+	cs, err := s.posts.ListContributors(ctx, p.ID)
+	if err != nil {
+		s.log.Error("failed to list post contributors", zap.Error(err))
+		return err
+	}
+	p.Contributors = cs
+	return nil
+}
+
+// isPostOwner reports whether userID owns p: its primary author or any other listed author.
+func (s *PostService) isPostOwner(ctx context.Context, p *model.Post, userID uint) (bool, error) {
+	if p.UserID == userID {
+		return true, nil
+	}
+	return s.posts.IsAuthor(ctx, p.ID, userID)
+}
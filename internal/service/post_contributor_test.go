@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/turahe/go-restfull/internal/handler/request"
+	"github.com/turahe/go-restfull/internal/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostContributors(t *testing.T) {
+	ctx := context.Background()
+	env := newPostTestEnv(t)
+	db, catRepo, posts := env.db, env.categories, env.posts
+
+	users := make([]model.User, 4)
+	for i := range users {
+		users[i] = model.User{Name: "U", Email: string(rune('a'+i)) + "@contrib.test", Password: "x"}
+		require.NoError(t, db.Create(&users[i]).Error)
+	}
+	lead, coauthor, editor, outsider := users[0].ID, users[1].ID, users[2].ID, users[3].ID
+	cat, err := catRepo.CreateRoot(ctx, "Features", lead)
+	require.NoError(t, err)
+
+	solo, err := posts.Create(ctx, lead, request.CreatePostRequest{Title: "Solo piece", Content: "x", CategoryID: cat.ID})
+	require.NoError(t, err)
+	require.Len(t, solo.Contributors, 1, "without contributors the creator is the sole author")
+	assert.Equal(t, model.ContributorAuthor, solo.Contributors[0].Role)
+	assert.Equal(t, lead, solo.Contributors[0].UserID)
+
+	_, err = posts.Create(ctx, lead, request.CreatePostRequest{Title: "No author", Content: "x", CategoryID: cat.ID,
+		Contributors: []request.PostContributorRequest{{UserID: editor, Role: "editor"}}})
+	assert.ErrorIs(t, err, ErrPostNeedsAuthor)
+	_, err = posts.Create(ctx, lead, request.CreatePostRequest{Title: "Twice", Content: "x", CategoryID: cat.ID,
+		Contributors: []request.PostContributorRequest{{UserID: lead, Role: "author"}, {UserID: lead, Role: "editor"}}})
+	assert.ErrorIs(t, err, ErrDuplicateContributor)
+	_, err = posts.Create(ctx, lead, request.CreatePostRequest{Title: "Ghost", Content: "x", CategoryID: cat.ID,
+		Contributors: []request.PostContributorRequest{{UserID: 999, Role: "author"}}})
+	assert.ErrorIs(t, err, ErrContributorNotFound)
+
+	// The first listed author becomes the primary author, even when listed after an editor.
+	long, err := posts.Create(ctx, lead, request.CreatePostRequest{Title: "Long read", Content: "x", CategoryID: cat.ID,
+		Contributors: []request.PostContributorRequest{{UserID: editor, Role: "editor"}, {UserID: coauthor, Role: "author"}, {UserID: lead, Role: "author"}}})
+	require.NoError(t, err)
+	assert.Equal(t, coauthor, long.UserID)
+	require.Len(t, long.Contributors, 3)
+	assert.Equal(t, []uint{editor, coauthor, lead}, []uint{long.Contributors[0].UserID, long.Contributors[1].UserID, long.Contributors[2].UserID})
+	require.NotNil(t, long.Contributors[0].User)
+
+	// Any listed author owns the post; editors and outsiders do not.
+	_, err = posts.Update(ctx, long.ID, lead, request.UpdatePostRequest{Title: "Long read, revised"})
+	assert.NoError(t, err, "a co-author may edit")
+	_, err = posts.Update(ctx, long.ID, editor, request.UpdatePostRequest{Title: "Editor edit"})
+	assert.ErrorIs(t, err, ErrNotPostOwner)
+	_, err = posts.Update(ctx, long.ID, outsider, request.UpdatePostRequest{Title: "Outsider edit"})
+	assert.ErrorIs(t, err, ErrNotPostOwner)
+
+	_, err = posts.Update(ctx, long.ID, lead, request.UpdatePostRequest{Contributors: []request.PostContributorRequest{}})
+	assert.ErrorIs(t, err, ErrPostNeedsAuthor, "an empty list would leave the post without an author")
+
+	updated, err := posts.Update(ctx, long.ID, lead, request.UpdatePostRequest{Contributors: []request.PostContributorRequest{
+		{UserID: lead, Role: "author"}, {UserID: outsider, Role: "illustrator"}}})
+	require.NoError(t, err)
+	assert.Equal(t, lead, updated.UserID)
+	require.Len(t, updated.Contributors, 2)
+	assert.Equal(t, model.ContributorIllustrator, updated.Contributors[1].Role)
+	assert.ErrorIs(t, posts.Delete(ctx, long.ID, coauthor), ErrNotPostOwner, "removed co-authors lose ownership")
+
+	got, err := posts.GetBySlug(ctx, long.Slug, PostAccess{}, LocalePreference{})
+	require.NoError(t, err)
+	require.Len(t, got.Contributors, 2)
+	assert.Equal(t, lead, got.Contributors[0].UserID)
+}
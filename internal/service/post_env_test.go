@@ -0,0 +1,59 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/turahe/go-restfull/internal/database"
+	"github.com/turahe/go-restfull/internal/repository"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// openMigratedTestDB opens the test database with the full production schema (database.AutoMigrate),
+// so schema changes need no edits to the tests.
+func openMigratedTestDB(t *testing.T) (*RBACService, *gorm.DB) {
+	t.Helper()
+	rbacSvc, db := openRBACServiceTestDB(t)
+	require.NoError(t, database.AutoMigrate(db))
+	return rbacSvc, db
+}
+
+// postTestEnv is a migrated test database with the repositories posts are built on and a PostService
+// wired to them. Tests enable optional features on posts with its With setters.
+type postTestEnv struct {
+	db         *gorm.DB
+	rbac       *RBACService
+	log        *zap.Logger
+	postRepo   *repository.PostRepository
+	categories *repository.CategoryRepository
+	tags       *repository.TagRepository
+	revisions  *repository.PostRevisionRepository
+	settings   *repository.SettingRepository
+	posts      *PostService
+}
+
+func newPostTestEnv(t *testing.T) *postTestEnv {
+	t.Helper()
+	rbacSvc, db := openMigratedTestDB(t)
+	log := zap.NewNop()
+	env := &postTestEnv{
+		db:         db,
+		rbac:       rbacSvc,
+		log:        log,
+		postRepo:   repository.NewPostRepository(db, log),
+		categories: repository.NewCategoryRepository(db, log),
+		tags:       repository.NewTagRepository(db, log),
+		revisions:  repository.NewPostRevisionRepository(db, log),
+		settings:   repository.NewSettingRepository(db, log),
+	}
+	env.posts = env.newPostService()
+	return env
+}
+
+// newPostService builds another PostService on the env's repositories, for tests that need two
+// differently configured services.
+func (e *postTestEnv) newPostService() *PostService {
+	return NewPostService(e.postRepo, e.categories, e.tags, e.revisions, e.settings, e.log)
+}
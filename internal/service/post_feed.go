@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/internal/repository"
+	"github.com/turahe/go-restfull/pkg/feed"
+	"github.com/turahe/go-restfull/pkg/locale"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// SettingSiteURL is the settings key holding the public site's base URL, used for links in feeds
+	// and sitemaps; the API's own origin is used when unset.
+	SettingSiteURL = "siteUrl"
+	// SettingFeedSize is the settings key holding how many posts a feed lists.
+	SettingFeedSize = "feedSize"
+)
+
+const (
+	defaultFeedSize = 20
+	maxFeedSize     = 100
+)
+
+// FeedQuery selects a feed: every live post, or those in one category (with its subcategories), with
+// one tag or by one author. Origin (scheme://host) and Path (with query) are where the feed was
+// requested, for its self link.
+type FeedQuery struct {
+	CategorySlug string
+	TagSlug      string
+	AuthorID     uint
+	Locale       string
+	Origin       string
+	Path         string
+}
+
+// Feed builds a feed of the most recently published live posts matching q, in q.Locale (default: the
+// site's default locale; untranslated posts fall back to it). Channel metadata comes from the siteTitle,
+// siteDescription and siteUrl settings, and the feedSize setting caps the item count.
+func (s *PostService) Feed(ctx context.Context, q FeedQuery) (*feed.Feed, error) {
+	def := s.defaultLocale(ctx)
+	loc := def
+	if q.Locale != "" {
+		l, err := locale.Normalize(q.Locale)
+		if err != nil {
+			return nil, ErrInvalidLocale
+		}
+		loc = l
+	}
+	siteURL := siteBaseURL(ctx, s.settings, q.Origin)
+	f := &feed.Feed{
+		Title:       settingString(ctx, s.settings, "siteTitle", fallbackPublicSettings["siteTitle"]),
+		Description: settingString(ctx, s.settings, "siteDescription", fallbackPublicSettings["siteDescription"]),
+		Link:        siteURL,
+		FeedURL:     q.Origin + q.Path,
+		Language:    loc,
+	}
+	filter := repository.FeedFilter{Locale: loc, DefaultLocale: def}
+	switch {
+	case q.CategorySlug != "":
+		name, ids, err := s.feedCategory(ctx, q.CategorySlug, loc)
+		if err != nil {
+			return nil, err
+		}
+		f.Title += " – " + name
+		filter.CategoryIDs = ids
+	case q.TagSlug != "":
+		t, err := s.feedTag(ctx, q.TagSlug, loc)
+		if err != nil {
+			return nil, err
+		}
+		f.Title += " – " + t.Name
+		filter.TagID = t.ID
+	case q.AuthorID != 0:
+		u, err := s.posts.FindUser(ctx, q.AuthorID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrUserNotFound
+			}
+			return nil, err
+		}
+		f.Title += " – " + u.Name
+		filter.AuthorID = u.ID
+	}
+
+	size := settingInt(ctx, s.settings, SettingFeedSize, defaultFeedSize)
+	size = max(1, min(size, maxFeedSize))
+	rows, err := s.posts.ListFeed(ctx, filter, time.Now(), size)
+	if err != nil {
+		return nil, err
+	}
+	s.refreshRenders(ctx, rows)
+	if loc != def {
+		ptrs := make([]*model.Post, len(rows))
+		for i := range rows {
+			ptrs[i] = &rows[i]
+		}
+		if err := s.localizeTaxonomy(ctx, ptrs, loc); err != nil {
+			return nil, err
+		}
+	}
+
+	host := "localhost"
+	if u, err := url.Parse(siteURL); err == nil && u.Hostname() != "" {
+		host = u.Hostname()
+	}
+	f.Items = make([]feed.Item, len(rows))
+	for i := range rows {
+		it := feedItem(&rows[i], siteURL, host)
+		if it.Updated.After(f.Updated) {
+			f.Updated = it.Updated
+		}
+		f.Items[i] = it
+	}
+	return f, nil
+}
+
+// feedCategory returns the display name of the category with slug (in loc when translated) and the ids
+// of its subtree. Old category slugs yield a *SlugMovedError.
+func (s *PostService) feedCategory(ctx context.Context, slug, loc string) (string, []uint, error) {
+	if s.categories == nil {
+		return "", nil, ErrCategoryNotFound
+	}
+	c, err := s.categories.FindBySlug(ctx, slug)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", nil, s.movedCategory(ctx, slug)
+		}
+		return "", nil, err
+	}
+	sub, err := s.categories.GetSubtree(ctx, c.ID)
+	if err != nil {
+		return "", nil, err
+	}
+	ids := make([]uint, len(sub))
+	for i := range sub {
+		ids[i] = sub[i].ID
+	}
+	name := c.Name
+	if ts, err := s.categories.TranslationsForLocale(ctx, []uint{c.ID}, loc); err == nil && len(ts) > 0 {
+		name = ts[0].Name
+	}
+	return name, ids, nil
+}
+
+// feedTag returns the tag with slug or a translated slug, named in loc when translated. Old tag slugs
+// yield a *SlugMovedError.
+func (s *PostService) feedTag(ctx context.Context, slug, loc string) (*model.Tag, error) {
+	if s.tags == nil {
+		return nil, ErrTagNotFound
+	}
+	t, err := s.tags.FindBySlug(ctx, slug)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		tr, terr := s.tags.FindTranslationBySlug(ctx, slug)
+		if terr != nil {
+			if errors.Is(terr, gorm.ErrRecordNotFound) {
+				return nil, s.movedFeedTag(ctx, slug)
+			}
+			return nil, terr
+		}
+		t, err = s.tags.FindByID(ctx, tr.TagID)
+	}
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTagNotFound
+		}
+		return nil, err
+	}
+	if ts, err := s.tags.TranslationsForLocale(ctx, []uint{t.ID}, loc); err == nil && len(ts) > 0 {
+		t.Name = ts[0].Name
+	}
+	return t, nil
+}
+
+// feedItem maps a post to a feed entry. Its id is a tag URI (RFC 4151) so it survives slug changes;
+// its link is the SEO canonical URL when set, else {siteURL}/posts/{slug}.
+func feedItem(p *model.Post, siteURL, host string) feed.Item {
+	published := p.CreatedAt
+	if p.PublishAt != nil {
+		published = *p.PublishAt
+	}
+	it := feed.Item{
+		ID:          fmt.Sprintf("tag:%s,%s:post-%d", host, p.CreatedAt.UTC().Format("2006-01-02"), p.ID),
+		Title:       p.Title,
+		Link:        siteURL + "/posts/" + p.Slug,
+		ContentHTML: p.ContentHTML,
+		Published:   published,
+		Updated:     p.UpdatedAt,
+	}
+	if published.After(it.Updated) {
+		it.Updated = published
+	}
+	if seo := p.PostSEO; seo != nil {
+		it.Summary = seo.Excerpt
+		it.Image = seo.OgImageURL
+		if seo.CanonicalURL != "" {
+			it.Link = seo.CanonicalURL
+		}
+	}
+	for _, c := range p.Contributors {
+		if c.Role == model.ContributorAuthor && c.User != nil {
+			it.Authors = append(it.Authors, c.User.Name)
+		}
+	}
+	if p.Category != nil {
+		it.Categories = append(it.Categories, p.Category.Name)
+	}
+	for _, t := range p.Tags {
+		it.Categories = append(it.Categories, t.Name)
+	}
+	return it
+}
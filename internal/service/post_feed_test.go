@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/handler/request"
+	"github.com/turahe/go-restfull/internal/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostFeed(t *testing.T) {
+	ctx := context.Background()
+	env := newPostTestEnv(t)
+	db, log, catRepo, tagRepo, settingRepo, posts := env.db, env.log, env.categories, env.tags, env.settings, env.posts
+
+	ada := model.User{Name: "Ada", Email: "ada@feed.test", Password: "x"}
+	bob := model.User{Name: "Bob", Email: "bob@feed.test", Password: "x"}
+	require.NoError(t, db.Create(&ada).Error)
+	require.NoError(t, db.Create(&bob).Error)
+	travel, err := catRepo.CreateRoot(ctx, "Travel", ada.ID)
+	require.NoError(t, err)
+	asia, err := catRepo.CreateChild(ctx, travel.ID, "Asia", ada.ID)
+	require.NoError(t, err)
+	food, err := catRepo.CreateRoot(ctx, "Food", ada.ID)
+	require.NoError(t, err)
+	tag := &model.Tag{Name: "Beaches", Slug: "beaches"}
+	require.NoError(t, tagRepo.Create(ctx, tag))
+
+	day := func(d int) *time.Time { t := time.Now().AddDate(0, 0, d); return &t }
+	create := func(author uint, req request.CreatePostRequest) *model.Post {
+		req.Content = "Some *content*."
+		p, err := posts.Create(ctx, author, req)
+		require.NoError(t, err)
+		return p
+	}
+	bali := create(ada.ID, request.CreatePostRequest{Title: "Bali beaches", CategoryID: asia.ID, TagIDs: []uint{tag.ID}, PublishAt: day(-3), Excerpt: "Sand and sun", CanonicalURL: "https://elsewhere.example/bali"})
+	ramen := create(bob.ID, request.CreatePostRequest{Title: "Ramen at home", CategoryID: food.ID, PublishAt: day(-1)})
+	lisbon := create(ada.ID, request.CreatePostRequest{Title: "Lisbon walks", CategoryID: travel.ID, PublishAt: day(-2)})
+	create(ada.ID, request.CreatePostRequest{Title: "Draft trip", CategoryID: travel.ID, Status: "draft"})
+	create(ada.ID, request.CreatePostRequest{Title: "Future trip", CategoryID: travel.ID, PublishAt: day(2)})
+
+	titles := func(q FeedQuery) []string {
+		f, err := posts.Feed(ctx, q)
+		require.NoError(t, err)
+		out := make([]string, len(f.Items))
+		for i, it := range f.Items {
+			out[i] = it.Title
+		}
+		return out
+	}
+
+	f, err := posts.Feed(ctx, FeedQuery{Origin: "https://api.example", Path: "/feeds/posts.rss"})
+	require.NoError(t, err)
+	assert.Equal(t, "Go REST Blog", f.Title, "channel metadata falls back to the default public settings")
+	assert.Equal(t, "https://api.example", f.Link)
+	assert.Equal(t, "https://api.example/feeds/posts.rss", f.FeedURL)
+	assert.Equal(t, "en", f.Language)
+	require.Len(t, f.Items, 3, "drafts and scheduled posts are left out")
+	assert.Equal(t, []string{ramen.Title, lisbon.Title, bali.Title}, []string{f.Items[0].Title, f.Items[1].Title, f.Items[2].Title}, "newest first")
+	last := f.Items[2]
+	assert.Equal(t, "Sand and sun", last.Summary, "summaries come from the SEO excerpt")
+	assert.Equal(t, "https://elsewhere.example/bali", last.Link)
+	assert.Equal(t, []string{"Ada"}, last.Authors)
+	assert.Equal(t, []string{"Asia", "Beaches"}, last.Categories)
+	assert.Contains(t, last.ContentHTML, "<em>content</em>")
+	assert.Regexp(t, `^tag:api\.example,\d{4}-\d{2}-\d{2}:post-\d+$`, last.ID)
+	assert.Equal(t, "https://api.example/posts/"+ramen.Slug, f.Items[0].Link)
+	assert.False(t, f.Updated.Before(f.Items[0].Updated))
+
+	require.NoError(t, settingRepo.Upsert(ctx, SettingSiteURL, "https://blog.example/", true))
+	require.NoError(t, settingRepo.Upsert(ctx, "siteTitle", "Wanderlust", true))
+	require.NoError(t, settingRepo.Upsert(ctx, SettingFeedSize, "2", false))
+	f, err = posts.Feed(ctx, FeedQuery{CategorySlug: travel.Slug, Origin: "https://api.example"})
+	require.NoError(t, err)
+	assert.Equal(t, "Wanderlust – Travel", f.Title)
+	assert.Equal(t, "https://blog.example", f.Link)
+	require.Len(t, f.Items, 2)
+	assert.Equal(t, "https://blog.example/posts/"+lisbon.Slug, f.Items[0].Link, "post links use siteUrl")
+	assert.Equal(t, bali.Title, f.Items[1].Title, "category feeds include subcategories")
+
+	assert.Equal(t, []string{bali.Title}, titles(FeedQuery{TagSlug: "beaches"}))
+	assert.Equal(t, []string{ramen.Title}, titles(FeedQuery{AuthorID: bob.ID}))
+
+	_, err = NewTagService(tagRepo, log).PutTranslation(ctx, tag.ID, "id", "Pantai")
+	require.NoError(t, err)
+	f, err = posts.Feed(ctx, FeedQuery{TagSlug: "pantai", Locale: "id"})
+	require.NoError(t, err)
+	assert.Equal(t, "Wanderlust – Pantai", f.Title, "translated tag slugs resolve and names are localized")
+	require.Len(t, f.Items, 1)
+	assert.Equal(t, "id", f.Language)
+
+	_, err = posts.Feed(ctx, FeedQuery{CategorySlug: "missing"})
+	assert.ErrorIs(t, err, ErrCategoryNotFound)
+	_, err = posts.Feed(ctx, FeedQuery{TagSlug: "missing"})
+	assert.ErrorIs(t, err, ErrTagNotFound)
+	_, err = posts.Feed(ctx, FeedQuery{AuthorID: 999})
+	assert.ErrorIs(t, err, ErrUserNotFound)
+	_, err = posts.Feed(ctx, FeedQuery{Locale: "not a locale"})
+	assert.ErrorIs(t, err, ErrInvalidLocale)
+}
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"strings"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/internal/service/dto"
+	"github.com/turahe/go-restfull/pkg/markup"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/html"
+)
+
+const (
+	// SettingDefaultShareImage is the settings key holding the share image for posts without one.
+	SettingDefaultShareImage = "defaultShareImage"
+	// SettingTwitterSite is the settings key holding the site's Twitter handle (twitter:site).
+	SettingTwitterSite = "twitterSite"
+
+	// metaDescriptionChars caps descriptions derived from the excerpt or content.
+	metaDescriptionChars = 160
+)
+
+// Meta builds the <head> metadata of the post with slug, resolved and access-checked like GetBySlug.
+// Each field falls back when the post's SEO row leaves it empty: the title to the post title, the
+// description to the excerpt and then the content, the canonical URL to {siteUrl}/posts/{slug}, and the
+// share image to the first image in the content and then the defaultShareImage setting. Origin is the
+// API's scheme://host, used when the siteUrl setting is unset. Unpublished posts (previews) are noindex.
+func (s *PostService) Meta(ctx context.Context, slug string, access PostAccess, pref LocalePreference, origin string) (*dto.PostMeta, error) {
+	p, err := s.GetBySlug(ctx, slug, access, pref)
+	if err != nil {
+		return nil, err
+	}
+	siteURL := siteBaseURL(ctx, s.settings, origin)
+	siteName := settingString(ctx, s.settings, "siteTitle", fallbackPublicSettings["siteTitle"])
+	seo := p.PostSEO
+	if seo == nil {
+		seo = &model.PostSEO{}
+	}
+
+	m := &dto.PostMeta{
+		Title:       cmp.Or(seo.MetaTitle, p.Title),
+		Description: seo.MetaDescription,
+		Canonical:   cmp.Or(seo.CanonicalURL, siteURL+"/posts/"+p.Slug),
+		Robots:      seo.RobotsMeta,
+		Locale:      p.Locale,
+	}
+	if m.Description == "" {
+		m.Description = markup.Excerpt(cmp.Or(seo.Excerpt, renderDocument(p.ContentFormat, p.Content).Text), metaDescriptionChars)
+	}
+	if !postLive(p, time.Now()) {
+		m.Robots = "noindex, nofollow"
+	}
+	image := cmp.Or(seo.OgImageURL, firstImage(p.ContentHTML, siteURL), settingString(ctx, s.settings, SettingDefaultShareImage, ""))
+
+	published := p.CreatedAt
+	if p.PublishAt != nil {
+		published = *p.PublishAt
+	}
+	modified := p.UpdatedAt
+	if published.After(modified) {
+		modified = published
+	}
+	var authors, tags []string
+	for _, c := range p.Contributors {
+		if c.Role == model.ContributorAuthor && c.User != nil {
+			authors = append(authors, c.User.Name)
+		}
+	}
+	for _, t := range p.Tags {
+		tags = append(tags, t.Name)
+	}
+	section := ""
+	if p.Category != nil {
+		section = p.Category.Name
+	}
+
+	m.OpenGraph = dto.OpenGraphMeta{
+		Type:          "article",
+		Title:         m.Title,
+		Description:   m.Description,
+		URL:           m.Canonical,
+		SiteName:      siteName,
+		Image:         image,
+		Locale:        ogLocale(p.Locale),
+		PublishedTime: published.UTC().Format(time.RFC3339),
+		ModifiedTime:  modified.UTC().Format(time.RFC3339),
+		Section:       section,
+		Tags:          tags,
+		Authors:       authors,
+	}
+	for _, a := range p.Alternates {
+		href := siteURL + "/posts/" + a.Slug
+		if a.Locale != p.Locale {
+			m.OpenGraph.LocaleAlternates = append(m.OpenGraph.LocaleAlternates, ogLocale(a.Locale))
+		}
+		m.Alternates = append(m.Alternates, dto.MetaAlternate{Hreflang: a.Locale, Href: href})
+		if a.Default {
+			m.Alternates = append(m.Alternates, dto.MetaAlternate{Hreflang: "x-default", Href: href})
+		}
+	}
+	m.Twitter = dto.TwitterCardMeta{
+		Card:        "summary",
+		Title:       m.Title,
+		Description: m.Description,
+		Image:       image,
+		Site:        settingString(ctx, s.settings, SettingTwitterSite, ""),
+	}
+	if image != "" {
+		m.Twitter.Card = "summary_large_image"
+	}
+
+	posting := map[string]any{
+		"@context":         "https://schema.org",
+		"@type":            "BlogPosting",
+		"headline":         m.Title,
+		"description":      m.Description,
+		"url":              m.Canonical,
+		"mainEntityOfPage": map[string]any{"@type": "WebPage", "@id": m.Canonical},
+		"datePublished":    m.OpenGraph.PublishedTime,
+		"dateModified":     m.OpenGraph.ModifiedTime,
+		"inLanguage":       p.Locale,
+		"wordCount":        p.WordCount,
+		"publisher":        map[string]any{"@type": "Organization", "name": siteName, "url": siteURL},
+	}
+	if image != "" {
+		posting["image"] = []string{image}
+	}
+	if len(authors) > 0 {
+		people := make([]map[string]any, len(authors))
+		for i, name := range authors {
+			people[i] = map[string]any{"@type": "Person", "name": name}
+		}
+		posting["author"] = people
+	}
+	if section != "" {
+		posting["articleSection"] = section
+	}
+	if len(tags) > 0 {
+		posting["keywords"] = strings.Join(tags, ", ")
+	}
+	m.JSONLD = []map[string]any{posting, s.breadcrumbs(ctx, p, siteURL, siteName, m)}
+	return m, nil
+}
+
+// breadcrumbs builds a BreadcrumbList: the site home, each category from the root down to the post's
+// (named in the post's locale when translated), then the post itself.
+func (s *PostService) breadcrumbs(ctx context.Context, p *model.Post, siteURL, siteName string, m *dto.PostMeta) map[string]any {
+	type crumb struct{ name, url string }
+	trail := []crumb{{siteName, siteURL + "/"}}
+	if s.categories != nil && p.CategoryID != 0 {
+		cats, err := s.categories.GetAncestors(ctx, p.CategoryID)
+		if err != nil {
+			s.log.Warn("failed to load category ancestors", zap.Error(err))
+		}
+		if len(cats) > 0 && p.Locale != s.defaultLocale(ctx) {
+			ids := make([]uint, len(cats))
+			for i := range cats {
+				ids[i] = cats[i].ID
+			}
+			if ts, err := s.categories.TranslationsForLocale(ctx, ids, p.Locale); err == nil {
+				byID := make(map[uint]model.CategoryTranslation, len(ts))
+				for _, t := range ts {
+					byID[t.CategoryID] = t
+				}
+				for i := range cats {
+					if t, ok := byID[cats[i].ID]; ok {
+						cats[i].Name, cats[i].Slug = t.Name, t.Slug
+					}
+				}
+			}
+		}
+		for _, c := range cats {
+			trail = append(trail, crumb{c.Name, siteURL + "/categories/" + c.Slug})
+		}
+	}
+	trail = append(trail, crumb{m.Title, m.Canonical})
+
+	items := make([]map[string]any, len(trail))
+	for i, c := range trail {
+		items[i] = map[string]any{"@type": "ListItem", "position": i + 1, "name": c.name, "item": c.url}
+	}
+	return map[string]any{"@context": "https://schema.org", "@type": "BreadcrumbList", "itemListElement": items}
+}
+
+// firstImage returns the src of the first <img> in rendered content, resolved against siteURL when
+// it is a root-relative path; other relative sources are skipped as they cannot be shared.
+func firstImage(contentHTML, siteURL string) string {
+	z := html.NewTokenizer(strings.NewReader(contentHTML))
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return ""
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			if string(name) != "img" {
+				continue
+			}
+			for hasAttr {
+				var key, val []byte
+				key, val, hasAttr = z.TagAttr()
+				if string(key) != "src" {
+					continue
+				}
+				src := string(val)
+				switch {
+				case strings.HasPrefix(src, "https://"), strings.HasPrefix(src, "http://"):
+					return src
+				case strings.HasPrefix(src, "/") && !strings.HasPrefix(src, "//"):
+					return siteURL + src
+				}
+			}
+		}
+	}
+}
+
+// ogLocale converts a BCP 47 tag to the Open Graph form (pt-BR → pt_BR).
+func ogLocale(tag string) string {
+	return strings.ReplaceAll(tag, "-", "_")
+}
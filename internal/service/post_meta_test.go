@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/turahe/go-restfull/internal/handler/request"
+	"github.com/turahe/go-restfull/internal/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostMeta(t *testing.T) {
+	ctx := context.Background()
+	env := newPostTestEnv(t)
+	db, log, catRepo, tagRepo, settingRepo, posts := env.db, env.log, env.categories, env.tags, env.settings, env.posts
+
+	ada := model.User{Name: "Ada", Email: "ada@meta.test", Password: "x"}
+	require.NoError(t, db.Create(&ada).Error)
+	travel, err := catRepo.CreateRoot(ctx, "Travel", ada.ID)
+	require.NoError(t, err)
+	asia, err := catRepo.CreateChild(ctx, travel.ID, "Asia", ada.ID)
+	require.NoError(t, err)
+	tag := &model.Tag{Name: "Beaches", Slug: "beaches"}
+	require.NoError(t, tagRepo.Create(ctx, tag))
+
+	bali, err := posts.Create(ctx, ada.ID, request.CreatePostRequest{
+		Title:      "Bali beaches",
+		Content:    "The sand is white and the water is warm.\n\n![Kuta](/media/kuta.jpg)",
+		CategoryID: asia.ID,
+		TagIDs:     []uint{tag.ID},
+	})
+	require.NoError(t, err)
+
+	m, err := posts.Meta(ctx, bali.Slug, PostAccess{}, LocalePreference{}, "https://api.example")
+	require.NoError(t, err)
+	assert.Equal(t, "Bali beaches", m.Title)
+	assert.Equal(t, "The sand is white and the water is warm.", m.Description, "descriptions fall back to the content")
+	assert.Equal(t, "https://api.example/posts/"+bali.Slug, m.Canonical)
+	assert.Empty(t, m.Robots)
+	assert.Equal(t, []string{"Beaches"}, m.OpenGraph.Tags)
+	assert.Equal(t, []string{"Ada"}, m.OpenGraph.Authors)
+	assert.Equal(t, "Asia", m.OpenGraph.Section)
+	assert.Equal(t, "Go REST Blog", m.OpenGraph.SiteName)
+	assert.Equal(t, "https://api.example/media/kuta.jpg", m.OpenGraph.Image, "the first content image is the share image")
+	assert.Equal(t, "summary_large_image", m.Twitter.Card)
+	require.Len(t, m.JSONLD, 2)
+	assert.Equal(t, "BlogPosting", m.JSONLD[0]["@type"])
+	assert.Equal(t, "Beaches", m.JSONLD[0]["keywords"])
+	crumbs := m.JSONLD[1]["itemListElement"].([]map[string]any)
+	require.Len(t, crumbs, 4)
+	assert.Equal(t, []any{"Go REST Blog", "Travel", "Asia", "Bali beaches"}, []any{crumbs[0]["name"], crumbs[1]["name"], crumbs[2]["name"], crumbs[3]["name"]})
+	assert.Equal(t, "https://api.example/categories/"+travel.Slug, crumbs[1]["item"])
+	assert.Equal(t, 4, crumbs[3]["position"])
+	assert.Equal(t, []string{"x-default"}, []string{m.Alternates[1].Hreflang})
+
+	require.NoError(t, settingRepo.Upsert(ctx, SettingSiteURL, "https://blog.example", true))
+	require.NoError(t, settingRepo.Upsert(ctx, SettingTwitterSite, "@blog", true))
+	require.NoError(t, settingRepo.Upsert(ctx, SettingDefaultShareImage, "https://blog.example/share.png", true))
+	ramen, err := posts.Create(ctx, ada.ID, request.CreatePostRequest{
+		Title:           "Ramen",
+		Content:         "Noodles.",
+		CategoryID:      travel.ID,
+		MetaTitle:       "Ramen at home",
+		MetaDescription: "A weeknight bowl.",
+		CanonicalURL:    "https://elsewhere.example/ramen",
+		RobotsMeta:      "noarchive",
+	})
+	require.NoError(t, err)
+	m, err = posts.Meta(ctx, ramen.Slug, PostAccess{}, LocalePreference{}, "https://api.example")
+	require.NoError(t, err)
+	assert.Equal(t, "Ramen at home", m.Title)
+	assert.Equal(t, "A weeknight bowl.", m.Description)
+	assert.Equal(t, "https://elsewhere.example/ramen", m.Canonical)
+	assert.Equal(t, "noarchive", m.Robots)
+	assert.Equal(t, "https://blog.example/share.png", m.OpenGraph.Image, "without images the defaultShareImage setting is used")
+	assert.Equal(t, "@blog", m.Twitter.Site)
+
+	id, err := posts.Create(ctx, ada.ID, request.CreatePostRequest{Title: "Pantai Bali", Content: "Pasir putih.", CategoryID: asia.ID, Locale: "id", TranslationOf: &bali.ID})
+	require.NoError(t, err)
+	_, err = NewCategoryService(catRepo, log).PutTranslation(ctx, asia.ID, "id", "Asia Tenggara", ada.ID)
+	require.NoError(t, err)
+	m, err = posts.Meta(ctx, id.Slug, PostAccess{}, LocalePreference{Requested: "id"}, "https://api.example")
+	require.NoError(t, err)
+	assert.Equal(t, "id", m.OpenGraph.Locale)
+	assert.Equal(t, []string{"en"}, m.OpenGraph.LocaleAlternates)
+	crumbs = m.JSONLD[1]["itemListElement"].([]map[string]any)
+	assert.Equal(t, "Asia Tenggara", crumbs[2]["name"], "breadcrumbs use category translations")
+
+	draft, err := posts.Create(ctx, ada.ID, request.CreatePostRequest{Title: "Draft", Content: "Soon.", CategoryID: travel.ID, Status: "draft"})
+	require.NoError(t, err)
+	_, err = posts.Meta(ctx, draft.Slug, PostAccess{}, LocalePreference{}, "https://api.example")
+	assert.ErrorIs(t, err, ErrPostNotFound)
+	m, err = posts.Meta(ctx, draft.Slug, PostAccess{ViewerID: ada.ID}, LocalePreference{}, "https://api.example")
+	require.NoError(t, err)
+	assert.Equal(t, "noindex, nofollow", m.Robots, "unpublished posts are never indexed")
+}
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/model"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrPreviewLinksDisabled  = errors.New("preview links are disabled: no signing secret configured")
+	ErrPreviewLinkNotFound   = errors.New("preview link not found")
+	ErrInvalidPreviewToken   = errors.New("invalid or expired preview token")
+	ErrInvalidPreviewLinkTTL = errors.New("preview link lifetime must be between 1 and 720 hours")
+)
+
+const (
+	defaultPreviewLinkTTL = 72 * time.Hour
+	maxPreviewLinkTTL     = 720 * time.Hour
+)
+
+// PostAccess carries what a reader can present to see a post that is not publicly live: their user
+// id (0 when anonymous) and a preview token.
+type PostAccess struct {
+	ViewerID     uint
+	PreviewToken string
+}
+
+// CreatedPreviewLink is a new preview link together with its token, which is not retrievable later.
+type CreatedPreviewLink struct {
+	model.PostPreviewLink
+	Token string `json:"token"`
+}
+
+// CreatePreviewLink mints a preview token for a post. ttl 0 uses the default of 72 hours.
+func (s *PostService) CreatePreviewLink(ctx context.Context, postID, actorUserID uint, ttl time.Duration) (*CreatedPreviewLink, error) {
+	if s.previews == nil || len(s.previewSecret) == 0 {
+		return nil, ErrPreviewLinksDisabled
+	}
+	if ttl == 0 {
+		ttl = defaultPreviewLinkTTL
+	}
+	if ttl < time.Hour || ttl > maxPreviewLinkTTL {
+		return nil, ErrInvalidPreviewLinkTTL
+	}
+	if _, err := s.findForMutation(ctx, postID, actorUserID); err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(ttl).UTC().Truncate(time.Second)
+	token, err := s.signPreviewToken(expiresAt)
+	if err != nil {
+		s.log.Error("failed to sign preview token", zap.Error(err))
+		return nil, err
+	}
+	l := &model.PostPreviewLink{
+		PostID:    postID,
+		TokenHash: previewTokenHash(token),
+		ExpiresAt: expiresAt,
+		CreatedBy: actorUserID,
+	}
+	if err := s.previews.Create(ctx, l); err != nil {
+		return nil, err
+	}
+	return &CreatedPreviewLink{PostPreviewLink: *l, Token: token}, nil
+}
+
+// ListPreviewLinks returns every preview link of a post, newest first.
+func (s *PostService) ListPreviewLinks(ctx context.Context, postID, actorUserID uint) ([]model.PostPreviewLink, error) {
+	if s.previews == nil {
+		return nil, ErrPreviewLinksDisabled
+	}
+	if _, err := s.findForMutation(ctx, postID, actorUserID); err != nil {
+		return nil, err
+	}
+	return s.previews.ListByPost(ctx, postID)
+}
+
+// RevokePreviewLink makes a link unusable immediately.
+func (s *PostService) RevokePreviewLink(ctx context.Context, postID, linkID, actorUserID uint) error {
+	if s.previews == nil {
+		return ErrPreviewLinksDisabled
+	}
+	if _, err := s.findForMutation(ctx, postID, actorUserID); err != nil {
+		return err
+	}
+	ok, err := s.previews.Revoke(ctx, postID, linkID, time.Now())
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrPreviewLinkNotFound
+	}
+	return nil
+}
+
+// GetByPreviewToken returns the post a valid preview token points at, whatever its status.
+func (s *PostService) GetByPreviewToken(ctx context.Context, token string) (*model.Post, error) {
+	l, err := s.previewLink(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	p, err := s.posts.FindByID(ctx, l.PostID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPostNotFound
+		}
+		return nil, err
+	}
+	p, err = s.posts.FindBySlugWithCategory(ctx, p.Slug)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPostNotFound
+		}
+		return nil, err
+	}
+	s.refreshRender(ctx, p)
+	if err := s.attachSeriesNav(ctx, p, time.Now()); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// canViewUnpublished reports whether access lets its holder read p outside its publish window:
+// a preview token for p, or a viewer allowed to edit p.
+func (s *PostService) canViewUnpublished(ctx context.Context, p *model.Post, access PostAccess) bool {
+	if access.PreviewToken != "" {
+		if l, err := s.previewLink(ctx, access.PreviewToken); err == nil && l.PostID == p.ID {
+			return true
+		}
+	}
+	if access.ViewerID != 0 {
+		if _, err := s.authorizePostMutation(ctx, p, access.ViewerID); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *PostService) findForMutation(ctx context.Context, postID, actorUserID uint) (*model.Post, error) {
+	p, err := s.posts.FindByID(ctx, postID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPostNotFound
+		}
+		return nil, err
+	}
+	if _, err := s.authorizePostMutation(ctx, p, actorUserID); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// previewLink checks the token's signature and expiry before looking up its (unrevoked) link, so
+// forged or stale tokens never reach the database.
+func (s *PostService) previewLink(ctx context.Context, token string) (*model.PostPreviewLink, error) {
+	if s.previews == nil || len(s.previewSecret) == 0 {
+		return nil, ErrInvalidPreviewToken
+	}
+	now := time.Now()
+	if !s.verifyPreviewToken(token, now) {
+		return nil, ErrInvalidPreviewToken
+	}
+	l, err := s.previews.FindActiveByHash(ctx, previewTokenHash(token), now)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidPreviewToken
+		}
+		s.log.Error("failed to find preview link", zap.Error(err))
+		return nil, err
+	}
+	return l, nil
+}
+
+// Preview tokens are "<expiry unix>.<nonce>.<signature>", all base64url, signed with HMAC-SHA256.
+func (s *PostService) signPreviewToken(expiresAt time.Time) (string, error) {
+	nonce := make([]byte, 18)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	payload := strconv.FormatInt(expiresAt.Unix(), 10) + "." + base64.RawURLEncoding.EncodeToString(nonce)
+	return payload + "." + s.previewSignature(payload), nil
+}
+
+func (s *PostService) verifyPreviewToken(token string, now time.Time) bool {
+	i := strings.LastIndexByte(token, '.')
+	if i <= 0 {
+		return false
+	}
+	payload, sig := token[:i], token[i+1:]
+	if !hmac.Equal([]byte(sig), []byte(s.previewSignature(payload))) {
+		return false
+	}
+	exp, err := strconv.ParseInt(strings.SplitN(payload, ".", 2)[0], 10, 64)
+	return err == nil && now.Unix() < exp
+}
+
+func (s *PostService) previewSignature(payload string) string {
+	m := hmac.New(sha256.New, s.previewSecret)
+	m.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(m.Sum(nil))
+}
+
+func previewTokenHash(token string) string {
+	h := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/handler/request"
+	"github.com/turahe/go-restfull/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostPreviewLinks(t *testing.T) {
+	ctx := context.Background()
+	env := newPostTestEnv(t)
+	db, log, catRepo := env.db, env.log, env.categories
+	posts := env.posts.WithPreviews(repository.NewPostPreviewLinkRepository(db, log), []byte("secret"))
+
+	const author, other = uint(1), uint(2)
+	cat, err := catRepo.CreateRoot(ctx, "News", author)
+	require.NoError(t, err)
+	draft, err := posts.Create(ctx, author, request.CreatePostRequest{Title: "Draft post", Content: "x", CategoryID: cat.ID, Status: "draft"})
+	require.NoError(t, err)
+
+	_, err = posts.GetBySlug(ctx, draft.Slug, PostAccess{}, LocalePreference{})
+	assert.ErrorIs(t, err, ErrPostNotFound, "drafts are hidden from anonymous readers")
+	_, err = posts.GetBySlug(ctx, draft.Slug, PostAccess{ViewerID: other}, LocalePreference{})
+	assert.ErrorIs(t, err, ErrPostNotFound, "and from users who cannot edit them")
+	_, err = posts.GetBySlug(ctx, draft.Slug, PostAccess{ViewerID: author}, LocalePreference{})
+	assert.NoError(t, err, "the author sees the draft")
+
+	_, err = posts.CreatePreviewLink(ctx, draft.ID, other, 0)
+	assert.ErrorIs(t, err, ErrNotPostOwner)
+	_, err = posts.CreatePreviewLink(ctx, draft.ID, author, time.Minute)
+	assert.ErrorIs(t, err, ErrInvalidPreviewLinkTTL)
+
+	link, err := posts.CreatePreviewLink(ctx, draft.ID, author, 0)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(defaultPreviewLinkTTL), link.ExpiresAt, time.Minute)
+
+	p, err := posts.GetByPreviewToken(ctx, link.Token)
+	require.NoError(t, err)
+	assert.Equal(t, draft.ID, p.ID)
+	_, err = posts.GetBySlug(ctx, draft.Slug, PostAccess{PreviewToken: link.Token}, LocalePreference{})
+	assert.NoError(t, err)
+
+	// Tampered tokens fail the signature check.
+	_, err = posts.GetByPreviewToken(ctx, link.Token+"x")
+	assert.ErrorIs(t, err, ErrInvalidPreviewToken)
+	forged := env.newPostService().WithPreviews(repository.NewPostPreviewLinkRepository(db, log), []byte("other"))
+	_, err = forged.GetByPreviewToken(ctx, link.Token)
+	assert.ErrorIs(t, err, ErrInvalidPreviewToken)
+
+	// Tokens for one post do not open another.
+	second, err := posts.Create(ctx, author, request.CreatePostRequest{Title: "Second draft", Content: "y", CategoryID: cat.ID, Status: "draft"})
+	require.NoError(t, err)
+	_, err = posts.GetBySlug(ctx, second.Slug, PostAccess{PreviewToken: link.Token}, LocalePreference{})
+	assert.ErrorIs(t, err, ErrPostNotFound)
+
+	require.NoError(t, posts.RevokePreviewLink(ctx, draft.ID, link.ID, author))
+	_, err = posts.GetByPreviewToken(ctx, link.Token)
+	assert.ErrorIs(t, err, ErrInvalidPreviewToken)
+	assert.ErrorIs(t, posts.RevokePreviewLink(ctx, draft.ID, link.ID, author), ErrPreviewLinkNotFound)
+
+	links, err := posts.ListPreviewLinks(ctx, draft.ID, author)
+	require.NoError(t, err)
+	require.Len(t, links, 1)
+	assert.NotNil(t, links[0].RevokedAt)
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/internal/repository"
+	"github.com/turahe/go-restfull/internal/service/dto"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// SettingRelatedPostWeights is the settings key holding related-post weights as JSON, e.g.
+// {"tags":3,"category":2,"recency":1,"halfLifeDays":30}. Missing fields keep their defaults.
+const SettingRelatedPostWeights = "relatedPostWeights"
+
+const defaultRelatedLimit = 5
+
+var defaultRelatedWeights = repository.RelatedWeights{Tags: 3, Category: 2, Recency: 1, HalfLifeDays: 30}
+
+// relatedWeights reads the current site's weights, falling back to the defaults when the setting is
+// missing or malformed. Negative weights are ignored.
+func (s *PostService) relatedWeights(ctx context.Context) repository.RelatedWeights {
+	w := defaultRelatedWeights
+	if s.settings == nil {
+		return w
+	}
+	row, err := s.settings.FindByKey(ctx, SettingRelatedPostWeights)
+	if err != nil {
+		return w
+	}
+	parsed := defaultRelatedWeights
+	if err := json.Unmarshal([]byte(strings.TrimSpace(row.Value)), &parsed); err != nil {
+		s.log.Warn("ignoring malformed related post weights", zap.Error(err))
+		return w
+	}
+	if parsed.Tags >= 0 && parsed.Category >= 0 && parsed.Recency >= 0 && parsed.HalfLifeDays >= 0 {
+		w = parsed
+	}
+	return w
+}
+
+// Related returns up to limit live posts related to post id, best first: by shared tags, same or
+// ancestor category, and recency, weighted by the relatedPostWeights setting.
+func (s *PostService) Related(ctx context.Context, id uint, limit int) ([]dto.RelatedPost, error) {
+	if limit <= 0 {
+		limit = defaultRelatedLimit
+	}
+	limit = min(limit, repository.RelatedPostsMax)
+	p, err := s.posts.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPostNotFound
+		}
+		return nil, err
+	}
+	now := time.Now()
+	if !postLive(p, now) {
+		return nil, ErrPostNotFound
+	}
+	scored, err := s.posts.RelatedPostIDs(ctx, p, s.relatedWeights(ctx), now)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uint, len(scored))
+	for i, sp := range scored {
+		ids[i] = sp.ID
+	}
+	posts, err := s.posts.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]*model.Post, len(posts))
+	for i := range posts {
+		byID[posts[i].ID] = &posts[i]
+	}
+	out := make([]dto.RelatedPost, 0, limit)
+	for _, sp := range scored {
+		rp, ok := byID[sp.ID]
+		// Cached ranks can outlive a post's publish window or the post itself.
+		if !ok || !postLive(rp, now) {
+			continue
+		}
+		s.refreshRender(ctx, rp)
+		out = append(out, dto.RelatedPost{Score: sp.Score, Post: rp})
+		if len(out) == limit {
+			break
+		}
+	}
+	return out, nil
+}
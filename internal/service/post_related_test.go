@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/turahe/go-restfull/internal/handler/request"
+	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/internal/service/dto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func relatedIDs(rows []dto.RelatedPost) []uint {
+	ids := make([]uint, len(rows))
+	for i, r := range rows {
+		ids[i] = r.Post.ID
+	}
+	return ids
+}
+
+func TestPostRelated_RankingWeightsAndCache(t *testing.T) {
+	ctx := context.Background()
+	env := newPostTestEnv(t)
+	catRepo, tagRepo, settingRepo, posts := env.categories, env.tags, env.settings, env.posts
+
+	const author = uint(1)
+	root, err := catRepo.CreateRoot(ctx, "Tech", author)
+	require.NoError(t, err)
+	child, err := catRepo.CreateChild(ctx, root.ID, "Go", author)
+	require.NoError(t, err)
+	other, err := catRepo.CreateRoot(ctx, "Travel", author)
+	require.NoError(t, err)
+	goTag := &model.Tag{Name: "Go", Slug: "go"}
+	dbTag := &model.Tag{Name: "Databases", Slug: "databases"}
+	require.NoError(t, tagRepo.Create(ctx, goTag))
+	require.NoError(t, tagRepo.Create(ctx, dbTag))
+
+	create := func(title string, cat uint, status string, tags ...uint) *model.Post {
+		p, err := posts.Create(ctx, author, request.CreatePostRequest{Title: title, Content: "x", CategoryID: cat, Status: status, TagIDs: tags})
+		require.NoError(t, err)
+		return p
+	}
+	src := create("Source post", child.ID, "", goTag.ID, dbTag.ID)
+	sameBoth := create("Same category, both tags", child.ID, "", goTag.ID, dbTag.ID)
+	ancestorOne := create("Ancestor category, one tag", root.ID, "", goTag.ID)
+	unrelated := create("Unrelated", other.ID, "")
+	create("Draft with both tags", child.ID, "draft", goTag.ID, dbTag.ID)
+
+	rows, err := posts.Related(ctx, src.ID, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{sameBoth.ID, ancestorOne.ID, unrelated.ID}, relatedIDs(rows), "drafts and the post itself are left out")
+	assert.Greater(t, rows[0].Score, rows[1].Score)
+
+	rows, err = posts.Related(ctx, src.ID, 1)
+	require.NoError(t, err)
+	assert.Len(t, rows, 1)
+
+	// Replacing tags invalidates cached rankings.
+	_, err = posts.Update(ctx, sameBoth.ID, author, request.UpdatePostRequest{TagIDs: []uint{}})
+	require.NoError(t, err)
+	rows, err = posts.Related(ctx, src.ID, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{ancestorOne.ID, sameBoth.ID, unrelated.ID}, relatedIDs(rows))
+
+	// Weights come from the site setting; recency alone ranks the newest first.
+	require.NoError(t, settingRepo.Upsert(ctx, SettingRelatedPostWeights, `{"tags":0,"category":0,"recency":1}`, false))
+	rows, err = posts.Related(ctx, src.ID, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{unrelated.ID, ancestorOne.ID, sameBoth.ID}, relatedIDs(rows))
+
+	draft := create("Hidden", child.ID, "draft")
+	_, err = posts.Related(ctx, draft.ID, 5)
+	assert.ErrorIs(t, err, ErrPostNotFound)
+}
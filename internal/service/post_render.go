@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+
+	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/pkg/markup"
+
+	"go.uber.org/zap"
+)
+
+const (
+	maxExcerptChars         = 2000
+	maxMetaDescriptionChars = 320
+)
+
+// renderContent renders p.Content in p.ContentFormat into the post's derived fields.
+func renderContent(p *model.Post) markup.Document {
+	if p.ContentFormat == "" {
+		p.ContentFormat = model.ContentFormatMarkdown
+	}
+	doc := renderDocument(p.ContentFormat, p.Content)
+	p.ContentHTML = doc.HTML
+	p.TOC = make([]model.PostHeading, len(doc.TOC))
+	for i, h := range doc.TOC {
+		p.TOC[i] = model.PostHeading{Level: h.Level, ID: h.ID, Text: h.Text}
+	}
+	p.WordCount = doc.Words
+	p.ReadingTimeMinutes = doc.ReadingMinutes
+	p.RenderVersion = markup.Version
+	return doc
+}
+
+func renderDocument(format model.ContentFormat, content string) markup.Document {
+	switch format {
+	case model.ContentFormatHTML:
+		return markup.HTML(content)
+	case model.ContentFormatPlain:
+		return markup.Plain(content)
+	default:
+		return markup.Markdown(content)
+	}
+}
+
+// refreshRender re-renders a post stored before the current rendering rules (including one that
+// predates rendering) and saves the result. A failed save is logged; the caller still gets the
+// fresh render.
+func (s *PostService) refreshRender(ctx context.Context, p *model.Post) {
+	if p.RenderVersion == markup.Version {
+		return
+	}
+	renderContent(p)
+	if err := s.posts.SaveRender(ctx, p); err != nil {
+		s.log.Warn("failed to store refreshed render", zap.Uint("postId", p.ID), zap.Error(err))
+	}
+}
+
+func (s *PostService) refreshRenders(ctx context.Context, posts []model.Post) {
+	for i := range posts {
+		s.refreshRender(ctx, &posts[i])
+	}
+}
+
+func defaultExcerptFromContent(format model.ContentFormat, content string) string {
+	return markup.Excerpt(renderDocument(format, content).Text, maxExcerptChars)
+}
+
+func defaultMetaDescriptionFromContent(format model.ContentFormat, content string) string {
+	return markup.Excerpt(renderDocument(format, content).Text, maxMetaDescriptionChars)
+}
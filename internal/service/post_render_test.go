@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/turahe/go-restfull/internal/handler/request"
+	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/pkg/markup"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostRender_CreateUpdateAndStaleRefresh(t *testing.T) {
+	ctx := context.Background()
+	env := newPostTestEnv(t)
+	db, catRepo, posts := env.db, env.categories, env.posts
+
+	const author = uint(1)
+	cat, err := catRepo.CreateRoot(ctx, "News", author)
+	require.NoError(t, err)
+
+	p, err := posts.Create(ctx, author, request.CreatePostRequest{
+		Title:      "Rendering",
+		Content:    "# Intro\n\nHello **world**.<script>alert(1)</script>\n\n## Usage\n\nRun it.",
+		CategoryID: cat.ID,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, model.ContentFormatMarkdown, p.ContentFormat)
+	assert.Contains(t, p.ContentHTML, `<h1 id="intro">Intro</h1>`)
+	assert.NotContains(t, p.ContentHTML, "<script")
+	require.Len(t, p.TOC, 2)
+	assert.Equal(t, model.PostHeading{Level: 2, ID: "usage", Text: "Usage"}, p.TOC[1])
+	assert.Equal(t, 6, p.WordCount)
+	assert.Equal(t, 1, p.ReadingTimeMinutes)
+	require.NotNil(t, p.PostSEO)
+	assert.NotContains(t, p.PostSEO.Excerpt, "**", "the default excerpt is plain text")
+
+	p, err = posts.Update(ctx, p.ID, author, request.UpdatePostRequest{Title: "Rendering", Content: "# Not a heading", ContentFormat: "plain"})
+	require.NoError(t, err)
+	assert.Equal(t, "<p># Not a heading</p>", p.ContentHTML)
+	assert.Empty(t, p.TOC)
+
+	// Posts rendered by older rules are re-rendered on read and the result is stored.
+	require.NoError(t, db.Model(&model.Post{}).Where("id = ?", p.ID).UpdateColumns(map[string]any{"content_html": "", "render_version": 0}).Error)
+	got, err := posts.GetBySlug(ctx, p.Slug, PostAccess{}, LocalePreference{})
+	require.NoError(t, err)
+	assert.Equal(t, "<p># Not a heading</p>", got.ContentHTML)
+	var stored model.Post
+	require.NoError(t, db.First(&stored, p.ID).Error)
+	assert.Equal(t, markup.Version, stored.RenderVersion)
+	assert.Equal(t, got.ContentHTML, stored.ContentHTML)
+}
@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+
+	"github.com/turahe/go-restfull/internal/handler/request"
+	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/internal/repository"
+	"github.com/turahe/go-restfull/internal/service/dto"
+	"github.com/turahe/go-restfull/pkg/textdiff"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var ErrPostRevisionNotFound = errors.New("post revision not found")
+
+// SettingPostRevisionRetention is the settings key holding how many revisions to keep per post (0 keeps all).
+const SettingPostRevisionRetention = "postRevisionRetention"
+
+const defaultPostRevisionRetention = 50
+
+// snapshotPost copies the editable state of p into a revision. Tags are read back from the join table
+// so the snapshot matches what was stored, whether or not the request touched them.
+func (s *PostService) snapshotPost(ctx context.Context, p *model.Post, actorUserID uint) (*model.PostRevision, error) {
+	tagIDs, err := s.posts.TagIDs(ctx, p.ID)
+	if err != nil {
+		return nil, err
+	}
+	rev := &model.PostRevision{
+		PostID:        p.ID,
+		Title:         p.Title,
+		Content:       p.Content,
+		ContentFormat: p.ContentFormat,
+		CategoryID:    p.CategoryID,
+		TagIDs:        tagIDs,
+		Layout:        p.Layout,
+		Status:        p.Status,
+		ActorID:       actorUserID,
+	}
+	if p.PostSEO != nil {
+		seo := *p.PostSEO
+		seo.PostID = 0
+		rev.SEO = &seo
+	}
+	return rev, nil
+}
+
+// recordRevision stores the current state of p as its newest revision and prunes past the retention setting.
+func (s *PostService) recordRevision(ctx context.Context, p *model.Post, actorUserID uint) error {
+	if s.revisions == nil {
+		return nil
+	}
+	rev, err := s.snapshotPost(ctx, p, actorUserID)
+	if err != nil {
+		s.log.Error("failed to snapshot post", zap.Error(err))
+		return err
+	}
+	if err := s.revisions.Create(ctx, rev); err != nil {
+		s.log.Error("failed to record post revision", zap.Error(err))
+		return err
+	}
+	keep := settingInt(ctx, s.settings, SettingPostRevisionRetention, defaultPostRevisionRetention)
+	return s.revisions.Prune(ctx, p.ID, keep)
+}
+
+// ensureBaselineRevision records the pre-update state of posts created before revisions existed,
+// so their first edit can still be diffed and undone.
+func (s *PostService) ensureBaselineRevision(ctx context.Context, p *model.Post) error {
+	if s.revisions == nil {
+		return nil
+	}
+	n, err := s.revisions.Count(ctx, p.ID)
+	if err != nil || n > 0 {
+		return err
+	}
+	actor := p.UpdatedBy
+	if actor == 0 {
+		actor = p.UserID
+	}
+	return s.recordRevision(ctx, p, actor)
+}
+
+// PostRevisionService lists, compares and restores post revisions. Access follows the post's
+// mutation rules: its owner, or an editor whose category scope covers it.
+type PostRevisionService struct {
+	posts     *PostService
+	revisions *repository.PostRevisionRepository
+	log       *zap.Logger
+}
+
+func NewPostRevisionService(posts *PostService, revisions *repository.PostRevisionRepository, log *zap.Logger) *PostRevisionService {
+	return &PostRevisionService{posts: posts, revisions: revisions, log: log}
+}
+
+func (s *PostRevisionService) authorize(ctx context.Context, postID, actorUserID uint) (*model.Post, error) {
+	return s.posts.findForMutation(ctx, postID, actorUserID)
+}
+
+func (s *PostRevisionService) find(ctx context.Context, postID, id uint) (*model.PostRevision, error) {
+	rev, err := s.revisions.FindByID(ctx, postID, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPostRevisionNotFound
+		}
+		return nil, err
+	}
+	return rev, nil
+}
+
+// List returns the post's revisions, newest first, without content.
+func (s *PostRevisionService) List(ctx context.Context, postID, actorUserID uint) ([]model.PostRevision, error) {
+	if _, err := s.authorize(ctx, postID, actorUserID); err != nil {
+		return nil, err
+	}
+	return s.revisions.List(ctx, postID)
+}
+
+// Diff compares revision id with revision against, or with the revision before it when against is 0.
+// The first revision is compared with an empty post.
+func (s *PostRevisionService) Diff(ctx context.Context, postID, id, against, actorUserID uint) (*dto.PostRevisionDiff, error) {
+	if _, err := s.authorize(ctx, postID, actorUserID); err != nil {
+		return nil, err
+	}
+	to, err := s.find(ctx, postID, id)
+	if err != nil {
+		return nil, err
+	}
+	var from *model.PostRevision
+	if against != 0 {
+		if from, err = s.find(ctx, postID, against); err != nil {
+			return nil, err
+		}
+	} else {
+		from, err = s.revisions.FindPrevious(ctx, postID, to.Number)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			from, err = &model.PostRevision{PostID: postID}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return diffPostRevisions(from, to), nil
+}
+
+func diffPostRevisions(from, to *model.PostRevision) *dto.PostRevisionDiff {
+	d := &dto.PostRevisionDiff{
+		PostID:     to.PostID,
+		FromID:     from.ID,
+		FromNumber: from.Number,
+		ToID:       to.ID,
+		ToNumber:   to.Number,
+		Fields:     []dto.FieldChange{},
+		Content:    textdiff.Lines(from.Content, to.Content),
+	}
+	add := func(field string, a, b any) {
+		if !reflect.DeepEqual(a, b) {
+			d.Fields = append(d.Fields, dto.FieldChange{Field: field, From: a, To: b})
+		}
+	}
+	fromSEO, toSEO := revisionSEO(from), revisionSEO(to)
+	add("title", from.Title, to.Title)
+	add("contentFormat", from.ContentFormat, to.ContentFormat)
+	add("categoryId", from.CategoryID, to.CategoryID)
+	add("tagIds", nonNilUints(from.TagIDs), nonNilUints(to.TagIDs))
+	add("layout", from.Layout, to.Layout)
+	add("status", from.Status, to.Status)
+	add("excerpt", fromSEO.Excerpt, toSEO.Excerpt)
+	add("metaTitle", fromSEO.MetaTitle, toSEO.MetaTitle)
+	add("metaDescription", fromSEO.MetaDescription, toSEO.MetaDescription)
+	add("canonicalUrl", fromSEO.CanonicalURL, toSEO.CanonicalURL)
+	add("ogImageUrl", fromSEO.OgImageURL, toSEO.OgImageURL)
+	add("robotsMeta", fromSEO.RobotsMeta, toSEO.RobotsMeta)
+	return d
+}
+
+func revisionSEO(r *model.PostRevision) model.PostSEO {
+	if r.SEO == nil {
+		return model.PostSEO{}
+	}
+	return *r.SEO
+}
+
+func nonNilUints(in []uint) []uint {
+	if in == nil {
+		return []uint{}
+	}
+	return in
+}
+
+// Restore writes revision id back to the post through PostService.Update, which records it as a new
+// revision. Publication state (status and schedule) is left as is; tags and the category are restored
+// only if they still exist.
+func (s *PostRevisionService) Restore(ctx context.Context, postID, id, actorUserID uint) (*model.Post, error) {
+	if _, err := s.authorize(ctx, postID, actorUserID); err != nil {
+		return nil, err
+	}
+	rev, err := s.find(ctx, postID, id)
+	if err != nil {
+		return nil, err
+	}
+	seo := revisionSEO(rev)
+	req := request.UpdatePostRequest{
+		Title:           rev.Title,
+		Content:         rev.Content,
+		ContentFormat:   string(rev.ContentFormat),
+		Layout:          string(rev.Layout),
+		Excerpt:         &seo.Excerpt,
+		MetaTitle:       &seo.MetaTitle,
+		MetaDescription: &seo.MetaDescription,
+		CanonicalURL:    &seo.CanonicalURL,
+		OgImageURL:      &seo.OgImageURL,
+		RobotsMeta:      &seo.RobotsMeta,
+		TagIDs:          []uint{},
+	}
+	cats, err := s.posts.categories.FindByIDs(ctx, []uint{rev.CategoryID})
+	if err != nil {
+		s.log.Error("failed to find categories by ids", zap.Error(err))
+		return nil, err
+	}
+	if len(cats) == 1 {
+		req.CategoryID = &rev.CategoryID
+	}
+	if len(rev.TagIDs) > 0 && s.posts.tags != nil {
+		tags, err := s.posts.tags.FindByIDs(ctx, UniqueUint(rev.TagIDs))
+		if err != nil {
+			s.log.Error("failed to find tags by ids", zap.Error(err))
+			return nil, err
+		}
+		for _, t := range tags {
+			req.TagIDs = append(req.TagIDs, t.ID)
+		}
+	}
+	return s.posts.Update(ctx, postID, actorUserID, req)
+}
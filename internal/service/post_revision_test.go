@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/turahe/go-restfull/internal/handler/request"
+	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/pkg/textdiff"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostRevisions_RecordDiffRestore(t *testing.T) {
+	ctx := context.Background()
+	env := newPostTestEnv(t)
+	log, catRepo, tagRepo, settingRepo, revRepo, posts := env.log, env.categories, env.tags, env.settings, env.revisions, env.posts
+	revs := NewPostRevisionService(posts, revRepo, log)
+
+	const author, other = uint(1), uint(2)
+	cat, err := catRepo.CreateRoot(ctx, "News", author)
+	require.NoError(t, err)
+	go1 := &model.Tag{Name: "Go", Slug: "go"}
+	require.NoError(t, tagRepo.Create(ctx, go1))
+
+	p, err := posts.Create(ctx, author, request.CreatePostRequest{
+		Title: "First title", Content: "one\ntwo\nthree", CategoryID: cat.ID, TagIDs: []uint{go1.ID},
+	})
+	require.NoError(t, err)
+	title := "Second title"
+	_, err = posts.Update(ctx, p.ID, author, request.UpdatePostRequest{Title: title, Content: "one\n2\nthree", MetaTitle: &title, TagIDs: []uint{}})
+	require.NoError(t, err)
+
+	list, err := revs.List(ctx, p.ID, author)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, uint(2), list[0].Number)
+	assert.Empty(t, list[0].Content, "list omits content")
+	_, err = revs.List(ctx, p.ID, other)
+	assert.ErrorIs(t, err, ErrNotPostOwner)
+
+	d, err := revs.Diff(ctx, p.ID, list[0].ID, 0, author)
+	require.NoError(t, err)
+	assert.Equal(t, list[1].ID, d.FromID)
+	fields := map[string]bool{}
+	for _, f := range d.Fields {
+		fields[f.Field] = true
+	}
+	assert.Equal(t, map[string]bool{"title": true, "tagIds": true, "metaTitle": true}, fields)
+	assert.Equal(t, []textdiff.Line{
+		{Op: textdiff.Equal, Text: "one"},
+		{Op: textdiff.Delete, Text: "two"},
+		{Op: textdiff.Insert, Text: "2"},
+		{Op: textdiff.Equal, Text: "three"},
+	}, d.Content)
+
+	_, err = revs.Diff(ctx, p.ID, 9999, 0, author)
+	assert.ErrorIs(t, err, ErrPostRevisionNotFound)
+
+	restored, err := revs.Restore(ctx, p.ID, list[1].ID, author)
+	require.NoError(t, err)
+	assert.Equal(t, "First title", restored.Title)
+	assert.Equal(t, "one\ntwo\nthree", restored.Content)
+	require.Len(t, restored.Tags, 1)
+	assert.Equal(t, go1.ID, restored.Tags[0].ID)
+
+	// Retention keeps the newest revisions only.
+	require.NoError(t, settingRepo.Upsert(ctx, SettingPostRevisionRetention, "2", false))
+	_, err = posts.Update(ctx, p.ID, author, request.UpdatePostRequest{Content: "four"})
+	require.NoError(t, err)
+	list, err = revs.List(ctx, p.ID, author)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, []uint{4, 3}, []uint{list[0].Number, list[1].Number})
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/internal/tenant"
+
+	"go.uber.org/zap"
+)
+
+var ErrInvalidSchedule = errors.New("invalid publish schedule: scheduled posts need a future publishAt, and unpublishAt must be later than publishAt and now")
+
+// normalizePostSchedule validates p's status against its publish window at now. Publishing with a
+// future PublishAt schedules the post instead; scheduling with a past PublishAt publishes it now.
+func normalizePostSchedule(p *model.Post, now time.Time) error {
+	if p.PublishAt != nil && p.UnpublishAt != nil && !p.UnpublishAt.After(*p.PublishAt) {
+		return ErrInvalidSchedule
+	}
+	switch p.Status {
+	case model.PostStatusScheduled:
+		if p.PublishAt == nil {
+			return ErrInvalidSchedule
+		}
+		if !p.PublishAt.After(now) {
+			p.Status = model.PostStatusPublished
+		}
+	case model.PostStatusPublished:
+		if p.PublishAt != nil && p.PublishAt.After(now) {
+			p.Status = model.PostStatusScheduled
+		}
+	}
+	if (p.Status == model.PostStatusPublished || p.Status == model.PostStatusScheduled) &&
+		p.UnpublishAt != nil && !p.UnpublishAt.After(now) {
+		return ErrInvalidSchedule
+	}
+	return nil
+}
+
+// postLive reports whether p is publicly visible at now (the same rule the list query applies).
+func postLive(p *model.Post, now time.Time) bool {
+	if p.Status != model.PostStatusPublished {
+		return false
+	}
+	if p.PublishAt != nil && p.PublishAt.After(now) {
+		return false
+	}
+	return p.UnpublishAt == nil || p.UnpublishAt.After(now)
+}
+
+// ApplySchedule publishes due scheduled posts and archives posts past their unpublishAt, on every site.
+// It reports ran=false when another replica holds the scheduler lock. Every post it changes is
+// reindexed, since the embedded search engine only lists published ones.
+func (s *PostService) ApplySchedule(ctx context.Context, now time.Time) (published, unpublished int64, ran bool, err error) {
+	publishedIDs, unpublishedIDs, ran, err := s.posts.ApplySchedule(ctx, now)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	ctx = tenant.WithAllSites(ctx)
+	for _, id := range append(publishedIDs, unpublishedIDs...) {
+		s.indexPost(ctx, id)
+	}
+	return int64(len(publishedIDs)), int64(len(unpublishedIDs)), ran, nil
+}
+
+// RunScheduler calls ApplySchedule every interval until ctx is done. Public reads already hide posts
+// outside their window, so the interval only bounds how long stored statuses lag behind.
+func (s *PostService) RunScheduler(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			published, unpublished, ran, err := s.ApplySchedule(ctx, time.Now())
+			if err != nil {
+				s.log.Error("post schedule failed", zap.Error(err))
+			} else if ran && (published > 0 || unpublished > 0) {
+				s.log.Info("post schedule applied", zap.Int64("published", published), zap.Int64("unpublished", unpublished))
+			}
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/handler/request"
+	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/internal/search"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizePostSchedule(t *testing.T) {
+	now := time.Now()
+	past, future, later := now.Add(-time.Hour), now.Add(time.Hour), now.Add(2*time.Hour)
+
+	cases := []struct {
+		name        string
+		status      model.PostStatus
+		publishAt   *time.Time
+		unpublishAt *time.Time
+		want        model.PostStatus
+		wantErr     bool
+	}{
+		{"publish with future date schedules", model.PostStatusPublished, &future, nil, model.PostStatusScheduled, false},
+		{"schedule with past date publishes", model.PostStatusScheduled, &past, nil, model.PostStatusPublished, false},
+		{"scheduled needs publishAt", model.PostStatusScheduled, nil, nil, "", true},
+		{"unpublish before publish", model.PostStatusScheduled, &later, &future, "", true},
+		{"unpublish in the past", model.PostStatusPublished, nil, &past, "", true},
+		{"draft keeps its dates", model.PostStatusDraft, &future, &later, model.PostStatusDraft, false},
+		{"window", model.PostStatusPublished, &future, &later, model.PostStatusScheduled, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &model.Post{Status: tc.status, PublishAt: tc.publishAt, UnpublishAt: tc.unpublishAt}
+			err := normalizePostSchedule(p, now)
+			if tc.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidSchedule)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, p.Status)
+		})
+	}
+
+	assert.True(t, postLive(&model.Post{Status: model.PostStatusPublished, PublishAt: &past, UnpublishAt: &future}, now))
+	assert.False(t, postLive(&model.Post{Status: model.PostStatusPublished, PublishAt: &future}, now))
+	assert.False(t, postLive(&model.Post{Status: model.PostStatusDraft}, now))
+}
+
+func TestPostApplySchedule_Reindexes(t *testing.T) {
+	ctx := context.Background()
+	env := newPostTestEnv(t)
+	posts := env.posts.WithSearch(search.NewMemory())
+
+	const author = uint(1)
+	cat, err := env.categories.CreateRoot(ctx, "News", author)
+	require.NoError(t, err)
+	unpublishAt := time.Now().Add(time.Hour)
+	p, err := posts.Create(ctx, author, request.CreatePostRequest{Title: "Flash sale", Content: "Bargains", CategoryID: cat.ID, UnpublishAt: &unpublishAt})
+	require.NoError(t, err)
+	res, err := posts.Search(ctx, request.PostSearchRequest{Q: "bargains"})
+	require.NoError(t, err)
+	require.Equal(t, 1, res.Total)
+
+	// Archive it now rather than waiting for its unpublishAt; the index must follow the stored status.
+	published, unpublished, ran, err := posts.ApplySchedule(ctx, unpublishAt)
+	require.NoError(t, err)
+	assert.True(t, ran)
+	assert.Zero(t, published)
+	assert.Equal(t, int64(1), unpublished)
+	got, err := env.postRepo.FindByID(ctx, p.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.PostStatusArchived, got.Status)
+	res, err = posts.Search(ctx, request.PostSearchRequest{Q: "bargains"})
+	require.NoError(t, err)
+	assert.Zero(t, res.Total, "archived posts drop out of search")
+}
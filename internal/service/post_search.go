@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/handler/request"
+	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/internal/search"
+	"github.com/turahe/go-restfull/internal/service/dto"
+	"github.com/turahe/go-restfull/internal/tenant"
+
+	"go.uber.org/zap"
+)
+
+var (
+	ErrSearchUnavailable = errors.New("search index is not configured")
+	ErrInvalidSearch     = errors.New("search query needs at least one letter or digit")
+)
+
+const (
+	maxSearchLimit = 50
+	// listSearchCap bounds how many index matches narrow a List call that passes ?search=.
+	listSearchCap    = 1000
+	reindexBatchSize = 500
+)
+
+// Search runs a ranked full-text query over the current site's live posts.
+func (s *PostService) Search(ctx context.Context, req request.PostSearchRequest) (*dto.PostSearchResult, error) {
+	if s.search == nil {
+		return nil, ErrSearchUnavailable
+	}
+	page, limit := req.Page, req.Limit
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+	limit = min(limit, maxSearchLimit)
+
+	q := search.Query{
+		SiteID: tenant.SiteID(ctx),
+		Text:   req.Q,
+		TagIDs: UniqueUint(req.TagIDs),
+		LiveAt: time.Now(),
+		Limit:  limit,
+		Offset: (page - 1) * limit,
+		From:   req.From,
+	}
+	if req.CategoryID != nil {
+		q.CategoryID = *req.CategoryID
+	}
+	if req.AuthorID != nil {
+		q.AuthorID = *req.AuthorID
+	}
+	if req.To != nil {
+		endOfDay := req.To.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		q.To = &endOfDay
+	}
+	res, err := s.search.Search(ctx, q)
+	if err != nil {
+		if errors.Is(err, search.ErrEmptyQuery) {
+			return nil, ErrInvalidSearch
+		}
+		s.log.Error("failed to search posts", zap.Error(err))
+		return nil, err
+	}
+
+	ids := make([]uint, len(res.Hits))
+	for i, h := range res.Hits {
+		ids[i] = h.ID
+	}
+	posts, err := s.posts.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	s.refreshRenders(ctx, posts)
+	byID := make(map[uint]*model.Post, len(posts))
+	for i := range posts {
+		byID[posts[i].ID] = &posts[i]
+	}
+	out := &dto.PostSearchResult{Total: res.Total, Page: page, Limit: limit, Hits: make([]dto.PostSearchHit, 0, len(res.Hits))}
+	for _, h := range res.Hits {
+		p, ok := byID[h.ID]
+		if !ok {
+			// Deleted since it was indexed.
+			continue
+		}
+		out.Hits = append(out.Hits, dto.PostSearchHit{Score: h.Score, Highlights: dto.SearchHighlights{Title: h.Title, Content: h.Snippet}, Post: p})
+	}
+	return out, nil
+}
+
+// listSearchIDs narrows List's ?search= through the index, or returns nil to keep the LIKE filter.
+func (s *PostService) listSearchIDs(ctx context.Context, text string, now time.Time) ([]uint, error) {
+	if s.search == nil || len(search.Terms(text)) == 0 {
+		return nil, nil
+	}
+	res, err := s.search.Search(ctx, search.Query{SiteID: tenant.SiteID(ctx), Text: text, LiveAt: now, Limit: listSearchCap})
+	if err != nil {
+		s.log.Error("failed to search posts", zap.Error(err))
+		return nil, err
+	}
+	ids := make([]uint, len(res.Hits))
+	for i, h := range res.Hits {
+		ids[i] = h.ID
+	}
+	return ids, nil
+}
+
+// indexPost refreshes the post's search document. Failures are logged, not returned: the write
+// already succeeded and `search reindex` repairs the index.
+func (s *PostService) indexPost(ctx context.Context, postID uint) {
+	if s.search == nil || s.search.Engine() == search.EngineMySQL {
+		return
+	}
+	p, err := s.posts.FindByID(ctx, postID)
+	if err == nil {
+		var tagIDs []uint
+		if tagIDs, err = s.posts.TagIDs(ctx, postID); err == nil {
+			err = s.search.Index(ctx, search.DocumentFromPost(p, tagIDs))
+		}
+	}
+	if err != nil {
+		s.log.Warn("failed to index post", zap.Uint("postId", postID), zap.Error(err))
+	}
+}
+
+func (s *PostService) unindexPost(ctx context.Context, postID uint) {
+	if s.search == nil {
+		return
+	}
+	if err := s.search.Delete(ctx, postID); err != nil {
+		s.log.Warn("failed to remove post from search index", zap.Uint("postId", postID), zap.Error(err))
+	}
+}
+
+// Reindex resets the search index and indexes every post of every site. It returns how many posts
+// were indexed.
+func (s *PostService) Reindex(ctx context.Context) (int, error) {
+	if s.search == nil {
+		return 0, ErrSearchUnavailable
+	}
+	if err := s.search.Reset(ctx); err != nil {
+		return 0, err
+	}
+	if s.search.Engine() == search.EngineMySQL {
+		// InnoDB maintains the FULLTEXT index itself.
+		return 0, nil
+	}
+	ctx = tenant.WithAllSites(ctx)
+	var after uint
+	n := 0
+	for {
+		batch, err := s.posts.ListBatch(ctx, after, reindexBatchSize)
+		if err != nil {
+			return n, err
+		}
+		if len(batch) == 0 {
+			return n, nil
+		}
+		docs := make([]search.Document, len(batch))
+		for i := range batch {
+			tagIDs := make([]uint, len(batch[i].Tags))
+			for j, t := range batch[i].Tags {
+				tagIDs[j] = t.ID
+			}
+			docs[i] = search.DocumentFromPost(&batch[i], tagIDs)
+		}
+		if err := s.search.Index(ctx, docs...); err != nil {
+			return n, err
+		}
+		n += len(batch)
+		after = batch[len(batch)-1].ID
+	}
+}
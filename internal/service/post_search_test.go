@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/turahe/go-restfull/internal/handler/request"
+	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/internal/search"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostSearch_IndexHooksAndReindex(t *testing.T) {
+	ctx := context.Background()
+	env := newPostTestEnv(t)
+	catRepo, tagRepo := env.categories, env.tags
+	idx := search.NewMemory()
+	posts := env.posts.WithSearch(idx)
+
+	const author = uint(1)
+	cat, err := catRepo.CreateRoot(ctx, "News", author)
+	require.NoError(t, err)
+	tag := &model.Tag{Name: "Go", Slug: "go"}
+	require.NoError(t, tagRepo.Create(ctx, tag))
+
+	p, err := posts.Create(ctx, author, request.CreatePostRequest{Title: "Gophers at work", Content: "Concurrency with channels.", CategoryID: cat.ID, TagIDs: []uint{tag.ID}})
+	require.NoError(t, err)
+	_, err = posts.Create(ctx, author, request.CreatePostRequest{Title: "Secret plans", Content: "channels", CategoryID: cat.ID, Status: "draft"})
+	require.NoError(t, err)
+
+	res, err := posts.Search(ctx, request.PostSearchRequest{Q: "channels"})
+	require.NoError(t, err)
+	require.Equal(t, 1, res.Total, "drafts are not searchable")
+	assert.Equal(t, p.ID, res.Hits[0].Post.ID)
+	assert.Contains(t, res.Hits[0].Highlights.Content, "<mark>channels</mark>")
+
+	res, err = posts.Search(ctx, request.PostSearchRequest{Q: "channels", TagIDs: []uint{tag.ID + 1}})
+	require.NoError(t, err)
+	assert.Zero(t, res.Total)
+
+	_, err = posts.Update(ctx, p.ID, author, request.UpdatePostRequest{Title: "Gophers at rest", Content: "Goroutines sleeping."})
+	require.NoError(t, err)
+	res, err = posts.Search(ctx, request.PostSearchRequest{Q: "work"})
+	require.NoError(t, err)
+	assert.Zero(t, res.Total, "updates replace the indexed text")
+
+	page, err := posts.List(ctx, request.PostListRequest{SearchRequest: request.SearchRequest{Search: "goroutines"}})
+	require.NoError(t, err)
+	items, ok := page.Items.([]model.Post)
+	require.True(t, ok)
+	require.Len(t, items, 1, "List narrows ?search= through the index")
+
+	require.NoError(t, idx.Reset(ctx))
+	n, err := posts.Reindex(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	res, err = posts.Search(ctx, request.PostSearchRequest{Q: "goroutines"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, res.Total)
+
+	require.NoError(t, posts.Delete(ctx, p.ID, author))
+	res, err = posts.Search(ctx, request.PostSearchRequest{Q: "goroutines"})
+	require.NoError(t, err)
+	assert.Zero(t, res.Total)
+
+	_, err = posts.Search(ctx, request.PostSearchRequest{Q: "?!"})
+	assert.ErrorIs(t, err, ErrInvalidSearch)
+}
@@ -6,10 +6,13 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/turahe/go-restfull/internal/handler/request"
 	"github.com/turahe/go-restfull/internal/model"
 	"github.com/turahe/go-restfull/internal/repository"
+	"github.com/turahe/go-restfull/internal/search"
+	"github.com/turahe/go-restfull/pkg/locale"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -27,23 +30,87 @@ type PostService struct {
 	posts      *repository.PostRepository
 	categories *repository.CategoryRepository
 	tags       *repository.TagRepository
-	log        *zap.Logger
+	revisions  *repository.PostRevisionRepository
+	settings   *repository.SettingRepository
+	previews   *repository.PostPreviewLinkRepository
+	// previewSecret signs preview tokens; preview links are disabled without it.
+	previewSecret []byte
+	// search ranks full-text queries; Search is unavailable and List falls back to LIKE without it.
+	search search.Index
+	// workflow and permissions guard status changes once WithWorkflow is called.
+	workflow    *repository.PostWorkflowRepository
+	permissions PermissionChecker
+	log         *zap.Logger
 }
 
-func NewPostService(posts *repository.PostRepository, categories *repository.CategoryRepository, tags *repository.TagRepository, log *zap.Logger) *PostService {
-	return &PostService{posts: posts, categories: categories, tags: tags, log: log}
+// NewPostService wires the post service. revisions and settings may be nil: without revisions no
+// history is recorded, and without settings the defaults apply. Optional features are enabled with
+// WithPreviews, WithSearch and WithWorkflow.
+func NewPostService(posts *repository.PostRepository, categories *repository.CategoryRepository, tags *repository.TagRepository, revisions *repository.PostRevisionRepository, settings *repository.SettingRepository, log *zap.Logger) *PostService {
+	return &PostService{
+		posts:      posts,
+		categories: categories,
+		tags:       tags,
+		revisions:  revisions,
+		settings:   settings,
+		log:        log,
+	}
+}
+
+// WithPreviews enables preview links, signed with secret; without both they are disabled.
+func (s *PostService) WithPreviews(previews *repository.PostPreviewLinkRepository, secret []byte) *PostService {
+	s.previews = previews
+	s.previewSecret = secret
+	return s
+}
+
+// WithSearch ranks full-text queries with index and keeps it up to date on writes.
+func (s *PostService) WithSearch(index search.Index) *PostService {
+	s.search = index
+	return s
 }
 
+// List returns publicly visible posts only (published and inside their publish window), in the locale
+// negotiated from req.Locale and req.AcceptLanguage; untranslated posts are listed in the default locale.
+// With req.ViewerID each post also lists the viewer's own reactions.
 func (s *PostService) List(ctx context.Context, req request.PostListRequest) (repository.CursorPage, error) {
+	now := time.Now()
+	req.LiveAt = &now
+	req.DefaultLocale = s.defaultLocale(ctx)
+	var available []string
+	if req.Locale == "" && req.AcceptLanguage != "" {
+		locales, err := s.posts.Locales(ctx)
+		if err != nil {
+			return repository.CursorPage{}, err
+		}
+		available = locales
+	}
+	req.Locale = locale.Negotiate(req.Locale, req.AcceptLanguage, available, req.DefaultLocale)
+	if strings.TrimSpace(req.Search) != "" {
+		ids, err := s.listSearchIDs(ctx, req.Search, now)
+		if err != nil {
+			return repository.CursorPage{}, err
+		}
+		req.MatchIDs = ids
+	}
 	page, err := s.posts.ListCursor(ctx, req)
 	if err != nil {
 		s.log.Error("failed to list posts", zap.Error(err))
 		return repository.CursorPage{}, err
 	}
+	if rows, ok := page.Items.([]model.Post); ok {
+		s.refreshRenders(ctx, rows)
+		s.localize(ctx, rows, req.Locale, req.DefaultLocale, now)
+		s.attachViewerReactions(ctx, rows, req.ViewerID)
+	}
 	return page, nil
 }
 
-func (s *PostService) GetBySlug(ctx context.Context, slug string) (*model.Post, error) {
+// GetBySlug returns a live post. Drafts, archived and not-yet-live posts are returned only to readers
+// whose access holds a preview token for the post or who may edit it. When the post has translations,
+// the live variant in the locale negotiated from pref is returned instead (else the default-locale one).
+// A slug the post used before yields a *SlugMovedError naming the current one.
+func (s *PostService) GetBySlug(ctx context.Context, slug string, access PostAccess, pref LocalePreference) (*model.Post, error) {
 	slug = strings.TrimSpace(slug)
 	if slug == "" {
 		s.log.Error("invalid slug")
@@ -52,15 +119,36 @@ func (s *PostService) GetBySlug(ctx context.Context, slug string) (*model.Post,
 	p, err := s.posts.FindBySlugWithCategory(ctx, slug)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, ErrPostNotFound
+			return nil, s.movedPost(ctx, slug, access)
 		}
 		return nil, err
 	}
+	now := time.Now()
+	if !postLive(p, now) && !s.canViewUnpublished(ctx, p, access) {
+		return nil, ErrPostNotFound
+	}
+	if p, err = s.resolveVariant(ctx, p, pref, now); err != nil {
+		return nil, err
+	}
+	if p.Locale != s.defaultLocale(ctx) {
+		if err := s.localizeTaxonomy(ctx, []*model.Post{p}, p.Locale); err != nil {
+			s.log.Warn("failed to localize post taxonomy", zap.Error(err))
+		}
+	}
+	s.refreshRender(ctx, p)
+	if err := s.attachSeriesNav(ctx, p, now); err != nil {
+		return nil, err
+	}
 	return p, nil
 }
 
 func (s *PostService) Create(ctx context.Context, userID uint, req request.CreatePostRequest) (*model.Post, error) {
 	base := slugify(req.Title)
+	if req.Slug != "" {
+		if base = slugify(req.Slug); base == "" {
+			return nil, ErrInvalidSlug
+		}
+	}
 	if base == "" {
 		base = "post"
 	}
@@ -80,15 +168,46 @@ func (s *PostService) Create(ctx context.Context, userID uint, req request.Creat
 		s.log.Error("category not found")
 		return nil, errors.New("category not found")
 	}
+	scope, err := loadCategoryScope(ctx, s.categories, userID)
+	if err != nil {
+		s.log.Error("failed to load category scope", zap.Error(err))
+		return nil, err
+	}
+	if scope.restricted && !scope.covers(&cats[0]) {
+		s.log.Error("post category outside granted scope")
+		return nil, ErrCategoryOutOfScope
+	}
+	contributors, authorID, err := s.contributorsFromRequest(ctx, req.Contributors, userID)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := s.postLocale(ctx, req.Locale)
+	if err != nil {
+		return nil, err
+	}
+	var groupID *uint
+	if req.TranslationOf != nil {
+		g, err := s.joinTranslationGroup(ctx, *req.TranslationOf, userID, loc)
+		if err != nil {
+			return nil, err
+		}
+		groupID = &g
+	}
 
 	p := &model.Post{
-		Title:      req.Title,
-		Slug:       slug,
-		Content:    req.Content,
-		UserID:     userID,
-		CategoryID: req.CategoryID,
-		CreatedBy:  userID,
-		UpdatedBy:  userID,
+		Title:              req.Title,
+		Slug:               slug,
+		Content:            req.Content,
+		ContentFormat:      model.ContentFormat(req.ContentFormat),
+		Locale:             loc,
+		TranslationGroupID: groupID,
+		UserID:             authorID,
+		CategoryID:         req.CategoryID,
+		CreatedBy:          userID,
+		UpdatedBy:          userID,
+		Status:             model.PostStatusPublished,
+		PublishAt:          req.PublishAt,
+		UnpublishAt:        req.UnpublishAt,
 	}
 	if req.Layout != "" {
 		p.Layout = model.PostLayout(req.Layout)
@@ -96,10 +215,26 @@ func (s *PostService) Create(ctx context.Context, userID uint, req request.Creat
 	if req.Status != "" {
 		p.Status = model.PostStatus(req.Status)
 	}
+	var transition *model.PostTransition
+	if s.workflow != nil {
+		if transition, err = s.createStatus(ctx, p, userID, req.Status); err != nil {
+			return nil, err
+		}
+	}
+	if err := normalizePostSchedule(p, time.Now()); err != nil {
+		return nil, err
+	}
+	renderContent(p)
 	if err := s.posts.Create(ctx, p); err != nil {
 		s.log.Error("failed to create post", zap.Error(err))
 		return nil, err
 	}
+	if err := s.recordTransition(ctx, transition, p); err != nil {
+		return nil, err
+	}
+	if err := s.saveContributors(ctx, p, contributors); err != nil {
+		return nil, err
+	}
 
 	if seo := postSEOFromCreateRequest(req); seo != nil {
 		seo.PostID = p.ID
@@ -127,6 +262,10 @@ func (s *PostService) Create(ctx context.Context, userID uint, req request.Creat
 		p.Tags = tags
 	}
 
+	if err := s.recordRevision(ctx, p, userID); err != nil {
+		return nil, err
+	}
+	s.indexPost(ctx, p.ID)
 	return p, nil
 }
 
@@ -138,17 +277,36 @@ func (s *PostService) Update(ctx context.Context, id uint, actorUserID uint, req
 		}
 		return nil, err
 	}
-	if p.UserID != actorUserID {
-		s.log.Error("not the post owner")
-		return nil, ErrNotPostOwner
+	scope, err := s.authorizePostMutation(ctx, p, actorUserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureBaselineRevision(ctx, p); err != nil {
+		return nil, err
 	}
 
 	if req.Title != "" {
 		p.Title = strings.TrimSpace(req.Title)
 	}
+	oldSlug := p.Slug
+	if req.Slug != "" {
+		slug := slugify(req.Slug)
+		if slug == "" {
+			return nil, ErrInvalidSlug
+		}
+		if slug != p.Slug {
+			if p.Slug, err = s.uniqueSlug(ctx, slug); err != nil {
+				return nil, err
+			}
+		}
+	}
 	if req.Content != "" {
 		p.Content = req.Content
 	}
+	if req.ContentFormat != "" {
+		p.ContentFormat = model.ContentFormat(req.ContentFormat)
+	}
+	renderContent(p)
 	if req.CategoryID != nil {
 		if *req.CategoryID == 0 {
 			s.log.Error("invalid payload")
@@ -163,13 +321,51 @@ func (s *PostService) Update(ctx context.Context, id uint, actorUserID uint, req
 			s.log.Error("category not found")
 			return nil, errors.New("category not found")
 		}
+		if scope.restricted && !scope.covers(&cats[0]) {
+			s.log.Error("post category outside granted scope")
+			return nil, ErrCategoryOutOfScope
+		}
 		p.CategoryID = *req.CategoryID
 	}
 	if req.Layout != "" {
 		p.Layout = model.PostLayout(req.Layout)
 	}
-	if req.Status != "" {
-		p.Status = model.PostStatus(req.Status)
+	// Contributors nil means "no change"; present (even empty) replaces the list.
+	var contributors []model.PostContributor
+	if req.Contributors != nil {
+		if len(req.Contributors) == 0 {
+			return nil, ErrPostNeedsAuthor
+		}
+		rows, authorID, err := s.contributorsFromRequest(ctx, req.Contributors, 0)
+		if err != nil {
+			return nil, err
+		}
+		contributors = rows
+		p.UserID = authorID
+	}
+	var transition *model.PostTransition
+	if req.Status != "" || req.PublishAt != nil || req.UnpublishAt != nil || req.ClearSchedule {
+		if req.ClearSchedule {
+			p.PublishAt, p.UnpublishAt = nil, nil
+		}
+		if req.PublishAt != nil {
+			p.PublishAt = req.PublishAt
+		}
+		if req.UnpublishAt != nil {
+			p.UnpublishAt = req.UnpublishAt
+		}
+		if req.Status != "" {
+			to := model.PostStatus(req.Status)
+			if s.workflow != nil {
+				if transition, err = s.statusChange(ctx, s.siteWorkflow(ctx), p, actorUserID, to); err != nil {
+					return nil, err
+				}
+			}
+			p.Status = to
+		}
+		if err := normalizePostSchedule(p, time.Now()); err != nil {
+			return nil, err
+		}
 	}
 	seoTouched := false
 	if req.Excerpt != nil {
@@ -179,7 +375,7 @@ func (s *PostService) Update(ctx context.Context, id uint, actorUserID uint, req
 	} else if p.PostSEO == nil || strings.TrimSpace(p.PostSEO.Excerpt) == "" {
 		seoTouched = true
 		ensurePostSEO(p)
-		p.PostSEO.Excerpt = defaultExcerptFromContent(p.Content)
+		p.PostSEO.Excerpt = defaultExcerptFromContent(p.ContentFormat, p.Content)
 	}
 	if req.MetaTitle != nil {
 		seoTouched = true
@@ -216,6 +412,12 @@ func (s *PostService) Update(ctx context.Context, id uint, actorUserID uint, req
 		s.log.Error("failed to update post", zap.Error(err))
 		return nil, err
 	}
+	if err := s.recordTransition(ctx, transition, p); err != nil {
+		return nil, err
+	}
+	if err := s.posts.RecordSlugChange(ctx, p.ID, oldSlug, p.Slug); err != nil {
+		return nil, err
+	}
 
 	if seoTouched {
 		if postSEOEmpty(p.PostSEO) {
@@ -251,6 +453,18 @@ func (s *PostService) Update(ctx context.Context, id uint, actorUserID uint, req
 		}
 		p.Tags = tags
 	}
+	if contributors != nil {
+		err = s.saveContributors(ctx, p, contributors)
+	} else {
+		err = s.loadContributors(ctx, p)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := s.recordRevision(ctx, p, actorUserID); err != nil {
+		return nil, err
+	}
+	s.indexPost(ctx, p.ID)
 	return p, nil
 }
 
@@ -264,18 +478,50 @@ func (s *PostService) Delete(ctx context.Context, id uint, actorUserID uint) err
 		s.log.Error("failed to find post by id", zap.Error(err))
 		return err
 	}
-	// Safer default: only author can delete.
-	if p.UserID != actorUserID {
-		s.log.Error("not the post owner")
-		return ErrNotPostOwner
+	// Safer default: only author (or an editor scoped to the post's category) can delete.
+	if _, err := s.authorizePostMutation(ctx, p, actorUserID); err != nil {
+		return err
 	}
 	if err := s.posts.SoftDeleteByID(ctx, id, actorUserID); err != nil {
 		s.log.Error("failed to soft delete post by id", zap.Error(err))
 		return err
 	}
+	s.unindexPost(ctx, id)
 	return nil
 }
 
+// authorizePostMutation allows the post's owners (any listed author), or any user whose category-scoped grants cover the post's category.
+// Users holding scoped grants are confined to their subtrees, including for their own posts.
+func (s *PostService) authorizePostMutation(ctx context.Context, p *model.Post, actorUserID uint) (categoryScope, error) {
+	scope, err := loadCategoryScope(ctx, s.categories, actorUserID)
+	if err != nil {
+		s.log.Error("failed to load category scope", zap.Error(err))
+		return categoryScope{}, err
+	}
+	if !scope.restricted {
+		owner, err := s.isPostOwner(ctx, p, actorUserID)
+		if err != nil {
+			s.log.Error("failed to check post ownership", zap.Error(err))
+			return scope, err
+		}
+		if !owner {
+			s.log.Error("not the post owner")
+			return scope, ErrNotPostOwner
+		}
+		return scope, nil
+	}
+	ok, err := scope.coversID(ctx, s.categories, p.CategoryID)
+	if err != nil {
+		s.log.Error("failed to check post category scope", zap.Error(err))
+		return scope, err
+	}
+	if !ok {
+		s.log.Error("post category outside granted scope")
+		return scope, ErrCategoryOutOfScope
+	}
+	return scope, nil
+}
+
 func (s *PostService) uniqueSlug(ctx context.Context, base string) (string, error) {
 	slug := base
 	for i := 1; i <= 50; i++ {
@@ -315,10 +561,10 @@ func postSEOFromCreateRequest(req request.CreatePostRequest) *model.PostSEO {
 	req.RobotsMeta = strings.TrimSpace(req.RobotsMeta)
 
 	if req.Excerpt == "" {
-		req.Excerpt = defaultExcerptFromContent(req.Content)
+		req.Excerpt = defaultExcerptFromContent(model.ContentFormat(req.ContentFormat), req.Content)
 	}
 	if req.MetaDescription == "" {
-		req.MetaDescription = defaultMetaDescriptionFromContent(req.Content)
+		req.MetaDescription = defaultMetaDescriptionFromContent(model.ContentFormat(req.ContentFormat), req.Content)
 	}
 
 	seo := &model.PostSEO{
@@ -353,21 +599,3 @@ func postSEOEmpty(seo *model.PostSEO) bool {
 		strings.TrimSpace(seo.OgImageURL) == "" &&
 		strings.TrimSpace(seo.RobotsMeta) == ""
 }
-
-func defaultExcerptFromContent(content string) string {
-	const maxExcerptChars = 2000
-	clean := strings.TrimSpace(content)
-	if len(clean) <= maxExcerptChars {
-		return clean
-	}
-	return clean[:maxExcerptChars]
-}
-
-func defaultMetaDescriptionFromContent(content string) string {
-	const maxMetaDescriptionChars = 320
-	clean := strings.TrimSpace(content)
-	if len(clean) <= maxMetaDescriptionChars {
-		return clean
-	}
-	return clean[:maxMetaDescriptionChars]
-}
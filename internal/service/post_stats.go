@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/internal/service/dto"
+	"github.com/turahe/go-restfull/internal/tenant"
+
+	"go.uber.org/zap"
+)
+
+var ErrInvalidWindow = errors.New("window must be between 1d and 365d")
+
+const (
+	defaultPopularWindowDays = 7
+	maxStatsDays             = 365
+	defaultStatsDays         = 30
+	defaultPopularLimit      = 10
+	maxPopularLimit          = 50
+	statsDayLayout           = "2006-01-02"
+)
+
+// Visitor identifies who viewed a post: a signed-in user, else the client IP and user agent.
+type Visitor struct {
+	UserID    uint
+	IP        string
+	UserAgent string
+}
+
+// key returns the identity views are deduplicated by; anonymous visitors are hashed, not stored.
+func (v Visitor) key() string {
+	if v.UserID != 0 {
+		return "u" + strconv.FormatUint(uint64(v.UserID), 10)
+	}
+	sum := sha256.Sum256([]byte(v.IP + "|" + v.UserAgent))
+	return "a" + hex.EncodeToString(sum[:12])
+}
+
+// PostStatsService counts post views and reports them. Views go to a ViewBuffer first, deduplicated
+// per visitor within window; RunFlusher adds the buffered counts to post_stats_daily. Without a buffer
+// views are not tracked, but stored stats are still reported.
+type PostStatsService struct {
+	posts  *PostService
+	buffer ViewBuffer
+	window time.Duration
+	now    func() time.Time
+	log    *zap.Logger
+}
+
+func NewPostStatsService(posts *PostService, buffer ViewBuffer, window time.Duration, log *zap.Logger) *PostStatsService {
+	return &PostStatsService{posts: posts, buffer: buffer, window: window, now: time.Now, log: log}
+}
+
+// RecordView counts a view of p by v unless p is not live, v looks like a crawler, or v already viewed
+// p within the window. Buffer failures are logged and the view is dropped, so reads never fail on it.
+func (s *PostStatsService) RecordView(ctx context.Context, p *model.Post, v Visitor) {
+	now := s.now()
+	if s.buffer == nil || !postLive(p, now) || isCrawler(v.UserAgent) {
+		return
+	}
+	siteID := tenant.SiteID(ctx)
+	first, err := s.buffer.FirstView(ctx, siteID, p.ID, v.key(), s.window)
+	if err == nil && first {
+		err = s.buffer.Add(ctx, ViewKey{SiteID: siteID, PostID: p.ID, Day: now.UTC().Format(statsDayLayout)}, 1)
+	}
+	if err != nil {
+		s.log.Warn("post view not recorded", zap.Uint("post_id", p.ID), zap.Error(err))
+	}
+}
+
+// Flush writes the buffered counts of every site to post_stats_daily and returns how many post-days
+// it wrote. Counts that fail to write go back into the buffer for the next flush.
+func (s *PostStatsService) Flush(ctx context.Context) (int, error) {
+	if s.buffer == nil {
+		return 0, nil
+	}
+	counts, err := s.buffer.Drain(ctx)
+	if err != nil || len(counts) == 0 {
+		return 0, err
+	}
+	rows := make([]model.PostStatDaily, 0, len(counts))
+	for k, n := range counts {
+		rows = append(rows, model.PostStatDaily{SiteID: k.SiteID, PostID: k.PostID, Day: k.Day, Views: n})
+	}
+	if err := s.posts.posts.AddDailyViews(tenant.WithAllSites(ctx), rows); err != nil {
+		for k, n := range counts {
+			if aerr := s.buffer.Add(ctx, k, n); aerr != nil {
+				s.log.Error("post views lost", zap.Uint("post_id", k.PostID), zap.Int64("views", n), zap.Error(aerr))
+			}
+		}
+		return 0, err
+	}
+	return len(rows), nil
+}
+
+// RunFlusher calls Flush every interval until ctx is done, then flushes once more so an in-memory
+// buffer is not lost on shutdown.
+func (s *PostStatsService) RunFlusher(ctx context.Context, interval time.Duration) {
+	if interval <= 0 || s.buffer == nil {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			final, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+			if _, err := s.Flush(final); err != nil {
+				s.log.Error("final post view flush failed", zap.Error(err))
+			}
+			cancel()
+			return
+		case <-ticker.C:
+			if _, err := s.Flush(ctx); err != nil {
+				s.log.Error("post view flush failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Popular returns up to limit live posts with the most views over window ("7d": today and the six
+// days before, in UTC; default 7d), most viewed first.
+func (s *PostStatsService) Popular(ctx context.Context, window string, limit int) ([]dto.PopularPost, error) {
+	days, err := parseStatsWindow(window)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = defaultPopularLimit
+	}
+	limit = min(limit, maxPopularLimit)
+	now := s.now()
+	counts, err := s.posts.posts.PopularPosts(ctx, statsSince(now, days), now, limit)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uint, len(counts))
+	for i, c := range counts {
+		ids[i] = c.PostID
+	}
+	posts, err := s.posts.posts.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]*model.Post, len(posts))
+	for i := range posts {
+		byID[posts[i].ID] = &posts[i]
+	}
+	out := make([]dto.PopularPost, 0, len(counts))
+	for _, c := range counts {
+		p, ok := byID[c.PostID]
+		if !ok {
+			continue
+		}
+		s.posts.refreshRender(ctx, p)
+		out = append(out, dto.PopularPost{Views: c.Views, Post: p})
+	}
+	return out, nil
+}
+
+// Stats returns the post's views per day over the last days days (default 30, max 365) and in total,
+// for whoever may edit the post.
+func (s *PostStatsService) Stats(ctx context.Context, postID, actorUserID uint, days int) (*dto.PostStats, error) {
+	if _, err := s.posts.findForMutation(ctx, postID, actorUserID); err != nil {
+		return nil, err
+	}
+	if days <= 0 {
+		days = defaultStatsDays
+	}
+	days = min(days, maxStatsDays)
+	now := s.now()
+	rows, err := s.posts.posts.DailyViews(ctx, postID, statsSince(now, days))
+	if err != nil {
+		return nil, err
+	}
+	total, err := s.posts.posts.TotalViews(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	byDay := make(map[string]int64, len(rows))
+	for _, r := range rows {
+		byDay[r.Day] = r.Views
+	}
+	out := &dto.PostStats{PostID: postID, Total: total, Days: make([]dto.DailyViews, days)}
+	first := now.UTC().AddDate(0, 0, 1-days)
+	for i := range out.Days {
+		day := first.AddDate(0, 0, i).Format(statsDayLayout)
+		out.Days[i] = dto.DailyViews{Day: day, Views: byDay[day]}
+	}
+	return out, nil
+}
+
+// parseStatsWindow reads a window of whole days such as "7d"; empty means the default.
+func parseStatsWindow(w string) (int, error) {
+	w = strings.TrimSpace(w)
+	if w == "" {
+		return defaultPopularWindowDays, nil
+	}
+	n, err := strconv.Atoi(strings.TrimSuffix(w, "d"))
+	if err != nil || !strings.HasSuffix(w, "d") || n < 1 || n > maxStatsDays {
+		return 0, ErrInvalidWindow
+	}
+	return n, nil
+}
+
+// statsSince is the first UTC day of a span of days ending today.
+func statsSince(now time.Time, days int) string {
+	return now.UTC().AddDate(0, 0, 1-days).Format(statsDayLayout)
+}
+
+// isCrawler reports whether ua looks like a search engine or other bot, whose fetches are not reads.
+func isCrawler(ua string) bool {
+	ua = strings.ToLower(ua)
+	for _, s := range []string{"bot", "crawler", "spider", "slurp", "facebookexternalhit"} {
+		if strings.Contains(ua, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/handler/request"
+	"github.com/turahe/go-restfull/internal/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryViewBuffer(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	b := NewMemoryViewBuffer().(*memoryViewBuffer)
+	b.now = func() time.Time { return now }
+
+	first, _ := b.FirstView(ctx, 1, 7, "u1", time.Minute)
+	assert.True(t, first)
+	first, _ = b.FirstView(ctx, 1, 7, "u1", time.Minute)
+	assert.False(t, first, "repeat within the window")
+	first, _ = b.FirstView(ctx, 2, 7, "u1", time.Minute)
+	assert.True(t, first, "windows are per site")
+
+	now = now.Add(time.Minute)
+	first, _ = b.FirstView(ctx, 1, 7, "u1", time.Minute)
+	assert.True(t, first, "window passed")
+
+	key := ViewKey{SiteID: 1, PostID: 7, Day: "2026-03-01"}
+	require.NoError(t, b.Add(ctx, key, 2))
+	require.NoError(t, b.Add(ctx, key, 1))
+	got, err := b.Drain(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[ViewKey]int64{key: 3}, got)
+	got, _ = b.Drain(ctx)
+	assert.Empty(t, got)
+}
+
+func TestPostStats(t *testing.T) {
+	ctx := context.Background()
+	env := newPostTestEnv(t)
+	db, log, catRepo, posts := env.db, env.log, env.categories, env.posts
+	stats := NewPostStatsService(posts, NewMemoryViewBuffer(), 30*time.Minute, log)
+	today := time.Now().UTC()
+	stats.now = func() time.Time { return today }
+
+	ada := model.User{Name: "Ada", Email: "ada@stats.test", Password: "x"}
+	bob := model.User{Name: "Bob", Email: "bob@stats.test", Password: "x"}
+	require.NoError(t, db.Create(&ada).Error)
+	require.NoError(t, db.Create(&bob).Error)
+	cat, err := catRepo.CreateRoot(ctx, "News", ada.ID)
+	require.NoError(t, err)
+	past := today.Add(-time.Hour)
+	create := func(title, status string) *model.Post {
+		req := request.CreatePostRequest{Title: title, Content: "x", CategoryID: cat.ID, Status: status}
+		if status == "" {
+			req.PublishAt = &past
+		}
+		p, err := posts.Create(ctx, ada.ID, req)
+		require.NoError(t, err)
+		return p
+	}
+	hot := create("Hot", "")
+	warm := create("Warm", "")
+	draft := create("Draft", "draft")
+
+	reader := func(i int) Visitor { return Visitor{IP: "10.0.0." + string(rune('0'+i)), UserAgent: "Mozilla/5.0"} }
+	for i := range 3 {
+		stats.RecordView(ctx, hot, reader(i))
+		stats.RecordView(ctx, hot, reader(i)) // deduplicated
+	}
+	stats.RecordView(ctx, hot, Visitor{UserID: bob.ID})
+	stats.RecordView(ctx, hot, Visitor{IP: "10.0.0.9", UserAgent: "Googlebot/2.1"})
+	stats.RecordView(ctx, warm, reader(0))
+	stats.RecordView(ctx, draft, Visitor{UserID: ada.ID})
+
+	n, err := stats.Flush(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	// Older days and a second flush add up.
+	require.NoError(t, posts.posts.AddDailyViews(ctx, []model.PostStatDaily{
+		{PostID: warm.ID, Day: today.AddDate(0, 0, -2).Format(statsDayLayout), Views: 10},
+		{PostID: warm.ID, Day: today.AddDate(0, 0, -20).Format(statsDayLayout), Views: 100},
+	}))
+	stats.RecordView(ctx, warm, reader(5))
+	_, err = stats.Flush(ctx)
+	require.NoError(t, err)
+
+	rank := func(window string) ([]uint, []int64) {
+		rows, err := stats.Popular(ctx, window, 0)
+		require.NoError(t, err)
+		var ids []uint
+		var views []int64
+		for _, r := range rows {
+			ids = append(ids, r.Post.ID)
+			views = append(views, r.Views)
+		}
+		return ids, views
+	}
+	ids, views := rank("1d")
+	assert.Equal(t, []uint{hot.ID, warm.ID}, ids)
+	assert.Equal(t, []int64{4, 2}, views)
+	ids, views = rank("") // 7d
+	assert.Equal(t, []uint{warm.ID, hot.ID}, ids)
+	assert.Equal(t, []int64{12, 4}, views)
+	_, views = rank("30d")
+	assert.Equal(t, []int64{112, 4}, views)
+	for _, w := range []string{"0d", "7", "1w", "366d"} {
+		_, err := stats.Popular(ctx, w, 0)
+		assert.ErrorIs(t, err, ErrInvalidWindow, w)
+	}
+
+	st, err := stats.Stats(ctx, warm.ID, ada.ID, 3)
+	require.NoError(t, err)
+	assert.Equal(t, int64(112), st.Total)
+	require.Len(t, st.Days, 3)
+	assert.Equal(t, today.AddDate(0, 0, -2).Format(statsDayLayout), st.Days[0].Day)
+	assert.Equal(t, []int64{10, 0, 2}, []int64{st.Days[0].Views, st.Days[1].Views, st.Days[2].Views})
+
+	_, err = stats.Stats(ctx, warm.ID, bob.ID, 0)
+	assert.ErrorIs(t, err, ErrNotPostOwner)
+	_, err = stats.Stats(ctx, 9999, ada.ID, 0)
+	assert.ErrorIs(t, err, ErrPostNotFound)
+}
@@ -0,0 +1,242 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/pkg/locale"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidLocale       = errors.New("invalid locale")
+	ErrTranslationExists   = errors.New("the translation group already has a post in this locale")
+	ErrTranslationNotFound = errors.New("translation not found")
+)
+
+// SettingDefaultLocale is the settings key holding the site's default content locale.
+const SettingDefaultLocale = "defaultLocale"
+
+const fallbackDefaultLocale = "en"
+
+// LocalePreference is what a reader asked for: the ?locale= parameter and the Accept-Language header.
+type LocalePreference struct {
+	Requested      string
+	AcceptLanguage string
+}
+
+// defaultLocale returns the site's defaultLocale setting, normalized ("en" when unset or invalid).
+func (s *PostService) defaultLocale(ctx context.Context) string {
+	l, err := locale.Normalize(settingString(ctx, s.settings, SettingDefaultLocale, fallbackDefaultLocale))
+	if err != nil {
+		return fallbackDefaultLocale
+	}
+	return l
+}
+
+// postLocale normalizes the locale of a new post; empty means the site default.
+func (s *PostService) postLocale(ctx context.Context, raw string) (string, error) {
+	if raw == "" {
+		return s.defaultLocale(ctx), nil
+	}
+	l, err := locale.Normalize(raw)
+	if err != nil {
+		return "", ErrInvalidLocale
+	}
+	return l, nil
+}
+
+// joinTranslationGroup returns the translation group of sourceID for a new variant in loc, creating
+// the group (with the source as its first member) if needed. The actor must be allowed to edit the source.
+func (s *PostService) joinTranslationGroup(ctx context.Context, sourceID, actorUserID uint, loc string) (uint, error) {
+	src, err := s.findForMutation(ctx, sourceID, actorUserID)
+	if err != nil {
+		return 0, err
+	}
+	if src.TranslationGroupID == nil {
+		if src.Locale == loc {
+			return 0, ErrTranslationExists
+		}
+		if err := s.posts.SetTranslationGroup(ctx, src.ID, src.ID); err != nil {
+			return 0, err
+		}
+		return src.ID, nil
+	}
+	taken, err := s.posts.LocaleTaken(ctx, *src.TranslationGroupID, loc)
+	if err != nil {
+		return 0, err
+	}
+	if taken {
+		return 0, ErrTranslationExists
+	}
+	return *src.TranslationGroupID, nil
+}
+
+// resolveVariant returns the post to serve for p under pref: the live variant of p's translation group
+// in the negotiated locale, else the one in the default locale, else p itself. It also fills Alternates.
+func (s *PostService) resolveVariant(ctx context.Context, p *model.Post, pref LocalePreference, now time.Time) (*model.Post, error) {
+	def := s.defaultLocale(ctx)
+	if p.TranslationGroupID == nil {
+		p.Alternates = []model.PostAlternate{{Locale: p.Locale, Slug: p.Slug, Default: p.Locale == def}}
+		return p, nil
+	}
+	rows, err := s.posts.TranslationVariants(ctx, []uint{*p.TranslationGroupID})
+	if err != nil {
+		return nil, err
+	}
+	variants := liveVariants(rows, p, now)
+	available := make([]string, len(variants))
+	for i, v := range variants {
+		available[i] = v.Locale
+	}
+	target := p
+	want := locale.Negotiate(pref.Requested, pref.AcceptLanguage, available, def)
+	if v := variantIn(variants, want); v != nil {
+		target = v
+	} else if v := variantIn(variants, def); v != nil {
+		target = v
+	}
+	if target.ID != p.ID {
+		full, err := s.posts.FindBySlugWithCategory(ctx, target.Slug)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrPostNotFound
+			}
+			return nil, err
+		}
+		p = full
+	}
+	p.Alternates = alternatesOf(variants, def)
+	return p, nil
+}
+
+// liveVariants keeps the group members live at now, plus self (which the reader was already allowed to see).
+func liveVariants(rows []model.Post, self *model.Post, now time.Time) []*model.Post {
+	out := make([]*model.Post, 0, len(rows))
+	for i := range rows {
+		if rows[i].ID == self.ID {
+			out = append(out, self)
+		} else if postLive(&rows[i], now) {
+			out = append(out, &rows[i])
+		}
+	}
+	return out
+}
+
+func variantIn(variants []*model.Post, loc string) *model.Post {
+	for _, v := range variants {
+		if v.Locale == loc {
+			return v
+		}
+	}
+	return nil
+}
+
+func alternatesOf(variants []*model.Post, def string) []model.PostAlternate {
+	out := make([]model.PostAlternate, len(variants))
+	for i, v := range variants {
+		out[i] = model.PostAlternate{Locale: v.Locale, Slug: v.Slug, Default: v.Locale == def}
+	}
+	return out
+}
+
+// attachAlternates fills Alternates on list rows with the live variants of each row's translation group.
+func (s *PostService) attachAlternates(ctx context.Context, rows []model.Post, def string, now time.Time) error {
+	var groups []uint
+	for i := range rows {
+		if rows[i].TranslationGroupID != nil {
+			groups = append(groups, *rows[i].TranslationGroupID)
+		}
+	}
+	variants, err := s.posts.TranslationVariants(ctx, UniqueUint(groups))
+	if err != nil {
+		return err
+	}
+	byGroup := make(map[uint][]*model.Post)
+	for i := range variants {
+		if postLive(&variants[i], now) {
+			g := *variants[i].TranslationGroupID
+			byGroup[g] = append(byGroup[g], &variants[i])
+		}
+	}
+	for i := range rows {
+		p := &rows[i]
+		if p.TranslationGroupID == nil {
+			p.Alternates = []model.PostAlternate{{Locale: p.Locale, Slug: p.Slug, Default: p.Locale == def}}
+			continue
+		}
+		p.Alternates = alternatesOf(byGroup[*p.TranslationGroupID], def)
+	}
+	return nil
+}
+
+// localizeTaxonomy replaces the category and tag names and slugs on rows with their translations in loc,
+// where one exists.
+func (s *PostService) localizeTaxonomy(ctx context.Context, rows []*model.Post, loc string) error {
+	var catIDs, tagIDs []uint
+	for _, p := range rows {
+		if p.Category != nil {
+			catIDs = append(catIDs, p.Category.ID)
+		}
+		for _, t := range p.Tags {
+			tagIDs = append(tagIDs, t.ID)
+		}
+	}
+	if len(catIDs) > 0 && s.categories != nil {
+		ts, err := s.categories.TranslationsForLocale(ctx, UniqueUint(catIDs), loc)
+		if err != nil {
+			return err
+		}
+		byID := make(map[uint]model.CategoryTranslation, len(ts))
+		for _, t := range ts {
+			byID[t.CategoryID] = t
+		}
+		for _, p := range rows {
+			if p.Category == nil {
+				continue
+			}
+			if t, ok := byID[p.Category.ID]; ok {
+				p.Category.Name, p.Category.Slug = t.Name, t.Slug
+			}
+		}
+	}
+	if len(tagIDs) > 0 && s.tags != nil {
+		ts, err := s.tags.TranslationsForLocale(ctx, UniqueUint(tagIDs), loc)
+		if err != nil {
+			return err
+		}
+		byID := make(map[uint]model.TagTranslation, len(ts))
+		for _, t := range ts {
+			byID[t.TagID] = t
+		}
+		for _, p := range rows {
+			for i := range p.Tags {
+				if t, ok := byID[p.Tags[i].ID]; ok {
+					p.Tags[i].Name, p.Tags[i].Slug = t.Name, t.Slug
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// localize fills alternates and translated taxonomy on list rows served in loc.
+func (s *PostService) localize(ctx context.Context, rows []model.Post, loc, def string, now time.Time) {
+	if err := s.attachAlternates(ctx, rows, def, now); err != nil {
+		s.log.Warn("failed to attach post alternates", zap.Error(err))
+	}
+	if loc == def {
+		return
+	}
+	ptrs := make([]*model.Post, len(rows))
+	for i := range rows {
+		ptrs[i] = &rows[i]
+	}
+	if err := s.localizeTaxonomy(ctx, ptrs, loc); err != nil {
+		s.log.Warn("failed to localize post taxonomy", zap.Error(err))
+	}
+}
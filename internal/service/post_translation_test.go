@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/turahe/go-restfull/internal/handler/request"
+	"github.com/turahe/go-restfull/internal/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostTranslations(t *testing.T) {
+	ctx := context.Background()
+	env := newPostTestEnv(t)
+	log, catRepo, tagRepo, posts := env.log, env.categories, env.tags, env.posts
+	cats := NewCategoryService(catRepo, log)
+	tags := NewTagService(tagRepo, log)
+
+	const author, other = uint(1), uint(2)
+	cat, err := catRepo.CreateRoot(ctx, "Travel", author)
+	require.NoError(t, err)
+	tag := &model.Tag{Name: "Beaches", Slug: "beaches"}
+	require.NoError(t, tagRepo.Create(ctx, tag))
+
+	en, err := posts.Create(ctx, author, request.CreatePostRequest{Title: "Hello world", Content: "x", CategoryID: cat.ID, TagIDs: []uint{tag.ID}})
+	require.NoError(t, err)
+	assert.Equal(t, "en", en.Locale, "new posts default to the site locale")
+	solo, err := posts.Create(ctx, author, request.CreatePostRequest{Title: "Only in English", Content: "x", CategoryID: cat.ID})
+	require.NoError(t, err)
+
+	_, err = posts.Create(ctx, author, request.CreatePostRequest{Title: "Bad", Content: "x", CategoryID: cat.ID, Locale: "not a locale"})
+	assert.ErrorIs(t, err, ErrInvalidLocale)
+	_, err = posts.Create(ctx, other, request.CreatePostRequest{Title: "Hijack", Content: "x", CategoryID: cat.ID, Locale: "de", TranslationOf: &en.ID})
+	assert.ErrorIs(t, err, ErrNotPostOwner, "translating needs edit rights on the source")
+
+	id, err := posts.Create(ctx, author, request.CreatePostRequest{Title: "Halo dunia", Content: "x", CategoryID: cat.ID, TagIDs: []uint{tag.ID}, Locale: "ID", TranslationOf: &en.ID})
+	require.NoError(t, err)
+	assert.Equal(t, "id", id.Locale)
+	require.NotNil(t, id.TranslationGroupID)
+	assert.Equal(t, en.ID, *id.TranslationGroupID, "the source post founds the group")
+	_, err = posts.Create(ctx, author, request.CreatePostRequest{Title: "Halo lagi", Content: "x", CategoryID: cat.ID, Locale: "id", TranslationOf: &en.ID})
+	assert.ErrorIs(t, err, ErrTranslationExists)
+	_, err = posts.Create(ctx, author, request.CreatePostRequest{Title: "Draft Deutsch", Content: "x", CategoryID: cat.ID, Locale: "de", Status: "draft", TranslationOf: &id.ID})
+	require.NoError(t, err)
+
+	_, err = cats.PutTranslation(ctx, cat.ID, "id", "Perjalanan", author)
+	require.NoError(t, err)
+	_, err = tags.PutTranslation(ctx, tag.ID, "id", "Pantai")
+	require.NoError(t, err)
+
+	listIDs := func(req request.PostListRequest) ([]uint, []model.Post) {
+		page, err := posts.List(ctx, req)
+		require.NoError(t, err)
+		rows := page.Items.([]model.Post)
+		ids := make([]uint, len(rows))
+		for i, p := range rows {
+			ids[i] = p.ID
+		}
+		return ids, rows
+	}
+
+	ids, rows := listIDs(request.PostListRequest{})
+	assert.Equal(t, []uint{en.ID, solo.ID}, ids, "default locale lists English posts only")
+	require.Len(t, rows[0].Alternates, 2, "the draft German variant is not an alternate")
+	assert.Equal(t, model.PostAlternate{Locale: "en", Slug: en.Slug, Default: true}, rows[0].Alternates[0])
+	assert.Equal(t, "Travel", rows[0].Category.Name)
+
+	ids, rows = listIDs(request.PostListRequest{Locale: "id"})
+	assert.Equal(t, []uint{solo.ID, id.ID}, ids, "Indonesian replaces its English variant; untranslated posts fall back")
+	assert.Equal(t, "Perjalanan", rows[1].Category.Name)
+	assert.Equal(t, "pantai", rows[1].Tags[0].Slug)
+
+	ids, _ = listIDs(request.PostListRequest{AcceptLanguage: "id-ID,id;q=0.9,en;q=0.5"})
+	assert.Equal(t, []uint{solo.ID, id.ID}, ids, "Accept-Language is negotiated when no locale is given")
+	ids, _ = listIDs(request.PostListRequest{Locale: "de"})
+	assert.Equal(t, []uint{en.ID, solo.ID}, ids, "a locale without live variants falls back to the default")
+
+	got, err := posts.GetBySlug(ctx, en.Slug, PostAccess{}, LocalePreference{Requested: "id"})
+	require.NoError(t, err)
+	assert.Equal(t, id.ID, got.ID)
+	assert.Equal(t, "Perjalanan", got.Category.Name)
+	got, err = posts.GetBySlug(ctx, id.Slug, PostAccess{}, LocalePreference{Requested: "de"})
+	require.NoError(t, err)
+	assert.Equal(t, en.ID, got.ID, "a draft variant is not served; the default locale is")
+	got, err = posts.GetBySlug(ctx, solo.Slug, PostAccess{}, LocalePreference{Requested: "id"})
+	require.NoError(t, err)
+	assert.Equal(t, solo.ID, got.ID)
+	assert.Equal(t, []model.PostAlternate{{Locale: "en", Slug: solo.Slug, Default: true}}, got.Alternates)
+
+	byTranslated, err := tags.GetBySlug(ctx, "pantai")
+	require.NoError(t, err)
+	assert.Equal(t, tag.ID, byTranslated.ID)
+	assert.Equal(t, "Pantai", byTranslated.Name)
+	assert.ErrorIs(t, cats.DeleteTranslation(ctx, cat.ID, "fr", author), ErrTranslationNotFound)
+	require.NoError(t, cats.DeleteTranslation(ctx, cat.ID, "id", author))
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ViewKey identifies one post's views on one UTC day (YYYY-MM-DD).
+type ViewKey struct {
+	SiteID uint
+	PostID uint
+	Day    string
+}
+
+// ViewBuffer collects post views between flushes. NewRedisViewBuffer shares it between replicas;
+// NewMemoryViewBuffer keeps it per process.
+type ViewBuffer interface {
+	// FirstView marks visitor as having viewed the post and reports whether it had not within window.
+	FirstView(ctx context.Context, siteID, postID uint, visitor string, window time.Duration) (bool, error)
+	// Add adds n views to key.
+	Add(ctx context.Context, key ViewKey, n int64) error
+	// Drain returns the buffered counts and empties the buffer.
+	Drain(ctx context.Context) (map[ViewKey]int64, error)
+}
+
+type memoryViewBuffer struct {
+	mu     sync.Mutex
+	seen   map[string]time.Time
+	counts map[ViewKey]int64
+	now    func() time.Time
+}
+
+// NewMemoryViewBuffer returns an in-process ViewBuffer, for running without Redis. Each replica then
+// deduplicates and counts on its own.
+func NewMemoryViewBuffer() ViewBuffer {
+	return &memoryViewBuffer{seen: make(map[string]time.Time), counts: make(map[ViewKey]int64), now: time.Now}
+}
+
+func (b *memoryViewBuffer) FirstView(_ context.Context, siteID, postID uint, visitor string, window time.Duration) (bool, error) {
+	key := fmt.Sprintf("%d:%d:%s", siteID, postID, visitor)
+	now := b.now()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if until, ok := b.seen[key]; ok && now.Before(until) {
+		return false, nil
+	}
+	b.seen[key] = now.Add(window)
+	return true, nil
+}
+
+func (b *memoryViewBuffer) Add(_ context.Context, key ViewKey, n int64) error {
+	b.mu.Lock()
+	b.counts[key] += n
+	b.mu.Unlock()
+	return nil
+}
+
+// Drain also forgets visitors whose window has passed, which bounds the dedup map.
+func (b *memoryViewBuffer) Drain(context.Context) (map[ViewKey]int64, error) {
+	now := b.now()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for k, until := range b.seen {
+		if !now.Before(until) {
+			delete(b.seen, k)
+		}
+	}
+	out := b.counts
+	b.counts = make(map[ViewKey]int64)
+	return out, nil
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// drainViewsLua reads and deletes the counts hash in one step, so views added meanwhile go to the next
+// flush instead of being lost.
+var drainViewsLua = redis.NewScript(`
+local v = redis.call("HGETALL", KEYS[1])
+redis.call("DEL", KEYS[1])
+return v
+`)
+
+type redisViewBuffer struct {
+	rdb    *redis.Client
+	prefix string
+}
+
+// NewRedisViewBuffer returns a ViewBuffer in Redis under keyPrefix: one key per visitor and post that
+// expires with the dedup window, and one hash of counts shared by every replica.
+func NewRedisViewBuffer(rdb *redis.Client, keyPrefix string) ViewBuffer {
+	if keyPrefix == "" {
+		keyPrefix = "views:"
+	}
+	return &redisViewBuffer{rdb: rdb, prefix: keyPrefix}
+}
+
+func (b *redisViewBuffer) countsKey() string { return b.prefix + "counts" }
+
+func (b *redisViewBuffer) FirstView(ctx context.Context, siteID, postID uint, visitor string, window time.Duration) (bool, error) {
+	key := fmt.Sprintf("%sseen:%d:%d:%s", b.prefix, siteID, postID, visitor)
+	return b.rdb.SetNX(ctx, key, 1, window).Result()
+}
+
+func (b *redisViewBuffer) Add(ctx context.Context, key ViewKey, n int64) error {
+	field := fmt.Sprintf("%d:%d:%s", key.SiteID, key.PostID, key.Day)
+	return b.rdb.HIncrBy(ctx, b.countsKey(), field, n).Err()
+}
+
+func (b *redisViewBuffer) Drain(ctx context.Context) (map[ViewKey]int64, error) {
+	res, err := drainViewsLua.Run(ctx, b.rdb, []string{b.countsKey()}).StringSlice()
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[ViewKey]int64, len(res)/2)
+	for i := 0; i+1 < len(res); i += 2 {
+		key, ok := parseViewField(res[i])
+		n, err := strconv.ParseInt(res[i+1], 10, 64)
+		if !ok || err != nil {
+			continue
+		}
+		out[key] += n
+	}
+	return out, nil
+}
+
+// parseViewField reads a counts hash field, "site:post:day".
+func parseViewField(f string) (ViewKey, bool) {
+	parts := strings.SplitN(f, ":", 3)
+	if len(parts) != 3 {
+		return ViewKey{}, false
+	}
+	site, err1 := strconv.ParseUint(parts[0], 10, 64)
+	post, err2 := strconv.ParseUint(parts[1], 10, 64)
+	if err1 != nil || err2 != nil {
+		return ViewKey{}, false
+	}
+	return ViewKey{SiteID: uint(site), PostID: uint(post), Day: parts[2]}, true
+}
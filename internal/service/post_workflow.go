@@ -0,0 +1,484 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/handler/request"
+	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/internal/repository"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrWorkflowDisabled     = errors.New("editorial workflow is not enabled")
+	ErrUnknownTransition    = errors.New("unknown workflow transition")
+	ErrTransitionNotAllowed = errors.New("the workflow does not allow this status change")
+	ErrTransitionForbidden  = errors.New("not permitted to run this workflow transition")
+	ErrReviewerNotFound     = errors.New("reviewer not found")
+	ErrReviewerIsAuthor     = errors.New("authors cannot review their own post")
+)
+
+// SettingPostWorkflow is the settings key holding the site's workflow as JSON (see Workflow). Without
+// it, or when it is malformed, defaultWorkflow applies.
+const SettingPostWorkflow = "postWorkflow"
+
+// Who may run a transition, on top of holding its permission.
+const (
+	// TransitionByAuthor: the post's owners, or editors whose category scope covers it.
+	TransitionByAuthor = "author"
+	// TransitionByReviewer: the post's assigned reviewers.
+	TransitionByReviewer = "reviewer"
+	// TransitionByAnyone: the permission alone decides.
+	TransitionByAnyone = "anyone"
+)
+
+// workflowPermissionAct is the RBAC action of workflow permissions; their objects are
+// transitionPermission and reviewersPermission.
+const workflowPermissionAct = "POST"
+
+// reviewersPermission is the RBAC object of the route assigning reviewers. Holders may also read the
+// review of any post.
+const reviewersPermission = "/api/v1/posts/:id/reviewers"
+
+// transitionPermission is the RBAC object guarding transition name, e.g.
+// /api/v1/posts/:id/transitions/approve (granted by patterns such as /api/v1/posts/*/transitions/approve).
+func transitionPermission(name string) string {
+	return "/api/v1/posts/:id/transitions/" + name
+}
+
+var transitionNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+// PermissionChecker answers RBAC questions for checks made inside services; RBACService implements it.
+type PermissionChecker interface {
+	Enforce(ctx context.Context, userID uint, obj string, act string) (bool, error)
+}
+
+// WorkflowTransition moves a post from any status in From to To.
+type WorkflowTransition struct {
+	Name string             `json:"name"`
+	From []model.PostStatus `json:"from"`
+	To   model.PostStatus   `json:"to"`
+	// By is who may run it: TransitionByAuthor, TransitionByReviewer or TransitionByAnyone (default).
+	By string `json:"by,omitempty"`
+}
+
+// Workflow is the editorial workflow of a site: posts start in Initial and change status only through
+// Transitions. Scheduled counts as published: publishing a post with a future publishAt schedules it.
+// States lists every status the workflow uses, Initial first.
+type Workflow struct {
+	Initial     model.PostStatus     `json:"initial"`
+	States      []model.PostStatus   `json:"states"`
+	Transitions []WorkflowTransition `json:"transitions"`
+}
+
+var defaultWorkflow = Workflow{
+	Initial: model.PostStatusDraft,
+	Transitions: []WorkflowTransition{
+		{Name: "submit", From: []model.PostStatus{model.PostStatusDraft, model.PostStatusChangesRequested}, To: model.PostStatusInReview, By: TransitionByAuthor},
+		{Name: "withdraw", From: []model.PostStatus{model.PostStatusInReview, model.PostStatusApproved}, To: model.PostStatusDraft, By: TransitionByAuthor},
+		{Name: "request_changes", From: []model.PostStatus{model.PostStatusInReview, model.PostStatusApproved}, To: model.PostStatusChangesRequested, By: TransitionByReviewer},
+		{Name: "approve", From: []model.PostStatus{model.PostStatusInReview}, To: model.PostStatusApproved, By: TransitionByReviewer},
+		{Name: "publish", From: []model.PostStatus{model.PostStatusApproved}, To: model.PostStatusPublished, By: TransitionByAnyone},
+		{Name: "unpublish", From: []model.PostStatus{model.PostStatusPublished}, To: model.PostStatusDraft, By: TransitionByAnyone},
+		{Name: "archive", From: []model.PostStatus{model.PostStatusPublished}, To: model.PostStatusArchived, By: TransitionByAnyone},
+		{Name: "restore", From: []model.PostStatus{model.PostStatusArchived}, To: model.PostStatusDraft, By: TransitionByAnyone},
+	},
+}
+
+// workflowStatus folds scheduled into published, the status the workflow knows it by.
+func workflowStatus(s model.PostStatus) model.PostStatus {
+	if s == model.PostStatusScheduled {
+		return model.PostStatusPublished
+	}
+	return s
+}
+
+// normalize validates w, folding scheduled into published and filling States and default By values.
+func (w Workflow) normalize() (Workflow, error) {
+	if w.Initial == "" {
+		w.Initial = model.PostStatusDraft
+	}
+	w.Initial = workflowStatus(w.Initial)
+	if !w.Initial.IsValid() {
+		return w, fmt.Errorf("invalid initial status %q", w.Initial)
+	}
+	if len(w.Transitions) == 0 {
+		return w, errors.New("no transitions")
+	}
+	w.States = []model.PostStatus{w.Initial}
+	addState := func(s model.PostStatus) {
+		if !slices.Contains(w.States, s) {
+			w.States = append(w.States, s)
+		}
+	}
+	out := make([]WorkflowTransition, 0, len(w.Transitions))
+	seen := map[string]bool{}
+	for _, t := range w.Transitions {
+		if !transitionNamePattern.MatchString(t.Name) || seen[t.Name] {
+			return w, fmt.Errorf("invalid or duplicate transition name %q", t.Name)
+		}
+		seen[t.Name] = true
+		if t.By == "" {
+			t.By = TransitionByAnyone
+		}
+		if t.By != TransitionByAuthor && t.By != TransitionByReviewer && t.By != TransitionByAnyone {
+			return w, fmt.Errorf("transition %s: invalid by %q", t.Name, t.By)
+		}
+		if t.To = workflowStatus(t.To); !t.To.IsValid() || len(t.From) == 0 {
+			return w, fmt.Errorf("transition %s: invalid statuses", t.Name)
+		}
+		from := make([]model.PostStatus, 0, len(t.From))
+		for _, f := range t.From {
+			if f = workflowStatus(f); !f.IsValid() {
+				return w, fmt.Errorf("transition %s: invalid status %q", t.Name, f)
+			}
+			from = append(from, f)
+			addState(f)
+		}
+		t.From = from
+		addState(t.To)
+		out = append(out, t)
+	}
+	w.Transitions = out
+	return w, nil
+}
+
+func (w Workflow) transition(name string) (WorkflowTransition, bool) {
+	for _, t := range w.Transitions {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return WorkflowTransition{}, false
+}
+
+func (t WorkflowTransition) leaves(s model.PostStatus) bool {
+	return slices.Contains(t.From, workflowStatus(s))
+}
+
+// WithWorkflow routes every status change through the site's editorial workflow: Create starts posts
+// in its initial status, Update only makes changes a transition allows, and Transition runs them by
+// name. Transitions are checked against permissions. Without it statuses are set freely.
+func (s *PostService) WithWorkflow(workflow *repository.PostWorkflowRepository, permissions PermissionChecker) *PostService {
+	s.workflow = workflow
+	s.permissions = permissions
+	return s
+}
+
+// siteWorkflow reads the current site's workflow, falling back to the default when the setting is
+// missing or invalid.
+func (s *PostService) siteWorkflow(ctx context.Context) Workflow {
+	def, _ := defaultWorkflow.normalize()
+	raw := settingString(ctx, s.settings, SettingPostWorkflow, "")
+	if raw == "" {
+		return def
+	}
+	var w Workflow
+	if err := json.Unmarshal([]byte(raw), &w); err != nil {
+		s.log.Warn("ignoring malformed post workflow", zap.Error(err))
+		return def
+	}
+	w, err := w.normalize()
+	if err != nil {
+		s.log.Warn("ignoring invalid post workflow", zap.Error(err))
+		return def
+	}
+	return w
+}
+
+// Workflow returns the current site's workflow.
+func (s *PostService) Workflow(ctx context.Context) (*Workflow, error) {
+	if s.workflow == nil {
+		return nil, ErrWorkflowDisabled
+	}
+	w := s.siteWorkflow(ctx)
+	return &w, nil
+}
+
+// mayRun reports whether actor may run t on p: they need its permission and, unless t is open to
+// anyone, to be an owner (or covering editor) or an assigned reviewer of p.
+func (s *PostService) mayRun(ctx context.Context, p *model.Post, actorUserID uint, t WorkflowTransition) (bool, error) {
+	ok, err := s.permissions.Enforce(ctx, actorUserID, transitionPermission(t.Name), workflowPermissionAct)
+	if err != nil || !ok {
+		return false, err
+	}
+	switch t.By {
+	case TransitionByAuthor:
+		_, err := s.authorizePostMutation(ctx, p, actorUserID)
+		if errors.Is(err, ErrNotPostOwner) || errors.Is(err, ErrCategoryOutOfScope) {
+			return false, nil
+		}
+		return err == nil, err
+	case TransitionByReviewer:
+		if p.ID == 0 {
+			return false, nil
+		}
+		return s.workflow.IsReviewer(ctx, p.ID, actorUserID)
+	}
+	return true, nil
+}
+
+// statusChange finds a transition of w that moves p to status to and that actor may run, and returns
+// the history row to record once the change is saved; nil when the status stays the same.
+func (s *PostService) statusChange(ctx context.Context, w Workflow, p *model.Post, actorUserID uint, to model.PostStatus) (*model.PostTransition, error) {
+	if workflowStatus(to) == workflowStatus(p.Status) {
+		return nil, nil
+	}
+	found := false
+	for _, t := range w.Transitions {
+		if !t.leaves(p.Status) || t.To != workflowStatus(to) {
+			continue
+		}
+		found = true
+		ok, err := s.mayRun(ctx, p, actorUserID, t)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return &model.PostTransition{PostID: p.ID, Name: t.Name, FromStatus: p.Status, ActorID: actorUserID}, nil
+		}
+	}
+	if found {
+		return nil, ErrTransitionForbidden
+	}
+	return nil, ErrTransitionNotAllowed
+}
+
+// createStatus sets the status of new post p: the workflow's initial status, or status when a
+// transition from there allows the creator to reach it.
+func (s *PostService) createStatus(ctx context.Context, p *model.Post, actorUserID uint, status string) (*model.PostTransition, error) {
+	w := s.siteWorkflow(ctx)
+	to := model.PostStatus(status)
+	if to == "" {
+		to = w.Initial
+	}
+	p.Status = w.Initial
+	t, err := s.statusChange(ctx, w, p, actorUserID, to)
+	if err != nil {
+		return nil, err
+	}
+	p.Status = to
+	return t, nil
+}
+
+// recordTransition completes t with the status p ended up in (scheduling may have changed it) and stores it.
+func (s *PostService) recordTransition(ctx context.Context, t *model.PostTransition, p *model.Post) error {
+	if t == nil || s.workflow == nil {
+		return nil
+	}
+	t.PostID, t.ToStatus = p.ID, p.Status
+	return s.workflow.CreateTransition(ctx, t)
+}
+
+// Transition runs the named transition of the site's workflow on a post, with an optional note kept
+// in its history.
+func (s *PostService) Transition(ctx context.Context, postID, actorUserID uint, req request.PostTransitionRequest) (*model.Post, error) {
+	if s.workflow == nil {
+		return nil, ErrWorkflowDisabled
+	}
+	p, err := s.posts.FindByID(ctx, postID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPostNotFound
+		}
+		return nil, err
+	}
+	t, ok := s.siteWorkflow(ctx).transition(strings.TrimSpace(req.Transition))
+	if !ok {
+		return nil, ErrUnknownTransition
+	}
+	if !t.leaves(p.Status) {
+		return nil, ErrTransitionNotAllowed
+	}
+	if ok, err = s.mayRun(ctx, p, actorUserID, t); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, ErrTransitionForbidden
+	}
+	if err := s.ensureBaselineRevision(ctx, p); err != nil {
+		return nil, err
+	}
+
+	from := p.Status
+	p.Status = t.To
+	if err := normalizePostSchedule(p, time.Now()); err != nil {
+		return nil, err
+	}
+	p.UpdatedBy = actorUserID
+	if err := s.posts.Update(ctx, p); err != nil {
+		s.log.Error("failed to update post status", zap.Error(err))
+		return nil, err
+	}
+	rec := &model.PostTransition{Name: t.Name, FromStatus: from, ActorID: actorUserID, Note: strings.TrimSpace(req.Note)}
+	if err := s.recordTransition(ctx, rec, p); err != nil {
+		return nil, err
+	}
+	if err := s.loadContributors(ctx, p); err != nil {
+		return nil, err
+	}
+	if err := s.recordRevision(ctx, p, actorUserID); err != nil {
+		return nil, err
+	}
+	s.indexPost(ctx, p.ID)
+	return p, nil
+}
+
+// findForReview loads a post for its review: its owners (or covering editors), its assigned reviewers
+// and users who may assign reviewers have access.
+func (s *PostService) findForReview(ctx context.Context, postID, actorUserID uint) (*model.Post, error) {
+	if s.workflow == nil {
+		return nil, ErrWorkflowDisabled
+	}
+	p, err := s.posts.FindByID(ctx, postID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPostNotFound
+		}
+		return nil, err
+	}
+	reviewer, err := s.workflow.IsReviewer(ctx, p.ID, actorUserID)
+	if err != nil || reviewer {
+		return p, err
+	}
+	manager, err := s.permissions.Enforce(ctx, actorUserID, reviewersPermission, workflowPermissionAct)
+	if err != nil || manager {
+		return p, err
+	}
+	if _, err := s.authorizePostMutation(ctx, p, actorUserID); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Transitions returns a post's workflow history, newest first.
+func (s *PostService) Transitions(ctx context.Context, postID, actorUserID uint) ([]model.PostTransition, error) {
+	if _, err := s.findForReview(ctx, postID, actorUserID); err != nil {
+		return nil, err
+	}
+	return s.workflow.ListTransitions(ctx, postID)
+}
+
+// Reviewers returns a post's assigned reviewers.
+func (s *PostService) Reviewers(ctx context.Context, postID, actorUserID uint) ([]model.PostReviewer, error) {
+	if _, err := s.findForReview(ctx, postID, actorUserID); err != nil {
+		return nil, err
+	}
+	return s.workflow.ListReviewers(ctx, postID)
+}
+
+// findForAssignment loads a post whose reviewers actor changes; permission to do so is checked on the
+// route, and editors with category-scoped grants are confined to their subtrees.
+func (s *PostService) findForAssignment(ctx context.Context, postID, actorUserID uint) (*model.Post, error) {
+	if s.workflow == nil {
+		return nil, ErrWorkflowDisabled
+	}
+	p, err := s.posts.FindByID(ctx, postID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPostNotFound
+		}
+		return nil, err
+	}
+	scope, err := loadCategoryScope(ctx, s.categories, actorUserID)
+	if err != nil {
+		return nil, err
+	}
+	if scope.restricted {
+		ok, err := scope.coversID(ctx, s.categories, p.CategoryID)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, ErrCategoryOutOfScope
+		}
+	}
+	return p, nil
+}
+
+// AssignReviewer adds a reviewer to a post and returns its reviewers. Authors cannot review their
+// own post.
+func (s *PostService) AssignReviewer(ctx context.Context, postID, actorUserID uint, req request.AssignPostReviewerRequest) ([]model.PostReviewer, error) {
+	p, err := s.findForAssignment(ctx, postID, actorUserID)
+	if err != nil {
+		return nil, err
+	}
+	n, err := s.posts.CountUsers(ctx, []uint{req.UserID})
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, ErrReviewerNotFound
+	}
+	if author, err := s.isPostOwner(ctx, p, req.UserID); err != nil {
+		return nil, err
+	} else if author {
+		return nil, ErrReviewerIsAuthor
+	}
+	if err := s.workflow.AddReviewer(ctx, &model.PostReviewer{PostID: p.ID, UserID: req.UserID, AssignedBy: actorUserID}); err != nil {
+		return nil, err
+	}
+	return s.workflow.ListReviewers(ctx, p.ID)
+}
+
+// UnassignReviewer removes a reviewer from a post. Their review comments stay.
+func (s *PostService) UnassignReviewer(ctx context.Context, postID, reviewerUserID, actorUserID uint) error {
+	if _, err := s.findForAssignment(ctx, postID, actorUserID); err != nil {
+		return err
+	}
+	removed, err := s.workflow.RemoveReviewer(ctx, postID, reviewerUserID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrReviewerNotFound
+	}
+	return nil
+}
+
+// ReviewComments returns a post's review comments, oldest first.
+func (s *PostService) ReviewComments(ctx context.Context, postID, actorUserID uint) ([]model.PostReviewComment, error) {
+	if _, err := s.findForReview(ctx, postID, actorUserID); err != nil {
+		return nil, err
+	}
+	return s.workflow.ListReviewComments(ctx, postID)
+}
+
+// AddReviewComment attaches a review comment to a revision of the post, its latest by default.
+func (s *PostService) AddReviewComment(ctx context.Context, postID, actorUserID uint, req request.CreatePostReviewCommentRequest) (*model.PostReviewComment, error) {
+	p, err := s.findForReview(ctx, postID, actorUserID)
+	if err != nil {
+		return nil, err
+	}
+	if s.revisions == nil {
+		return nil, ErrPostRevisionNotFound
+	}
+	var rev *model.PostRevision
+	if req.RevisionID != 0 {
+		rev, err = s.revisions.FindByID(ctx, p.ID, req.RevisionID)
+	} else {
+		if err := s.ensureBaselineRevision(ctx, p); err != nil {
+			return nil, err
+		}
+		rev, err = s.revisions.FindLatest(ctx, p.ID)
+	}
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPostRevisionNotFound
+		}
+		return nil, err
+	}
+	c := &model.PostReviewComment{PostID: p.ID, RevisionID: rev.ID, UserID: actorUserID, Body: strings.TrimSpace(req.Body)}
+	if err := s.workflow.CreateReviewComment(ctx, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
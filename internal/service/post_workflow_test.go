@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/turahe/go-restfull/internal/handler/request"
+	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostWorkflow(t *testing.T) {
+	ctx := context.Background()
+	env := newPostTestEnv(t)
+	db, catRepo, settingRepo, rbacSvc := env.db, env.categories, env.settings, env.rbac
+	posts := env.posts.WithWorkflow(repository.NewPostWorkflowRepository(db, env.log), rbacSvc)
+
+	users := make([]model.User, 3)
+	for i := range users {
+		users[i] = model.User{Name: "U", Email: string(rune('a'+i)) + "@workflow.test", Password: "x"}
+		require.NoError(t, db.Create(&users[i]).Error)
+	}
+	author, reviewer, editor := users[0].ID, users[1].ID, users[2].ID
+	for _, name := range []string{"submit", "withdraw", "approve", "request_changes"} {
+		_, err := rbacSvc.AddPermissionToRole(ctx, "writer", "/api/v1/posts/*/transitions/"+name, "POST")
+		require.NoError(t, err)
+	}
+	_, err := rbacSvc.AddPermissionToRole(ctx, "chief", "/api/v1/posts/*/transitions/*", "POST")
+	require.NoError(t, err)
+	_, err = rbacSvc.AddPermissionToRole(ctx, "chief", "/api/v1/posts/*/reviewers", "POST")
+	require.NoError(t, err)
+	for _, id := range []uint{author, reviewer} {
+		_, err = rbacSvc.AssignRole(ctx, id, "writer")
+		require.NoError(t, err)
+	}
+	_, err = rbacSvc.AssignRole(ctx, editor, "chief")
+	require.NoError(t, err)
+
+	cat, err := catRepo.CreateRoot(ctx, "News", editor)
+	require.NoError(t, err)
+	p, err := posts.Create(ctx, author, request.CreatePostRequest{Title: "Under review", Content: "v1", CategoryID: cat.ID})
+	require.NoError(t, err)
+	assert.Equal(t, model.PostStatusDraft, p.Status, "posts start in the workflow's initial status")
+	_, err = posts.Create(ctx, author, request.CreatePostRequest{Title: "Straight out", Content: "x", CategoryID: cat.ID, Status: "published"})
+	assert.ErrorIs(t, err, ErrTransitionNotAllowed)
+
+	// Authors cannot skip review through Update, nor run reviewer transitions.
+	_, err = posts.Update(ctx, p.ID, author, request.UpdatePostRequest{Status: "published"})
+	assert.ErrorIs(t, err, ErrTransitionNotAllowed)
+	_, err = posts.Transition(ctx, p.ID, author, request.PostTransitionRequest{Transition: "approve"})
+	assert.ErrorIs(t, err, ErrTransitionNotAllowed, "approve only leaves in_review")
+	_, err = posts.Transition(ctx, p.ID, author, request.PostTransitionRequest{Transition: "nope"})
+	assert.ErrorIs(t, err, ErrUnknownTransition)
+
+	p, err = posts.Transition(ctx, p.ID, author, request.PostTransitionRequest{Transition: "submit", Note: "ready"})
+	require.NoError(t, err)
+	assert.Equal(t, model.PostStatusInReview, p.Status)
+	_, err = posts.Transition(ctx, p.ID, author, request.PostTransitionRequest{Transition: "approve"})
+	assert.ErrorIs(t, err, ErrTransitionForbidden, "authors are not reviewers")
+	_, err = posts.Transition(ctx, p.ID, reviewer, request.PostTransitionRequest{Transition: "approve"})
+	assert.ErrorIs(t, err, ErrTransitionForbidden, "reviewers must be assigned")
+
+	_, err = posts.AssignReviewer(ctx, p.ID, editor, request.AssignPostReviewerRequest{UserID: author})
+	assert.ErrorIs(t, err, ErrReviewerIsAuthor)
+	_, err = posts.AssignReviewer(ctx, p.ID, editor, request.AssignPostReviewerRequest{UserID: 999})
+	assert.ErrorIs(t, err, ErrReviewerNotFound)
+	reviewers, err := posts.AssignReviewer(ctx, p.ID, editor, request.AssignPostReviewerRequest{UserID: reviewer})
+	require.NoError(t, err)
+	require.Len(t, reviewers, 1)
+	assert.Equal(t, reviewer, reviewers[0].UserID)
+
+	// Review comments attach to the latest revision unless one is named.
+	rc, err := posts.AddReviewComment(ctx, p.ID, reviewer, request.CreatePostReviewCommentRequest{Body: "Tighten the intro"})
+	require.NoError(t, err)
+	revs, err := posts.revisions.List(ctx, p.ID)
+	require.NoError(t, err)
+	assert.Equal(t, revs[0].ID, rc.RevisionID)
+	_, err = posts.AddReviewComment(ctx, p.ID, reviewer, request.CreatePostReviewCommentRequest{Body: "x", RevisionID: 999})
+	assert.ErrorIs(t, err, ErrPostRevisionNotFound)
+	_, err = posts.ReviewComments(ctx, p.ID, editor+100)
+	assert.ErrorIs(t, err, ErrNotPostOwner, "outsiders cannot read the review")
+	comments, err := posts.ReviewComments(ctx, p.ID, author)
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+	assert.Equal(t, "Tighten the intro", comments[0].Body)
+
+	p, err = posts.Transition(ctx, p.ID, reviewer, request.PostTransitionRequest{Transition: "request_changes"})
+	require.NoError(t, err)
+	assert.Equal(t, model.PostStatusChangesRequested, p.Status)
+	_, err = posts.Update(ctx, p.ID, author, request.UpdatePostRequest{Content: "v2", Status: "in_review"})
+	require.NoError(t, err, "Update runs the matching transition")
+	p, err = posts.Transition(ctx, p.ID, reviewer, request.PostTransitionRequest{Transition: "approve"})
+	require.NoError(t, err)
+	assert.Equal(t, model.PostStatusApproved, p.Status)
+
+	_, err = posts.Update(ctx, p.ID, author, request.UpdatePostRequest{Status: "published"})
+	assert.ErrorIs(t, err, ErrTransitionForbidden, "writers may not publish")
+	p, err = posts.Transition(ctx, p.ID, editor, request.PostTransitionRequest{Transition: "publish"})
+	require.NoError(t, err)
+	assert.Equal(t, model.PostStatusPublished, p.Status)
+
+	history, err := posts.Transitions(ctx, p.ID, author)
+	require.NoError(t, err)
+	var names []string
+	for _, h := range history {
+		names = append(names, h.Name)
+	}
+	assert.Equal(t, []string{"publish", "approve", "submit", "request_changes", "submit"}, names)
+	assert.Equal(t, model.PostStatusApproved, history[0].FromStatus)
+	assert.Equal(t, editor, history[0].ActorID)
+	assert.Equal(t, "ready", history[len(history)-1].Note)
+
+	require.NoError(t, posts.UnassignReviewer(ctx, p.ID, reviewer, editor))
+	assert.ErrorIs(t, posts.UnassignReviewer(ctx, p.ID, reviewer, editor), ErrReviewerNotFound)
+
+	// Sites may configure their own workflow; invalid ones fall back to the default.
+	require.NoError(t, settingRepo.Upsert(ctx, SettingPostWorkflow, `{"transitions":[{"name":"publish","from":["draft"],"to":"published","by":"author"}]}`, false))
+	direct, err := posts.Create(ctx, author, request.CreatePostRequest{Title: "Direct", Content: "x", CategoryID: cat.ID})
+	require.NoError(t, err)
+	_, err = posts.Update(ctx, direct.ID, author, request.UpdatePostRequest{Status: "published"})
+	assert.ErrorIs(t, err, ErrTransitionForbidden, "the permission is still required")
+	_, err = posts.Transition(ctx, direct.ID, editor, request.PostTransitionRequest{Transition: "publish"})
+	assert.ErrorIs(t, err, ErrTransitionForbidden, "and so is authorship")
+	_, err = rbacSvc.AddPermissionToRole(ctx, "writer", "/api/v1/posts/*/transitions/publish", "POST")
+	require.NoError(t, err)
+	direct, err = posts.Update(ctx, direct.ID, author, request.UpdatePostRequest{Status: "published"})
+	require.NoError(t, err)
+	assert.Equal(t, model.PostStatusPublished, direct.Status)
+
+	require.NoError(t, settingRepo.Upsert(ctx, SettingPostWorkflow, `{"transitions":[{"name":"Bad Name","from":["draft"],"to":"published"}]}`, false))
+	w, err := posts.Workflow(ctx)
+	require.NoError(t, err)
+	assert.Len(t, w.Transitions, len(defaultWorkflow.Transitions))
+	assert.Equal(t, []model.PostStatus{model.PostStatusDraft, model.PostStatusChangesRequested, model.PostStatusInReview, model.PostStatusApproved, model.PostStatusPublished, model.PostStatusArchived}, w.States)
+}
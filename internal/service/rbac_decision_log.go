@@ -0,0 +1,43 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/turahe/go-restfull/internal/service/dto"
+)
+
+// rbacDecisionLog keeps the most recent traced decisions in memory, keyed by decision id.
+// It is per process: a decision id is only resolvable on the replica that issued it.
+type rbacDecisionLog struct {
+	mu    sync.Mutex
+	max   int
+	order []string
+	byID  map[string]dto.RBACDecision
+}
+
+func newRBACDecisionLog(max int) *rbacDecisionLog {
+	if max <= 0 {
+		max = 1000
+	}
+	return &rbacDecisionLog{max: max, byID: make(map[string]dto.RBACDecision, max)}
+}
+
+func (l *rbacDecisionLog) put(d dto.RBACDecision) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.byID[d.ID]; !ok {
+		l.order = append(l.order, d.ID)
+	}
+	l.byID[d.ID] = d
+	for len(l.order) > l.max {
+		delete(l.byID, l.order[0])
+		l.order = l.order[1:]
+	}
+}
+
+func (l *rbacDecisionLog) get(id string) (dto.RBACDecision, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	d, ok := l.byID[id]
+	return d, ok
+}
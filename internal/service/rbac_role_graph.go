@@ -0,0 +1,261 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/internal/rbac"
+	"github.com/turahe/go-restfull/internal/tenant"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type roleGrant struct {
+	Role string
+	Key  string
+}
+
+// activeUserRoles restricts a user_roles query to grants whose validity window contains now.
+func activeUserRoles(db *gorm.DB, now time.Time) *gorm.DB {
+	return db.Where("(user_roles.valid_from IS NULL OR user_roles.valid_from <= ?) AND (user_roles.valid_until IS NULL OR user_roles.valid_until > ?)", now, now)
+}
+
+// directRoles returns the user's currently valid role assignments ordered by role id. Category-scoped
+// grants are only included with scoped: they open just the routes whose services confine them to their
+// subtrees (see categoryScopedRoutes).
+func (s *RBACService) directRoles(ctx context.Context, userID uint, scoped bool) ([]model.Role, error) {
+	var roles []model.Role
+	q := s.db.WithContext(ctx).
+		Table("user_roles").
+		Select("DISTINCT roles.id, roles.name").
+		Joins("JOIN roles ON roles.id = user_roles.role_id AND roles.deleted_at IS NULL").
+		Where("user_roles.user_id = ? AND user_roles.site_id = ?", userID, tenant.SiteID(ctx))
+	if !scoped {
+		q = q.Where("user_roles.category_id IS NULL")
+	}
+	if err := activeUserRoles(q, time.Now()).Order("roles.id asc").Scan(&roles).Error; err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+// effectiveRoles returns the direct roles and, in all, the direct roles followed by every role they
+// inherit from (breadth first). Each role appears once, so cycles in existing data cannot loop.
+func (s *RBACService) effectiveRoles(ctx context.Context, userID uint, scoped bool) (direct []model.Role, all []model.Role, err error) {
+	direct, err = s.directRoles(ctx, userID, scoped)
+	if err != nil {
+		return nil, nil, err
+	}
+	seen := make(map[uint]struct{}, len(direct))
+	frontier := make([]uint, 0, len(direct))
+	for _, r := range direct {
+		if _, ok := seen[r.ID]; ok {
+			continue
+		}
+		seen[r.ID] = struct{}{}
+		all = append(all, r)
+		frontier = append(frontier, r.ID)
+	}
+	for len(frontier) > 0 {
+		var parents []model.Role
+		err := s.db.WithContext(ctx).
+			Table("role_inheritances").
+			Select("roles.id, roles.name").
+			Joins("JOIN roles ON roles.id = role_inheritances.parent_role_id AND roles.deleted_at IS NULL").
+			Where("role_inheritances.role_id IN ?", frontier).
+			Order("roles.id asc").
+			Scan(&parents).Error
+		if err != nil {
+			return nil, nil, err
+		}
+		frontier = frontier[:0]
+		for _, p := range parents {
+			if _, ok := seen[p.ID]; ok {
+				continue
+			}
+			seen[p.ID] = struct{}{}
+			all = append(all, p)
+			frontier = append(frontier, p.ID)
+		}
+	}
+	return direct, all, nil
+}
+
+// grantsForRoles returns (role name, permission key) pairs for roles, ordered by role then permission id.
+func (s *RBACService) grantsForRoles(ctx context.Context, roles []model.Role) ([]roleGrant, error) {
+	if len(roles) == 0 {
+		return nil, nil
+	}
+	ids := make([]uint, 0, len(roles))
+	for _, r := range roles {
+		ids = append(ids, r.ID)
+	}
+	var grants []roleGrant
+	err := s.db.WithContext(ctx).
+		Table("role_permissions").
+		Select("roles.name AS role, permissions.key AS `key`").
+		Joins("JOIN roles ON roles.id = role_permissions.role_id").
+		Joins("JOIN permissions ON permissions.id = role_permissions.permission_id AND permissions.deleted_at IS NULL").
+		Where("role_permissions.role_id IN ?", ids).
+		Order("roles.id asc, permissions.id asc").
+		Scan(&grants).Error
+	if err != nil {
+		return nil, err
+	}
+	return grants, nil
+}
+
+// inheritsFrom reports whether roleID reaches ancestorID by following role_inheritances upward.
+func inheritsFrom(tx *gorm.DB, roleID, ancestorID uint) (bool, error) {
+	seen := map[uint]struct{}{roleID: {}}
+	frontier := []uint{roleID}
+	for len(frontier) > 0 {
+		var parents []uint
+		if err := tx.Model(&model.RoleInheritance{}).Where("role_id IN ?", frontier).Pluck("parent_role_id", &parents).Error; err != nil {
+			return false, err
+		}
+		frontier = frontier[:0]
+		for _, p := range parents {
+			if p == ancestorID {
+				return true, nil
+			}
+			if _, ok := seen[p]; ok {
+				continue
+			}
+			seen[p] = struct{}{}
+			frontier = append(frontier, p)
+		}
+	}
+	return false, nil
+}
+
+// AddRoleInheritance makes role inherit every permission of parentRole. Both roles must exist.
+// Edges that would close a cycle (including role == parentRole) are rejected with ErrRoleInheritanceCycle.
+func (s *RBACService) AddRoleInheritance(ctx context.Context, role, parentRole string) (bool, error) {
+	role = strings.TrimSpace(role)
+	parentRole = strings.TrimSpace(parentRole)
+	if role == "" || parentRole == "" {
+		return false, errors.New("role and parent role are required")
+	}
+	if role == parentRole {
+		return false, ErrRoleInheritanceCycle
+	}
+
+	if s.db != nil {
+		added := false
+		if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			var child, parent model.Role
+			if err := tx.Where("name = ?", role).First(&child).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return ErrRoleNotFound
+				}
+				return err
+			}
+			if err := tx.Where("name = ?", parentRole).First(&parent).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return ErrRoleNotFound
+				}
+				return err
+			}
+			cycle, err := inheritsFrom(tx, parent.ID, child.ID)
+			if err != nil {
+				return err
+			}
+			if cycle {
+				return ErrRoleInheritanceCycle
+			}
+			ri := model.RoleInheritance{RoleID: child.ID, ParentRoleID: parent.ID}
+			res := tx.Where("role_id = ? AND parent_role_id = ?", child.ID, parent.ID).FirstOrCreate(&ri)
+			added = res.RowsAffected > 0
+			return res.Error
+		}); err != nil {
+			s.log.Error("failed to add role inheritance", zap.Error(err))
+			return false, err
+		}
+		return added, s.reloadPolicy()
+	}
+
+	// Without a DB handle, write through the enforcer (g, role, parentRole, *).
+	added, err := s.e.AddGroupingPolicy(role, parentRole, rbac.AnyDomain)
+	if err != nil {
+		s.log.Error("failed to add grouping policy", zap.Error(err))
+		return false, err
+	}
+	return added, nil
+}
+
+// RemoveRoleInheritance deletes the role -> parentRole edge (no-op when it does not exist).
+func (s *RBACService) RemoveRoleInheritance(ctx context.Context, role, parentRole string) (bool, error) {
+	role = strings.TrimSpace(role)
+	parentRole = strings.TrimSpace(parentRole)
+	if role == "" || parentRole == "" {
+		return false, errors.New("role and parent role are required")
+	}
+
+	if s.db != nil {
+		res := s.db.WithContext(ctx).Exec(
+			"DELETE FROM role_inheritances WHERE role_id IN (SELECT id FROM roles WHERE name = ?) AND parent_role_id IN (SELECT id FROM roles WHERE name = ?)",
+			role, parentRole,
+		)
+		if res.Error != nil {
+			s.log.Error("failed to remove role inheritance", zap.Error(res.Error))
+			return false, res.Error
+		}
+		return res.RowsAffected > 0, s.reloadPolicy()
+	}
+
+	ok, err := s.e.RemoveGroupingPolicy(role, parentRole, rbac.AnyDomain)
+	if err != nil {
+		s.log.Error("failed to remove grouping policy", zap.Error(err))
+		return false, err
+	}
+	return ok, nil
+}
+
+// SweepExpiredRoleGrants deletes user_roles rows whose valid_until has passed, on every site.
+func (s *RBACService) SweepExpiredRoleGrants(ctx context.Context) (int64, error) {
+	if s.db == nil {
+		return 0, nil
+	}
+	res := s.db.WithContext(tenant.WithAllSites(ctx)).
+		Where("valid_until IS NOT NULL AND valid_until <= ?", time.Now()).
+		Delete(&model.UserRole{})
+	if res.Error != nil {
+		s.log.Error("failed to sweep expired role grants", zap.Error(res.Error))
+		return 0, res.Error
+	}
+	return res.RowsAffected, nil
+}
+
+// RunGrantSweeper calls SweepExpiredRoleGrants every interval until ctx is done, then reloads the
+// enforcer so grants whose window opened or closed since the last tick are reflected in Casbin too.
+// Expired grants are already ignored by DB evaluation; the sweep keeps user_roles small.
+func (s *RBACService) RunGrantSweeper(ctx context.Context, interval time.Duration) {
+	if s.db == nil || interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := s.SweepExpiredRoleGrants(ctx)
+			if err != nil {
+				s.log.Error("role grant sweep failed", zap.Error(err))
+			} else if n > 0 {
+				s.log.Info("expired role grants removed", zap.Int64("count", n))
+			}
+			// Reload even when the sweep failed: grants may have expired regardless. A failed reload
+			// leaves Casbin enforcing stale grants until the next tick retries it.
+			if err := s.reloadPolicy(); err != nil {
+				s.log.Error("role grant sweeper could not reload policy; retrying next tick", zap.Error(err))
+			}
+		}
+	}
+}
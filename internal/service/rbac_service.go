@@ -5,9 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/turahe/go-restfull/internal/model"
 	"github.com/turahe/go-restfull/internal/rbac"
+	"github.com/turahe/go-restfull/internal/service/dto"
+	"github.com/turahe/go-restfull/internal/tenant"
+	"github.com/turahe/go-restfull/pkg/ids"
 
 	"github.com/casbin/casbin/v3/util"
 	"go.uber.org/zap"
@@ -18,32 +22,39 @@ type RBACService struct {
 	e   *rbac.Enforcer
 	db  *gorm.DB
 	log *zap.Logger
+
+	// decisions is nil unless EnableDecisionLog was called (see TraceDenied).
+	decisions *rbacDecisionLog
 }
 
 func NewRBACService(e *rbac.Enforcer, db *gorm.DB, log *zap.Logger) *RBACService {
 	return &RBACService{e: e, db: db, log: log}
 }
 
+var (
+	ErrRoleInheritanceCycle   = errors.New("role inheritance would create a cycle")
+	ErrInvalidRoleGrantWindow = errors.New("invalid role grant window")
+)
+
+// RolesForUser returns the user's directly assigned roles whose grant window is currently open.
+// Inherited roles are not included (see PermissionsForUser).
 func (s *RBACService) RolesForUser(ctx context.Context, userID uint) ([]string, error) {
 	// Prefer DB roles table (source of truth for user_roles).
 	if s.db != nil {
-		var names []string
-		err := s.db.WithContext(ctx).
-			Table("user_roles").
-			Select("roles.name").
-			Joins("JOIN roles ON roles.id = user_roles.role_id AND roles.deleted_at IS NULL").
-			Where("user_roles.user_id = ?", userID).
-			Order("roles.id asc").
-			Scan(&names).Error
+		roles, err := s.directRoles(ctx, userID, true)
 		if err != nil {
 			s.log.Error("failed to get roles for user", zap.Error(err))
 			return nil, err
 		}
+		names := make([]string, 0, len(roles))
+		for _, r := range roles {
+			names = append(names, r.Name)
+		}
 		return names, nil
 	}
 
 	// Fallback to Casbin grouping policies.
-	roles, err := s.e.GetRolesForUser(fmt.Sprintf("%d", userID))
+	roles, err := s.e.GetRolesForUser(fmt.Sprintf("%d", userID), siteDomain(ctx))
 	if err != nil {
 		s.log.Error("failed to get roles for user", zap.Error(err))
 		return nil, err
@@ -51,48 +62,55 @@ func (s *RBACService) RolesForUser(ctx context.Context, userID uint) ([]string,
 	return roles, nil
 }
 
-// PermissionsForUser returns implicit permissions as "obj:act" strings.
+// PermissionsForUser returns implicit permissions as "obj:act" strings, including permissions
+// granted through inherited roles (transitive closure over role_inheritances).
 func (s *RBACService) PermissionsForUser(ctx context.Context, userID uint) ([]string, error) {
-	// Prefer DB permissions tables (user_roles -> role_permissions -> permissions).
+	// Prefer DB permissions tables (user_roles -> role_inheritances -> role_permissions -> permissions).
 	if s.db != nil {
-		var keys []string
-		err := s.db.WithContext(ctx).
-			Table("user_roles").
-			Select("permissions.key").
-			Joins("JOIN roles ON roles.id = user_roles.role_id AND roles.deleted_at IS NULL").
-			Joins("JOIN role_permissions ON role_permissions.role_id = roles.id").
-			Joins("JOIN permissions ON permissions.id = role_permissions.permission_id AND permissions.deleted_at IS NULL").
-			Where("user_roles.user_id = ?", userID).
-			Order("permissions.id asc").
-			Scan(&keys).Error
-		if err != nil {
-			s.log.Error("failed to get permissions for user", zap.Error(err))
-			return nil, err
-		}
-		out := make([]string, 0, len(keys))
-		for _, k := range keys {
-			k = strings.TrimSpace(k)
-			if k == "" {
-				continue
-			}
-			out = append(out, k)
-		}
-		return out, nil
+		return s.permissionKeys(ctx, userID, true)
 	}
 
 	// Fallback to Casbin (implicit permissions).
-	perms, err := s.e.GetImplicitPermissionsForUser(fmt.Sprintf("%d", userID))
+	perms, err := s.e.PermissionsInDomain(fmt.Sprintf("%d", userID), siteDomain(ctx))
 	if err != nil {
 		s.log.Error("failed to get implicit permissions for user", zap.Error(err))
 		return nil, err
 	}
 	out := make([]string, 0, len(perms))
 	for _, p := range perms {
-		// p = [sub obj act]
-		if len(p) < 3 {
+		// p = [sub dom obj act]
+		if len(p) < 4 {
+			continue
+		}
+		out = append(out, strings.TrimSpace(p[2])+":"+strings.TrimSpace(p[3]))
+	}
+	return out, nil
+}
+
+// permissionKeys lists the user's permission keys from the DB; category-scoped grants only count with scoped.
+func (s *RBACService) permissionKeys(ctx context.Context, userID uint, scoped bool) ([]string, error) {
+	_, roles, err := s.effectiveRoles(ctx, userID, scoped)
+	if err != nil {
+		s.log.Error("failed to resolve roles for user", zap.Error(err))
+		return nil, err
+	}
+	grants, err := s.grantsForRoles(ctx, roles)
+	if err != nil {
+		s.log.Error("failed to get permissions for user", zap.Error(err))
+		return nil, err
+	}
+	out := make([]string, 0, len(grants))
+	seen := make(map[string]struct{}, len(grants))
+	for _, g := range grants {
+		k := strings.TrimSpace(g.Key)
+		if k == "" {
 			continue
 		}
-		out = append(out, strings.TrimSpace(p[1])+":"+strings.TrimSpace(p[2]))
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		out = append(out, k)
 	}
 	return out, nil
 }
@@ -100,24 +118,15 @@ func (s *RBACService) PermissionsForUser(ctx context.Context, userID uint) ([]st
 func (s *RBACService) Enforce(ctx context.Context, userID uint, obj string, act string) (bool, error) {
 	// Prefer DB-based enforcement: evaluate permission patterns (keyMatch2 + regexMatch).
 	// Permission keys are stored as "obj:act" where obj may contain keyMatch2 wildcards
-	// and act may be a regex (same semantics as the Casbin matcher).
+	// and act may be a regex (same semantics as the Casbin matcher). Category-scoped grants only
+	// count on routes whose services confine them to their subtrees.
 	if s.db != nil {
-		keys, err := s.PermissionsForUser(ctx, userID)
+		keys, err := s.permissionKeys(ctx, userID, categoryScopedRoute(obj))
 		if err != nil {
-			s.log.Error("failed to get permissions for user", zap.Error(err))
 			return false, err
 		}
 		for _, k := range keys {
-			parts := strings.SplitN(k, ":", 2)
-			if len(parts) != 2 {
-				continue
-			}
-			objPat := strings.TrimSpace(parts[0])
-			actPat := strings.TrimSpace(parts[1])
-			if objPat == "" || actPat == "" {
-				continue
-			}
-			if util.KeyMatch2(obj, objPat) && util.RegexMatch(act, actPat) {
+			if checkPermission("", k, obj, act).Matched {
 				return true, nil
 			}
 		}
@@ -125,7 +134,7 @@ func (s *RBACService) Enforce(ctx context.Context, userID uint, obj string, act
 	}
 
 	// Fallback to Casbin.
-	allowed, err := s.e.Enforce(fmt.Sprintf("%d", userID), obj, act)
+	allowed, err := s.e.Enforce(fmt.Sprintf("%d", userID), siteDomain(ctx), obj, act)
 	if err != nil {
 		s.log.Error("failed to enforce", zap.Error(err))
 		return false, err
@@ -133,36 +142,257 @@ func (s *RBACService) Enforce(ctx context.Context, userID uint, obj string, act
 	return allowed, nil
 }
 
+// Explain evaluates (obj, act) for userID the same way Enforce does, but evaluates every
+// permission the user holds and records why each one matched or not.
+func (s *RBACService) Explain(ctx context.Context, userID uint, obj string, act string) (*dto.RBACDecision, error) {
+	d := &dto.RBACDecision{
+		UserID:      userID,
+		Obj:         obj,
+		Act:         act,
+		Roles:       []string{},
+		Checks:      []dto.RBACPermissionCheck{},
+		EvaluatedAt: time.Now(),
+	}
+
+	if s.db != nil {
+		d.Source = dto.RBACSourceDB
+		direct, all, err := s.effectiveRoles(ctx, userID, categoryScopedRoute(obj))
+		if err != nil {
+			s.log.Error("failed to resolve roles for user", zap.Error(err))
+			return nil, err
+		}
+		for i, r := range all {
+			if i < len(direct) {
+				d.Roles = append(d.Roles, r.Name)
+			} else {
+				d.InheritedRoles = append(d.InheritedRoles, r.Name)
+			}
+		}
+
+		grants, err := s.grantsForRoles(ctx, all)
+		if err != nil {
+			s.log.Error("failed to get permission grants for user", zap.Error(err))
+			return nil, err
+		}
+		for _, g := range grants {
+			chk := checkPermission(g.Role, g.Key, obj, act)
+			d.Checks = append(d.Checks, chk)
+			if chk.Matched && !d.Allowed {
+				d.Allowed = true
+				d.MatchedKey = chk.Key
+			}
+		}
+	} else {
+		d.Source = dto.RBACSourceCasbin
+		sub := fmt.Sprintf("%d", userID)
+		dom := siteDomain(ctx)
+		roles, err := s.e.GetRolesForUser(sub, dom)
+		if err != nil {
+			s.log.Error("failed to get roles for user", zap.Error(err))
+			return nil, err
+		}
+		d.Roles = append(d.Roles, roles...)
+
+		perms, err := s.e.PermissionsInDomain(sub, dom)
+		if err != nil {
+			s.log.Error("failed to get implicit permissions for user", zap.Error(err))
+			return nil, err
+		}
+		for _, p := range perms {
+			// p = [sub dom obj act]
+			if len(p) < 4 {
+				continue
+			}
+			chk := checkPermission(p[0], strings.TrimSpace(p[2])+":"+strings.TrimSpace(p[3]), obj, act)
+			d.Checks = append(d.Checks, chk)
+			if chk.Matched && d.MatchedKey == "" {
+				d.MatchedKey = chk.Key
+			}
+		}
+		// The enforcer is authoritative on this path (its matcher may differ from the DB semantics).
+		allowed, err := s.e.Enforce(sub, dom, obj, act)
+		if err != nil {
+			s.log.Error("failed to enforce", zap.Error(err))
+			return nil, err
+		}
+		d.Allowed = allowed
+	}
+
+	d.Reason = decisionReason(d)
+	return d, nil
+}
+
+// EnableDecisionLog turns on in-memory tracing of denied decisions (see TraceDenied), keeping at most max entries.
+func (s *RBACService) EnableDecisionLog(max int) {
+	s.decisions = newRBACDecisionLog(max)
+}
+
+// TraceDenied explains a denied request and stores the trace under a new decision id.
+// It returns "" when the decision log is disabled.
+func (s *RBACService) TraceDenied(ctx context.Context, userID uint, obj string, act string) (string, error) {
+	if s.decisions == nil {
+		return "", nil
+	}
+	d, err := s.Explain(ctx, userID, obj, act)
+	if err != nil {
+		return "", err
+	}
+	id, err := ids.New()
+	if err != nil {
+		return "", err
+	}
+	d.ID = id
+	s.decisions.put(*d)
+	return id, nil
+}
+
+// Decision returns a trace previously stored by TraceDenied.
+func (s *RBACService) Decision(id string) (*dto.RBACDecision, bool) {
+	if s.decisions == nil {
+		return nil, false
+	}
+	d, ok := s.decisions.get(strings.TrimSpace(id))
+	if !ok {
+		return nil, false
+	}
+	return &d, true
+}
+
+// reloadPolicy refreshes the enforcer after a write to the RBAC tables it reads (see rbac.Adapter).
+// Admin mutations are rare, so a full reload is simpler than mirroring validity windows and scopes.
+func (s *RBACService) reloadPolicy() error {
+	if err := s.e.LoadPolicy(); err != nil {
+		s.log.Error("failed to reload casbin policy", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// siteDomain is the Casbin domain of the request's site.
+func siteDomain(ctx context.Context) string {
+	return tenant.Domain(tenant.SiteID(ctx))
+}
+
+// checkPermission matches a stored "obj:act" key with keyMatch2 (obj) and regexMatch (act),
+// the same semantics as the Casbin matcher in configs/casbin_model.conf.
+func checkPermission(role, key, obj, act string) dto.RBACPermissionCheck {
+	chk := dto.RBACPermissionCheck{Role: role, Key: key}
+	parts := strings.SplitN(key, ":", 2)
+	if len(parts) != 2 {
+		chk.Reason = "malformed permission key (expected obj:act)"
+		return chk
+	}
+	chk.ObjPattern = strings.TrimSpace(parts[0])
+	chk.ActPattern = strings.TrimSpace(parts[1])
+	if chk.ObjPattern == "" || chk.ActPattern == "" {
+		chk.Reason = "empty obj or act pattern"
+		return chk
+	}
+	chk.ObjMatched = util.KeyMatch2(obj, chk.ObjPattern)
+	chk.ActMatched = util.RegexMatch(act, chk.ActPattern)
+	chk.Matched = chk.ObjMatched && chk.ActMatched
+	switch {
+	case chk.Matched:
+		chk.Reason = "matched"
+	case !chk.ObjMatched && !chk.ActMatched:
+		chk.Reason = "path and method do not match"
+	case !chk.ObjMatched:
+		chk.Reason = "path does not match"
+	default:
+		chk.Reason = "method does not match"
+	}
+	return chk
+}
+
+func decisionReason(d *dto.RBACDecision) string {
+	switch {
+	case d.Allowed && d.MatchedKey != "":
+		return "allowed by " + d.MatchedKey
+	case d.Allowed:
+		return "allowed by casbin policy"
+	case len(d.Roles) == 0:
+		return "user has no roles"
+	case len(d.Checks) == 0:
+		return "user roles grant no permissions"
+	default:
+		return "no permission matched path and method"
+	}
+}
+
 // Admin helpers
 func (s *RBACService) AssignRole(ctx context.Context, userID uint, role string) (bool, error) {
+	return s.GrantRole(ctx, userID, role, dto.RoleGrantOptions{})
+}
+
+// GrantRole assigns role to the user, optionally scoped to a category subtree and bounded to
+// [ValidFrom, ValidUntil). Each (role, category) pair is one grant; re-granting it replaces its window.
+// Scoped and time-bound grants require the database.
+func (s *RBACService) GrantRole(ctx context.Context, userID uint, role string, opts dto.RoleGrantOptions) (bool, error) {
 	role = strings.TrimSpace(role)
 	if role == "" {
 		return false, errors.New("role is required")
 	}
+	if opts.ValidUntil != nil {
+		if !opts.ValidUntil.After(time.Now()) {
+			return false, ErrInvalidRoleGrantWindow
+		}
+		if opts.ValidFrom != nil && !opts.ValidUntil.After(*opts.ValidFrom) {
+			return false, ErrInvalidRoleGrantWindow
+		}
+	}
+	if opts.CategoryID != nil && *opts.CategoryID == 0 {
+		return false, ErrCategoryNotFound
+	}
 
 	// Persist to RBAC tables (roles, user_roles) if DB is available.
 	if s.db != nil {
 		if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if opts.CategoryID != nil {
+				var n int64
+				if err := tx.Model(&model.CategoryModel{}).Where("id = ?", *opts.CategoryID).Count(&n).Error; err != nil {
+					return err
+				}
+				if n == 0 {
+					return ErrCategoryNotFound
+				}
+			}
 			r := model.Role{Name: role}
 			if err := tx.Where("name = ?", role).FirstOrCreate(&r).Error; err != nil {
 				s.log.Error("failed to create role", zap.Error(err))
 				return err
 			}
-			ur := model.UserRole{UserID: userID, RoleID: r.ID}
-			if err := tx.Where("user_id = ? AND role_id = ?", userID, r.ID).FirstOrCreate(&ur).Error; err != nil {
+			q := tx.Where("user_id = ? AND role_id = ?", userID, r.ID)
+			if opts.CategoryID != nil {
+				q = q.Where("category_id = ?", *opts.CategoryID)
+			} else {
+				q = q.Where("category_id IS NULL")
+			}
+			ur := model.UserRole{UserID: userID, RoleID: r.ID, CategoryID: opts.CategoryID, ValidFrom: opts.ValidFrom, ValidUntil: opts.ValidUntil}
+			if err := q.FirstOrCreate(&ur).Error; err != nil {
 				s.log.Error("failed to create user role", zap.Error(err))
 				return err
 			}
+			if err := tx.Model(&model.UserRole{}).Where("id = ?", ur.ID).Updates(map[string]interface{}{
+				"valid_from":  opts.ValidFrom,
+				"valid_until": opts.ValidUntil,
+			}).Error; err != nil {
+				s.log.Error("failed to update user role window", zap.Error(err))
+				return err
+			}
 			return nil
 		}); err != nil {
 			s.log.Error("failed to assign role", zap.Error(err))
 			return false, err
 		}
-		return true, nil
+		return true, s.reloadPolicy()
 	}
 
-	// Also persist to Casbin grouping policy for enforcement.
-	added, err := s.e.AddRoleForUser(fmt.Sprintf("%d", userID), role)
+	if opts.CategoryID != nil || opts.ValidFrom != nil || opts.ValidUntil != nil {
+		return false, errors.New("scoped or time-bound role grants require database")
+	}
+
+	// Without a DB handle, write through the enforcer (its adapter persists to the same tables).
+	added, err := s.e.AddRoleForUser(fmt.Sprintf("%d", userID), role, siteDomain(ctx))
 	if err != nil {
 		s.log.Error("failed to add role for user", zap.Error(err))
 		return false, err
@@ -211,6 +441,7 @@ func (s *RBACService) AddPermissionToRole(ctx context.Context, role, obj, act st
 	// Persist to RBAC tables (roles, permissions, role_permissions) if DB is available.
 	if s.db != nil {
 		key := obj + ":" + act
+		added := false
 		if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 			r := model.Role{Name: role}
 			if err := tx.Where("name = ?", role).FirstOrCreate(&r).Error; err != nil {
@@ -223,19 +454,23 @@ func (s *RBACService) AddPermissionToRole(ctx context.Context, role, obj, act st
 				return err
 			}
 			rp := model.RolePermission{RoleID: r.ID, PermissionID: p.ID}
-			if err := tx.Where("role_id = ? AND permission_id = ?", r.ID, p.ID).FirstOrCreate(&rp).Error; err != nil {
-				s.log.Error("failed to create role permission", zap.Error(err))
-				return err
+			res := tx.Where("role_id = ? AND permission_id = ?", r.ID, p.ID).FirstOrCreate(&rp)
+			if res.Error != nil {
+				s.log.Error("failed to create role permission", zap.Error(res.Error))
+				return res.Error
 			}
+			added = res.RowsAffected > 0
 			return nil
 		}); err != nil {
 			s.log.Error("failed to add permission to role", zap.Error(err))
 			return false, err
 		}
+		return added, s.reloadPolicy()
 	}
 
-	// Also persist to Casbin policy for enforcement.
-	added, err := s.e.AddPolicy(role, obj, act)
+	// Without a DB handle, write through the enforcer (its adapter persists to the same tables).
+	// Roles and their permissions are shared by all sites; only user grants are per site.
+	added, err := s.e.AddPolicy(role, rbac.AnyDomain, obj, act)
 	if err != nil {
 		s.log.Error("failed to add policy", zap.Error(err))
 		return false, err
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/domain/entities"
+	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/internal/rbac"
+	"github.com/turahe/go-restfull/internal/seeder"
+	"github.com/turahe/go-restfull/internal/service/dto"
+	"github.com/turahe/go-restfull/internal/testutil"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func openRBACServiceTestDB(t *testing.T) (*RBACService, *gorm.DB) {
+	t.Helper()
+	dsn := "file:" + url.QueryEscape(t.Name()) + "?mode=memory&cache=shared"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{
+		Logger: logger.Default.LogMode(testutil.GormLogLevelFromEnv()),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&model.Role{}, &model.Permission{}, &model.UserRole{}, &model.RolePermission{}, &model.RoleInheritance{}))
+	e, err := rbac.NewEnforcer(db, "../../configs/casbin_model.conf")
+	require.NoError(t, err)
+	return NewRBACService(e, db, zap.NewNop()), db
+}
+
+func TestRBACService_RoleInheritance(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := openRBACServiceTestDB(t)
+
+	_, err := svc.AddPermissionToRole(ctx, "author", "/api/v1/posts", "POST")
+	require.NoError(t, err)
+	_, err = svc.AddPermissionToRole(ctx, "editor", "/api/v1/posts/*", "PUT")
+	require.NoError(t, err)
+	_, err = svc.AddPermissionToRole(ctx, "chief", "/api/v1/posts/*", "DELETE")
+	require.NoError(t, err)
+	_, err = svc.AssignRole(ctx, 1, "chief")
+	require.NoError(t, err)
+
+	_, err = svc.AddRoleInheritance(ctx, "chief", "editor")
+	require.NoError(t, err)
+	_, err = svc.AddRoleInheritance(ctx, "editor", "author")
+	require.NoError(t, err)
+
+	ok, err := svc.Enforce(ctx, 1, "/api/v1/posts", "POST")
+	require.NoError(t, err)
+	assert.True(t, ok, "permission should be inherited transitively")
+
+	roles, err := svc.RolesForUser(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"chief"}, roles)
+
+	d, err := svc.Explain(ctx, 1, "/api/v1/posts", "POST")
+	require.NoError(t, err)
+	assert.True(t, d.Allowed)
+	assert.Equal(t, []string{"editor", "author"}, d.InheritedRoles)
+
+	_, err = svc.AddRoleInheritance(ctx, "author", "chief")
+	assert.ErrorIs(t, err, ErrRoleInheritanceCycle)
+	_, err = svc.AddRoleInheritance(ctx, "author", "author")
+	assert.ErrorIs(t, err, ErrRoleInheritanceCycle)
+	_, err = svc.AddRoleInheritance(ctx, "author", "missing")
+	assert.ErrorIs(t, err, ErrRoleNotFound)
+
+	removed, err := svc.RemoveRoleInheritance(ctx, "editor", "author")
+	require.NoError(t, err)
+	assert.True(t, removed)
+	ok, err = svc.Enforce(ctx, 1, "/api/v1/posts", "POST")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestRBACService_TimeBoundGrants(t *testing.T) {
+	ctx := context.Background()
+	svc, db := openRBACServiceTestDB(t)
+
+	_, err := svc.AddPermissionToRole(ctx, "reviewer", "/api/v1/posts/*", "PUT")
+	require.NoError(t, err)
+
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	_, err = svc.GrantRole(ctx, 1, "reviewer", dto.RoleGrantOptions{ValidUntil: &past})
+	assert.ErrorIs(t, err, ErrInvalidRoleGrantWindow)
+	_, err = svc.GrantRole(ctx, 1, "reviewer", dto.RoleGrantOptions{ValidFrom: &future, ValidUntil: &future})
+	assert.ErrorIs(t, err, ErrInvalidRoleGrantWindow)
+
+	// Not yet valid.
+	_, err = svc.GrantRole(ctx, 1, "reviewer", dto.RoleGrantOptions{ValidFrom: &future})
+	require.NoError(t, err)
+	ok, err := svc.Enforce(ctx, 1, "/api/v1/posts/*", "PUT")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// Re-assigning replaces the window.
+	_, err = svc.GrantRole(ctx, 1, "reviewer", dto.RoleGrantOptions{ValidFrom: &past, ValidUntil: &future})
+	require.NoError(t, err)
+	ok, err = svc.Enforce(ctx, 1, "/api/v1/posts/*", "PUT")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// Expire the grant in place, then sweep it.
+	require.NoError(t, db.Model(&model.UserRole{}).Where("user_id = ?", 1).Update("valid_until", past).Error)
+	ok, err = svc.Enforce(ctx, 1, "/api/v1/posts/*", "PUT")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	n, err := svc.SweepExpiredRoleGrants(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	var count int64
+	require.NoError(t, db.Model(&model.UserRole{}).Count(&count).Error)
+	assert.Zero(t, count)
+}
+
+// TestSeedDefaultRBAC_Routes enforces the seeded policy against route templates as the RBAC
+// middleware sees them (c.FullPath()), where keyMatch2 only treats "/*" as a wildcard.
+func TestSeedDefaultRBAC_Routes(t *testing.T) {
+	ctx := context.Background()
+	svc, db := openRBACServiceTestDB(t)
+	require.NoError(t, seeder.SeedDefaultRBAC(ctx, db, svc.e))
+	const user, support = uint(1), uint(2)
+	_, err := svc.AssignRole(ctx, user, entities.RoleUser)
+	require.NoError(t, err)
+	_, err = svc.AssignRole(ctx, support, entities.RoleSupport)
+	require.NoError(t, err)
+
+	allowed := []struct {
+		user     uint
+		obj, act string
+	}{
+		{user, "/api/v1/reading-lists", "GET"},
+		{user, "/api/v1/reading-lists", "POST"},
+		{user, "/api/v1/reading-lists/:id", "GET"},
+		{user, "/api/v1/reading-lists/:id", "PUT"},
+		{user, "/api/v1/reading-lists/:id", "DELETE"},
+		{user, "/api/v1/reading-lists/:id/items", "POST"},
+		{user, "/api/v1/reading-lists/:id/items", "PUT"},
+		{user, "/api/v1/reading-lists/:id/items/:postId", "PUT"},
+		{user, "/api/v1/reading-lists/:id/items/:postId", "DELETE"},
+		{user, "/api/v1/posts/:id/revisions", "GET"},
+		{user, "/api/v1/posts/:id/revisions/:rid/diff", "GET"},
+		{user, "/api/v1/posts/:id/revisions/:rid/restore", "POST"},
+		{user, "/api/v1/posts/:id/preview-links", "GET"},
+		{user, "/api/v1/posts/:id/preview-links", "POST"},
+		{user, "/api/v1/posts/:id/preview-links/:lid", "DELETE"},
+		{support, "/api/v1/trash", "GET"},
+		{support, "/api/v1/trash/:type/:id/restore", "POST"},
+		{support, "/api/v1/trash/:type/:id", "DELETE"},
+		{user, "/api/v1/series", "POST"},
+		{user, "/api/v1/series/:id", "PUT"},
+		{user, "/api/v1/series/:id", "DELETE"},
+		{user, "/api/v1/series/:id/posts", "GET"},
+		{user, "/api/v1/series/:id/posts", "POST"},
+		{user, "/api/v1/series/:id/posts", "PUT"},
+		{user, "/api/v1/series/:id/posts/:postId", "DELETE"},
+		{support, "/api/v1/series", "POST"},
+		{support, "/api/v1/series/:id", "PUT"},
+		{support, "/api/v1/series/:id", "DELETE"},
+		{support, "/api/v1/series/:id/posts", "GET"},
+		{support, "/api/v1/series/:id/posts", "POST"},
+		{support, "/api/v1/series/:id/posts", "PUT"},
+		{support, "/api/v1/series/:id/posts/:postId", "DELETE"},
+		{support, "/api/v1/redirects", "GET"},
+		{support, "/api/v1/redirects", "POST"},
+		{support, "/api/v1/redirects/:id", "PUT"},
+		{support, "/api/v1/redirects/:id", "DELETE"},
+	}
+	for _, a := range allowed {
+		ok, err := svc.Enforce(ctx, a.user, a.obj, a.act)
+		require.NoError(t, err)
+		assert.True(t, ok, "%d %s %s", a.user, a.act, a.obj)
+	}
+	ok, err := svc.Enforce(ctx, user, "/api/v1/rbac/assign-role", "POST")
+	require.NoError(t, err)
+	assert.False(t, ok, "users stay out of RBAC administration")
+}
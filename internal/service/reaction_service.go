@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/internal/repository"
+	"github.com/turahe/go-restfull/internal/service/dto"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// SettingReactionTypes is the settings key listing the reaction types readers may use, comma
+// separated, e.g. "like,love,insightful". Without it defaultReactionTypes apply.
+const SettingReactionTypes = "reactionTypes"
+
+var defaultReactionTypes = []string{"like", "love", "laugh", "insightful", "sad"}
+
+var reactionTypePattern = regexp.MustCompile(`^[a-z0-9_-]{1,32}$`)
+
+var ErrInvalidReactionType = errors.New("invalid reaction type")
+
+// ReactionService records readers' reactions to live posts and their comments.
+type ReactionService struct {
+	posts    *repository.PostRepository
+	comments *repository.CommentRepository
+	settings *repository.SettingRepository
+	log      *zap.Logger
+}
+
+// NewReactionService wires the reaction service; settings may be nil, leaving the default types.
+func NewReactionService(posts *repository.PostRepository, comments *repository.CommentRepository, settings *repository.SettingRepository, log *zap.Logger) *ReactionService {
+	return &ReactionService{posts: posts, comments: comments, settings: settings, log: log}
+}
+
+// Types returns the reaction types of the current site, in configured order. Malformed entries of the
+// setting are skipped; when none remain the defaults apply.
+func (s *ReactionService) Types(ctx context.Context) []string {
+	raw := settingString(ctx, s.settings, SettingReactionTypes, "")
+	var out []string
+	seen := map[string]bool{}
+	for _, t := range strings.Split(raw, ",") {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if !reactionTypePattern.MatchString(t) || seen[t] {
+			continue
+		}
+		seen[t] = true
+		out = append(out, t)
+	}
+	if len(out) == 0 {
+		return defaultReactionTypes
+	}
+	return out
+}
+
+// checkType validates typ. Adding requires a configured type; removing only a well-formed one, so
+// reactions of a retired type can still be taken back.
+func (s *ReactionService) checkType(ctx context.Context, typ string, on bool) error {
+	if !reactionTypePattern.MatchString(typ) {
+		return ErrInvalidReactionType
+	}
+	if !on {
+		return nil
+	}
+	for _, t := range s.Types(ctx) {
+		if t == typ {
+			return nil
+		}
+	}
+	return ErrInvalidReactionType
+}
+
+// livePost returns post id when it is publicly visible; ErrPostNotFound otherwise.
+func (s *ReactionService) livePost(ctx context.Context, id uint) (*model.Post, error) {
+	p, err := s.posts.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPostNotFound
+		}
+		return nil, err
+	}
+	if !postLive(p, time.Now()) {
+		return nil, ErrPostNotFound
+	}
+	return p, nil
+}
+
+// SetPostReaction adds (on) or removes userID's reaction typ to a live post. Both are idempotent.
+func (s *ReactionService) SetPostReaction(ctx context.Context, postID, userID uint, typ string, on bool) (*dto.Reactions, error) {
+	if err := s.checkType(ctx, typ, on); err != nil {
+		return nil, err
+	}
+	if _, err := s.livePost(ctx, postID); err != nil {
+		return nil, err
+	}
+	counts, err := s.posts.SetReaction(ctx, postID, userID, typ, on)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPostNotFound
+		}
+		return nil, err
+	}
+	mine, err := s.posts.ViewerReactions(ctx, []uint{postID}, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.Reactions{Counts: counts, Mine: reactionList(mine[postID])}, nil
+}
+
+// SetCommentReaction adds (on) or removes userID's reaction typ to a comment of a live post.
+func (s *ReactionService) SetCommentReaction(ctx context.Context, postID, commentID, userID uint, typ string, on bool) (*dto.Reactions, error) {
+	if err := s.checkType(ctx, typ, on); err != nil {
+		return nil, err
+	}
+	if _, err := s.livePost(ctx, postID); err != nil {
+		return nil, err
+	}
+	if _, err := s.comments.GetByIDInPost(ctx, postID, commentID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCommentNotFound
+		}
+		return nil, err
+	}
+	counts, err := s.comments.SetReaction(ctx, commentID, userID, typ, on)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCommentNotFound
+		}
+		return nil, err
+	}
+	mine, err := s.comments.ViewerReactions(ctx, []uint{commentID}, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.Reactions{Counts: counts, Mine: reactionList(mine[commentID])}, nil
+}
+
+// reactionList keeps an empty list from encoding as null.
+func reactionList(types []string) []string {
+	if types == nil {
+		return []string{}
+	}
+	return types
+}
+
+// attachViewerReactions fills MyReactions of rows for a signed-in viewer. Failures only leave them
+// empty, so a list never fails over them.
+func (s *PostService) attachViewerReactions(ctx context.Context, rows []model.Post, viewerID uint) {
+	if viewerID == 0 || len(rows) == 0 {
+		return
+	}
+	ids := make([]uint, len(rows))
+	for i := range rows {
+		ids[i] = rows[i].ID
+	}
+	mine, err := s.posts.ViewerReactions(ctx, ids, viewerID)
+	if err != nil {
+		s.log.Warn("failed to load viewer reactions", zap.Error(err))
+		return
+	}
+	for i := range rows {
+		rows[i].MyReactions = mine[rows[i].ID]
+	}
+}
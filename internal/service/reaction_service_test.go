@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/handler/request"
+	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReactions(t *testing.T) {
+	ctx := context.Background()
+	env := newPostTestEnv(t)
+	db, log, catRepo, settingRepo, postRepo, posts := env.db, env.log, env.categories, env.settings, env.postRepo, env.posts
+	commentRepo := repository.NewCommentRepository(db, log)
+	comments := NewCommentService(commentRepo, nil, log)
+	reactions := NewReactionService(postRepo, commentRepo, settingRepo, log)
+
+	ada := model.User{Name: "Ada", Email: "ada@react.test", Password: "x"}
+	bob := model.User{Name: "Bob", Email: "bob@react.test", Password: "x"}
+	require.NoError(t, db.Create(&ada).Error)
+	require.NoError(t, db.Create(&bob).Error)
+	cat, err := catRepo.CreateRoot(ctx, "News", ada.ID)
+	require.NoError(t, err)
+	past := time.Now().Add(-time.Hour)
+	live, err := posts.Create(ctx, ada.ID, request.CreatePostRequest{Title: "Live", Content: "x", CategoryID: cat.ID, PublishAt: &past})
+	require.NoError(t, err)
+	draft, err := posts.Create(ctx, ada.ID, request.CreatePostRequest{Title: "Draft", Content: "x", CategoryID: cat.ID, Status: "draft"})
+	require.NoError(t, err)
+
+	t.Run("post reactions are counted once per user and type", func(t *testing.T) {
+		out, err := reactions.SetPostReaction(ctx, live.ID, ada.ID, "like", true)
+		require.NoError(t, err)
+		assert.Equal(t, model.ReactionCounts{"like": 1}, out.Counts)
+		assert.Equal(t, []string{"like"}, out.Mine)
+
+		_, err = reactions.SetPostReaction(ctx, live.ID, ada.ID, "like", true) // idempotent
+		require.NoError(t, err)
+		_, err = reactions.SetPostReaction(ctx, live.ID, ada.ID, "love", true)
+		require.NoError(t, err)
+		out, err = reactions.SetPostReaction(ctx, live.ID, bob.ID, "like", true)
+		require.NoError(t, err)
+		assert.Equal(t, model.ReactionCounts{"like": 2, "love": 1}, out.Counts)
+		assert.Equal(t, []string{"like"}, out.Mine)
+
+		out, err = reactions.SetPostReaction(ctx, live.ID, bob.ID, "like", false)
+		require.NoError(t, err)
+		assert.Equal(t, model.ReactionCounts{"like": 1, "love": 1}, out.Counts)
+		assert.Equal(t, []string{}, out.Mine)
+		_, err = reactions.SetPostReaction(ctx, live.ID, bob.ID, "like", false) // idempotent
+		require.NoError(t, err)
+
+		var stored model.Post
+		require.NoError(t, db.First(&stored, live.ID).Error)
+		assert.Equal(t, model.ReactionCounts{"like": 1, "love": 1}, stored.Reactions)
+		assert.Equal(t, live.UpdatedAt.Unix(), stored.UpdatedAt.Unix(), "reactions are not edits")
+	})
+
+	t.Run("unknown types and unpublished posts are rejected", func(t *testing.T) {
+		_, err := reactions.SetPostReaction(ctx, live.ID, ada.ID, "angry", true)
+		assert.ErrorIs(t, err, ErrInvalidReactionType)
+		_, err = reactions.SetPostReaction(ctx, live.ID, ada.ID, "Not a type!", false)
+		assert.ErrorIs(t, err, ErrInvalidReactionType)
+		_, err = reactions.SetPostReaction(ctx, draft.ID, ada.ID, "like", true)
+		assert.ErrorIs(t, err, ErrPostNotFound)
+		_, err = reactions.SetPostReaction(ctx, 9999, ada.ID, "like", true)
+		assert.ErrorIs(t, err, ErrPostNotFound)
+	})
+
+	t.Run("types come from the site setting", func(t *testing.T) {
+		assert.Equal(t, defaultReactionTypes, reactions.Types(ctx))
+		require.NoError(t, settingRepo.Upsert(ctx, SettingReactionTypes, " Clap, like, clap, bad type ", true))
+		assert.Equal(t, []string{"clap", "like"}, reactions.Types(ctx))
+		_, err := reactions.SetPostReaction(ctx, live.ID, bob.ID, "clap", true)
+		require.NoError(t, err)
+		// A retired type can still be taken back.
+		out, err := reactions.SetPostReaction(ctx, live.ID, ada.ID, "love", false)
+		require.NoError(t, err)
+		assert.Equal(t, model.ReactionCounts{"like": 1, "clap": 1}, out.Counts)
+		_, err = reactions.SetPostReaction(ctx, live.ID, ada.ID, "love", true)
+		assert.ErrorIs(t, err, ErrInvalidReactionType)
+	})
+
+	t.Run("lists carry totals and the viewer's reactions", func(t *testing.T) {
+		page, err := posts.List(ctx, request.PostListRequest{ViewerID: ada.ID})
+		require.NoError(t, err)
+		rows := page.Items.([]model.Post)
+		require.Len(t, rows, 1)
+		assert.Equal(t, model.ReactionCounts{"like": 1, "clap": 1}, rows[0].Reactions)
+		assert.Equal(t, []string{"like"}, rows[0].MyReactions)
+
+		page, err = posts.List(ctx, request.PostListRequest{})
+		require.NoError(t, err)
+		assert.Nil(t, page.Items.([]model.Post)[0].MyReactions)
+	})
+
+	t.Run("comment reactions show in the tree", func(t *testing.T) {
+		root, err := comments.CreateRoot(ctx, live.ID, ada.ID, request.CreateCommentRequest{Content: "First"})
+		require.NoError(t, err)
+		reply, err := comments.CreateChild(ctx, live.ID, root.ID, bob.ID, request.CreateCommentRequest{Content: "Reply"})
+		require.NoError(t, err)
+
+		_, err = reactions.SetCommentReaction(ctx, live.ID, reply.ID, ada.ID, "like", true)
+		require.NoError(t, err)
+		out, err := reactions.SetCommentReaction(ctx, live.ID, reply.ID, bob.ID, "clap", true)
+		require.NoError(t, err)
+		assert.Equal(t, model.ReactionCounts{"like": 1, "clap": 1}, out.Counts)
+
+		_, err = reactions.SetCommentReaction(ctx, draft.ID, reply.ID, ada.ID, "like", true)
+		assert.ErrorIs(t, err, ErrPostNotFound)
+		_, err = reactions.SetCommentReaction(ctx, live.ID, 9999, ada.ID, "like", true)
+		assert.ErrorIs(t, err, ErrCommentNotFound)
+
+		tree, err := comments.GetTree(ctx, live.ID, ada.ID)
+		require.NoError(t, err)
+		require.Len(t, tree, 1)
+		assert.Empty(t, tree[0].Reactions)
+		require.Len(t, tree[0].Children, 1)
+		node := tree[0].Children[0]
+		assert.Equal(t, model.ReactionCounts{"like": 1, "clap": 1}, node.Reactions)
+		assert.Equal(t, []string{"like"}, node.MyReactions)
+
+		sub, err := comments.GetSubtree(ctx, live.ID, reply.ID, 0)
+		require.NoError(t, err)
+		assert.Nil(t, sub[0].MyReactions)
+		assert.Equal(t, int64(1), sub[0].Reactions["clap"])
+	})
+}
@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/handler/request"
+	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/internal/repository"
+	"github.com/turahe/go-restfull/internal/service/dto"
+	"github.com/turahe/go-restfull/pkg/ids"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// maxReadingListItems caps one list, so it can always be reordered in one request.
+const maxReadingListItems = 1000
+
+var (
+	ErrReadingListNotFound    = errors.New("reading list not found")
+	ErrReadingListExists      = repository.ErrReadingListExists
+	ErrReadingListFull        = errors.New("reading list is full")
+	ErrPostNotInReadingList   = errors.New("post is not in this reading list")
+	ErrReadingListSetMismatch = repository.ErrReadingListSetMismatch
+)
+
+// ReadingListService manages users' reading lists. Lists are visible to their owner only, unless
+// shared, and only the owner changes them.
+type ReadingListService struct {
+	posts *PostService
+	lists *repository.ReadingListRepository
+	log   *zap.Logger
+}
+
+func NewReadingListService(posts *PostService, lists *repository.ReadingListRepository, log *zap.Logger) *ReadingListService {
+	return &ReadingListService{posts: posts, lists: lists, log: log}
+}
+
+// List returns the user's lists by name, with their entry counts.
+func (s *ReadingListService) List(ctx context.Context, userID uint) ([]model.ReadingList, error) {
+	return s.lists.ListByUser(ctx, userID)
+}
+
+func (s *ReadingListService) Create(ctx context.Context, userID uint, req request.CreateReadingListRequest) (*model.ReadingList, error) {
+	l := &model.ReadingList{UserID: userID, Name: strings.TrimSpace(req.Name)}
+	if l.Name == "" {
+		return nil, ErrInvalidPayload
+	}
+	if req.Shared {
+		if err := shareReadingList(l); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.lists.Create(ctx, l); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Update renames the list and shares or unshares it. Sharing again after unsharing issues a new slug,
+// so links given out before stop working.
+func (s *ReadingListService) Update(ctx context.Context, id, userID uint, req request.UpdateReadingListRequest) (*model.ReadingList, error) {
+	l, err := s.findOwned(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if n := strings.TrimSpace(req.Name); n != "" {
+		l.Name = n
+	}
+	if req.Shared != nil {
+		switch {
+		case !*req.Shared:
+			l.ShareSlug = nil
+		case l.ShareSlug == nil:
+			if err := shareReadingList(l); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := s.lists.Update(ctx, l); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Delete removes the list and its entries; the posts stay.
+func (s *ReadingListService) Delete(ctx context.Context, id, userID uint) error {
+	if _, err := s.findOwned(ctx, id, userID); err != nil {
+		return err
+	}
+	return s.lists.DeleteByID(ctx, id)
+}
+
+// Get returns the owner's list with every entry in order. Entries whose post is no longer live keep
+// their place but carry no post until it is live again.
+func (s *ReadingListService) Get(ctx context.Context, id, userID uint) (*dto.ReadingListView, error) {
+	l, err := s.findOwned(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.view(ctx, l)
+}
+
+// Shared returns a shared list by its share slug, read-only, with the entries whose post is live.
+// Read markers are the owner's business and are left out.
+func (s *ReadingListService) Shared(ctx context.Context, slug string) (*dto.ReadingListView, error) {
+	slug = strings.TrimSpace(slug)
+	if slug == "" {
+		return nil, ErrReadingListNotFound
+	}
+	l, err := s.lists.FindByShareSlug(ctx, slug)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrReadingListNotFound
+		}
+		return nil, err
+	}
+	out, err := s.view(ctx, l)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]model.ReadingListItem, 0, len(out.Items))
+	for _, it := range out.Items {
+		if it.Post != nil {
+			it.ReadAt = nil
+			items = append(items, it)
+		}
+	}
+	out.Items = items
+	return out, nil
+}
+
+// view loads the list's entries, dropping the post of entries that are not live.
+func (s *ReadingListService) view(ctx context.Context, l *model.ReadingList) (*dto.ReadingListView, error) {
+	items, err := s.lists.Items(ctx, l.ID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for i := range items {
+		if items[i].Post == nil || !postLive(items[i].Post, now) {
+			items[i].Post = nil
+			continue
+		}
+		s.posts.refreshRender(ctx, items[i].Post)
+	}
+	return &dto.ReadingListView{List: l, Items: items}, nil
+}
+
+// AddItem saves a live post to the list at req.Position (appending by default), or moves it there
+// when it is already in the list.
+func (s *ReadingListService) AddItem(ctx context.Context, id, userID uint, req request.AddReadingListItemRequest) (*dto.ReadingListView, error) {
+	l, err := s.findOwned(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	p, err := s.posts.posts.FindByID(ctx, req.PostID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPostNotFound
+		}
+		return nil, err
+	}
+	if !postLive(p, time.Now()) {
+		return nil, ErrPostNotFound
+	}
+	n, err := s.lists.CountItems(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if n >= maxReadingListItems {
+		return nil, ErrReadingListFull
+	}
+	if err := s.lists.AddItem(ctx, id, req.PostID, req.Position); err != nil {
+		return nil, err
+	}
+	return s.view(ctx, l)
+}
+
+func (s *ReadingListService) RemoveItem(ctx context.Context, id, postID, userID uint) error {
+	if _, err := s.findOwned(ctx, id, userID); err != nil {
+		return err
+	}
+	if err := s.lists.RemoveItem(ctx, id, postID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrPostNotInReadingList
+		}
+		return err
+	}
+	return nil
+}
+
+// Reorder sets the entry order to req.PostIDs, which must list every post in the list once.
+func (s *ReadingListService) Reorder(ctx context.Context, id, userID uint, req request.ReorderReadingListRequest) (*dto.ReadingListView, error) {
+	l, err := s.findOwned(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.lists.Reorder(ctx, id, req.PostIDs); err != nil {
+		return nil, err
+	}
+	return s.view(ctx, l)
+}
+
+// MarkRead sets or clears the read marker of a post in the list.
+func (s *ReadingListService) MarkRead(ctx context.Context, id, postID, userID uint, read bool) error {
+	if _, err := s.findOwned(ctx, id, userID); err != nil {
+		return err
+	}
+	var at *time.Time
+	if read {
+		now := time.Now()
+		at = &now
+	}
+	if err := s.lists.SetRead(ctx, id, postID, at); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrPostNotInReadingList
+		}
+		return err
+	}
+	return nil
+}
+
+// findOwned loads the list for its owner. Other users get ErrReadingListNotFound, so private lists do
+// not reveal that they exist.
+func (s *ReadingListService) findOwned(ctx context.Context, id, userID uint) (*model.ReadingList, error) {
+	l, err := s.lists.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrReadingListNotFound
+		}
+		return nil, err
+	}
+	if l.UserID != userID {
+		return nil, ErrReadingListNotFound
+	}
+	return l, nil
+}
+
+func shareReadingList(l *model.ReadingList) error {
+	slug, err := ids.New()
+	if err != nil {
+		return err
+	}
+	l.ShareSlug = &slug
+	return nil
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/handler/request"
+	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadingLists(t *testing.T) {
+	ctx := context.Background()
+	env := newPostTestEnv(t)
+	db, log, catRepo, posts := env.db, env.log, env.categories, env.posts
+	lists := NewReadingListService(posts, repository.NewReadingListRepository(db, log), log)
+
+	ada := model.User{Name: "Ada", Email: "ada@lists.test", Password: "x"}
+	bob := model.User{Name: "Bob", Email: "bob@lists.test", Password: "x"}
+	require.NoError(t, db.Create(&ada).Error)
+	require.NoError(t, db.Create(&bob).Error)
+	cat, err := catRepo.CreateRoot(ctx, "News", ada.ID)
+	require.NoError(t, err)
+	past := time.Now().Add(-time.Hour)
+	var live []*model.Post
+	for _, title := range []string{"One", "Two", "Three"} {
+		p, err := posts.Create(ctx, ada.ID, request.CreatePostRequest{Title: title, Content: "x", CategoryID: cat.ID, PublishAt: &past})
+		require.NoError(t, err)
+		live = append(live, p)
+	}
+	draft, err := posts.Create(ctx, ada.ID, request.CreatePostRequest{Title: "Draft", Content: "x", CategoryID: cat.ID, Status: "draft"})
+	require.NoError(t, err)
+
+	order := func(t *testing.T, id uint) []uint {
+		t.Helper()
+		v, err := lists.Get(ctx, id, ada.ID)
+		require.NoError(t, err)
+		out := make([]uint, 0, len(v.Items))
+		for i, it := range v.Items {
+			assert.Equal(t, i+1, it.Position)
+			out = append(out, it.PostID)
+		}
+		return out
+	}
+
+	later, err := lists.Create(ctx, ada.ID, request.CreateReadingListRequest{Name: " Later "})
+	require.NoError(t, err)
+	assert.Equal(t, "Later", later.Name)
+	assert.Nil(t, later.ShareSlug)
+
+	t.Run("names are unique per owner", func(t *testing.T) {
+		_, err := lists.Create(ctx, ada.ID, request.CreateReadingListRequest{Name: "Later"})
+		assert.ErrorIs(t, err, ErrReadingListExists)
+		other, err := lists.Create(ctx, bob.ID, request.CreateReadingListRequest{Name: "Later"})
+		require.NoError(t, err)
+		require.NoError(t, lists.Delete(ctx, other.ID, bob.ID))
+	})
+
+	t.Run("adding appends, inserts and moves", func(t *testing.T) {
+		for _, p := range live[:2] {
+			_, err := lists.AddItem(ctx, later.ID, ada.ID, request.AddReadingListItemRequest{PostID: p.ID})
+			require.NoError(t, err)
+		}
+		v, err := lists.AddItem(ctx, later.ID, ada.ID, request.AddReadingListItemRequest{PostID: live[2].ID, Position: 1})
+		require.NoError(t, err)
+		require.Len(t, v.Items, 3)
+		require.NotNil(t, v.Items[0].Post)
+		assert.Equal(t, "Three", v.Items[0].Post.Title)
+		assert.Equal(t, []uint{live[2].ID, live[0].ID, live[1].ID}, order(t, later.ID))
+
+		_, err = lists.AddItem(ctx, later.ID, ada.ID, request.AddReadingListItemRequest{PostID: live[2].ID})
+		require.NoError(t, err)
+		assert.Equal(t, []uint{live[0].ID, live[1].ID, live[2].ID}, order(t, later.ID))
+
+		_, err = lists.AddItem(ctx, later.ID, ada.ID, request.AddReadingListItemRequest{PostID: draft.ID})
+		assert.ErrorIs(t, err, ErrPostNotFound)
+		_, err = lists.AddItem(ctx, later.ID, bob.ID, request.AddReadingListItemRequest{PostID: live[0].ID})
+		assert.ErrorIs(t, err, ErrReadingListNotFound)
+
+		all, err := lists.List(ctx, ada.ID)
+		require.NoError(t, err)
+		require.Len(t, all, 1)
+		assert.Equal(t, int64(3), all[0].ItemCount)
+	})
+
+	t.Run("reorder needs every post once", func(t *testing.T) {
+		_, err := lists.Reorder(ctx, later.ID, ada.ID, request.ReorderReadingListRequest{PostIDs: []uint{live[1].ID, live[0].ID}})
+		assert.ErrorIs(t, err, ErrReadingListSetMismatch)
+		_, err = lists.Reorder(ctx, later.ID, ada.ID, request.ReorderReadingListRequest{PostIDs: []uint{live[1].ID, live[1].ID, live[0].ID}})
+		assert.ErrorIs(t, err, ErrReadingListSetMismatch)
+		_, err = lists.Reorder(ctx, later.ID, ada.ID, request.ReorderReadingListRequest{PostIDs: []uint{live[1].ID, live[2].ID, live[0].ID}})
+		require.NoError(t, err)
+		assert.Equal(t, []uint{live[1].ID, live[2].ID, live[0].ID}, order(t, later.ID))
+	})
+
+	t.Run("read markers", func(t *testing.T) {
+		require.NoError(t, lists.MarkRead(ctx, later.ID, live[2].ID, ada.ID, true))
+		v, err := lists.Get(ctx, later.ID, ada.ID)
+		require.NoError(t, err)
+		assert.NotNil(t, v.Items[1].ReadAt)
+		assert.Nil(t, v.Items[0].ReadAt)
+
+		_, err = lists.AddItem(ctx, later.ID, ada.ID, request.AddReadingListItemRequest{PostID: live[2].ID, Position: 1})
+		require.NoError(t, err)
+		v, err = lists.Get(ctx, later.ID, ada.ID)
+		require.NoError(t, err)
+		assert.NotNil(t, v.Items[0].ReadAt, "moving keeps the marker")
+
+		assert.ErrorIs(t, lists.MarkRead(ctx, later.ID, draft.ID, ada.ID, true), ErrPostNotInReadingList)
+		assert.ErrorIs(t, lists.MarkRead(ctx, later.ID, live[2].ID, bob.ID, false), ErrReadingListNotFound)
+	})
+
+	t.Run("private lists filter posts for their owner only", func(t *testing.T) {
+		page, err := posts.List(ctx, request.PostListRequest{ReadingListID: &later.ID, ViewerID: ada.ID})
+		require.NoError(t, err)
+		assert.Len(t, page.Items.([]model.Post), 3)
+
+		page, err = posts.List(ctx, request.PostListRequest{ReadingListID: &later.ID, ViewerID: bob.ID})
+		require.NoError(t, err)
+		assert.Empty(t, page.Items.([]model.Post))
+		page, err = posts.List(ctx, request.PostListRequest{ReadingListID: &later.ID})
+		require.NoError(t, err)
+		assert.Empty(t, page.Items.([]model.Post))
+
+		_, err = lists.Get(ctx, later.ID, bob.ID)
+		assert.ErrorIs(t, err, ErrReadingListNotFound)
+	})
+
+	t.Run("sharing exposes the list read-only", func(t *testing.T) {
+		shared := true
+		l, err := lists.Update(ctx, later.ID, ada.ID, request.UpdateReadingListRequest{Shared: &shared})
+		require.NoError(t, err)
+		require.NotNil(t, l.ShareSlug)
+		slug := *l.ShareSlug
+
+		page, err := posts.List(ctx, request.PostListRequest{ReadingListID: &later.ID})
+		require.NoError(t, err)
+		assert.Len(t, page.Items.([]model.Post), 3)
+
+		require.NoError(t, db.Model(&model.Post{}).Where("id = ?", live[1].ID).Update("publish_at", time.Now().Add(time.Hour)).Error)
+		v, err := lists.Shared(ctx, slug)
+		require.NoError(t, err)
+		require.Len(t, v.Items, 2, "posts that are not live are hidden")
+		for _, it := range v.Items {
+			assert.Nil(t, it.ReadAt)
+		}
+		own, err := lists.Get(ctx, later.ID, ada.ID)
+		require.NoError(t, err)
+		require.Len(t, own.Items, 3, "the owner keeps every entry")
+		assert.Nil(t, own.Items[1].Post)
+
+		shared = false
+		_, err = lists.Update(ctx, later.ID, ada.ID, request.UpdateReadingListRequest{Shared: &shared})
+		require.NoError(t, err)
+		_, err = lists.Shared(ctx, slug)
+		assert.ErrorIs(t, err, ErrReadingListNotFound)
+		shared = true
+		l, err = lists.Update(ctx, later.ID, ada.ID, request.UpdateReadingListRequest{Shared: &shared})
+		require.NoError(t, err)
+		assert.NotEqual(t, slug, *l.ShareSlug, "sharing again issues a new slug")
+	})
+
+	t.Run("deleting a post removes its entries", func(t *testing.T) {
+		before := order(t, later.ID)
+		require.NoError(t, posts.Delete(ctx, before[0], ada.ID))
+		assert.Equal(t, before[1:], order(t, later.ID))
+
+		require.NoError(t, lists.RemoveItem(ctx, later.ID, before[1], ada.ID))
+		assert.Equal(t, before[2:], order(t, later.ID))
+		assert.ErrorIs(t, lists.RemoveItem(ctx, later.ID, before[1], ada.ID), ErrPostNotInReadingList)
+	})
+}
@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/handler/request"
+	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/internal/repository"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrRedirectNotFound = errors.New("redirect not found")
+	ErrRedirectExists   = errors.New("a redirect from this path already exists")
+	ErrInvalidRedirect  = errors.New("fromPath must be a site path and toPath a site path or http(s) URL, and they must differ")
+	ErrRedirectLoop     = errors.New("redirect would loop back to its own path")
+)
+
+// maxRedirectChain is how many redirects a loop check follows before giving up on a chain.
+const maxRedirectChain = 10
+
+// RedirectService manages arbitrary path-to-path redirects for the public site, which looks paths up
+// through Resolve. Slug changes of posts, categories and tags redirect on their own (see SlugMovedError).
+type RedirectService struct {
+	redirects *repository.RedirectRepository
+	log       *zap.Logger
+}
+
+func NewRedirectService(redirects *repository.RedirectRepository, log *zap.Logger) *RedirectService {
+	return &RedirectService{redirects: redirects, log: log}
+}
+
+func (s *RedirectService) List(ctx context.Context, req request.RedirectListRequest) (repository.CursorPage, error) {
+	page, err := s.redirects.List(ctx, req)
+	if err != nil {
+		s.log.Error("failed to list redirects", zap.Error(err))
+		return repository.CursorPage{}, err
+	}
+	return page, nil
+}
+
+func (s *RedirectService) Create(ctx context.Context, actorUserID uint, req request.CreateRedirectRequest) (*model.Redirect, error) {
+	rd := &model.Redirect{StatusCode: http.StatusMovedPermanently, CreatedBy: actorUserID, UpdatedBy: actorUserID}
+	if err := s.apply(ctx, rd, req.FromPath, req.ToPath, req.StatusCode); err != nil {
+		return nil, err
+	}
+	if err := s.redirects.Create(ctx, rd); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrRedirectExists
+		}
+		return nil, err
+	}
+	return rd, nil
+}
+
+// Update changes the fields given; the hit count is kept.
+func (s *RedirectService) Update(ctx context.Context, id, actorUserID uint, req request.UpdateRedirectRequest) (*model.Redirect, error) {
+	rd, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.apply(ctx, rd, req.FromPath, req.ToPath, req.StatusCode); err != nil {
+		return nil, err
+	}
+	rd.UpdatedBy = actorUserID
+	if err := s.redirects.Update(ctx, rd); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrRedirectExists
+		}
+		return nil, err
+	}
+	return rd, nil
+}
+
+func (s *RedirectService) Delete(ctx context.Context, id uint) error {
+	if err := s.redirects.DeleteByID(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrRedirectNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// Resolve returns the redirect for path (its query and trailing slash ignored) and counts the hit.
+func (s *RedirectService) Resolve(ctx context.Context, path string) (*model.Redirect, error) {
+	from, ok := normalizeRedirectPath(path)
+	if !ok {
+		return nil, ErrInvalidRedirect
+	}
+	rd, err := s.redirects.FindByFromPath(ctx, from)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRedirectNotFound
+		}
+		return nil, err
+	}
+	now := time.Now()
+	if err := s.redirects.Hit(ctx, rd.ID, now); err != nil {
+		return nil, err
+	}
+	rd.Hits++
+	rd.LastHitAt = &now
+	return rd, nil
+}
+
+// apply validates and sets the non-empty fields on rd, rejecting a from-path taken by another
+// redirect and a target whose chain of redirects leads back to the from-path.
+func (s *RedirectService) apply(ctx context.Context, rd *model.Redirect, fromPath, toPath string, status int) error {
+	if fromPath != "" {
+		from, ok := normalizeRedirectPath(fromPath)
+		if !ok {
+			return ErrInvalidRedirect
+		}
+		rd.FromPath = from
+	}
+	if toPath != "" {
+		to, ok := normalizeRedirectTarget(toPath)
+		if !ok {
+			return ErrInvalidRedirect
+		}
+		rd.ToPath = to
+	}
+	if status != 0 {
+		rd.StatusCode = status
+	}
+	if rd.FromPath == "" || rd.ToPath == "" || rd.FromPath == rd.ToPath {
+		return ErrInvalidRedirect
+	}
+
+	other, err := s.redirects.FindByFromPath(ctx, rd.FromPath)
+	switch {
+	case err == nil && other.ID != rd.ID:
+		return ErrRedirectExists
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound):
+		return err
+	}
+	next := rd.ToPath
+	for range maxRedirectChain {
+		path, ok := normalizeRedirectPath(next)
+		if !ok {
+			return nil // an absolute URL leaves the site
+		}
+		if path == rd.FromPath {
+			return ErrRedirectLoop
+		}
+		hop, err := s.redirects.FindByFromPath(ctx, path)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if hop.ID == rd.ID {
+			return nil
+		}
+		next = hop.ToPath
+	}
+	return nil
+}
+
+func (s *RedirectService) find(ctx context.Context, id uint) (*model.Redirect, error) {
+	rd, err := s.redirects.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRedirectNotFound
+		}
+		return nil, err
+	}
+	return rd, nil
+}
+
+// normalizeRedirectPath reduces p to the form redirects are stored and looked up by: an absolute site
+// path without query, fragment or trailing slash ("/Old/page/?x=1" -> "/Old/page").
+func normalizeRedirectPath(p string) (string, bool) {
+	p = strings.TrimSpace(p)
+	if i := strings.IndexAny(p, "?#"); i >= 0 {
+		p = p[:i]
+	}
+	if !strings.HasPrefix(p, "/") || strings.HasPrefix(p, "//") {
+		return "", false
+	}
+	if len(p) > 1 {
+		p = strings.TrimRight(p, "/")
+	}
+	return p, p != ""
+}
+
+// normalizeRedirectTarget accepts a site path (kept with its query) or an absolute http(s) URL.
+func normalizeRedirectTarget(t string) (string, bool) {
+	t = strings.TrimSpace(t)
+	if strings.HasPrefix(t, "/") {
+		if strings.HasPrefix(t, "//") {
+			return "", false
+		}
+		path, rest, _ := strings.Cut(t, "?")
+		if len(path) > 1 {
+			path = strings.TrimRight(path, "/")
+		}
+		if rest != "" {
+			return path + "?" + rest, true
+		}
+		return path, true
+	}
+	u, err := url.Parse(t)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", false
+	}
+	return t, true
+}
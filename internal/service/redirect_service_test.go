@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/turahe/go-restfull/internal/handler/request"
+	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRedirectService(t *testing.T) {
+	ctx := context.Background()
+	_, db := openRBACServiceTestDB(t)
+	require.NoError(t, db.AutoMigrate(&model.Redirect{}))
+	svc := NewRedirectService(repository.NewRedirectRepository(db, zap.NewNop()), zap.NewNop())
+
+	about, err := svc.Create(ctx, 1, request.CreateRedirectRequest{FromPath: " /about-us/ ", ToPath: "/about"})
+	require.NoError(t, err)
+	assert.Equal(t, "/about-us", about.FromPath)
+	assert.Equal(t, 301, about.StatusCode)
+
+	for _, req := range []request.CreateRedirectRequest{
+		{FromPath: "about", ToPath: "/x"},
+		{FromPath: "//evil.example/x", ToPath: "/x"},
+		{FromPath: "/x", ToPath: "ftp://files.example/x"},
+		{FromPath: "/x", ToPath: "/x/"},
+	} {
+		_, err := svc.Create(ctx, 1, req)
+		assert.ErrorIs(t, err, ErrInvalidRedirect, "%+v", req)
+	}
+	_, err = svc.Create(ctx, 1, request.CreateRedirectRequest{FromPath: "/about-us?ref=1", ToPath: "/elsewhere"})
+	assert.ErrorIs(t, err, ErrRedirectExists)
+
+	// /about -> /team -> /about-us -> /about would loop.
+	_, err = svc.Create(ctx, 1, request.CreateRedirectRequest{FromPath: "/team", ToPath: "/about-us"})
+	require.NoError(t, err)
+	_, err = svc.Create(ctx, 1, request.CreateRedirectRequest{FromPath: "/about", ToPath: "/team"})
+	assert.ErrorIs(t, err, ErrRedirectLoop)
+
+	ext, err := svc.Create(ctx, 2, request.CreateRedirectRequest{FromPath: "/shop", ToPath: "https://shop.example/", StatusCode: 302})
+	require.NoError(t, err)
+	ext, err = svc.Update(ctx, ext.ID, 3, request.UpdateRedirectRequest{StatusCode: 307})
+	require.NoError(t, err)
+	assert.Equal(t, 307, ext.StatusCode)
+	assert.Equal(t, "https://shop.example/", ext.ToPath)
+	assert.Equal(t, uint(3), ext.UpdatedBy)
+
+	rd, err := svc.Resolve(ctx, "/about-us/?utm=x")
+	require.NoError(t, err)
+	assert.Equal(t, "/about", rd.ToPath)
+	_, err = svc.Resolve(ctx, "/about-us")
+	require.NoError(t, err)
+	_, err = svc.Resolve(ctx, "/nowhere")
+	assert.ErrorIs(t, err, ErrRedirectNotFound)
+
+	page, err := svc.List(ctx, request.RedirectListRequest{Sort: "hits"})
+	require.NoError(t, err)
+	rows := page.Items.([]model.Redirect)
+	require.Len(t, rows, 3)
+	assert.Equal(t, about.ID, rows[0].ID)
+	assert.Equal(t, int64(2), rows[0].Hits)
+	assert.NotNil(t, rows[0].LastHitAt)
+
+	require.NoError(t, svc.Delete(ctx, about.ID))
+	assert.ErrorIs(t, svc.Delete(ctx, about.ID), ErrRedirectNotFound)
+	_, err = svc.Resolve(ctx, "/about-us")
+	assert.ErrorIs(t, err, ErrRedirectNotFound)
+}
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/handler/request"
+	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/internal/repository"
+	"github.com/turahe/go-restfull/internal/service/dto"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrSeriesNotFound     = errors.New("series not found")
+	ErrNotSeriesOwner     = errors.New("not the series owner")
+	ErrPostNotInSeries    = errors.New("post is not in this series")
+	ErrChapterSetMismatch = repository.ErrChapterSetMismatch
+)
+
+// SeriesService manages series and their chapter order. Changing a series takes its owner; adding a
+// post also takes the right to edit that post.
+type SeriesService struct {
+	posts  *PostService
+	series *repository.SeriesRepository
+	log    *zap.Logger
+}
+
+func NewSeriesService(posts *PostService, series *repository.SeriesRepository, log *zap.Logger) *SeriesService {
+	return &SeriesService{posts: posts, series: series, log: log}
+}
+
+func (s *SeriesService) List(ctx context.Context, req request.SeriesListRequest) (repository.CursorPage, error) {
+	page, err := s.series.List(ctx, req)
+	if err != nil {
+		s.log.Error("failed to list series", zap.Error(err))
+		return repository.CursorPage{}, err
+	}
+	return page, nil
+}
+
+// Landing returns the series with its live chapters in order and how far publication has come.
+func (s *SeriesService) Landing(ctx context.Context, slug string) (*dto.SeriesLanding, error) {
+	slug = strings.TrimSpace(slug)
+	if slug == "" {
+		return nil, ErrInvalidSlug
+	}
+	sr, err := s.series.FindBySlug(ctx, slug)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSeriesNotFound
+		}
+		return nil, err
+	}
+	posts, err := s.series.Chapters(ctx, sr.ID)
+	if err != nil {
+		return nil, err
+	}
+	s.posts.refreshRenders(ctx, posts)
+
+	now := time.Now()
+	out := &dto.SeriesLanding{Series: sr, Chapters: []dto.SeriesChapterSummary{}, Progress: dto.SeriesProgress{Total: len(posts)}}
+	for i := range posts {
+		p := &posts[i]
+		if postLive(p, now) {
+			ch := dto.SeriesChapterSummary{
+				SeriesChapter:      model.SeriesChapter{ID: p.ID, Title: p.Title, Slug: p.Slug, Chapter: p.Chapter, PublishAt: p.PublishAt},
+				ReadingTimeMinutes: p.ReadingTimeMinutes,
+			}
+			if p.PostSEO != nil {
+				ch.Excerpt = p.PostSEO.Excerpt
+			}
+			out.Chapters = append(out.Chapters, ch)
+			continue
+		}
+		if p.Status == model.PostStatusScheduled && p.PublishAt != nil && p.PublishAt.After(now) {
+			if out.Progress.NextPublishAt == nil || p.PublishAt.Before(*out.Progress.NextPublishAt) {
+				out.Progress.NextPublishAt = p.PublishAt
+			}
+		}
+	}
+	out.Progress.Published = len(out.Chapters)
+	if out.Progress.Total > 0 {
+		out.Progress.Percent = out.Progress.Published * 100 / out.Progress.Total
+	}
+	return out, nil
+}
+
+// Chapters returns every post in the series in chapter order, drafts included, for its owner.
+func (s *SeriesService) Chapters(ctx context.Context, id, actorUserID uint) ([]model.Post, error) {
+	if _, err := s.findOwned(ctx, id, actorUserID); err != nil {
+		return nil, err
+	}
+	return s.series.Chapters(ctx, id)
+}
+
+func (s *SeriesService) Create(ctx context.Context, actorUserID uint, req request.CreateSeriesRequest) (*model.Series, error) {
+	base := slugify(req.Title)
+	if base == "" {
+		base = "series"
+	}
+	slug, err := s.uniqueSlug(ctx, base)
+	if err != nil {
+		return nil, err
+	}
+	sr := &model.Series{
+		Title:       strings.TrimSpace(req.Title),
+		Slug:        slug,
+		Description: strings.TrimSpace(req.Description),
+		UserID:      actorUserID,
+		CreatedBy:   actorUserID,
+		UpdatedBy:   actorUserID,
+	}
+	if err := s.series.Create(ctx, sr); err != nil {
+		return nil, err
+	}
+	return sr, nil
+}
+
+func (s *SeriesService) Update(ctx context.Context, id, actorUserID uint, req request.UpdateSeriesRequest) (*model.Series, error) {
+	sr, err := s.findOwned(ctx, id, actorUserID)
+	if err != nil {
+		return nil, err
+	}
+	if t := strings.TrimSpace(req.Title); t != "" {
+		sr.Title = t
+	}
+	if req.Description != nil {
+		sr.Description = strings.TrimSpace(*req.Description)
+	}
+	sr.UpdatedBy = actorUserID
+	if err := s.series.Update(ctx, sr); err != nil {
+		return nil, err
+	}
+	return sr, nil
+}
+
+// Delete removes the series; its posts stay, without a series.
+func (s *SeriesService) Delete(ctx context.Context, id, actorUserID uint) error {
+	if _, err := s.findOwned(ctx, id, actorUserID); err != nil {
+		return err
+	}
+	return s.series.DeleteByID(ctx, id)
+}
+
+// AddPost inserts the post at req.Chapter (appending by default), or moves it there if it is already
+// in the series. A post in another series leaves that one.
+func (s *SeriesService) AddPost(ctx context.Context, id, actorUserID uint, req request.AddSeriesPostRequest) ([]model.Post, error) {
+	if _, err := s.findOwned(ctx, id, actorUserID); err != nil {
+		return nil, err
+	}
+	if _, err := s.posts.findForMutation(ctx, req.PostID, actorUserID); err != nil {
+		return nil, err
+	}
+	if err := s.series.AddPost(ctx, id, req.PostID, req.Chapter); err != nil {
+		return nil, err
+	}
+	return s.series.Chapters(ctx, id)
+}
+
+func (s *SeriesService) RemovePost(ctx context.Context, id, postID, actorUserID uint) error {
+	if _, err := s.findOwned(ctx, id, actorUserID); err != nil {
+		return err
+	}
+	if err := s.series.RemovePost(ctx, id, postID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrPostNotInSeries
+		}
+		return err
+	}
+	return nil
+}
+
+// Reorder sets the chapter order to req.PostIDs, which must list every post in the series once.
+func (s *SeriesService) Reorder(ctx context.Context, id, actorUserID uint, req request.ReorderSeriesRequest) ([]model.Post, error) {
+	if _, err := s.findOwned(ctx, id, actorUserID); err != nil {
+		return nil, err
+	}
+	if err := s.series.Reorder(ctx, id, req.PostIDs); err != nil {
+		return nil, err
+	}
+	return s.series.Chapters(ctx, id)
+}
+
+func (s *SeriesService) findOwned(ctx context.Context, id, actorUserID uint) (*model.Series, error) {
+	sr, err := s.series.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSeriesNotFound
+		}
+		return nil, err
+	}
+	if sr.UserID != actorUserID {
+		return nil, ErrNotSeriesOwner
+	}
+	return sr, nil
+}
+
+func (s *SeriesService) uniqueSlug(ctx context.Context, base string) (string, error) {
+	slug := base
+	for i := 1; i <= 50; i++ {
+		exists, err := s.series.SlugExists(ctx, slug)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return slug, nil
+		}
+		slug = fmt.Sprintf("%s-%d", base, i+1)
+	}
+	return "", errors.New("could not generate unique slug")
+}
+
+// attachSeriesNav fills p.SeriesNav with the live chapters around p when p is in a series.
+func (s *PostService) attachSeriesNav(ctx context.Context, p *model.Post, now time.Time) error {
+	if p.SeriesID == nil || p.Series == nil {
+		return nil
+	}
+	prev, next, published, err := s.posts.SeriesNavigation(ctx, *p.SeriesID, p.Chapter, now)
+	if err != nil {
+		return err
+	}
+	p.SeriesNav = &model.SeriesNavigation{Published: published, Prev: prev, Next: next}
+	return nil
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/turahe/go-restfull/internal/handler/request"
+	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func chapterIDs(posts []model.Post) []uint {
+	ids := make([]uint, len(posts))
+	for i, p := range posts {
+		ids[i] = p.ID
+	}
+	return ids
+}
+
+func TestSeries_ChaptersNavigationAndLanding(t *testing.T) {
+	ctx := context.Background()
+	env := newPostTestEnv(t)
+	db, log, catRepo, posts := env.db, env.log, env.categories, env.posts
+	series := NewSeriesService(posts, repository.NewSeriesRepository(db, log), log)
+
+	const author, other = uint(1), uint(2)
+	cat, err := catRepo.CreateRoot(ctx, "Books", author)
+	require.NoError(t, err)
+	sr, err := series.Create(ctx, author, request.CreateSeriesRequest{Title: "Learning Go"})
+	require.NoError(t, err)
+	assert.Equal(t, "learning-go", sr.Slug)
+
+	var ids []uint
+	for _, title := range []string{"Chapter one", "Chapter two", "Chapter three"} {
+		p, err := posts.Create(ctx, author, request.CreatePostRequest{Title: title, Content: "Some words here.", CategoryID: cat.ID, Layout: "book"})
+		require.NoError(t, err)
+		ids = append(ids, p.ID)
+		_, err = series.AddPost(ctx, sr.ID, author, request.AddSeriesPostRequest{PostID: p.ID})
+		require.NoError(t, err)
+	}
+	draft, err := posts.Create(ctx, author, request.CreatePostRequest{Title: "Chapter draft", Content: "x", CategoryID: cat.ID, Status: "draft"})
+	require.NoError(t, err)
+
+	_, err = series.AddPost(ctx, sr.ID, other, request.AddSeriesPostRequest{PostID: draft.ID})
+	assert.ErrorIs(t, err, ErrNotSeriesOwner)
+	rows, err := series.AddPost(ctx, sr.ID, author, request.AddSeriesPostRequest{PostID: draft.ID, Chapter: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []uint{ids[0], draft.ID, ids[1], ids[2]}, chapterIDs(rows), "inserting shifts later chapters")
+
+	_, err = series.Reorder(ctx, sr.ID, author, request.ReorderSeriesRequest{PostIDs: []uint{ids[2], ids[0]}})
+	assert.ErrorIs(t, err, ErrChapterSetMismatch)
+	rows, err = series.Reorder(ctx, sr.ID, author, request.ReorderSeriesRequest{PostIDs: []uint{ids[2], draft.ID, ids[0], ids[1]}})
+	require.NoError(t, err)
+	for i, p := range rows {
+		assert.Equal(t, i+1, p.Chapter)
+	}
+
+	// Navigation skips the draft in between.
+	first, err := posts.GetBySlug(ctx, rows[0].Slug, PostAccess{}, LocalePreference{})
+	require.NoError(t, err)
+	require.NotNil(t, first.SeriesNav)
+	assert.Nil(t, first.SeriesNav.Prev)
+	require.NotNil(t, first.SeriesNav.Next)
+	assert.Equal(t, ids[0], first.SeriesNav.Next.ID)
+	assert.Equal(t, 3, first.SeriesNav.Next.Chapter)
+	assert.EqualValues(t, 3, first.SeriesNav.Published)
+	require.NotNil(t, first.Series)
+	assert.Equal(t, sr.ID, first.Series.ID)
+
+	landing, err := series.Landing(ctx, sr.Slug)
+	require.NoError(t, err)
+	require.Len(t, landing.Chapters, 3)
+	assert.Equal(t, 3, landing.Progress.Published)
+	assert.Equal(t, 4, landing.Progress.Total)
+	assert.Equal(t, 75, landing.Progress.Percent)
+
+	page, err := posts.List(ctx, request.PostListRequest{})
+	require.NoError(t, err)
+	for _, p := range page.Items.([]model.Post) {
+		require.NotNil(t, p.Series, "list rows carry their series")
+		assert.NotZero(t, p.Chapter)
+	}
+
+	// Deleting a post closes the gap it leaves.
+	require.NoError(t, posts.Delete(ctx, ids[2], author))
+	rows, err = series.Chapters(ctx, sr.ID, author)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{draft.ID, ids[0], ids[1]}, chapterIDs(rows))
+	assert.Equal(t, 1, rows[0].Chapter)
+
+	require.NoError(t, series.RemovePost(ctx, sr.ID, draft.ID, author))
+	assert.ErrorIs(t, series.RemovePost(ctx, sr.ID, draft.ID, author), ErrPostNotInSeries)
+
+	require.NoError(t, series.Delete(ctx, sr.ID, author))
+	got, err := posts.GetBySlug(ctx, rows[1].Slug, PostAccess{}, LocalePreference{})
+	require.NoError(t, err)
+	assert.Nil(t, got.SeriesID)
+	assert.Nil(t, got.SeriesNav)
+}
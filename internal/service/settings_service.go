@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"strconv"
+	"strings"
 
 	"github.com/turahe/go-restfull/internal/repository"
 )
@@ -47,3 +49,38 @@ func (s *SettingsService) Public(ctx context.Context) (PublicSettings, error) {
 	}
 	return m, nil
 }
+
+// settingInt reads an integer setting of the current site. It returns def when repo is nil, the key is
+// missing or unreadable, or the value is not an integer.
+func settingInt(ctx context.Context, repo *repository.SettingRepository, key string, def int) int {
+	if repo == nil {
+		return def
+	}
+	row, err := repo.FindByKey(ctx, key)
+	if err != nil {
+		return def
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(row.Value))
+	if err != nil {
+		return def
+	}
+	return n
+}
+
+// settingString reads a string setting of the current site, trimmed. It returns def when repo is nil
+// or the key is missing, unreadable or blank.
+func settingString(ctx context.Context, repo *repository.SettingRepository, key string, def string) string {
+	if repo == nil {
+		return def
+	}
+	row, err := repo.FindByKey(ctx, key)
+	if err != nil || strings.TrimSpace(row.Value) == "" {
+		return def
+	}
+	return strings.TrimSpace(row.Value)
+}
+
+// siteBaseURL is the current site's siteUrl setting without a trailing slash, or origin when unset.
+func siteBaseURL(ctx context.Context, repo *repository.SettingRepository, origin string) string {
+	return strings.TrimRight(settingString(ctx, repo, SettingSiteURL, origin), "/")
+}
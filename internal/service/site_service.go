@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net"
+	"regexp"
+	"strings"
+
+	"github.com/turahe/go-restfull/internal/handler/request"
+	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/internal/repository"
+	"github.com/turahe/go-restfull/internal/tenant"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrSiteNotFound   = errors.New("site not found")
+	ErrSiteExists     = errors.New("site key or host already in use")
+	ErrInvalidSiteKey = errors.New("site key must be lowercase letters, digits and dashes")
+)
+
+var siteKeyPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{1,63}$`)
+
+type SiteService struct {
+	sites *repository.SiteRepository
+	// strictHost rejects requests whose Host matches no site instead of serving the default site.
+	strictHost bool
+	log        *zap.Logger
+}
+
+func NewSiteService(sites *repository.SiteRepository, strictHost bool, log *zap.Logger) *SiteService {
+	return &SiteService{sites: sites, strictHost: strictHost, log: log}
+}
+
+// Resolve picks the site for a request: an explicit key (X-Site header) wins, then the Host header.
+// Unknown keys are always an error; unknown hosts fall back to the default site unless strictHost is set.
+func (s *SiteService) Resolve(ctx context.Context, key, host string) (*model.Site, error) {
+	if key = strings.ToLower(strings.TrimSpace(key)); key != "" {
+		return s.find(s.sites.FindByKey(ctx, key))
+	}
+	if host = normalizeSiteHost(host); host != "" {
+		site, err := s.sites.FindByHost(ctx, host)
+		if err == nil {
+			return site, nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			s.log.Error("failed to resolve site by host", zap.Error(err))
+			return nil, err
+		}
+	}
+	if s.strictHost {
+		return nil, ErrSiteNotFound
+	}
+	return s.find(s.sites.FindByID(ctx, tenant.DefaultSiteID))
+}
+
+func (s *SiteService) find(site *model.Site, err error) (*model.Site, error) {
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSiteNotFound
+		}
+		s.log.Error("failed to resolve site", zap.Error(err))
+		return nil, err
+	}
+	return site, nil
+}
+
+func (s *SiteService) List(ctx context.Context) ([]model.Site, error) {
+	return s.sites.List(ctx)
+}
+
+func (s *SiteService) Create(ctx context.Context, req request.CreateSiteRequest) (*model.Site, error) {
+	key := strings.ToLower(strings.TrimSpace(req.Key))
+	if !siteKeyPattern.MatchString(key) {
+		return nil, ErrInvalidSiteKey
+	}
+	site := &model.Site{Key: key, Name: strings.TrimSpace(req.Name)}
+	if h := normalizeSiteHost(req.Host); h != "" {
+		site.Host = &h
+	}
+	exists, err := s.sites.ExistsKeyOrHost(ctx, site.Key, site.Host)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrSiteExists
+	}
+	if err := s.sites.Create(ctx, site); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrSiteExists
+		}
+		return nil, err
+	}
+	return site, nil
+}
+
+// normalizeSiteHost lowercases host and strips any port ("Blog.Example.com:8080" -> "blog.example.com").
+func normalizeSiteHost(host string) string {
+	host = strings.ToLower(strings.TrimSpace(host))
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.Trim(host, "[]")
+}
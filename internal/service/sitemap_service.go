@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/repository"
+	"github.com/turahe/go-restfull/internal/tenant"
+	"github.com/turahe/go-restfull/pkg/sitemap"
+
+	"go.uber.org/zap"
+)
+
+var ErrSitemapNotFound = errors.New("sitemap not found")
+
+// SitemapKind names a child sitemap.
+type SitemapKind string
+
+const (
+	SitemapPosts      SitemapKind = "posts"
+	SitemapCategories SitemapKind = "categories"
+	SitemapTags       SitemapKind = "tags"
+)
+
+var sitemapKinds = []SitemapKind{SitemapPosts, SitemapCategories, SitemapTags}
+
+const (
+	// sitemapPageSize is how many ids a child sitemap covers (the protocol allows up to 50,000 URLs).
+	sitemapPageSize = 10000
+	// sitemapSummaryTTL is how long page summaries are reused before the database is asked again;
+	// new and changed content shows up in the sitemaps within this delay.
+	sitemapSummaryTTL = time.Minute
+)
+
+// SitemapDoc is a rendered sitemap and the latest modification among its URLs.
+type SitemapDoc struct {
+	Body    []byte
+	LastMod time.Time
+}
+
+type sitemapSummary struct {
+	pages   map[SitemapKind][]repository.SitemapPage
+	expires time.Time
+}
+
+type sitemapPageKey struct {
+	site uint
+	kind SitemapKind
+	page int
+}
+
+// sitemapPageDoc is a rendered page and the fingerprint it was rendered from.
+type sitemapPageDoc struct {
+	count   int64
+	lastMod time.Time
+	baseURL string
+	doc     *SitemapDoc
+}
+
+// SitemapService serves /sitemap.xml and its child sitemaps. Pages are rendered on demand and kept
+// until their fingerprint (row count and latest update, see repository.SitemapPage) changes, so on a
+// large site only the pages touched since the last request are rebuilt.
+type SitemapService struct {
+	posts      *repository.PostRepository
+	categories *repository.CategoryRepository
+	tags       *repository.TagRepository
+	settings   *repository.SettingRepository
+	log        *zap.Logger
+
+	pageSize int
+	now      func() time.Time
+
+	mu        sync.Mutex
+	summaries map[uint]sitemapSummary
+	pages     map[sitemapPageKey]sitemapPageDoc
+}
+
+// NewSitemapService wires the sitemap service; settings may be nil (links then use the API origin).
+func NewSitemapService(posts *repository.PostRepository, categories *repository.CategoryRepository, tags *repository.TagRepository, settings *repository.SettingRepository, log *zap.Logger) *SitemapService {
+	return &SitemapService{
+		posts:      posts,
+		categories: categories,
+		tags:       tags,
+		settings:   settings,
+		log:        log,
+		pageSize:   sitemapPageSize,
+		now:        time.Now,
+		summaries:  map[uint]sitemapSummary{},
+		pages:      map[sitemapPageKey]sitemapPageDoc{},
+	}
+}
+
+// Index renders the sitemap index of the current site: one entry per non-empty child page, at
+// {origin}/sitemaps/{kind}-{page}.xml.
+func (s *SitemapService) Index(ctx context.Context, origin string) (*SitemapDoc, error) {
+	summary, err := s.summary(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var refs []sitemap.Entry
+	doc := &SitemapDoc{}
+	for _, kind := range sitemapKinds {
+		for _, p := range summary[kind] {
+			refs = append(refs, sitemap.Entry{Loc: fmt.Sprintf("%s/sitemaps/%s-%d.xml", origin, kind, p.Page), LastMod: p.LastMod})
+			if p.LastMod.After(doc.LastMod) {
+				doc.LastMod = p.LastMod
+			}
+		}
+	}
+	if doc.Body, err = sitemap.Index(refs); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// Page renders one child sitemap. Posts link to their canonical URL when set, else
+// {siteUrl}/posts/{slug}; categories and tags to {siteUrl}/categories/{slug} and {siteUrl}/tags/{slug}.
+// Posts that are not live or whose robots meta says noindex are left out.
+func (s *SitemapService) Page(ctx context.Context, kind SitemapKind, page int, origin string) (*SitemapDoc, error) {
+	summary, err := s.summary(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var meta *repository.SitemapPage
+	for i := range summary[kind] {
+		if summary[kind][i].Page == page {
+			meta = &summary[kind][i]
+		}
+	}
+	if meta == nil {
+		return nil, ErrSitemapNotFound
+	}
+	baseURL := siteBaseURL(ctx, s.settings, origin)
+	key := sitemapPageKey{site: tenant.SiteID(ctx), kind: kind, page: page}
+	s.mu.Lock()
+	cached, ok := s.pages[key]
+	s.mu.Unlock()
+	if ok && cached.count == meta.Count && cached.lastMod.Equal(meta.LastMod) && cached.baseURL == baseURL {
+		return cached.doc, nil
+	}
+
+	entries, err := s.entries(ctx, kind, page)
+	if err != nil {
+		return nil, err
+	}
+	urls := make([]sitemap.Entry, len(entries))
+	for i, e := range entries {
+		loc := fmt.Sprintf("%s/%s/%s", baseURL, kind, e.Slug)
+		if e.Canonical != "" {
+			loc = e.Canonical
+		}
+		urls[i] = sitemap.Entry{Loc: loc, LastMod: e.UpdatedAt}
+	}
+	doc := &SitemapDoc{LastMod: meta.LastMod}
+	if doc.Body, err = sitemap.URLSet(urls); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	s.pages[key] = sitemapPageDoc{count: meta.Count, lastMod: meta.LastMod, baseURL: baseURL, doc: doc}
+	s.mu.Unlock()
+	return doc, nil
+}
+
+func (s *SitemapService) entries(ctx context.Context, kind SitemapKind, page int) ([]repository.SitemapEntry, error) {
+	switch kind {
+	case SitemapPosts:
+		return s.posts.SitemapEntries(ctx, s.now(), page, s.pageSize)
+	case SitemapCategories:
+		return s.categories.SitemapEntries(ctx, page, s.pageSize)
+	case SitemapTags:
+		return s.tags.SitemapEntries(ctx, page, s.pageSize)
+	}
+	return nil, ErrSitemapNotFound
+}
+
+// summary returns the current site's page summaries, reusing them for sitemapSummaryTTL.
+func (s *SitemapService) summary(ctx context.Context) (map[SitemapKind][]repository.SitemapPage, error) {
+	site := tenant.SiteID(ctx)
+	now := s.now()
+	s.mu.Lock()
+	cached, ok := s.summaries[site]
+	s.mu.Unlock()
+	if ok && now.Before(cached.expires) {
+		return cached.pages, nil
+	}
+
+	pages := make(map[SitemapKind][]repository.SitemapPage, len(sitemapKinds))
+	var err error
+	if pages[SitemapPosts], err = s.posts.SitemapPages(ctx, now, s.pageSize); err != nil {
+		return nil, err
+	}
+	if pages[SitemapCategories], err = s.categories.SitemapPages(ctx, s.pageSize); err != nil {
+		return nil, err
+	}
+	if pages[SitemapTags], err = s.tags.SitemapPages(ctx, s.pageSize); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	s.summaries[site] = sitemapSummary{pages: pages, expires: now.Add(sitemapSummaryTTL)}
+	s.mu.Unlock()
+	return pages, nil
+}
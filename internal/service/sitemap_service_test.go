@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/handler/request"
+	"github.com/turahe/go-restfull/internal/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSitemapService(t *testing.T) {
+	ctx := context.Background()
+	env := newPostTestEnv(t)
+	db, log, catRepo, tagRepo, settingRepo, postRepo, posts := env.db, env.log, env.categories, env.tags, env.settings, env.postRepo, env.posts
+	sitemaps := NewSitemapService(postRepo, catRepo, tagRepo, settingRepo, log)
+	sitemaps.pageSize = 2
+	now := time.Now()
+	sitemaps.now = func() time.Time { return now }
+
+	user := model.User{Name: "Ada", Email: "ada@sitemap.test", Password: "x"}
+	require.NoError(t, db.Create(&user).Error)
+	travel, err := catRepo.CreateRoot(ctx, "Travel", user.ID)
+	require.NoError(t, err)
+	require.NoError(t, tagRepo.Create(ctx, &model.Tag{Name: "Go", Slug: "go"}))
+
+	past := now.Add(-time.Hour)
+	create := func(req request.CreatePostRequest) *model.Post {
+		req.Content = "Body"
+		req.CategoryID = travel.ID
+		if req.PublishAt == nil {
+			req.PublishAt = &past
+		}
+		p, err := posts.Create(ctx, user.ID, req)
+		require.NoError(t, err)
+		return p
+	}
+	first := create(request.CreatePostRequest{Title: "First"})                                                      // id 1, page 1
+	canonical := create(request.CreatePostRequest{Title: "Canonical", CanonicalURL: "https://elsewhere.example/c"}) // id 2, page 2
+	create(request.CreatePostRequest{Title: "Hidden", RobotsMeta: "NOINDEX, follow"})                               // id 3, page 2
+	create(request.CreatePostRequest{Title: "Draft", Status: "draft"})                                              // id 4, page 3
+	later := now.Add(time.Hour)
+	create(request.CreatePostRequest{Title: "Scheduled", PublishAt: &later}) // id 5, page 3
+
+	idx, err := sitemaps.Index(ctx, "https://api.example")
+	require.NoError(t, err)
+	body := string(idx.Body)
+	assert.Contains(t, body, "<loc>https://api.example/sitemaps/posts-1.xml</loc>")
+	assert.Contains(t, body, "<loc>https://api.example/sitemaps/posts-2.xml</loc>")
+	assert.NotContains(t, body, "posts-3.xml", "pages without indexable posts are not listed")
+	assert.Contains(t, body, "<loc>https://api.example/sitemaps/categories-1.xml</loc>")
+	assert.Contains(t, body, "<loc>https://api.example/sitemaps/tags-1.xml</loc>")
+	assert.False(t, idx.LastMod.IsZero())
+
+	page2, err := sitemaps.Page(ctx, SitemapPosts, 2, "https://api.example")
+	require.NoError(t, err)
+	assert.Contains(t, string(page2.Body), "<loc>https://elsewhere.example/c</loc>", "canonical URLs are used")
+	assert.NotContains(t, string(page2.Body), "hidden", "noindex posts are skipped")
+	assert.Contains(t, string(page2.Body), "<lastmod>"+canonical.UpdatedAt.UTC().Format(time.RFC3339)+"</lastmod>")
+
+	page1, err := sitemaps.Page(ctx, SitemapPosts, 1, "https://api.example")
+	require.NoError(t, err)
+	assert.Contains(t, string(page1.Body), "<loc>https://api.example/posts/"+first.Slug+"</loc>")
+	cats, err := sitemaps.Page(ctx, SitemapCategories, 1, "https://api.example")
+	require.NoError(t, err)
+	assert.Contains(t, string(cats.Body), "<loc>https://api.example/categories/"+travel.Slug+"</loc>")
+	tags, err := sitemaps.Page(ctx, SitemapTags, 1, "https://api.example")
+	require.NoError(t, err)
+	assert.Contains(t, string(tags.Body), "<loc>https://api.example/tags/go</loc>")
+
+	_, err = sitemaps.Page(ctx, SitemapPosts, 3, "https://api.example")
+	assert.ErrorIs(t, err, ErrSitemapNotFound)
+	_, err = sitemaps.Page(ctx, "users", 1, "https://api.example")
+	assert.ErrorIs(t, err, ErrSitemapNotFound)
+
+	// Touch a post on page 2: once the summaries expire only that page is rendered again.
+	require.NoError(t, db.Model(&model.Post{}).Where("id = ?", canonical.ID).Update("updated_at", now.Add(time.Minute)).Error)
+	again, err := sitemaps.Page(ctx, SitemapPosts, 2, "https://api.example")
+	require.NoError(t, err)
+	assert.Same(t, page2, again, "summaries are reused within their TTL")
+	now = now.Add(2 * sitemapSummaryTTL)
+	again, err = sitemaps.Page(ctx, SitemapPosts, 2, "https://api.example")
+	require.NoError(t, err)
+	assert.NotSame(t, page2, again, "a changed page is rendered again")
+	unchanged, err := sitemaps.Page(ctx, SitemapPosts, 1, "https://api.example")
+	require.NoError(t, err)
+	assert.Same(t, page1, unchanged, "unchanged pages come from the cache")
+
+	require.NoError(t, settingRepo.Upsert(ctx, SettingSiteURL, "https://blog.example/", true))
+	page1, err = sitemaps.Page(ctx, SitemapPosts, 1, "https://api.example")
+	require.NoError(t, err)
+	assert.Contains(t, string(page1.Body), "<loc>https://blog.example/posts/"+first.Slug+"</loc>", "links follow siteUrl")
+}
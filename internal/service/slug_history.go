@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// SlugMovedError is returned by slug lookups that hit a slug the entity used before; Slug is its
+// current one. Handlers answer it with a permanent redirect.
+type SlugMovedError struct {
+	Slug string
+}
+
+func (e *SlugMovedError) Error() string {
+	return "moved to slug " + e.Slug
+}
+
+// movedPost turns a slug lookup miss into a SlugMovedError when a post the caller may read used that
+// slug before, and into ErrPostNotFound otherwise.
+func (s *PostService) movedPost(ctx context.Context, slug string, access PostAccess) error {
+	id, err := s.posts.ResolveOldSlug(ctx, slug)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrPostNotFound
+		}
+		return err
+	}
+	p, err := s.posts.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrPostNotFound
+		}
+		return err
+	}
+	if !postLive(p, time.Now()) && !s.canViewUnpublished(ctx, p, access) {
+		return ErrPostNotFound
+	}
+	return &SlugMovedError{Slug: p.Slug}
+}
+
+// movedTag is movedPost for tags.
+func (s *TagService) movedTag(ctx context.Context, slug string) error {
+	id, err := s.tags.ResolveOldSlug(ctx, slug)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrTagNotFound
+		}
+		return err
+	}
+	t, err := s.findTag(ctx, id)
+	if err != nil {
+		return err
+	}
+	return &SlugMovedError{Slug: t.Slug}
+}
+
+// movedCategory is movedPost for categories.
+func (s *PostService) movedCategory(ctx context.Context, slug string) error {
+	id, err := s.categories.ResolveOldSlug(ctx, slug)
+	if err == nil {
+		var c *model.CategoryModel
+		if c, err = s.categories.GetByID(ctx, id); err == nil {
+			return &SlugMovedError{Slug: c.Slug}
+		}
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrCategoryNotFound
+	}
+	return err
+}
+
+// movedFeedTag is movedTag for the feeds, which read tags through PostService.
+func (s *PostService) movedFeedTag(ctx context.Context, slug string) error {
+	id, err := s.tags.ResolveOldSlug(ctx, slug)
+	if err == nil {
+		var t *model.Tag
+		if t, err = s.tags.FindByID(ctx, id); err == nil {
+			return &SlugMovedError{Slug: t.Slug}
+		}
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrTagNotFound
+	}
+	return err
+}
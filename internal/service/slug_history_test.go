@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/handler/request"
+	"github.com/turahe/go-restfull/internal/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func movedTo(t *testing.T, err error) string {
+	t.Helper()
+	var moved *SlugMovedError
+	require.True(t, errors.As(err, &moved), "want *SlugMovedError, got %v", err)
+	return moved.Slug
+}
+
+func TestSlugHistory(t *testing.T) {
+	ctx := context.Background()
+	env := newPostTestEnv(t)
+	db, log, catRepo, tagRepo, posts := env.db, env.log, env.categories, env.tags, env.posts
+	tags := NewTagService(tagRepo, log)
+
+	ada := model.User{Name: "Ada", Email: "ada@slugs.test", Password: "x"}
+	require.NoError(t, db.Create(&ada).Error)
+	cat, err := catRepo.CreateRoot(ctx, "Travel", ada.ID)
+	require.NoError(t, err)
+	past := time.Now().Add(-time.Hour)
+
+	t.Run("post slug change redirects the old slug", func(t *testing.T) {
+		p, err := posts.Create(ctx, ada.ID, request.CreatePostRequest{Title: "Old title", Content: "x", CategoryID: cat.ID, PublishAt: &past})
+		require.NoError(t, err)
+		assert.Equal(t, "old-title", p.Slug)
+
+		p, err = posts.Update(ctx, p.ID, ada.ID, request.UpdatePostRequest{Title: "New title"})
+		require.NoError(t, err)
+		assert.Equal(t, "old-title", p.Slug, "a new title keeps the slug")
+
+		p, err = posts.Update(ctx, p.ID, ada.ID, request.UpdatePostRequest{Slug: "New Title!"})
+		require.NoError(t, err)
+		assert.Equal(t, "new-title", p.Slug)
+		_, err = posts.GetBySlug(ctx, "old-title", PostAccess{}, LocalePreference{})
+		assert.Equal(t, "new-title", movedTo(t, err))
+
+		p, err = posts.Update(ctx, p.ID, ada.ID, request.UpdatePostRequest{Slug: "newest-title"})
+		require.NoError(t, err)
+		_, err = posts.GetBySlug(ctx, "old-title", PostAccess{}, LocalePreference{})
+		assert.Equal(t, "newest-title", movedTo(t, err), "older slugs follow to the current one")
+
+		// Moving back frees the slug from the history again.
+		_, err = posts.Update(ctx, p.ID, ada.ID, request.UpdatePostRequest{Slug: "old-title"})
+		require.NoError(t, err)
+		got, err := posts.GetBySlug(ctx, "old-title", PostAccess{}, LocalePreference{})
+		require.NoError(t, err)
+		assert.Equal(t, p.ID, got.ID)
+
+		_, err = posts.GetBySlug(ctx, "never-used", PostAccess{}, LocalePreference{})
+		assert.ErrorIs(t, err, ErrPostNotFound)
+	})
+
+	t.Run("drafts do not leak through old slugs", func(t *testing.T) {
+		p, err := posts.Create(ctx, ada.ID, request.CreatePostRequest{Title: "Secret", Content: "x", CategoryID: cat.ID, Status: "draft"})
+		require.NoError(t, err)
+		_, err = posts.Update(ctx, p.ID, ada.ID, request.UpdatePostRequest{Slug: "secret-2"})
+		require.NoError(t, err)
+
+		_, err = posts.GetBySlug(ctx, "secret", PostAccess{}, LocalePreference{})
+		assert.ErrorIs(t, err, ErrPostNotFound)
+		_, err = posts.GetBySlug(ctx, "secret", PostAccess{ViewerID: ada.ID}, LocalePreference{})
+		assert.Equal(t, "secret-2", movedTo(t, err))
+	})
+
+	t.Run("tag slug change redirects the old slug", func(t *testing.T) {
+		tag, err := tags.Create(ctx, ada.ID, request.CreateTagRequest{Name: "Golang"})
+		require.NoError(t, err)
+		_, err = tags.Update(ctx, tag.ID, ada.ID, request.UpdateTagRequest{Slug: "go"})
+		require.NoError(t, err)
+
+		_, err = tags.GetBySlug(ctx, "golang")
+		assert.Equal(t, "go", movedTo(t, err))
+		got, err := tags.GetBySlug(ctx, "go")
+		require.NoError(t, err)
+		assert.Equal(t, tag.ID, got.ID)
+	})
+
+	t.Run("category rename redirects its feed", func(t *testing.T) {
+		c, err := catRepo.CreateRoot(ctx, "Food", ada.ID)
+		require.NoError(t, err)
+		_, err = catRepo.UpdateName(ctx, c.ID, "Cooking", ada.ID)
+		require.NoError(t, err)
+
+		_, err = posts.Feed(ctx, FeedQuery{CategorySlug: "food"})
+		assert.Equal(t, "cooking", movedTo(t, err))
+		_, err = posts.Feed(ctx, FeedQuery{CategorySlug: "cooking"})
+		require.NoError(t, err)
+	})
+}
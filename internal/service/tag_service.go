@@ -38,6 +38,8 @@ func (s *TagService) List(ctx context.Context, req request.TagListRequest) (repo
 	return page, nil
 }
 
+// GetBySlug finds a tag by its slug or by one of its translated slugs. A slug the tag used before yields
+// a *SlugMovedError naming the current one.
 func (s *TagService) GetBySlug(ctx context.Context, slug string) (*model.Tag, error) {
 	slug = strings.TrimSpace(slug)
 	if slug == "" {
@@ -47,8 +49,12 @@ func (s *TagService) GetBySlug(ctx context.Context, slug string) (*model.Tag, er
 	t, err := s.tags.FindBySlug(ctx, slug)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			s.log.Error("tag not found")
-			return nil, ErrTagNotFound
+			// Translated slugs resolve to their tag, named in that translation; old slugs redirect.
+			t, err := s.findByTranslatedSlug(ctx, slug)
+			if err == ErrTagNotFound {
+				return nil, s.movedTag(ctx, slug)
+			}
+			return t, err
 		}
 		s.log.Error("failed to find tag by slug", zap.Error(err))
 		return nil, err
@@ -96,15 +102,29 @@ func (s *TagService) Update(ctx context.Context, id uint, actorUserID uint, req
 	if req.Name != "" {
 		t.Name = req.Name
 	}
+	oldSlug := t.Slug
+	if req.Slug != "" {
+		slug := slugify(req.Slug)
+		if slug == "" {
+			return nil, ErrInvalidSlug
+		}
+		if slug != t.Slug {
+			if t.Slug, err = s.uniqueSlug(ctx, slug); err != nil {
+				return nil, err
+			}
+		}
+	}
 	if err := s.tags.Update(ctx, t); err != nil {
 		s.log.Error("failed to update tag", zap.Error(err))
 		return nil, err
 	}
+	if err := s.tags.RecordSlugChange(ctx, t.ID, oldSlug, t.Slug); err != nil {
+		return nil, err
+	}
 	return t, nil
 }
 
 func (s *TagService) Delete(ctx context.Context, id uint, actorUserID uint) error {
-	_ = actorUserID
 	if id == 0 {
 		s.log.Error("invalid tag id")
 		return ErrInvalidTagID
@@ -118,7 +138,7 @@ func (s *TagService) Delete(ctx context.Context, id uint, actorUserID uint) erro
 		s.log.Error("failed to find tag by id", zap.Error(err))
 		return err
 	}
-	return s.tags.DeleteByID(ctx, id)
+	return s.tags.SoftDeleteByID(ctx, id, actorUserID)
 }
 
 func (s *TagService) FindByIDs(ctx context.Context, ids []uint) ([]model.Tag, error) {
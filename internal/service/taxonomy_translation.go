@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/pkg/locale"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func normalizeLocale(raw string) (string, error) {
+	l, err := locale.Normalize(raw)
+	if err != nil {
+		return "", ErrInvalidLocale
+	}
+	return l, nil
+}
+
+// translationSlug derives a slug from name (fallback when name has no slug characters) and suffixes it
+// until taken reports it free.
+func translationSlug(name, fallback string, taken func(slug string) (bool, error)) (string, error) {
+	base := slugify(name)
+	if base == "" {
+		base = fallback
+	}
+	slug := base
+	for i := 1; i <= 50; i++ {
+		exists, err := taken(slug)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return slug, nil
+		}
+		slug = fmt.Sprintf("%s-%d", base, i+1)
+	}
+	return "", errors.New("could not generate unique slug")
+}
+
+// ListTranslations returns the category's translations by locale.
+func (u *CategoryService) ListTranslations(ctx context.Context, id uint) ([]model.CategoryTranslation, error) {
+	if _, err := u.repo.GetByID(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCategoryNotFound
+		}
+		return nil, err
+	}
+	return u.repo.ListTranslations(ctx, id)
+}
+
+// PutTranslation sets the category's name in a locale. The slug is derived from the name and unique
+// among the site's category slugs in that locale. Scope rules are those of Update.
+func (u *CategoryService) PutTranslation(ctx context.Context, id uint, rawLocale, name string, actorUserID uint) (*model.CategoryTranslation, error) {
+	loc, err := normalizeLocale(rawLocale)
+	if err != nil {
+		return nil, err
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrInvalidName
+	}
+	if err := u.checkScope(ctx, id, actorUserID, false); err != nil {
+		return nil, err
+	}
+	if _, err := u.repo.GetByID(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCategoryNotFound
+		}
+		return nil, err
+	}
+	t, err := u.repo.FindTranslation(ctx, id, loc)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		t = &model.CategoryTranslation{CategoryID: id, Locale: loc}
+	}
+	slug, err := translationSlug(name, "category", func(slug string) (bool, error) {
+		return u.repo.TranslationSlugExists(ctx, loc, slug, id)
+	})
+	if err != nil {
+		u.log.Error("failed to generate translation slug", zap.Error(err))
+		return nil, err
+	}
+	t.Name, t.Slug = name, slug
+	if err := u.repo.SaveTranslation(ctx, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// DeleteTranslation removes the category's translation in a locale.
+func (u *CategoryService) DeleteTranslation(ctx context.Context, id uint, rawLocale string, actorUserID uint) error {
+	loc, err := normalizeLocale(rawLocale)
+	if err != nil {
+		return err
+	}
+	if err := u.checkScope(ctx, id, actorUserID, false); err != nil {
+		return err
+	}
+	ok, err := u.repo.DeleteTranslation(ctx, id, loc)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrTranslationNotFound
+	}
+	return nil
+}
+
+// ListTranslations returns the tag's translations by locale.
+func (s *TagService) ListTranslations(ctx context.Context, id uint) ([]model.TagTranslation, error) {
+	if _, err := s.findTag(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.tags.ListTranslations(ctx, id)
+}
+
+// PutTranslation sets the tag's name in a locale; the slug is derived from the name and unique among
+// the site's tag slugs in that locale.
+func (s *TagService) PutTranslation(ctx context.Context, id uint, rawLocale, name string) (*model.TagTranslation, error) {
+	loc, err := normalizeLocale(rawLocale)
+	if err != nil {
+		return nil, err
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrInvalidTagReq
+	}
+	if _, err := s.findTag(ctx, id); err != nil {
+		return nil, err
+	}
+	t, err := s.tags.FindTranslation(ctx, id, loc)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		t = &model.TagTranslation{TagID: id, Locale: loc}
+	}
+	slug, err := translationSlug(name, "tag", func(slug string) (bool, error) {
+		return s.tags.TranslationSlugExists(ctx, loc, slug, id)
+	})
+	if err != nil {
+		s.log.Error("failed to generate translation slug", zap.Error(err))
+		return nil, err
+	}
+	t.Name, t.Slug = name, slug
+	if err := s.tags.SaveTranslation(ctx, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// DeleteTranslation removes the tag's translation in a locale.
+func (s *TagService) DeleteTranslation(ctx context.Context, id uint, rawLocale string) error {
+	loc, err := normalizeLocale(rawLocale)
+	if err != nil {
+		return err
+	}
+	ok, err := s.tags.DeleteTranslation(ctx, id, loc)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrTranslationNotFound
+	}
+	return nil
+}
+
+func (s *TagService) findTag(ctx context.Context, id uint) (*model.Tag, error) {
+	if id == 0 {
+		return nil, ErrInvalidTagID
+	}
+	t, err := s.tags.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTagNotFound
+		}
+		return nil, err
+	}
+	return t, nil
+}
+
+// findByTranslatedSlug returns the tag owning a translated slug, with its name and slug in that translation.
+func (s *TagService) findByTranslatedSlug(ctx context.Context, slug string) (*model.Tag, error) {
+	tr, err := s.tags.FindTranslationBySlug(ctx, slug)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTagNotFound
+		}
+		return nil, err
+	}
+	t, err := s.findTag(ctx, tr.TagID)
+	if err != nil {
+		return nil, err
+	}
+	t.Name, t.Slug = tr.Name, tr.Slug
+	return t, nil
+}
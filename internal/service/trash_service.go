@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/handler/request"
+	"github.com/turahe/go-restfull/internal/repository"
+	"github.com/turahe/go-restfull/internal/tenant"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// trashPurgeBatch caps how many entries of each type one auto-purge run removes.
+const trashPurgeBatch = 200
+
+var (
+	ErrTrashItemNotFound  = errors.New("item not found in trash")
+	ErrInvalidTrashType   = repository.ErrInvalidTrashType
+	ErrTrashParentDeleted = repository.ErrTrashParentDeleted
+	ErrTrashInUse         = repository.ErrTrashInUse
+	ErrTrashConflict      = repository.ErrTrashConflict
+)
+
+// TrashService lists soft-deleted posts, categories, comments, tags and media, restores them and
+// purges them, by hand or once they are older than the retention period.
+type TrashService struct {
+	trash *repository.TrashRepository
+	posts *PostService
+	// media deletes the files of purged media; nil leaves them in storage.
+	media     *MediaService
+	retention time.Duration
+	log       *zap.Logger
+}
+
+// NewTrashService builds the service; retentionDays <= 0 keeps deleted items until purged by hand.
+func NewTrashService(trash *repository.TrashRepository, posts *PostService, media *MediaService, retentionDays int, log *zap.Logger) *TrashService {
+	var retention time.Duration
+	if retentionDays > 0 {
+		retention = time.Duration(retentionDays) * 24 * time.Hour
+	}
+	return &TrashService{trash: trash, posts: posts, media: media, retention: retention, log: log}
+}
+
+// List pages the trash entries of req.Type, each with when auto-purge will remove it.
+func (s *TrashService) List(ctx context.Context, req request.TrashListRequest) (repository.CursorPage, error) {
+	page, err := s.trash.List(ctx, req)
+	if err != nil {
+		return page, err
+	}
+	if s.retention > 0 {
+		for i, rows := 0, page.Items.([]repository.TrashEntry); i < len(rows); i++ {
+			at := rows[i].DeletedAt.Add(s.retention)
+			rows[i].PurgeAt = &at
+		}
+	}
+	return page, nil
+}
+
+// Restore brings a trash entry back. Categories, comments and media return with the subtree deleted
+// with them; a post needs its category to be live, a comment its post.
+func (s *TrashService) Restore(ctx context.Context, typ string, id uint, actorUserID uint) error {
+	if err := s.trash.Restore(ctx, typ, id, actorUserID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrTrashItemNotFound
+		}
+		return err
+	}
+	if typ == repository.TrashPosts && s.posts != nil {
+		s.posts.indexPost(ctx, id)
+	}
+	return nil
+}
+
+// Purge removes a trash entry for good, along with the stored files of purged media.
+func (s *TrashService) Purge(ctx context.Context, typ string, id uint) error {
+	keys, err := s.trash.Purge(ctx, typ, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrTrashItemNotFound
+		}
+		return err
+	}
+	s.deleteObjects(ctx, keys)
+	return nil
+}
+
+// deleteObjects removes purged files from storage. The rows are gone already, so failures only
+// leave orphaned objects behind and are logged.
+func (s *TrashService) deleteObjects(ctx context.Context, keys []string) {
+	if s.media == nil {
+		return
+	}
+	for _, key := range keys {
+		if err := s.media.store.Delete(ctx, key); err != nil {
+			s.log.Warn("failed to delete purged media object", zap.String("key", key), zap.Error(err))
+		}
+	}
+}
+
+// PurgeExpired purges entries deleted before now minus the retention period, on every site, up to
+// trashPurgeBatch per type. Posts go first, so their comments leave with them; categories that
+// deleted posts still use are skipped until those posts are purged too.
+func (s *TrashService) PurgeExpired(ctx context.Context, now time.Time) (int, error) {
+	if s.retention <= 0 {
+		return 0, nil
+	}
+	cutoff := now.Add(-s.retention)
+	purged := 0
+	for _, typ := range []string{repository.TrashPosts, repository.TrashComments, repository.TrashTags, repository.TrashCategories, repository.TrashMedia} {
+		entries, err := s.trash.Expired(ctx, typ, cutoff, trashPurgeBatch)
+		if err != nil {
+			return purged, err
+		}
+		for _, e := range entries {
+			err := s.Purge(tenant.WithSiteID(ctx, e.SiteID), typ, e.ID)
+			switch {
+			case err == nil:
+				purged++
+			case errors.Is(err, ErrTrashItemNotFound), errors.Is(err, ErrTrashInUse):
+			default:
+				return purged, err
+			}
+		}
+	}
+	return purged, nil
+}
+
+// RunPurger calls PurgeExpired every interval until ctx is done.
+func (s *TrashService) RunPurger(ctx context.Context, interval time.Duration) {
+	if interval <= 0 || s.retention <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := s.PurgeExpired(ctx, time.Now())
+			if err != nil {
+				s.log.Error("trash purge failed", zap.Error(err))
+			} else if n > 0 {
+				s.log.Info("trash purged", zap.Int("items", n))
+			}
+		}
+	}
+}
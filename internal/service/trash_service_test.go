@@ -0,0 +1,262 @@
+package service
+
+import (
+	"context"
+	"io"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/turahe/go-restfull/internal/handler/request"
+	"github.com/turahe/go-restfull/internal/model"
+	"github.com/turahe/go-restfull/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type trashTestStore struct{ deleted []string }
+
+func (s *trashTestStore) Put(context.Context, string, io.Reader, int64, string) error { return nil }
+func (s *trashTestStore) SignedURL(context.Context, string, time.Duration) (string, error) {
+	return "", nil
+}
+func (s *trashTestStore) Delete(_ context.Context, key string) error {
+	s.deleted = append(s.deleted, key)
+	return nil
+}
+
+// assertNestedSet checks that the live rows of one forest form a gapless nested set consistent with
+// their parent ids.
+func assertNestedSet[T any](t *testing.T, db *gorm.DB, m *T, where string, args ...any) {
+	t.Helper()
+	type node struct {
+		ID       uint
+		ParentID *uint
+		Lft      int
+		Rgt      int
+		Depth    int
+	}
+	var rows []node
+	require.NoError(t, db.Model(m).Where(where, args...).Select("id", "parent_id", "lft", "rgt", "depth").Find(&rows).Error)
+	byID := map[uint]node{}
+	var bounds []int
+	for _, n := range rows {
+		byID[n.ID] = n
+		bounds = append(bounds, n.Lft, n.Rgt)
+	}
+	sort.Ints(bounds)
+	for i, b := range bounds {
+		require.Equal(t, i+1, b, "bounds must be 1..2n without gaps")
+	}
+	for _, n := range rows {
+		require.Less(t, n.Lft, n.Rgt)
+		if n.ParentID == nil {
+			require.Equal(t, 0, n.Depth)
+			continue
+		}
+		p, ok := byID[*n.ParentID]
+		require.True(t, ok, "parent of %d must be live", n.ID)
+		require.True(t, p.Lft < n.Lft && n.Rgt < p.Rgt, "%d must nest in its parent", n.ID)
+		require.Equal(t, p.Depth+1, n.Depth)
+	}
+}
+
+func TestTrash(t *testing.T) {
+	ctx := context.Background()
+	env := newPostTestEnv(t)
+	db, log, catRepo, tagRepo, posts := env.db, env.log, env.categories, env.tags, env.posts
+	require.NoError(t, db.Exec("CREATE TABLE IF NOT EXISTS category_media (category_id integer, media_id integer)").Error)
+	commentRepo := repository.NewCommentRepository(db, log)
+	mediaRepo := repository.NewMediaRepository(db, log)
+	comments := NewCommentService(commentRepo, nil, log)
+	tags := NewTagService(tagRepo, log)
+	store := &trashTestStore{}
+	media := &MediaService{repo: mediaRepo, store: store, log: log}
+	trash := NewTrashService(repository.NewTrashRepository(db, log), posts, media, 30, log)
+
+	ada := model.User{Name: "Ada", Email: "ada@trash.test", Password: "x"}
+	require.NoError(t, db.Create(&ada).Error)
+	list := func(t *testing.T, typ string) []repository.TrashEntry {
+		t.Helper()
+		page, err := trash.List(ctx, request.TrashListRequest{Type: typ})
+		require.NoError(t, err)
+		return page.Items.([]repository.TrashEntry)
+	}
+	live := func(m any, id uint) bool {
+		var n int64
+		require.NoError(t, db.Model(m).Where("id = ?", id).Count(&n).Error)
+		return n == 1
+	}
+	news, err := catRepo.CreateRoot(ctx, "News", ada.ID)
+	require.NoError(t, err)
+
+	t.Run("category subtrees come back whole, after later siblings", func(t *testing.T) {
+		world, err := catRepo.CreateChild(ctx, news.ID, "World", ada.ID)
+		require.NoError(t, err)
+		europe, err := catRepo.CreateChild(ctx, world.ID, "Europe", ada.ID)
+		require.NoError(t, err)
+		asia, err := catRepo.CreateChild(ctx, world.ID, "Asia", ada.ID)
+		require.NoError(t, err)
+		_, err = catRepo.CreateRoot(ctx, "Sport", ada.ID)
+		require.NoError(t, err)
+
+		// Asia goes first on its own, then World with Europe.
+		require.NoError(t, catRepo.DeleteSubtree(ctx, asia.ID, ada.ID))
+		require.NoError(t, catRepo.DeleteSubtree(ctx, world.ID, ada.ID))
+		_, err = catRepo.CreateChild(ctx, news.ID, "Local", ada.ID)
+		require.NoError(t, err)
+		assertNestedSet(t, db, &model.CategoryModel{}, "1 = 1")
+
+		entries := list(t, repository.TrashCategories)
+		require.Len(t, entries, 2)
+		assert.Equal(t, world.ID, entries[0].ID, "most recently deleted first")
+		assert.Equal(t, "World", entries[0].Title)
+		assert.Equal(t, 2, entries[0].Items)
+		assert.Equal(t, ada.ID, *entries[0].DeletedBy)
+		require.NotNil(t, entries[0].PurgeAt)
+		assert.WithinDuration(t, entries[0].DeletedAt.Add(30*24*time.Hour), *entries[0].PurgeAt, time.Second)
+		assert.Equal(t, asia.ID, entries[1].ID)
+
+		assert.ErrorIs(t, trash.Restore(ctx, repository.TrashCategories, asia.ID, ada.ID), ErrTrashParentDeleted)
+		assert.ErrorIs(t, trash.Restore(ctx, repository.TrashCategories, europe.ID, ada.ID), ErrTrashItemNotFound, "only entries can be restored")
+
+		require.NoError(t, trash.Restore(ctx, repository.TrashCategories, world.ID, ada.ID))
+		assertNestedSet(t, db, &model.CategoryModel{}, "1 = 1")
+		assert.True(t, live(&model.CategoryModel{}, europe.ID))
+		assert.False(t, live(&model.CategoryModel{}, asia.ID))
+		sub, err := catRepo.GetSubtree(ctx, news.ID)
+		require.NoError(t, err)
+		names := make([]string, 0, len(sub))
+		for _, c := range sub {
+			names = append(names, c.Name)
+		}
+		assert.Equal(t, []string{"News", "Local", "World", "Europe"}, names)
+
+		require.NoError(t, trash.Restore(ctx, repository.TrashCategories, asia.ID, ada.ID))
+		assertNestedSet(t, db, &model.CategoryModel{}, "1 = 1")
+		assert.Empty(t, list(t, repository.TrashCategories))
+		assert.ErrorIs(t, trash.Restore(ctx, repository.TrashCategories, asia.ID, ada.ID), ErrTrashItemNotFound)
+	})
+
+	past := time.Now().Add(-time.Hour)
+	desk, err := catRepo.CreateChild(ctx, news.ID, "Desk", ada.ID)
+	require.NoError(t, err)
+	post, err := posts.Create(ctx, ada.ID, request.CreatePostRequest{Title: "Story", Content: "x", CategoryID: desk.ID, PublishAt: &past})
+	require.NoError(t, err)
+
+	t.Run("comment threads come back whole", func(t *testing.T) {
+		root, err := comments.CreateRoot(ctx, post.ID, ada.ID, request.CreateCommentRequest{Content: "Root"})
+		require.NoError(t, err)
+		reply, err := comments.CreateChild(ctx, post.ID, root.ID, ada.ID, request.CreateCommentRequest{Content: "Reply"})
+		require.NoError(t, err)
+		_, err = comments.CreateChild(ctx, post.ID, reply.ID, ada.ID, request.CreateCommentRequest{Content: "Nested"})
+		require.NoError(t, err)
+		_, err = comments.CreateRoot(ctx, post.ID, ada.ID, request.CreateCommentRequest{Content: "Other"})
+		require.NoError(t, err)
+
+		require.NoError(t, comments.Delete(ctx, post.ID, reply.ID, ada.ID))
+		entries := list(t, repository.TrashComments)
+		require.Len(t, entries, 1)
+		assert.Equal(t, "Reply", entries[0].Title)
+		assert.Equal(t, post.ID, *entries[0].PostID)
+		assert.Equal(t, 2, entries[0].Items)
+
+		require.NoError(t, trash.Restore(ctx, repository.TrashComments, reply.ID, ada.ID))
+		assertNestedSet(t, db, &model.Comment{}, "post_id = ?", post.ID)
+		tree, err := comments.GetTree(ctx, post.ID, 0)
+		require.NoError(t, err)
+		require.Len(t, tree, 2)
+		require.Len(t, tree[0].Children, 1)
+		assert.Len(t, tree[0].Children[0].Children, 1)
+	})
+
+	t.Run("posts need a live category and purge with their comments", func(t *testing.T) {
+		require.NoError(t, posts.Delete(ctx, post.ID, ada.ID))
+		entries := list(t, repository.TrashPosts)
+		require.Len(t, entries, 1)
+		assert.Equal(t, "Story", entries[0].Title)
+
+		require.NoError(t, trash.Restore(ctx, repository.TrashPosts, post.ID, ada.ID))
+		got, err := posts.GetBySlug(ctx, post.Slug, PostAccess{}, LocalePreference{})
+		require.NoError(t, err)
+		assert.Equal(t, post.ID, got.ID)
+
+		require.NoError(t, posts.Delete(ctx, post.ID, ada.ID))
+		require.NoError(t, catRepo.DeleteSubtree(ctx, desk.ID, ada.ID))
+		assert.ErrorIs(t, trash.Restore(ctx, repository.TrashPosts, post.ID, ada.ID), ErrTrashParentDeleted)
+		assert.ErrorIs(t, trash.Purge(ctx, repository.TrashCategories, desk.ID), ErrTrashInUse)
+
+		require.NoError(t, trash.Purge(ctx, repository.TrashPosts, post.ID))
+		var n int64
+		require.NoError(t, db.Unscoped().Model(&model.Comment{}).Where("post_id = ?", post.ID).Count(&n).Error)
+		assert.Zero(t, n)
+		require.NoError(t, db.Unscoped().Model(&model.Post{}).Where("id = ?", post.ID).Count(&n).Error)
+		assert.Zero(t, n)
+
+		require.NoError(t, trash.Purge(ctx, repository.TrashCategories, desk.ID))
+		require.NoError(t, db.Unscoped().Model(&model.CategoryModel{}).Where("id = ?", desk.ID).Count(&n).Error)
+		assert.Zero(t, n)
+		assert.ErrorIs(t, trash.Purge(ctx, repository.TrashCategories, desk.ID), ErrTrashItemNotFound)
+	})
+
+	t.Run("tags record who deleted them", func(t *testing.T) {
+		tag, err := tags.Create(ctx, ada.ID, request.CreateTagRequest{Name: "Go"})
+		require.NoError(t, err)
+		require.NoError(t, tags.Delete(ctx, tag.ID, ada.ID))
+		entries := list(t, repository.TrashTags)
+		require.Len(t, entries, 1)
+		assert.Equal(t, ada.ID, *entries[0].DeletedBy)
+
+		require.NoError(t, trash.Restore(ctx, repository.TrashTags, tag.ID, ada.ID))
+		assert.True(t, live(&model.Tag{}, tag.ID))
+		require.NoError(t, tags.Delete(ctx, tag.ID, ada.ID))
+		require.NoError(t, trash.Purge(ctx, repository.TrashTags, tag.ID))
+		assert.Empty(t, list(t, repository.TrashTags))
+	})
+
+	t.Run("media keeps its files until purged", func(t *testing.T) {
+		folder, err := mediaRepo.CreateFolderRoot(ctx, ada.ID, "Photos", ada.ID)
+		require.NoError(t, err)
+		file := &model.Media{UserID: ada.ID, Name: "a.png", MediaType: "image", OriginalName: "a.png", MimeType: "image/png", Size: 1, StoragePath: "media/a.png", CreatedBy: ada.ID, UpdatedBy: ada.ID}
+		require.NoError(t, mediaRepo.CreateFileChild(ctx, ada.ID, folder.ID, file))
+
+		require.NoError(t, media.Delete(ctx, ada.ID, folder.ID))
+		assert.Empty(t, store.deleted)
+		require.NoError(t, trash.Restore(ctx, repository.TrashMedia, folder.ID, ada.ID))
+		assert.True(t, live(&model.Media{}, file.ID))
+		assertNestedSet(t, db, &model.Media{}, "user_id = ?", ada.ID)
+
+		require.NoError(t, media.Delete(ctx, ada.ID, folder.ID))
+		require.NoError(t, trash.Purge(ctx, repository.TrashMedia, folder.ID))
+		assert.Equal(t, []string{"media/a.png"}, store.deleted)
+	})
+
+	t.Run("unknown types are rejected", func(t *testing.T) {
+		assert.ErrorIs(t, trash.Restore(ctx, "users", 1, ada.ID), ErrInvalidTrashType)
+		assert.ErrorIs(t, trash.Purge(ctx, "users", 1), ErrInvalidTrashType)
+	})
+
+	t.Run("auto-purge removes entries past retention", func(t *testing.T) {
+		fresh, err := tags.Create(ctx, ada.ID, request.CreateTagRequest{Name: "Fresh"})
+		require.NoError(t, err)
+		stale, err := tags.Create(ctx, ada.ID, request.CreateTagRequest{Name: "Stale"})
+		require.NoError(t, err)
+		require.NoError(t, tags.Delete(ctx, fresh.ID, ada.ID))
+		require.NoError(t, tags.Delete(ctx, stale.ID, ada.ID))
+		require.NoError(t, db.Unscoped().Model(&model.Tag{}).Where("id = ?", stale.ID).Update("deleted_at", time.Now().Add(-31*24*time.Hour)).Error)
+
+		n, err := trash.PurgeExpired(ctx, time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+		entries := list(t, repository.TrashTags)
+		require.Len(t, entries, 1)
+		assert.Equal(t, fresh.ID, entries[0].ID)
+
+		off := NewTrashService(repository.NewTrashRepository(db, log), posts, media, 0, log)
+		n, err = off.PurgeExpired(ctx, time.Now().Add(365*24*time.Hour))
+		require.NoError(t, err)
+		assert.Zero(t, n)
+	})
+}
@@ -31,7 +31,7 @@ func BenchmarkUserService_List(b *testing.B) {
 		users[i] = model.User{ID: uint(i + 1), Email: "a@b.com", Name: "A"}
 	}
 	listReq := request.UserListRequest{
-		PageRequest: request.PageRequest{Limit: 20},
+		CursorRequest: request.CursorRequest{Limit: 20},
 	}
 	repo.On("List", mock.Anything, listReq).Return(repository.CursorPage{
 		Items: users,
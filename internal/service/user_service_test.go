@@ -149,7 +149,7 @@ func TestUserService_List(t *testing.T) {
 	ctx := context.Background()
 	repo := &mockUserRepo{}
 	listReq := request.UserListRequest{
-		PageRequest: request.PageRequest{Limit: 10},
+		CursorRequest: request.CursorRequest{Limit: 10},
 	}
 	repo.On("List", mock.Anything, listReq).Return(repository.CursorPage{
 		Items: []model.User{{ID: 1}},
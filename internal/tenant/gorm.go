@@ -0,0 +1,98 @@
+package tenant
+
+import (
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// Column is the tenant column on site-scoped tables; models opt in by declaring a SiteID field.
+const Column = "site_id"
+
+// Plugin scopes every statement on a model with a SiteID field to the site in the statement context:
+// reads, updates and deletes get "site_id = ?" and creates get SiteID filled in.
+// Raw SQL (Exec/Raw) and Table() queries without a model are not rewritten and must filter explicitly.
+type Plugin struct{}
+
+func (Plugin) Name() string { return "tenant" }
+
+func (Plugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	if err := cb.Create().Before("gorm:create").Register("tenant:create", setSiteID); err != nil {
+		return err
+	}
+	if err := cb.Query().Before("gorm:query").Register("tenant:query", addSiteFilter); err != nil {
+		return err
+	}
+	if err := cb.Row().Before("gorm:row").Register("tenant:row", addSiteFilter); err != nil {
+		return err
+	}
+	if err := cb.Update().Before("gorm:update").Register("tenant:update", addSiteFilterGuarded); err != nil {
+		return err
+	}
+	return cb.Delete().Before("gorm:delete").Register("tenant:delete", addSiteFilterGuarded)
+}
+
+func siteField(db *gorm.DB) *schema.Field {
+	if db.Error != nil || db.Statement.Schema == nil {
+		return nil
+	}
+	return db.Statement.Schema.LookUpField("SiteID")
+}
+
+func setSiteID(db *gorm.DB) {
+	f := siteField(db)
+	if f == nil || AllSites(db.Statement.Context) {
+		return
+	}
+	siteID := SiteID(db.Statement.Context)
+	rv := db.Statement.ReflectValue
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			setFieldIfZero(db, f, reflect.Indirect(rv.Index(i)), siteID)
+		}
+	case reflect.Struct:
+		setFieldIfZero(db, f, rv, siteID)
+	}
+}
+
+func setFieldIfZero(db *gorm.DB, f *schema.Field, rv reflect.Value, siteID uint) {
+	if _, zero := f.ValueOf(db.Statement.Context, rv); zero {
+		if err := f.Set(db.Statement.Context, rv, siteID); err != nil {
+			_ = db.AddError(err)
+		}
+	}
+}
+
+func addSiteFilter(db *gorm.DB) {
+	if siteField(db) == nil || AllSites(db.Statement.Context) {
+		return
+	}
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: Column}, Value: SiteID(db.Statement.Context)},
+	}})
+}
+
+// addSiteFilterGuarded adds the filter only when the statement is already targeted (a WHERE clause or
+// primary key values), so GORM's missing-WHERE protection still rejects accidental global updates/deletes.
+func addSiteFilterGuarded(db *gorm.DB) {
+	if siteField(db) == nil {
+		return
+	}
+	if _, ok := db.Statement.Clauses["WHERE"]; !ok && !db.Statement.AllowGlobalUpdate && !hasPrimaryKey(db) {
+		return
+	}
+	addSiteFilter(db)
+}
+
+func hasPrimaryKey(db *gorm.DB) bool {
+	rv := db.Statement.ReflectValue
+	if !rv.IsValid() || len(db.Statement.Schema.PrimaryFields) == 0 {
+		return false
+	}
+	_, values := schema.GetIdentityFieldValuesMap(db.Statement.Context, rv, db.Statement.Schema.PrimaryFields)
+	return len(values) > 0
+}
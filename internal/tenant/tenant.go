@@ -0,0 +1,51 @@
+// Package tenant carries the current site through request contexts and scopes GORM queries to it.
+package tenant
+
+import (
+	"context"
+	"strconv"
+)
+
+// DefaultSiteID is the site every pre-existing row belongs to and the one used when a context carries no site.
+const DefaultSiteID uint = 1
+
+type ctxKey struct{}
+
+type scope struct {
+	siteID   uint
+	allSites bool
+}
+
+// WithSiteID returns a context scoped to siteID.
+func WithSiteID(ctx context.Context, siteID uint) context.Context {
+	return context.WithValue(ctx, ctxKey{}, scope{siteID: siteID})
+}
+
+// WithAllSites returns a context that bypasses the tenant filter (background jobs, migrations, CLI tools).
+func WithAllSites(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ctxKey{}, scope{allSites: true})
+}
+
+// SiteID returns the site id carried by ctx, or DefaultSiteID when none was set.
+func SiteID(ctx context.Context) uint {
+	if ctx != nil {
+		if s, ok := ctx.Value(ctxKey{}).(scope); ok && !s.allSites && s.siteID != 0 {
+			return s.siteID
+		}
+	}
+	return DefaultSiteID
+}
+
+// AllSites reports whether ctx was marked with WithAllSites.
+func AllSites(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	s, ok := ctx.Value(ctxKey{}).(scope)
+	return ok && s.allSites
+}
+
+// Domain is the Casbin domain string for a site.
+func Domain(siteID uint) string {
+	return strconv.FormatUint(uint64(siteID), 10)
+}
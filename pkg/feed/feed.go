@@ -0,0 +1,329 @@
+// Package feed renders a list of entries as RSS 2.0, Atom 1.0 or JSON Feed 1.1.
+package feed
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"time"
+)
+
+type Format string
+
+const (
+	RSS  Format = "rss"
+	Atom Format = "atom"
+	JSON Format = "json"
+)
+
+var ErrUnknownFormat = errors.New("unknown feed format")
+
+// ContentType is the media type a feed in f is served as.
+func (f Format) ContentType() string {
+	switch f {
+	case RSS:
+		return "application/rss+xml; charset=utf-8"
+	case Atom:
+		return "application/atom+xml; charset=utf-8"
+	case JSON:
+		return "application/feed+json; charset=utf-8"
+	}
+	return "application/octet-stream"
+}
+
+// Feed is the format-independent channel. Link is the site's home page and FeedURL the feed's own URL;
+// Updated is the latest change among the items (zero when there are none).
+type Feed struct {
+	Title       string
+	Description string
+	Link        string
+	FeedURL     string
+	Language    string
+	Updated     time.Time
+	Items       []Item
+}
+
+// Item is one entry. ID must be stable for the life of the entry (RSS guid, Atom id, JSON Feed id).
+type Item struct {
+	ID          string
+	Title       string
+	Link        string
+	Summary     string
+	ContentHTML string
+	Image       string
+	Authors     []string
+	Categories  []string
+	Published   time.Time
+	Updated     time.Time
+}
+
+// Render encodes f in format.
+func Render(f *Feed, format Format) ([]byte, error) {
+	switch format {
+	case RSS:
+		return marshalXML(rssOf(f))
+	case Atom:
+		return marshalXML(atomOf(f))
+	case JSON:
+		return json.MarshalIndent(jsonOf(f), "", "  ")
+	}
+	return nil, ErrUnknownFormat
+}
+
+func marshalXML(v any) ([]byte, error) {
+	b, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), b...), nil
+}
+
+type rssFeed struct {
+	XMLName   xml.Name   `xml:"rss"`
+	Version   string     `xml:"version,attr"`
+	AtomNS    string     `xml:"xmlns:atom,attr"`
+	ContentNS string     `xml:"xmlns:content,attr"`
+	DCNS      string     `xml:"xmlns:dc,attr"`
+	Channel   rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	Language      string    `xml:"language,omitempty"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Self          atomLink  `xml:"atom:link"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string        `xml:"title"`
+	Link        string        `xml:"link"`
+	GUID        rssGUID       `xml:"guid"`
+	Description string        `xml:"description,omitempty"`
+	Content     *cdata        `xml:"content:encoded,omitempty"`
+	Creators    []string      `xml:"dc:creator"`
+	Categories  []string      `xml:"category"`
+	PubDate     string        `xml:"pubDate,omitempty"`
+	Enclosure   *rssEnclosure `xml:"enclosure,omitempty"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Type   string `xml:"type,attr"`
+	Length int    `xml:"length,attr"`
+}
+
+type cdata struct {
+	Value string `xml:",cdata"`
+}
+
+func rssOf(f *Feed) rssFeed {
+	out := rssFeed{
+		Version:   "2.0",
+		AtomNS:    "http://www.w3.org/2005/Atom",
+		ContentNS: "http://purl.org/rss/1.0/modules/content/",
+		DCNS:      "http://purl.org/dc/elements/1.1/",
+		Channel: rssChannel{
+			Title:       f.Title,
+			Link:        f.Link,
+			Description: f.Description,
+			Language:    f.Language,
+			Self:        atomLink{Href: f.FeedURL, Rel: "self", Type: "application/rss+xml"},
+			Items:       make([]rssItem, len(f.Items)),
+		},
+	}
+	if !f.Updated.IsZero() {
+		out.Channel.LastBuildDate = f.Updated.UTC().Format(time.RFC1123Z)
+	}
+	for i, it := range f.Items {
+		ri := rssItem{
+			Title:       it.Title,
+			Link:        it.Link,
+			GUID:        rssGUID{Value: it.ID},
+			Description: it.Summary,
+			Creators:    it.Authors,
+			Categories:  it.Categories,
+		}
+		if it.ContentHTML != "" {
+			ri.Content = &cdata{Value: it.ContentHTML}
+		}
+		if !it.Published.IsZero() {
+			ri.PubDate = it.Published.UTC().Format(time.RFC1123Z)
+		}
+		if it.Image != "" {
+			ri.Enclosure = &rssEnclosure{URL: it.Image, Type: imageType(it.Image)}
+		}
+		out.Channel.Items[i] = ri
+	}
+	return out
+}
+
+type atomFeed struct {
+	XMLName  xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Lang     string      `xml:"xml:lang,attr,omitempty"`
+	ID       string      `xml:"id"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	Updated  string      `xml:"updated"`
+	Links    []atomLink  `xml:"link"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Links      []atomLink     `xml:"link"`
+	Published  string         `xml:"published,omitempty"`
+	Updated    string         `xml:"updated"`
+	Authors    []atomPerson   `xml:"author"`
+	Categories []atomCategory `xml:"category"`
+	Summary    string         `xml:"summary,omitempty"`
+	Content    *atomContent   `xml:"content,omitempty"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomContent struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+func atomOf(f *Feed) atomFeed {
+	updated := f.Updated
+	if updated.IsZero() {
+		// Atom requires <updated>; an empty feed reports the epoch so its ETag stays stable.
+		updated = time.Unix(0, 0)
+	}
+	out := atomFeed{
+		Lang:     f.Language,
+		ID:       f.FeedURL,
+		Title:    f.Title,
+		Subtitle: f.Description,
+		Updated:  updated.UTC().Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: f.Link, Rel: "alternate", Type: "text/html"},
+			{Href: f.FeedURL, Rel: "self", Type: "application/atom+xml"},
+		},
+		Entries: make([]atomEntry, len(f.Items)),
+	}
+	for i, it := range f.Items {
+		e := atomEntry{
+			ID:      it.ID,
+			Title:   it.Title,
+			Links:   []atomLink{{Href: it.Link, Rel: "alternate", Type: "text/html"}},
+			Updated: it.Updated.UTC().Format(time.RFC3339),
+			Summary: it.Summary,
+		}
+		if !it.Published.IsZero() {
+			e.Published = it.Published.UTC().Format(time.RFC3339)
+		}
+		if it.Image != "" {
+			e.Links = append(e.Links, atomLink{Href: it.Image, Rel: "enclosure", Type: imageType(it.Image)})
+		}
+		for _, a := range it.Authors {
+			e.Authors = append(e.Authors, atomPerson{Name: a})
+		}
+		for _, c := range it.Categories {
+			e.Categories = append(e.Categories, atomCategory{Term: c})
+		}
+		if it.ContentHTML != "" {
+			e.Content = &atomContent{Type: "html", Value: it.ContentHTML}
+		}
+		out.Entries[i] = e
+	}
+	return out
+}
+
+type jsonFeed struct {
+	Version     string     `json:"version"`
+	Title       string     `json:"title"`
+	HomePageURL string     `json:"home_page_url,omitempty"`
+	FeedURL     string     `json:"feed_url,omitempty"`
+	Description string     `json:"description,omitempty"`
+	Language    string     `json:"language,omitempty"`
+	Items       []jsonItem `json:"items"`
+}
+
+type jsonItem struct {
+	ID            string       `json:"id"`
+	URL           string       `json:"url,omitempty"`
+	Title         string       `json:"title,omitempty"`
+	ContentHTML   string       `json:"content_html,omitempty"`
+	Summary       string       `json:"summary,omitempty"`
+	Image         string       `json:"image,omitempty"`
+	DatePublished string       `json:"date_published,omitempty"`
+	DateModified  string       `json:"date_modified,omitempty"`
+	Authors       []jsonAuthor `json:"authors,omitempty"`
+	Tags          []string     `json:"tags,omitempty"`
+}
+
+type jsonAuthor struct {
+	Name string `json:"name"`
+}
+
+func jsonOf(f *Feed) jsonFeed {
+	out := jsonFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       f.Title,
+		HomePageURL: f.Link,
+		FeedURL:     f.FeedURL,
+		Description: f.Description,
+		Language:    f.Language,
+		Items:       make([]jsonItem, len(f.Items)),
+	}
+	for i, it := range f.Items {
+		ji := jsonItem{
+			ID:          it.ID,
+			URL:         it.Link,
+			Title:       it.Title,
+			ContentHTML: it.ContentHTML,
+			Summary:     it.Summary,
+			Image:       it.Image,
+			Tags:        it.Categories,
+		}
+		if ji.ContentHTML == "" {
+			// JSON Feed requires content_html or content_text.
+			ji.ContentHTML = it.Summary
+		}
+		if !it.Published.IsZero() {
+			ji.DatePublished = it.Published.UTC().Format(time.RFC3339)
+		}
+		if !it.Updated.IsZero() {
+			ji.DateModified = it.Updated.UTC().Format(time.RFC3339)
+		}
+		for _, a := range it.Authors {
+			ji.Authors = append(ji.Authors, jsonAuthor{Name: a})
+		}
+		out.Items[i] = ji
+	}
+	return out
+}
+
+// imageType guesses an image media type from its URL's extension (RSS enclosures require one).
+func imageType(url string) string {
+	for ext, typ := range map[string]string{".png": "image/png", ".gif": "image/gif", ".webp": "image/webp", ".svg": "image/svg+xml"} {
+		if len(url) > len(ext) && url[len(url)-len(ext):] == ext {
+			return typ
+		}
+	}
+	return "image/jpeg"
+}
@@ -0,0 +1,103 @@
+package feed
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sample() *Feed {
+	pub := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	return &Feed{
+		Title:       "Blog",
+		Description: "Notes & essays",
+		Link:        "https://blog.example",
+		FeedURL:     "https://blog.example/feeds/posts.rss",
+		Language:    "en",
+		Updated:     pub.Add(time.Hour),
+		Items: []Item{{
+			ID:          "tag:blog.example,2026-03-01:post-7",
+			Title:       "Hello <world>",
+			Link:        "https://blog.example/posts/hello",
+			Summary:     "A greeting",
+			ContentHTML: "<p>Hi]]></p>",
+			Image:       "https://cdn.example/hello.png",
+			Authors:     []string{"Ada"},
+			Categories:  []string{"Go", "Notes"},
+			Published:   pub,
+			Updated:     pub.Add(time.Hour),
+		}},
+	}
+}
+
+func TestRender_RSS(t *testing.T) {
+	b, err := Render(sample(), RSS)
+	require.NoError(t, err)
+	var doc struct {
+		Channel struct {
+			Title string `xml:"title"`
+			Items []struct {
+				Title    string   `xml:"title"`
+				GUID     string   `xml:"guid"`
+				PubDate  string   `xml:"pubDate"`
+				Content  string   `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+				Category []string `xml:"category"`
+			} `xml:"item"`
+		} `xml:"channel"`
+	}
+	require.NoError(t, xml.Unmarshal(b, &doc), string(b))
+	require.Len(t, doc.Channel.Items, 1)
+	it := doc.Channel.Items[0]
+	assert.Equal(t, "Hello <world>", it.Title)
+	assert.Equal(t, "tag:blog.example,2026-03-01:post-7", it.GUID)
+	assert.Equal(t, "Sun, 01 Mar 2026 09:30:00 +0000", it.PubDate)
+	assert.Equal(t, "<p>Hi]]></p>", it.Content, "CDATA terminators inside content survive")
+	assert.Equal(t, []string{"Go", "Notes"}, it.Category)
+	assert.Contains(t, string(b), `<atom:link href="https://blog.example/feeds/posts.rss" rel="self" type="application/rss+xml">`)
+	assert.Contains(t, string(b), `type="image/png"`)
+}
+
+func TestRender_Atom(t *testing.T) {
+	b, err := Render(sample(), Atom)
+	require.NoError(t, err)
+	var doc struct {
+		XMLName xml.Name `xml:"http://www.w3.org/2005/Atom feed"`
+		Updated string   `xml:"updated"`
+		Entries []struct {
+			ID      string `xml:"id"`
+			Content string `xml:"content"`
+			Author  string `xml:"author>name"`
+		} `xml:"entry"`
+	}
+	require.NoError(t, xml.Unmarshal(b, &doc), string(b))
+	assert.Equal(t, "2026-03-01T10:30:00Z", doc.Updated)
+	require.Len(t, doc.Entries, 1)
+	assert.Equal(t, "<p>Hi]]></p>", doc.Entries[0].Content)
+	assert.Equal(t, "Ada", doc.Entries[0].Author)
+
+	empty, err := Render(&Feed{Title: "Empty"}, Atom)
+	require.NoError(t, err)
+	assert.Contains(t, string(empty), "<updated>1970-01-01T00:00:00Z</updated>")
+}
+
+func TestRender_JSON(t *testing.T) {
+	b, err := Render(sample(), JSON)
+	require.NoError(t, err)
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(b, &doc))
+	assert.Equal(t, "https://jsonfeed.org/version/1.1", doc["version"])
+	item := doc["items"].([]any)[0].(map[string]any)
+	assert.Equal(t, "2026-03-01T09:30:00Z", item["date_published"])
+	assert.Equal(t, []any{"Go", "Notes"}, item["tags"])
+
+	empty, err := Render(&Feed{Title: "Empty"}, JSON)
+	require.NoError(t, err)
+	assert.Contains(t, string(empty), `"items": []`)
+
+	_, err = Render(sample(), Format("yaml"))
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+}
@@ -0,0 +1,71 @@
+// Package frontmatter splits Markdown documents (Hugo, Jekyll) into their front matter and body, and
+// writes documents with YAML front matter.
+package frontmatter
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+var ErrUnterminated = errors.New("front matter is not terminated")
+
+// Parse reads YAML front matter (between --- lines) or TOML front matter (between +++ lines) from
+// the start of doc. Top-level keys are lower-cased, as Hugo matches them case-insensitively. A
+// document without front matter yields an empty map and the whole document as body.
+func Parse(doc []byte) (map[string]any, []byte, error) {
+	doc = bytes.TrimPrefix(doc, []byte("\xef\xbb\xbf"))
+	meta := map[string]any{}
+	first, rest, _ := bytes.Cut(doc, []byte("\n"))
+	var closers []string
+	var unmarshal func([]byte, any) error
+	switch strings.TrimSpace(string(first)) {
+	case "---":
+		closers, unmarshal = []string{"---", "..."}, yaml.Unmarshal
+	case "+++":
+		closers, unmarshal = []string{"+++"}, toml.Unmarshal
+	default:
+		return meta, doc, nil
+	}
+
+	var head bytes.Buffer
+	for len(rest) > 0 {
+		line, tail, _ := bytes.Cut(rest, []byte("\n"))
+		rest = tail
+		for _, c := range closers {
+			if strings.TrimSpace(string(line)) == c {
+				raw := map[string]any{}
+				if err := unmarshal(head.Bytes(), &raw); err != nil {
+					return nil, nil, err
+				}
+				for k, v := range raw {
+					meta[strings.ToLower(k)] = v
+				}
+				return meta, bytes.TrimLeft(rest, "\r\n"), nil
+			}
+		}
+		head.Write(line)
+		head.WriteByte('\n')
+	}
+	return nil, nil, ErrUnterminated
+}
+
+// Write renders meta (a struct with yaml tags, or a map) as YAML front matter followed by body.
+func Write(meta any, body []byte) ([]byte, error) {
+	head, err := yaml.Marshal(meta)
+	if err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	out.WriteString("---\n")
+	out.Write(head)
+	out.WriteString("---\n\n")
+	out.Write(body)
+	if len(body) > 0 && body[len(body)-1] != '\n' {
+		out.WriteByte('\n')
+	}
+	return out.Bytes(), nil
+}
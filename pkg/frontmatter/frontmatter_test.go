@@ -0,0 +1,54 @@
+package frontmatter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseYAML(t *testing.T) {
+	meta, body, err := Parse([]byte("---\r\nTitle: Hello\r\ntags: [go, web]\r\ndraft: true\r\n---\r\n\r\n# Hi\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "Hello", meta["title"])
+	assert.Equal(t, []any{"go", "web"}, meta["tags"])
+	assert.Equal(t, true, meta["draft"])
+	assert.Equal(t, "# Hi\n", string(body))
+}
+
+func TestParseTOML(t *testing.T) {
+	meta, body, err := Parse([]byte("+++\ntitle = \"Hello\"\ndate = 2024-05-01T09:30:00Z\ncategories = [\"Travel\"]\n+++\nBody"))
+	require.NoError(t, err)
+	assert.Equal(t, "Hello", meta["title"])
+	assert.Equal(t, time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC), meta["date"])
+	assert.Equal(t, []any{"Travel"}, meta["categories"])
+	assert.Equal(t, "Body", string(body))
+}
+
+func TestParseWithoutFrontMatter(t *testing.T) {
+	meta, body, err := Parse([]byte("# Just text\n---\n"))
+	require.NoError(t, err)
+	assert.Empty(t, meta)
+	assert.Equal(t, "# Just text\n---\n", string(body))
+
+	_, _, err = Parse([]byte("---\ntitle: open\n"))
+	assert.ErrorIs(t, err, ErrUnterminated)
+	_, _, err = Parse([]byte("---\ntitle: [broken\n---\n"))
+	assert.Error(t, err)
+}
+
+func TestWriteRoundTrip(t *testing.T) {
+	type fm struct {
+		Title string   `yaml:"title"`
+		Tags  []string `yaml:"tags,omitempty"`
+	}
+	doc, err := Write(fm{Title: "A: B", Tags: []string{"go"}}, []byte("Body"))
+	require.NoError(t, err)
+	assert.Equal(t, "---\ntitle: 'A: B'\ntags:\n    - go\n---\n\nBody\n", string(doc))
+
+	meta, body, err := Parse(doc)
+	require.NoError(t, err)
+	assert.Equal(t, "A: B", meta["title"])
+	assert.Equal(t, "Body\n", string(body))
+}
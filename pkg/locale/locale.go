@@ -0,0 +1,66 @@
+// Package locale normalizes BCP 47 language tags and negotiates a content locale for a request.
+package locale
+
+import (
+	"errors"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// MaxLen is the longest locale string stored (BCP 47 tags rarely exceed it).
+const MaxLen = 35
+
+var ErrInvalid = errors.New("invalid locale")
+
+// Normalize returns the canonical form of a BCP 47 tag ("EN_us" becomes "en-US").
+func Normalize(s string) (string, error) {
+	s = strings.ReplaceAll(strings.TrimSpace(s), "_", "-")
+	if s == "" {
+		return "", ErrInvalid
+	}
+	tag, err := language.Parse(s)
+	if err != nil || tag == language.Und {
+		return "", ErrInvalid
+	}
+	out := tag.String()
+	if len(out) > MaxLen {
+		return "", ErrInvalid
+	}
+	return out, nil
+}
+
+// Negotiate picks the locale to serve. An explicit requested locale wins when it is valid. Otherwise
+// the best match of the Accept-Language header among available is used, and def when nothing matches.
+func Negotiate(requested, acceptLanguage string, available []string, def string) string {
+	if requested != "" {
+		if l, err := Normalize(requested); err == nil {
+			return l
+		}
+	}
+	if acceptLanguage == "" || len(available) == 0 {
+		return def
+	}
+	wanted, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(wanted) == 0 {
+		return def
+	}
+	supported := make([]language.Tag, 0, len(available))
+	names := make([]string, 0, len(available))
+	for _, a := range available {
+		tag, err := language.Parse(a)
+		if err != nil {
+			continue
+		}
+		supported = append(supported, tag)
+		names = append(names, a)
+	}
+	if len(supported) == 0 {
+		return def
+	}
+	_, i, conf := language.NewMatcher(supported).Match(wanted...)
+	if conf == language.No {
+		return def
+	}
+	return names[i]
+}
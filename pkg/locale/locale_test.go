@@ -0,0 +1,37 @@
+package locale
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	for in, want := range map[string]string{"en": "en", "EN_us": "en-US", " id ": "id", "pt-br": "pt-BR", "zh-hant": "zh-Hant"} {
+		got, err := Normalize(in)
+		assert.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+	for _, in := range []string{"", "und", "not a locale", "x"} {
+		_, err := Normalize(in)
+		assert.ErrorIs(t, err, ErrInvalid, in)
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	available := []string{"en", "id", "pt-BR"}
+	cases := []struct {
+		name, requested, accept, want string
+	}{
+		{"explicit wins", "ID", "pt-BR", "id"},
+		{"explicit without content still wins", "fr", "", "fr"},
+		{"invalid explicit falls through", "???", "id", "id"},
+		{"accept-language by quality", "", "fr;q=0.9, pt-BR;q=0.8, en;q=0.5", "pt-BR"},
+		{"regional variant matches base", "", "id-ID", "id"},
+		{"no match uses default", "", "ja", "en"},
+		{"nothing sent uses default", "", "", "en"},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.want, Negotiate(c.requested, c.accept, available, "en"), c.name)
+	}
+}
@@ -0,0 +1,79 @@
+// Package markup renders post content (Markdown, HTML or plain text) to sanitized HTML and derives
+// a table of contents, plain text, word count and reading time from the result.
+package markup
+
+import (
+	"html"
+	"strings"
+
+	"github.com/russross/blackfriday/v2"
+)
+
+// Version identifies the rendering rules. Bump it whenever output for the same input changes, so
+// stored renders are refreshed.
+const Version = 1
+
+// WordsPerMinute is the reading speed behind Document.ReadingMinutes.
+const WordsPerMinute = 200
+
+// Heading is a table-of-contents entry; ID is the anchor set on the heading element.
+type Heading struct {
+	Level int    `json:"level"`
+	ID    string `json:"id"`
+	Text  string `json:"text"`
+}
+
+// Document is rendered content.
+type Document struct {
+	// HTML is safe to embed: only allowlisted elements, attributes and URL schemes survive.
+	HTML string
+	TOC  []Heading
+	// Text is the visible text with whitespace collapsed.
+	Text           string
+	Words          int
+	ReadingMinutes int
+}
+
+const markdownExtensions = blackfriday.CommonExtensions | blackfriday.Footnotes
+
+// Markdown renders CommonMark-style Markdown (tables, fenced code, footnotes). Inline HTML is
+// allowed through the same sanitizer as HTML content.
+func Markdown(src string) Document {
+	return Sanitize(string(blackfriday.Run([]byte(src), blackfriday.WithExtensions(markdownExtensions))))
+}
+
+// HTML sanitizes author-supplied HTML.
+func HTML(src string) Document {
+	return Sanitize(src)
+}
+
+// Plain renders text as paragraphs (split on blank lines) with line breaks kept.
+func Plain(src string) Document {
+	var b strings.Builder
+	for _, para := range strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n\n") {
+		para = strings.TrimSpace(para)
+		if para == "" {
+			continue
+		}
+		b.WriteString("<p>")
+		b.WriteString(strings.ReplaceAll(html.EscapeString(para), "\n", "<br>"))
+		b.WriteString("</p>")
+	}
+	return Sanitize(b.String())
+}
+
+// Excerpt returns up to max runes of text, cut at a word boundary when possible.
+func Excerpt(text string, max int) string {
+	runes := []rune(text)
+	if len(runes) <= max {
+		return text
+	}
+	cut := max
+	for cut > max/2 && runes[cut] != ' ' {
+		cut--
+	}
+	if runes[cut] != ' ' {
+		cut = max
+	}
+	return strings.TrimSpace(string(runes[:cut]))
+}
@@ -0,0 +1,63 @@
+package markup
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkdown_TOCAndStats(t *testing.T) {
+	doc := Markdown("# Intro\n\nHello *world*.\n\n## Setup\n\n```go\nfmt.Println(1)\n```\n\n## Setup\n")
+	assert.Equal(t, []Heading{
+		{Level: 1, ID: "intro", Text: "Intro"},
+		{Level: 2, ID: "setup", Text: "Setup"},
+		{Level: 2, ID: "setup-2", Text: "Setup"},
+	}, doc.TOC)
+	assert.Contains(t, doc.HTML, `<h2 id="setup-2">`)
+	assert.Contains(t, doc.HTML, `<em>world</em>`)
+	assert.Contains(t, doc.HTML, `<code class="language-go">`)
+	assert.Equal(t, "Intro Hello world. Setup fmt.Println(1) Setup", doc.Text)
+	assert.Equal(t, 6, doc.Words)
+	assert.Equal(t, 1, doc.ReadingMinutes)
+
+	long := Plain(strings.Repeat("word ", 401))
+	assert.Equal(t, 3, long.ReadingMinutes)
+	assert.Zero(t, Plain("  ").ReadingMinutes)
+}
+
+func TestSanitize_StripsActiveContent(t *testing.T) {
+	cases := map[string]struct{ in, want string }{
+		"script":         {`<p>a<script>alert(1)</script>b</p>`, `<p>ab</p>`},
+		"handlers":       {`<img src="x.png" onerror="alert(1)">`, `<img src="x.png">`},
+		"js url":         {`<a href="javascript:alert(1)">x</a>`, `<a>x</a>`},
+		"entity js url":  {`<a href="&#106;avascript:alert(1)">x</a>`, `<a>x</a>`},
+		"tab js url":     {"<a href=\"java\tscript:alert(1)\">x</a>", `<a>x</a>`},
+		"data img":       {`<img src="data:image/svg+xml,<svg onload=alert(1)>">`, `<img>`},
+		"safe link":      {`<a href="https://example.com/?a=1&b=2" target="_blank">x</a>`, `<a href="https://example.com/?a=1&amp;b=2">x</a>`},
+		"unknown tag":    {`<custom><b>bold</b></custom>`, `<b>bold</b>`},
+		"style":          {`<p style="color:red">x<style>p{}</style></p>`, `<p>x</p>`},
+		"iframe":         {`<iframe src="https://evil"></iframe>ok`, `ok`},
+		"unbalanced":     {`<b>open <i>nested`, `<b>open <i>nested</i></b>`},
+		"code class":     {`<code class="x onclick">a</code>`, `<code>a</code>`},
+		"heading id":     {`<h2 id="Bad ID" onclick="x">Hi there</h2>`, `<h2 id="hi-there">Hi there</h2>`},
+		"escaped text":   {`1 < 2 & 3`, `1 &lt; 2 &amp; 3`},
+		"markdown raw":   {Markdown(`<script>x</script> **b**`).HTML, `<p> <strong>b</strong></p>`},
+		"html comment":   {`a<!-- secret -->b`, `ab`},
+		"mailto allowed": {`<a href="mailto:a@b.c">m</a>`, `<a href="mailto:a@b.c">m</a>`},
+		"mailto img":     {`<img src="mailto:a@b.c">`, `<img>`},
+	}
+	for name, c := range cases {
+		assert.Equal(t, c.want, Sanitize(c.in).HTML, name)
+	}
+}
+
+func TestPlainAndExcerpt(t *testing.T) {
+	doc := Plain("Line <one>\nline two\n\nSecond")
+	assert.Equal(t, "<p>Line &lt;one&gt;<br>line two</p><p>Second</p>", doc.HTML)
+	assert.Equal(t, "Line <one> line two Second", doc.Text)
+
+	assert.Equal(t, "short", Excerpt("short", 10))
+	assert.Equal(t, "hello big", Excerpt("hello big world", 12))
+	assert.Equal(t, "ééééé", Excerpt("éééééééééé", 5), "cuts runes, not bytes")
+}
@@ -0,0 +1,234 @@
+package markup
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// allowed maps each permitted element to its permitted attributes. Elements not listed are
+// unwrapped (their children are kept), except those in dropped, which go with their content.
+var allowed = map[atom.Atom][]string{
+	atom.P: nil, atom.Br: nil, atom.Hr: nil, atom.Div: nil, atom.Span: nil,
+	atom.H1: nil, atom.H2: nil, atom.H3: nil, atom.H4: nil, atom.H5: nil, atom.H6: nil,
+	atom.Strong: nil, atom.B: nil, atom.Em: nil, atom.I: nil, atom.U: nil, atom.S: nil,
+	atom.Del: nil, atom.Ins: nil, atom.Mark: nil, atom.Sub: nil, atom.Sup: nil, atom.Small: nil,
+	atom.Kbd: nil, atom.Abbr: {"title"}, atom.Q: nil, atom.Cite: nil,
+	atom.Blockquote: nil, atom.Pre: nil, atom.Code: {"class"},
+	atom.Ul: nil, atom.Ol: {"start"}, atom.Li: nil, atom.Dl: nil, atom.Dt: nil, atom.Dd: nil,
+	atom.A: {"href", "title"}, atom.Img: {"src", "alt", "title", "width", "height"},
+	atom.Figure: nil, atom.Figcaption: nil,
+	atom.Table: nil, atom.Thead: nil, atom.Tbody: nil, atom.Tfoot: nil, atom.Tr: nil,
+	atom.Th: {"align", "colspan", "rowspan"}, atom.Td: {"align", "colspan", "rowspan"},
+}
+
+var dropped = map[atom.Atom]bool{
+	atom.Script: true, atom.Style: true, atom.Iframe: true, atom.Object: true, atom.Embed: true,
+	atom.Noscript: true, atom.Template: true, atom.Textarea: true, atom.Select: true,
+	atom.Svg: true, atom.Math: true, atom.Head: true, atom.Title: true,
+}
+
+var blockLevel = map[atom.Atom]bool{
+	atom.P: true, atom.Br: true, atom.Hr: true, atom.Div: true, atom.Blockquote: true, atom.Pre: true,
+	atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true,
+	atom.Li: true, atom.Dt: true, atom.Dd: true, atom.Tr: true, atom.Td: true, atom.Th: true,
+	atom.Figcaption: true,
+}
+
+var (
+	codeClass = regexp.MustCompile(`^language-[A-Za-z0-9_+#-]{1,32}$`)
+	numeric   = regexp.MustCompile(`^[0-9]{1,4}$`)
+	anchorID  = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,79}$`)
+	nonAnchor = regexp.MustCompile(`[^\p{L}\p{N}]+`)
+)
+
+type sanitizer struct {
+	out  strings.Builder
+	text strings.Builder
+	toc  []Heading
+	ids  map[string]int
+}
+
+// Sanitize parses src as an HTML fragment and re-serializes only what the allowlist permits.
+// Headings get unique id anchors and are collected into the table of contents.
+func Sanitize(src string) Document {
+	ctx := &html.Node{Type: html.ElementNode, Data: "div", DataAtom: atom.Div}
+	nodes, err := html.ParseFragment(strings.NewReader(src), ctx)
+	if err != nil {
+		// The tokenizer only fails on reader errors; fall back to escaped text.
+		nodes = []*html.Node{{Type: html.TextNode, Data: src}}
+	}
+	s := &sanitizer{ids: map[string]int{}}
+	for _, n := range nodes {
+		s.node(n)
+	}
+	text := strings.Join(strings.Fields(s.text.String()), " ")
+	words := len(strings.Fields(text))
+	minutes := 0
+	if words > 0 {
+		minutes = (words + WordsPerMinute - 1) / WordsPerMinute
+	}
+	return Document{HTML: strings.TrimSpace(s.out.String()), TOC: s.toc, Text: text, Words: words, ReadingMinutes: minutes}
+}
+
+func (s *sanitizer) node(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		s.out.WriteString(html.EscapeString(n.Data))
+		s.text.WriteString(n.Data)
+		return
+	case html.ElementNode:
+	default:
+		// Comments and doctypes are dropped.
+		return
+	}
+	if dropped[n.DataAtom] {
+		return
+	}
+	attrs, ok := allowed[n.DataAtom]
+	if !ok {
+		s.children(n)
+		return
+	}
+	if blockLevel[n.DataAtom] {
+		s.text.WriteByte('\n')
+	}
+
+	s.out.WriteByte('<')
+	s.out.WriteString(n.Data)
+	if level := headingLevel(n.DataAtom); level > 0 {
+		h := Heading{Level: level, Text: strings.Join(strings.Fields(textOf(n)), " ")}
+		h.ID = s.anchor(attr(n, "id"), h.Text)
+		s.toc = append(s.toc, h)
+		fmt.Fprintf(&s.out, ` id="%s"`, html.EscapeString(h.ID))
+	}
+	for _, name := range attrs {
+		if v, ok := cleanAttr(n.DataAtom, name, attr(n, name)); ok {
+			fmt.Fprintf(&s.out, ` %s="%s"`, name, html.EscapeString(v))
+		}
+	}
+	s.out.WriteByte('>')
+	if n.DataAtom == atom.Br || n.DataAtom == atom.Hr || n.DataAtom == atom.Img {
+		return
+	}
+	s.children(n)
+	s.out.WriteString("</")
+	s.out.WriteString(n.Data)
+	s.out.WriteByte('>')
+	if blockLevel[n.DataAtom] {
+		s.text.WriteByte('\n')
+	}
+}
+
+func (s *sanitizer) children(n *html.Node) {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		s.node(c)
+	}
+}
+
+// anchor returns a unique id for a heading: the author's id when it is a plain slug, otherwise one
+// derived from the heading text.
+func (s *sanitizer) anchor(given, text string) string {
+	base := strings.ToLower(strings.TrimSpace(given))
+	if !anchorID.MatchString(base) {
+		base = strings.Trim(nonAnchor.ReplaceAllString(strings.ToLower(text), "-"), "-")
+		if r := []rune(base); len(r) > 80 {
+			base = strings.Trim(string(r[:80]), "-")
+		}
+		if base == "" {
+			base = "section"
+		}
+	}
+	s.ids[base]++
+	if n := s.ids[base]; n > 1 {
+		return fmt.Sprintf("%s-%d", base, n)
+	}
+	return base
+}
+
+func cleanAttr(el atom.Atom, name, v string) (string, bool) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return "", false
+	}
+	switch name {
+	case "href":
+		return v, safeURL(v, true)
+	case "src":
+		return v, safeURL(v, false)
+	case "class":
+		return v, el == atom.Code && codeClass.MatchString(v)
+	case "start", "width", "height", "colspan", "rowspan":
+		return v, numeric.MatchString(v)
+	case "align":
+		return v, v == "left" || v == "right" || v == "center"
+	default:
+		return v, true
+	}
+}
+
+// safeURL allows relative URLs and http(s); links may also be mailto. url.Parse rejects control
+// characters, so obfuscated schemes such as "java\tscript:" fail here too.
+func safeURL(v string, link bool) bool {
+	u, err := url.Parse(v)
+	if err != nil {
+		return false
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "", "http", "https":
+		return true
+	case "mailto":
+		return link
+	default:
+		return false
+	}
+}
+
+func headingLevel(a atom.Atom) int {
+	switch a {
+	case atom.H1:
+		return 1
+	case atom.H2:
+		return 2
+	case atom.H3:
+		return 3
+	case atom.H4:
+		return 4
+	case atom.H5:
+		return 5
+	case atom.H6:
+		return 6
+	}
+	return 0
+}
+
+func attr(n *html.Node, name string) string {
+	for _, a := range n.Attr {
+		if a.Namespace == "" && a.Key == name {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func textOf(n *html.Node) string {
+	var b strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			b.WriteString(n.Data)
+		}
+		if n.Type == html.ElementNode && dropped[n.DataAtom] {
+			return
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return b.String()
+}
@@ -16,6 +16,11 @@ const (
 	ServiceCodeRoles    = "08" // Roles
 	ServiceCodeMedia    = "09" // Media
 	ServiceCodeSettings = "10" // App settings (public, non-secret)
+	ServiceCodeSites    = "11" // Sites (tenants)
+	ServiceCodeSeries   = "12" // Post series
+	ServiceCodeRedirects = "13" // Path redirects
+	ServiceCodeReadingLists = "14" // Users' reading lists
+	ServiceCodeTrash = "15" // Trash (deleted items)
 )
 
 // Case codes (2 digits: 01-99)
@@ -45,6 +50,9 @@ const (
 	// Not found
 	CaseCodeNotFound = "31"
 
+	// Redirects
+	CaseCodeMovedPermanently = "41"
+
 	// Server errors
 	CaseCodeInternalError = "55"
 
@@ -13,6 +13,7 @@ type Envelope struct {
 	// Cursor-specific fields are optional and used by some pagination styles.
 	NextCursor *string `json:"nextCursor,omitempty"`
 	PrevCursor *string `json:"prevCursor,omitempty"`
+	Total      *int64  `json:"total,omitempty"`
 	Error   any    `json:"error,omitempty"`
 }
 
@@ -36,6 +37,22 @@ func JSONPaginated(c Context, httpStatus int, code int, message string, data any
 	})
 }
 
+// JSONCursorPaginated is JSONPaginated for keyset pages: next/prev are derived from the cursors,
+// which clients send back as after/before. total is omitted unless counted.
+func JSONCursorPaginated(c Context, httpStatus int, code int, message string, data any, nextCursor, prevCursor *string, total *int64) {
+	next, prev := nextCursor != nil, prevCursor != nil
+	c.JSON(httpStatus, Envelope{
+		Code:       code,
+		Message:    message,
+		Data:       data,
+		Next:       &next,
+		Prev:       &prev,
+		NextCursor: nextCursor,
+		PrevCursor: prevCursor,
+		Total:      total,
+	})
+}
+
 func OK(c Context, code int, message string, data any) {
 	JSON(c, http.StatusOK, code, message, data, nil)
 }
@@ -44,6 +61,10 @@ func OKPaginated(c Context, code int, message string, data any, next bool, prev
 	JSONPaginated(c, http.StatusOK, code, message, data, next, prev)
 }
 
+func OKCursorPaginated(c Context, code int, message string, data any, nextCursor, prevCursor *string, total *int64) {
+	JSONCursorPaginated(c, http.StatusOK, code, message, data, nextCursor, prevCursor, total)
+}
+
 func Created(c Context, code int, message string, data any) {
 	JSON(c, http.StatusCreated, code, message, data, nil)
 }
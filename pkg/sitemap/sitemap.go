@@ -0,0 +1,64 @@
+// Package sitemap encodes sitemap indexes and URL sets (sitemaps.org protocol 0.9).
+package sitemap
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// MaxURLs is the protocol's limit of URLs per sitemap (and of sitemaps per index).
+const MaxURLs = 50000
+
+const xmlns = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// Entry is a URL with its last modification; a zero LastMod is omitted.
+type Entry struct {
+	Loc     string
+	LastMod time.Time
+}
+
+type urlset struct {
+	XMLName xml.Name `xml:"urlset"`
+	XMLNS   string   `xml:"xmlns,attr"`
+	URLs    []loc    `xml:"url"`
+}
+
+type index struct {
+	XMLName  xml.Name `xml:"sitemapindex"`
+	XMLNS    string   `xml:"xmlns,attr"`
+	Sitemaps []loc    `xml:"sitemap"`
+}
+
+type loc struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// URLSet encodes a sitemap listing entries.
+func URLSet(entries []Entry) ([]byte, error) {
+	return marshal(urlset{XMLNS: xmlns, URLs: locs(entries)})
+}
+
+// Index encodes a sitemap index pointing at the given sitemaps.
+func Index(sitemaps []Entry) ([]byte, error) {
+	return marshal(index{XMLNS: xmlns, Sitemaps: locs(sitemaps)})
+}
+
+func locs(entries []Entry) []loc {
+	out := make([]loc, len(entries))
+	for i, e := range entries {
+		out[i] = loc{Loc: e.Loc}
+		if !e.LastMod.IsZero() {
+			out[i].LastMod = e.LastMod.UTC().Format(time.RFC3339)
+		}
+	}
+	return out
+}
+
+func marshal(v any) ([]byte, error) {
+	b, err := xml.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), b...), nil
+}
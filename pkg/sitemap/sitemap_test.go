@@ -0,0 +1,32 @@
+package sitemap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestURLSet(t *testing.T) {
+	b, err := URLSet([]Entry{
+		{Loc: "https://blog.example/posts/a?x=1&y=2", LastMod: time.Date(2026, 1, 2, 3, 4, 5, 0, time.FixedZone("WIB", 7*3600))},
+		{Loc: "https://blog.example/tags/go"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>`+"\n"+
+		`<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`+
+		`<url><loc>https://blog.example/posts/a?x=1&amp;y=2</loc><lastmod>2026-01-01T20:04:05Z</lastmod></url>`+
+		`<url><loc>https://blog.example/tags/go</loc></url>`+
+		`</urlset>`, string(b))
+}
+
+func TestIndex(t *testing.T) {
+	b, err := Index([]Entry{{Loc: "https://api.example/sitemaps/posts-1.xml", LastMod: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)}})
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9"><sitemap><loc>https://api.example/sitemaps/posts-1.xml</loc><lastmod>2026-01-02T00:00:00Z</lastmod></sitemap></sitemapindex>`)
+
+	empty, err := Index(nil)
+	require.NoError(t, err)
+	assert.Contains(t, string(empty), `<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9"></sitemapindex>`)
+}
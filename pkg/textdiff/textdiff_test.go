@@ -0,0 +1,67 @@
+package textdiff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// apply rebuilds both sides from a diff so any valid edit script can be checked.
+func apply(lines []Line) (a, b []string) {
+	for _, l := range lines {
+		if l.Op != Insert {
+			a = append(a, l.Text)
+		}
+		if l.Op != Delete {
+			b = append(b, l.Text)
+		}
+	}
+	return a, b
+}
+
+func TestLines(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name, a, b string
+		edits      int
+	}{
+		{"equal", "a\nb\nc", "a\nb\nc\n", 0},
+		{"both empty", "", "", 0},
+		{"insert into empty", "", "x\ny", 2},
+		{"delete all", "x\ny", "", 2},
+		{"replace middle", "a\nb\nc", "a\nB\nc", 2},
+		{"insert and delete", "a\nb\nc\nd", "b\nc\nx\nd", 2},
+		{"crlf", "a\r\nb", "a\nb", 0},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := Lines(tc.a, tc.b)
+			a, b := apply(got)
+			assert.Equal(t, splitLines(tc.a), a)
+			assert.Equal(t, splitLines(tc.b), b)
+			edits := 0
+			for _, l := range got {
+				if l.Op != Equal {
+					edits++
+				}
+			}
+			assert.Equal(t, tc.edits, edits)
+			assert.Equal(t, tc.edits > 0, Changed(got))
+		})
+	}
+}
+
+func TestLines_Order(t *testing.T) {
+	t.Parallel()
+	got := Lines(strings.Join([]string{"a", "b", "c"}, "\n"), strings.Join([]string{"a", "x", "c"}, "\n"))
+	assert.Equal(t, []Line{
+		{Op: Equal, Text: "a"},
+		{Op: Delete, Text: "b"},
+		{Op: Insert, Text: "x"},
+		{Op: Equal, Text: "c"},
+	}, got)
+}
@@ -0,0 +1,322 @@
+// Package wxr reads WordPress eXtended RSS (WXR) export files, as written by Tools > Export.
+package wxr
+
+import (
+	"encoding/xml"
+	"errors"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrNotWXR = errors.New("not a WordPress export (WXR) file")
+
+// Export is the content of one WXR file. Elements are matched by local name, so exports of every
+// WXR version (1.0 to 1.2) read the same.
+type Export struct {
+	Title       string
+	Link        string
+	BaseSiteURL string
+	Authors     []Author
+	Categories  []Category
+	Tags        []Tag
+	Items       []Item
+}
+
+type Author struct {
+	ID          int
+	Login       string
+	Email       string
+	DisplayName string
+}
+
+// Category is a category term; Parent is the slug (nicename) of its parent, empty for roots.
+type Category struct {
+	TermID int
+	Slug   string
+	Parent string
+	Name   string
+}
+
+type Tag struct {
+	TermID int
+	Slug   string
+	Name   string
+}
+
+// Item is a post, page, attachment or any other post type. Date and Modified are UTC; Date is zero
+// for drafts that were never dated. Creator is the author's login.
+type Item struct {
+	ID            int
+	Title         string
+	Link          string
+	Creator       string
+	Content       string
+	Excerpt       string
+	Slug          string
+	Status        string
+	Type          string
+	Parent        int
+	AttachmentURL string
+	Date          time.Time
+	Modified      time.Time
+	Terms         []Term
+	Meta          map[string]string
+	Comments      []Comment
+}
+
+// Term is a category or tag assigned to an item; Domain is "category" or "post_tag".
+type Term struct {
+	Domain string
+	Slug   string
+	Name   string
+}
+
+// Comment is a comment on an item; Parent is 0 for top-level comments, and UserID 0 for guests.
+// Approved is "1" for approved comments ("0" pending, "spam", "trash"); Type is empty or "comment"
+// for comments, else "pingback" or "trackback".
+type Comment struct {
+	ID          int
+	Parent      int
+	UserID      int
+	Author      string
+	AuthorEmail string
+	AuthorURL   string
+	Content     string
+	Approved    string
+	Type        string
+	Date        time.Time
+}
+
+// TermsIn returns the item's terms in domain.
+func (it Item) TermsIn(domain string) []Term {
+	var out []Term
+	for _, t := range it.Terms {
+		if t.Domain == domain {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+type rawRSS struct {
+	XMLName xml.Name   `xml:"rss"`
+	Channel rawChannel `xml:"channel"`
+}
+
+type rawChannel struct {
+	Title string `xml:"title"`
+	// Links also matches atom:link, which has no text.
+	Links       []string      `xml:"link"`
+	BaseSiteURL string        `xml:"base_site_url"`
+	Authors     []rawAuthor   `xml:"author"`
+	Categories  []rawCategory `xml:"category"`
+	Tags        []rawTag      `xml:"tag"`
+	Items       []rawItem     `xml:"item"`
+}
+
+type rawAuthor struct {
+	ID          string `xml:"author_id"`
+	Login       string `xml:"author_login"`
+	Email       string `xml:"author_email"`
+	DisplayName string `xml:"author_display_name"`
+}
+
+type rawCategory struct {
+	TermID   string `xml:"term_id"`
+	Nicename string `xml:"category_nicename"`
+	Parent   string `xml:"category_parent"`
+	Name     string `xml:"cat_name"`
+}
+
+type rawTag struct {
+	TermID string `xml:"term_id"`
+	Slug   string `xml:"tag_slug"`
+	Name   string `xml:"tag_name"`
+}
+
+// rawEncoded is content:encoded or excerpt:encoded, told apart by namespace.
+type rawEncoded struct {
+	XMLName xml.Name
+	Value   string `xml:",chardata"`
+}
+
+type rawTerm struct {
+	Domain   string `xml:"domain,attr"`
+	Nicename string `xml:"nicename,attr"`
+	Name     string `xml:",chardata"`
+}
+
+type rawMeta struct {
+	Key   string `xml:"meta_key"`
+	Value string `xml:"meta_value"`
+}
+
+type rawComment struct {
+	ID          string `xml:"comment_id"`
+	Author      string `xml:"comment_author"`
+	AuthorEmail string `xml:"comment_author_email"`
+	AuthorURL   string `xml:"comment_author_url"`
+	Date        string `xml:"comment_date"`
+	DateGMT     string `xml:"comment_date_gmt"`
+	Content     string `xml:"comment_content"`
+	Approved    string `xml:"comment_approved"`
+	Type        string `xml:"comment_type"`
+	Parent      string `xml:"comment_parent"`
+	UserID      string `xml:"comment_user_id"`
+}
+
+type rawItem struct {
+	Title         string       `xml:"title"`
+	Link          string       `xml:"link"`
+	Creator       string       `xml:"creator"`
+	Encoded       []rawEncoded `xml:"encoded"`
+	ID            string       `xml:"post_id"`
+	Date          string       `xml:"post_date"`
+	DateGMT       string       `xml:"post_date_gmt"`
+	ModifiedGMT   string       `xml:"post_modified_gmt"`
+	Slug          string       `xml:"post_name"`
+	Status        string       `xml:"status"`
+	Parent        string       `xml:"post_parent"`
+	Type          string       `xml:"post_type"`
+	AttachmentURL string       `xml:"attachment_url"`
+	Terms         []rawTerm    `xml:"category"`
+	Meta          []rawMeta    `xml:"postmeta"`
+	Comments      []rawComment `xml:"comment"`
+}
+
+// Parse reads a WXR document.
+func Parse(r io.Reader) (*Export, error) {
+	dec := xml.NewDecoder(r)
+	dec.Entity = xml.HTMLEntity
+	var doc rawRSS
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+	ch := doc.Channel
+	if ch.BaseSiteURL == "" && len(ch.Authors) == 0 && !hasWordPressItems(ch.Items) {
+		return nil, ErrNotWXR
+	}
+
+	out := &Export{Title: text(ch.Title), BaseSiteURL: trim(ch.BaseSiteURL)}
+	for _, l := range ch.Links {
+		if out.Link = trim(l); out.Link != "" {
+			break
+		}
+	}
+	for _, a := range ch.Authors {
+		if trim(a.Login) == "" {
+			continue
+		}
+		out.Authors = append(out.Authors, Author{ID: atoi(a.ID), Login: trim(a.Login), Email: trim(a.Email), DisplayName: text(a.DisplayName)})
+	}
+	for _, c := range ch.Categories {
+		// Plain RSS <category> elements on the channel carry no nicename.
+		if trim(c.Nicename) == "" {
+			continue
+		}
+		out.Categories = append(out.Categories, Category{TermID: atoi(c.TermID), Slug: trim(c.Nicename), Parent: trim(c.Parent), Name: text(c.Name)})
+	}
+	for _, t := range ch.Tags {
+		if trim(t.Slug) == "" {
+			continue
+		}
+		out.Tags = append(out.Tags, Tag{TermID: atoi(t.TermID), Slug: trim(t.Slug), Name: text(t.Name)})
+	}
+	for _, ri := range ch.Items {
+		out.Items = append(out.Items, convertItem(ri))
+	}
+	return out, nil
+}
+
+func hasWordPressItems(items []rawItem) bool {
+	for _, it := range items {
+		if it.ID != "" || it.Type != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func convertItem(ri rawItem) Item {
+	it := Item{
+		ID:            atoi(ri.ID),
+		Title:         text(ri.Title),
+		Link:          trim(ri.Link),
+		Creator:       trim(ri.Creator),
+		Slug:          trim(ri.Slug),
+		Status:        trim(ri.Status),
+		Type:          trim(ri.Type),
+		Parent:        atoi(ri.Parent),
+		AttachmentURL: trim(ri.AttachmentURL),
+		Date:          wpTime(ri.DateGMT, ri.Date),
+		Modified:      wpTime(ri.ModifiedGMT, ""),
+	}
+	for _, e := range ri.Encoded {
+		if strings.Contains(e.XMLName.Space, "excerpt") {
+			it.Excerpt = e.Value
+		} else {
+			it.Content = e.Value
+		}
+	}
+	for _, t := range ri.Terms {
+		if t.Domain == "" {
+			continue
+		}
+		it.Terms = append(it.Terms, Term{Domain: t.Domain, Slug: trim(t.Nicename), Name: text(t.Name)})
+	}
+	if len(ri.Meta) > 0 {
+		it.Meta = make(map[string]string, len(ri.Meta))
+		for _, m := range ri.Meta {
+			it.Meta[trim(m.Key)] = m.Value
+		}
+	}
+	for _, c := range ri.Comments {
+		it.Comments = append(it.Comments, Comment{
+			ID:          atoi(c.ID),
+			Parent:      atoi(c.Parent),
+			UserID:      atoi(c.UserID),
+			Author:      text(c.Author),
+			AuthorEmail: trim(c.AuthorEmail),
+			AuthorURL:   trim(c.AuthorURL),
+			Content:     c.Content,
+			Approved:    trim(c.Approved),
+			Type:        trim(c.Type),
+			Date:        wpTime(c.DateGMT, c.Date),
+		})
+	}
+	return it
+}
+
+const wpTimeLayout = "2006-01-02 15:04:05"
+
+// wpTime parses the GMT timestamp, falling back to the site-local one (read as UTC); WordPress
+// writes 0000-00-00 00:00:00 for undated drafts.
+func wpTime(gmt, local string) time.Time {
+	for _, v := range []string{gmt, local} {
+		v = trim(v)
+		if v == "" || strings.HasPrefix(v, "0000") {
+			continue
+		}
+		if t, err := time.Parse(wpTimeLayout, v); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(trim(s))
+	return n
+}
+
+func trim(s string) string {
+	return strings.TrimSpace(s)
+}
+
+// text trims a name or title; WordPress stores them HTML-escaped (Food &amp; Drink).
+func text(s string) string {
+	return html.UnescapeString(trim(s))
+}
@@ -0,0 +1,124 @@
+package wxr
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sample = `<?xml version="1.0" encoding="UTF-8" ?>
+<rss version="2.0"
+	xmlns:excerpt="http://wordpress.org/export/1.2/excerpt/"
+	xmlns:content="http://purl.org/rss/1.0/modules/content/"
+	xmlns:dc="http://purl.org/dc/elements/1.1/"
+	xmlns:atom="http://www.w3.org/2005/Atom"
+	xmlns:wp="http://wordpress.org/export/1.2/">
+<channel>
+	<title>Notes</title>
+	<atom:link href="https://notes.example/feed/" rel="self" type="application/rss+xml" />
+	<link>https://notes.example</link>
+	<wp:wxr_version>1.2</wp:wxr_version>
+	<wp:base_site_url>https://notes.example</wp:base_site_url>
+	<wp:author><wp:author_id>1</wp:author_id><wp:author_login><![CDATA[ada]]></wp:author_login><wp:author_email><![CDATA[ada@notes.example]]></wp:author_email><wp:author_display_name><![CDATA[Ada L.]]></wp:author_display_name></wp:author>
+	<wp:category><wp:term_id>2</wp:term_id><wp:category_nicename><![CDATA[travel]]></wp:category_nicename><wp:category_parent><![CDATA[]]></wp:category_parent><wp:cat_name><![CDATA[Travel]]></wp:cat_name></wp:category>
+	<wp:category><wp:term_id>3</wp:term_id><wp:category_nicename><![CDATA[asia]]></wp:category_nicename><wp:category_parent><![CDATA[travel]]></wp:category_parent><wp:cat_name><![CDATA[Asia &amp; Pacific]]></wp:cat_name></wp:category>
+	<wp:tag><wp:term_id>4</wp:term_id><wp:tag_slug><![CDATA[food]]></wp:tag_slug><wp:tag_name><![CDATA[Food]]></wp:tag_name></wp:tag>
+	<item>
+		<title>Ramen in Tokyo</title>
+		<link>https://notes.example/2024/ramen/</link>
+		<dc:creator><![CDATA[ada]]></dc:creator>
+		<content:encoded><![CDATA[<p>Broth &amp; noodles.</p>]]></content:encoded>
+		<excerpt:encoded><![CDATA[Short]]></excerpt:encoded>
+		<wp:post_id>10</wp:post_id>
+		<wp:post_date><![CDATA[2024-05-01 18:30:00]]></wp:post_date>
+		<wp:post_date_gmt><![CDATA[2024-05-01 09:30:00]]></wp:post_date_gmt>
+		<wp:post_modified_gmt><![CDATA[2024-05-02 09:30:00]]></wp:post_modified_gmt>
+		<wp:post_name><![CDATA[ramen]]></wp:post_name>
+		<wp:status><![CDATA[publish]]></wp:status>
+		<wp:post_parent>0</wp:post_parent>
+		<wp:post_type><![CDATA[post]]></wp:post_type>
+		<category domain="category" nicename="asia"><![CDATA[Asia &amp; Pacific]]></category>
+		<category domain="post_tag" nicename="food"><![CDATA[Food]]></category>
+		<wp:postmeta><wp:meta_key><![CDATA[_thumbnail_id]]></wp:meta_key><wp:meta_value><![CDATA[11]]></wp:meta_value></wp:postmeta>
+		<wp:comment>
+			<wp:comment_id>5</wp:comment_id>
+			<wp:comment_author><![CDATA[Bob]]></wp:comment_author>
+			<wp:comment_author_email><![CDATA[bob@example.com]]></wp:comment_author_email>
+			<wp:comment_date_gmt><![CDATA[2024-05-03 10:00:00]]></wp:comment_date_gmt>
+			<wp:comment_content><![CDATA[Looks great]]></wp:comment_content>
+			<wp:comment_approved><![CDATA[1]]></wp:comment_approved>
+			<wp:comment_type><![CDATA[comment]]></wp:comment_type>
+			<wp:comment_parent>0</wp:comment_parent>
+			<wp:comment_user_id>0</wp:comment_user_id>
+		</wp:comment>
+		<wp:comment>
+			<wp:comment_id>6</wp:comment_id>
+			<wp:comment_author><![CDATA[ada]]></wp:comment_author>
+			<wp:comment_date><![CDATA[2024-05-03 20:00:00]]></wp:comment_date>
+			<wp:comment_date_gmt><![CDATA[0000-00-00 00:00:00]]></wp:comment_date_gmt>
+			<wp:comment_content><![CDATA[Thanks!]]></wp:comment_content>
+			<wp:comment_approved><![CDATA[1]]></wp:comment_approved>
+			<wp:comment_parent>5</wp:comment_parent>
+			<wp:comment_user_id>1</wp:comment_user_id>
+		</wp:comment>
+	</item>
+	<item>
+		<title>bowl.jpg</title>
+		<dc:creator><![CDATA[ada]]></dc:creator>
+		<wp:post_id>11</wp:post_id>
+		<wp:post_date_gmt><![CDATA[0000-00-00 00:00:00]]></wp:post_date_gmt>
+		<wp:status><![CDATA[inherit]]></wp:status>
+		<wp:post_parent>10</wp:post_parent>
+		<wp:post_type><![CDATA[attachment]]></wp:post_type>
+		<wp:attachment_url><![CDATA[https://notes.example/wp-content/uploads/bowl.jpg]]></wp:attachment_url>
+	</item>
+</channel>
+</rss>`
+
+func TestParse(t *testing.T) {
+	ex, err := Parse(strings.NewReader(sample))
+	require.NoError(t, err)
+	assert.Equal(t, "Notes", ex.Title)
+	assert.Equal(t, "https://notes.example", ex.Link)
+	assert.Equal(t, []Author{{ID: 1, Login: "ada", Email: "ada@notes.example", DisplayName: "Ada L."}}, ex.Authors)
+	assert.Equal(t, []Category{{TermID: 2, Slug: "travel", Name: "Travel"}, {TermID: 3, Slug: "asia", Parent: "travel", Name: "Asia & Pacific"}}, ex.Categories)
+	assert.Equal(t, []Tag{{TermID: 4, Slug: "food", Name: "Food"}}, ex.Tags)
+
+	require.Len(t, ex.Items, 2)
+	post := ex.Items[0]
+	assert.Equal(t, 10, post.ID)
+	assert.Equal(t, "post", post.Type)
+	assert.Equal(t, "publish", post.Status)
+	assert.Equal(t, "ramen", post.Slug)
+	assert.Equal(t, "ada", post.Creator)
+	assert.Equal(t, "<p>Broth &amp; noodles.</p>", post.Content)
+	assert.Equal(t, "Short", post.Excerpt)
+	assert.Equal(t, time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC), post.Date)
+	assert.Equal(t, time.Date(2024, 5, 2, 9, 30, 0, 0, time.UTC), post.Modified)
+	assert.Equal(t, []Term{{Domain: "category", Slug: "asia", Name: "Asia & Pacific"}}, post.TermsIn("category"))
+	assert.Equal(t, []Term{{Domain: "post_tag", Slug: "food", Name: "Food"}}, post.TermsIn("post_tag"))
+	assert.Equal(t, "11", post.Meta["_thumbnail_id"])
+
+	require.Len(t, post.Comments, 2)
+	assert.Equal(t, Comment{ID: 5, Author: "Bob", AuthorEmail: "bob@example.com", Content: "Looks great", Approved: "1", Type: "comment", Date: time.Date(2024, 5, 3, 10, 0, 0, 0, time.UTC)}, post.Comments[0])
+	assert.Equal(t, 5, post.Comments[1].Parent)
+	assert.Equal(t, 1, post.Comments[1].UserID)
+	assert.Equal(t, time.Date(2024, 5, 3, 20, 0, 0, 0, time.UTC), post.Comments[1].Date, "falls back to the local date")
+
+	att := ex.Items[1]
+	assert.Equal(t, "attachment", att.Type)
+	assert.Equal(t, 10, att.Parent)
+	assert.Equal(t, "https://notes.example/wp-content/uploads/bowl.jpg", att.AttachmentURL)
+	assert.True(t, att.Date.IsZero())
+}
+
+func TestParseRejectsOtherFeeds(t *testing.T) {
+	_, err := Parse(strings.NewReader(`<rss version="2.0"><channel><title>Feed</title><item><title>x</title></item></channel></rss>`))
+	assert.ErrorIs(t, err, ErrNotWXR)
+
+	_, err = Parse(strings.NewReader(`<feed xmlns="http://www.w3.org/2005/Atom"></feed>`))
+	assert.Error(t, err)
+}